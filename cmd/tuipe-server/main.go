@@ -0,0 +1,88 @@
+// Package main provides tuipe-server, a self-hostable leaderboard for tuipe daily challenges.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/leaderboard"
+)
+
+const (
+	defaultAddr     = "localhost:7171"
+	shutdownTimeout = 5 * time.Second
+)
+
+var (
+	serverAddr string
+	serverData string
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "tuipe-server",
+		Short:        "Leaderboard server for tuipe daily challenges",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runServer,
+	}
+	cmd.Flags().StringVar(&serverAddr, "addr", defaultAddr, "listen address (e.g. :7171 for all interfaces)")
+	cmd.Flags().StringVar(&serverData, "data", "", "JSON file the results are kept in (empty = memory only)")
+	return cmd
+}
+
+func runServer(_ *cobra.Command, _ []string) error {
+	board, err := leaderboard.NewServer(serverData)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", serverAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serverAddr, err)
+	}
+	srv := &http.Server{
+		Handler:           board,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+	if _, err := fmt.Fprintf(os.Stderr, "Serving the leaderboard on http://%s/api/\n", listener.Addr()); err != nil {
+		// Best-effort logging to stderr.
+		_ = err
+	}
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("failed to serve: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+var (
+	cardCopy  bool
+	cardColor bool
+)
+
+func newStatsCardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "card",
+		Short: "Print a shareable card for the latest session",
+		Args:  cobra.NoArgs,
+		RunE:  runStatsCardCmd,
+	}
+	cmd.Flags().BoolVar(&cardCopy, "copy", false, "copy the plain card to the clipboard")
+	cmd.Flags().BoolVar(&cardColor, "color", false, "print the card with ANSI styling")
+	return cmd
+}
+
+func runStatsCardCmd(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadStatsConfig(cmd)
+	if err != nil {
+		return err
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	ctx := context.Background()
+	sessions, err := st.ListSessions(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no sessions found")
+	}
+	allSessions, err := st.ListSessions(ctx, model.StatsConfig{})
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	last := sessions[len(sessions)-1]
+	wpm, _, acc := stats.SessionMetrics(last.Correct, last.Incorrect, last.DurationMs)
+	card := stats.ShareCard{
+		WPM:      wpm,
+		Accuracy: acc,
+		Streak:   stats.Streak(allSessions, time.Now()),
+		Date:     last.EndedAt,
+		Lang:     cfg.Lang,
+	}
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), stats.RenderShareCard(card, cardColor)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if cardCopy {
+		if err := clipboard.WriteAll(stats.RenderShareCard(card, false)); err != nil {
+			return fmt.Errorf("failed to copy card: %w", err)
+		}
+		logErrln("Copied card to clipboard")
+	}
+	return nil
+}
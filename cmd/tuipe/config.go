@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/internal/hooks"
+	"github.com/verte-zerg/tuipe/internal/wordfreq"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+var (
+	configShowPreset string
+	configInitForce  bool
+)
+
+func newConfigInitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write the commented config template with every option and its default",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigInitCmd,
+	}
+	cmd.Flags().BoolVar(&configInitForce, "force", false, "overwrite an existing config (the old one is kept as config.toml.bak)")
+	return cmd
+}
+
+func runConfigInitCmd(cmd *cobra.Command, _ []string) error {
+	path := config.DefaultConfigPath()
+	if _, err := os.Stat(path); err == nil {
+		if !configInitForce {
+			return fmt.Errorf("%s already exists (use --force to regenerate it)", path)
+		}
+		if err := os.Rename(path, path+".bak"); err != nil {
+			return fmt.Errorf("failed to back up config: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Saved the previous config as %s.bak\n", path)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat config: %w", err)
+	}
+	if err := ensureConfigFile(cmd.Root(), path); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+	return nil
+}
+
+func newConfigCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Report syntax errors, unknown keys and out-of-range values in the config",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigCheckCmd,
+	}
+}
+
+func newConfigShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration and where each value comes from",
+		Args:  cobra.NoArgs,
+		RunE:  runConfigShowCmd,
+	}
+	cmd.Flags().StringVar(&configShowPreset, "preset", "", "show [practice] with a [preset.<name>] block applied")
+	return cmd
+}
+
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <section.key>",
+		Short: "Print the effective value of a setting (e.g. practice.lang)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runConfigGetCmd,
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <section.key> <value>",
+		Short: "Change a setting in the config file (e.g. practice.words 40)",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runConfigSetCmd,
+	}
+}
+
+func runConfigGetCmd(cmd *cobra.Command, args []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	key := strings.TrimSpace(args[0])
+	field, ok := fileCfg.Lookup(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	if field.Value != nil {
+		fmt.Fprintln(cmd.OutOrStdout(), rawConfigValue(field.Value))
+		return nil
+	}
+	def, ok := configDefaults(cmd.Root())[key]
+	if !ok {
+		return fmt.Errorf("%s is not set", key)
+	}
+	if text, err := strconv.Unquote(def); err == nil {
+		def = text
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), def)
+	return nil
+}
+
+func runConfigSetCmd(cmd *cobra.Command, args []string) error {
+	path := config.DefaultConfigPath()
+	if err := ensureConfigFile(cmd.Root(), path); err != nil {
+		return err
+	}
+	return config.SetValue(path, strings.TrimSpace(args[0]), args[1])
+}
+
+func runConfigCheckCmd(cmd *cobra.Command, _ []string) error {
+	path := config.DefaultConfigPath()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: not found (defaults are used)\n", path)
+		return nil
+	}
+	issues, err := config.Check(path)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: ok\n", path)
+		return nil
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", path, issue)
+	}
+	return fmt.Errorf("config has %d issue(s)", len(issues))
+}
+
+func runConfigShowCmd(cmd *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	preset := strings.TrimSpace(configShowPreset)
+	if _, err := fileCfg.PracticeWithPreset(preset); err != nil {
+		return err
+	}
+	defaults := configDefaults(cmd.Root())
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "# %s\n", config.DefaultConfigPath())
+	for _, section := range fileCfg.Sections() {
+		fmt.Fprintf(w, "\n[%s]\n", section.Name)
+		if section.Name == "paths" {
+			writePathsSection(w, fileCfg)
+			continue
+		}
+		var presetFields []config.Field
+		if section.Name == "practice" && preset != "" {
+			presetFields = config.SectionFields(fileCfg.Presets[preset])
+		}
+		for j, field := range section.Fields {
+			key := section.Name + "." + field.Key
+			value, source := formatConfigValue(field.Value), "config"
+			switch {
+			case presetFields != nil && presetFields[j].Value != nil:
+				value, source = formatConfigValue(presetFields[j].Value), "preset "+preset
+			case field.Value == nil:
+				value, source = defaults[key], "default"
+			}
+			fmt.Fprintf(w, "%s = %s\t# %s\n", field.Key, value, source)
+		}
+	}
+	if len(fileCfg.PunctSets) > 0 {
+		fmt.Fprintf(w, "\n[punct-sets]\n")
+		for _, lang := range sortedKeys(fileCfg.PunctSets) {
+			fmt.Fprintf(w, "%s = %q\t# config\n", lang, fileCfg.PunctSets[lang])
+		}
+	}
+	if len(fileCfg.PunctWeights) > 0 {
+		fmt.Fprintf(w, "\n[punct-weights]\n")
+		marks := make([]string, 0, len(fileCfg.PunctWeights))
+		for mark := range fileCfg.PunctWeights {
+			marks = append(marks, mark)
+		}
+		sort.Strings(marks)
+		for _, mark := range marks {
+			fmt.Fprintf(w, "%q = %d\t# config\n", mark, fileCfg.PunctWeights[mark])
+		}
+	}
+	for _, name := range fileCfg.PresetNames() {
+		fmt.Fprintf(w, "\n[preset.%s]\n", name)
+		for _, field := range config.SectionFields(fileCfg.Presets[name]) {
+			if field.Value != nil {
+				fmt.Fprintf(w, "%s = %s\t# config\n", field.Key, formatConfigValue(field.Value))
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// writePathsSection prints the resolved data paths; they also follow flags and env vars.
+func writePathsSection(w *tabwriter.Writer, fileCfg config.FileConfig) {
+	paths := []struct {
+		key, flag, flagName, env string
+		file                     *string
+		fallback                 string
+	}{
+		{"db", rootDBPath, "--db", config.EnvDB, fileCfg.Paths.DB, config.DefaultDBPath()},
+		{"wordlists", rootWordlistDir, "--wordlist-dir", config.EnvWordlists, fileCfg.Paths.Wordlists, config.DefaultWordListDir()},
+	}
+	for _, p := range paths {
+		value, source := p.fallback, "default"
+		switch {
+		case p.flag != "":
+			value, source = config.ExpandHome(p.flag), "flag "+p.flagName
+		case os.Getenv(p.env) != "":
+			value, source = config.ExpandHome(os.Getenv(p.env)), "env "+p.env
+		case p.file != nil && *p.file != "":
+			value, source = config.ExpandHome(*p.file), "config"
+		}
+		fmt.Fprintf(w, "%s = %q\t# %s\n", p.key, value, source)
+	}
+}
+
+// configDefaults returns the built-in value of each "section.key", formatted as TOML.
+// Practice and stats defaults come from the flags so they cannot drift.
+func configDefaults(root *cobra.Command) map[string]string {
+	defaults := map[string]string{}
+	for _, field := range config.SectionFields(config.PracticeConfig{}) {
+		if flag := root.Flags().Lookup(field.Key); flag != nil {
+			defaults["practice."+field.Key] = formatFlagDefault(flag.Value.Type(), flag.DefValue)
+		}
+	}
+	if statsCmd, _, err := root.Find([]string{"stats"}); err == nil {
+		for _, field := range config.SectionFields(config.StatsConfig{}) {
+			name := field.Key
+			if name == "chars" {
+				name = "char"
+			}
+			if flag := statsCmd.PersistentFlags().Lookup(name); flag != nil {
+				defaults["stats."+field.Key] = formatFlagDefault(flag.Value.Type(), flag.DefValue)
+			}
+		}
+	}
+	theme := model.DefaultTheme()
+	for key, value := range map[string]any{
+		"paths.db":           config.DefaultDBPath(),
+		"paths.wordlists":    config.DefaultWordListDir(),
+		"ui.content-width":   defaultContentWidth,
+		"ui.plot-height":     defaultPlotHeight,
+		"ui.lang":            "",
+		"ui.accessible":      false,
+		"ui.bidi":            model.BidiApp,
+		"ui.wpm":             model.WPMChars,
+		"db.auto-backup":     false,
+		"db.backup-keep":     defaultBackupKeep,
+		"download.index-url": wordfreq.DefaultIndexURL,
+		"download.proxy":     "",
+		"download.retries":   wordfreq.DefaultRetries,
+		"theme.palette":      model.PaletteDefault,
+		"theme.text":         theme.Text,
+		"theme.error":        theme.Error,
+		"theme.pending":      theme.Pending,
+		"theme.accent":       theme.Accent,
+		"theme.muted":        theme.Muted,
+		"theme.border":       theme.Border,
+		"theme.subtle":       theme.Subtle,
+		"hooks.on-session":   "",
+		"hooks.webhook":      "",
+		"hooks.timeout":      int(hooks.DefaultTimeout / time.Second),
+		"remind.at":          "",
+		"leaderboard.url":    "",
+		"leaderboard.name":   "",
+		"leaderboard.group":  "",
+		"leaderboard.submit": false,
+	} {
+		defaults[key] = formatConfigValue(value)
+	}
+	return defaults
+}
+
+func formatFlagDefault(kind, value string) string {
+	switch kind {
+	case "string":
+		return strconv.Quote(value)
+	case "float64":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return formatConfigValue(f)
+		}
+	}
+	return value
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// rawConfigValue renders a config value for scripts: strings are not quoted.
+func rawConfigValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return formatConfigValue(value)
+}
+
+// formatConfigValue renders a config value the way it would be written in TOML.
+func formatConfigValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		text := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.Contains(text, ".") {
+			text += ".0"
+		}
+		return text
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+var (
+	dbExportOut    string
+	dbExportFormat string
+	dbBackupKeep   int
+	dbPruneBefore  string
+	dbPruneDryRun  bool
+	dbPruneYes     bool
+)
+
+// Formats of `tuipe db export`.
+const (
+	exportFormatJSON       = "json"
+	exportFormatMonkeytype = "monkeytype"
+)
+
+func newDBCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the session database",
+	}
+	cmd.AddCommand(newDBExportCmd())
+	cmd.AddCommand(newDBImportCmd())
+	cmd.AddCommand(newDBMergeCmd())
+	cmd.AddCommand(newDBBackupCmd())
+	cmd.AddCommand(newDBPruneCmd())
+	cmd.AddCommand(newDBVacuumCmd())
+	return cmd
+}
+
+func newDBExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all sessions and char stats as JSON or Monkeytype CSV",
+		Args:  cobra.NoArgs,
+		RunE:  runDBExportCmd,
+	}
+	cmd.Flags().StringVar(&dbExportOut, "out", "-", "output file ('-' for stdout)")
+	cmd.Flags().StringVar(&dbExportFormat, "format", exportFormatJSON, "export format: json (full, re-importable) or monkeytype (results CSV)")
+	return cmd
+}
+
+func newDBImportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import sessions from a JSON export ('-' for stdin)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDBImportCmd,
+	}
+}
+
+func newDBMergeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge <other.db>",
+		Short: "Merge sessions from another tuipe database, skipping duplicates",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDBMergeCmd,
+	}
+}
+
+func newDBBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Snapshot the database into the backups directory",
+		Args:  cobra.NoArgs,
+		RunE:  runDBBackupCmd,
+	}
+	cmd.Flags().IntVar(&dbBackupKeep, "keep", defaultBackupKeep, "number of backups to keep (0 = keep all)")
+	return cmd
+}
+
+func newDBPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete sessions that ended before a date",
+		Args:  cobra.NoArgs,
+		RunE:  runDBPruneCmd,
+	}
+	cmd.Flags().StringVar(&dbPruneBefore, "before", "", "delete sessions ended before this date (YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&dbPruneDryRun, "dry-run", false, "only report how many sessions would be deleted")
+	cmd.Flags().BoolVarP(&dbPruneYes, "yes", "y", false, "skip the confirmation prompt")
+	return cmd
+}
+
+func newDBVacuumCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vacuum",
+		Short: "Compact the database file",
+		Args:  cobra.NoArgs,
+		RunE:  runDBVacuumCmd,
+	}
+}
+
+func runDBExportCmd(cmd *cobra.Command, _ []string) error {
+	format := strings.ToLower(strings.TrimSpace(dbExportFormat))
+	if format != exportFormatJSON && format != exportFormatMonkeytype {
+		return fmt.Errorf("--format must be %q or %q", exportFormatJSON, exportFormatMonkeytype)
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	export, err := st.ExportSessions(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to export sessions: %w", err)
+	}
+	return writeOutput(cmd, dbExportOut, func(w io.Writer) error {
+		if format == exportFormatMonkeytype {
+			return stats.WriteMonkeytypeCSV(w, export.Sessions)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(export); err != nil {
+			return fmt.Errorf("failed to encode export: %w", err)
+		}
+		return nil
+	})
+}
+
+func runDBImportCmd(cmd *cobra.Command, args []string) error {
+	export, err := readExport(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	count, err := st.ImportSessions(context.Background(), export.Sessions)
+	if err != nil {
+		return fmt.Errorf("failed to import sessions: %w", err)
+	}
+	logErrf("Imported %d sessions\n", count)
+	return nil
+}
+
+func readExport(cmd *cobra.Command, path string) (store.Export, error) {
+	var r io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return store.Export{}, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer func() {
+			if cerr := file.Close(); cerr != nil {
+				// Best-effort close after read.
+				_ = cerr
+			}
+		}()
+		r = file
+	}
+	var export store.Export
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return store.Export{}, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	if export.Version != store.ExportFormatVersion {
+		return store.Export{}, fmt.Errorf("unsupported export version %d (expected %d)", export.Version, store.ExportFormatVersion)
+	}
+	return export, nil
+}
+
+func runDBMergeCmd(_ *cobra.Command, args []string) error {
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	result, err := st.MergeFrom(context.Background(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", args[0], err)
+	}
+	logErrf("Merged %d sessions (%d duplicates skipped)\n", result.Added, result.Skipped)
+	return nil
+}
+
+func runDBBackupCmd(cmd *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	applyIntConfig(cmd, "keep", &dbBackupKeep, fileCfg.DB.BackupKeep)
+	if dbBackupKeep < 0 {
+		return fmt.Errorf("--keep must be >= 0")
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	path, err := st.Backup(context.Background(), config.BackupDir(resolveDBPath(fileCfg)), dbBackupKeep)
+	if err != nil {
+		return fmt.Errorf("failed to back up db: %w", err)
+	}
+	logErrf("Wrote %s\n", path)
+	return nil
+}
+
+func backupKeep(fileCfg config.FileConfig) int {
+	if fileCfg.DB.BackupKeep != nil {
+		return *fileCfg.DB.BackupKeep
+	}
+	return defaultBackupKeep
+}
+
+func runDBPruneCmd(cmd *cobra.Command, _ []string) error {
+	if dbPruneBefore == "" {
+		return fmt.Errorf("--before is required")
+	}
+	before, err := time.ParseInLocation("2006-01-02", dbPruneBefore, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid --before value: %w", err)
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	ctx := context.Background()
+	count, err := st.CountSessionsBefore(ctx, before)
+	if err != nil {
+		return fmt.Errorf("failed to count sessions: %w", err)
+	}
+	if count == 0 {
+		logErrf("No sessions ended before %s\n", dbPruneBefore)
+		return nil
+	}
+	if dbPruneDryRun {
+		logErrf("Would delete %d sessions ended before %s\n", count, dbPruneBefore)
+		return nil
+	}
+	if !dbPruneYes {
+		ok, err := confirm(cmd, fmt.Sprintf("Delete %d sessions ended before %s? [y/N] ", count, dbPruneBefore))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			logErrln("Aborted")
+			return nil
+		}
+	}
+
+	deleted, err := st.PruneSessions(ctx, before)
+	if err != nil {
+		return fmt.Errorf("failed to prune sessions: %w", err)
+	}
+	logErrf("Deleted %d sessions (run `tuipe db vacuum` to reclaim space)\n", deleted)
+	return nil
+}
+
+func runDBVacuumCmd(_ *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	path := resolveDBPath(fileCfg)
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	sizeBefore := fileSize(path)
+	if err := st.Vacuum(context.Background()); err != nil {
+		return fmt.Errorf("failed to vacuum db: %w", err)
+	}
+	logErrf("Vacuumed %s (%d -> %d bytes)\n", path, sizeBefore, fileSize(path))
+	return nil
+}
+
+func confirm(cmd *cobra.Command, prompt string) (bool, error) {
+	logErrf("%s", prompt)
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
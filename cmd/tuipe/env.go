@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+var statsEnvBy string
+
+func newStatsEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Compare stats per terminal, OS, SSH, window size or practice option",
+		Args:  cobra.NoArgs,
+		RunE:  runStatsEnvCmd,
+	}
+	cmd.Flags().StringVar(&statsEnvBy, "by", stats.EnvBySSH, "group sessions by term, os, ssh, size or option")
+	return cmd
+}
+
+func runStatsEnvCmd(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadStatsConfig(cmd)
+	if err != nil {
+		return err
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	sessions, err := st.ListSessions(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+	if err := stats.RenderEnvBreakdown(cmd.OutOrStdout(), sessions, statsEnvBy, cfg.WPMFormula); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
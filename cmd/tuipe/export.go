@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+const (
+	defaultExportWidth  = 800
+	defaultExportHeight = 400
+)
+
+var (
+	exportPlotFormat string
+	exportPlotOut    string
+	exportPlotWidth  int
+	exportPlotHeight int
+)
+
+func newStatsExportPlotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-plot",
+		Short: "Export learning curves as an SVG or PNG image",
+		Args:  cobra.NoArgs,
+		RunE:  runStatsExportPlotCmd,
+	}
+	cmd.Flags().StringVar(&exportPlotFormat, "format", "", "image format: svg or png (default: from --out extension)")
+	cmd.Flags().StringVar(&exportPlotOut, "out", "", "output file ('-' for stdout)")
+	cmd.Flags().IntVar(&exportPlotWidth, "width", defaultExportWidth, "image width in pixels")
+	cmd.Flags().IntVar(&exportPlotHeight, "height", defaultExportHeight, "image height in pixels")
+	return cmd
+}
+
+func runStatsExportPlotCmd(cmd *cobra.Command, _ []string) error {
+	if exportPlotOut == "" {
+		return fmt.Errorf("--out is required")
+	}
+	format, err := resolveExportFormat(exportPlotFormat, exportPlotOut)
+	if err != nil {
+		return err
+	}
+	if exportPlotWidth <= 0 || exportPlotHeight <= 0 {
+		return fmt.Errorf("--width and --height must be > 0")
+	}
+
+	cfg, err := loadStatsConfig(cmd)
+	if err != nil {
+		return err
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	report, err := stats.BuildReport(context.Background(), st, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to build report: %w", err)
+	}
+	if len(report.Sessions) == 0 {
+		return fmt.Errorf("no sessions found")
+	}
+	series := stats.CurveSeriesWithFormula(report.Sessions, cfg.WPMFormula, cfg.CurveWindow)
+
+	return writeOutput(cmd, exportPlotOut, func(w io.Writer) error {
+		if format == "png" {
+			return stats.WritePNG(w, series, exportPlotWidth, exportPlotHeight)
+		}
+		return stats.WriteSVG(w, "Learning Curves", series, exportPlotWidth, exportPlotHeight)
+	})
+}
+
+func resolveExportFormat(format, out string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(out)), ".")
+	}
+	switch format {
+	case "svg", "png":
+		return format, nil
+	case "":
+		return "svg", nil
+	default:
+		return "", fmt.Errorf("unsupported --format %q (use svg or png)", format)
+	}
+}
+
+// writeOutput writes to path through a buffered writer, or to stdout when path is "-".
+func writeOutput(cmd *cobra.Command, path string, write func(io.Writer) error) (err error) {
+	if path == "-" {
+		return write(cmd.OutOrStdout())
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s: %w", path, cerr)
+		}
+		if err == nil {
+			logErrf("Wrote %s\n", path)
+		}
+	}()
+	writer := bufio.NewWriter(file)
+	if err := write(writer); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var statsIgnoreUndo bool
+
+func newStatsIgnoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ignore <session-id>...",
+		Short: "Leave sessions out of every aggregate and curve without deleting them",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runStatsIgnoreCmd,
+	}
+	cmd.Flags().BoolVar(&statsIgnoreUndo, "undo", false, "count the sessions again")
+	return cmd
+}
+
+func runStatsIgnoreCmd(cmd *cobra.Command, args []string) error {
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid session id %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	for _, id := range ids {
+		if err := st.SetSessionIgnored(context.Background(), id, !statsIgnoreUndo); err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// historyParser converts another tool's export into session records.
+type historyParser func(io.Reader) ([]store.SessionRecord, error)
+
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import session history from other typing tools",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "monkeytype <results.csv>",
+		Short: "Import a Monkeytype results CSV ('-' for stdin)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImportHistory(cmd, args[0], stats.ParseMonkeytypeCSV)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "keybr <export.json>",
+		Short: "Import a keybr.com data export ('-' for stdin)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImportHistory(cmd, args[0], stats.ParseKeybr)
+		},
+	})
+	return cmd
+}
+
+func runImportHistory(cmd *cobra.Command, path string, parse historyParser) error {
+	var r io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer func() {
+			if cerr := file.Close(); cerr != nil {
+				// Best-effort close after read.
+				_ = cerr
+			}
+		}()
+		r = file
+	}
+	records, err := parse(r)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	result, err := st.AddSessions(context.Background(), records)
+	if err != nil {
+		return fmt.Errorf("failed to import sessions: %w", err)
+	}
+	logErrf("Imported %d sessions (%d duplicates skipped)\n", result.Added, result.Skipped)
+	return nil
+}
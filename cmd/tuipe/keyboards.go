@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+func newStatsKeyboardsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "keyboards",
+		Short: "Compare stats per keyboard and layout",
+		Args:  cobra.NoArgs,
+		RunE:  runStatsKeyboardsCmd,
+	}
+}
+
+func runStatsKeyboardsCmd(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadStatsConfig(cmd)
+	if err != nil {
+		return err
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	sessions, err := st.ListSessions(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+	if err := stats.RenderKeyboardBreakdown(cmd.OutOrStdout(), sessions); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
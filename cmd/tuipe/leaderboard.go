@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/internal/leaderboard"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// leaderboardConfig is the [leaderboard] section with its defaults applied.
+type leaderboardConfig struct {
+	URL    string
+	Name   string
+	Group  string
+	Submit bool
+}
+
+// Enabled reports whether a leaderboard server is configured.
+func (c leaderboardConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// loadLeaderboardConfig reads the [leaderboard] section. Submitting is opt-in and
+// needs a server, a name and a group.
+func loadLeaderboardConfig() (leaderboardConfig, error) {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return leaderboardConfig{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	section := fileCfg.Leaderboard
+	var cfg leaderboardConfig
+	if v := section.URL; v != nil {
+		cfg.URL = strings.TrimSpace(*v)
+	}
+	if v := section.Name; v != nil {
+		cfg.Name = strings.TrimSpace(*v)
+	}
+	if v := section.Group; v != nil {
+		cfg.Group = strings.TrimSpace(*v)
+	}
+	if v := section.Submit; v != nil {
+		cfg.Submit = *v
+	}
+	if cfg.Enabled() && cfg.Group == "" {
+		return leaderboardConfig{}, fmt.Errorf("[leaderboard] group is required with url")
+	}
+	if cfg.Submit && (!cfg.Enabled() || cfg.Name == "") {
+		return leaderboardConfig{}, fmt.Errorf("[leaderboard] submit needs url, name and group")
+	}
+	return cfg, nil
+}
+
+// submitChallenge sends the scores of a daily challenge session to the leaderboard.
+// Sessions of other texts are ignored.
+func submitChallenge(ctx context.Context, client *leaderboard.Client, cfg leaderboardConfig, session model.SessionStats) error {
+	day, ok := generator.ChallengeOf(session.Seed, session.StartedAt)
+	if !ok {
+		return nil
+	}
+	wpm, _, acc := stats.SessionMetrics(session.CorrectNonSpace, session.IncorrectNonSpace, session.DurationMs)
+	result := leaderboard.Result{
+		Group:      cfg.Group,
+		Day:        day,
+		Params:     session.ParamsHash,
+		Name:       cfg.Name,
+		Lang:       session.Lang,
+		Mode:       session.Mode,
+		Words:      session.Words,
+		WPM:        wpm,
+		Accuracy:   acc,
+		DurationMs: session.DurationMs,
+		Version:    session.AppVersion,
+	}
+	if err := client.Submit(ctx, result); err != nil {
+		return fmt.Errorf("leaderboard: %w", err)
+	}
+	return nil
+}
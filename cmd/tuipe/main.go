@@ -4,44 +4,65 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/verte-zerg/tuipe/internal/config"
-	"github.com/verte-zerg/tuipe/internal/generator"
-	"github.com/verte-zerg/tuipe/internal/model"
-	"github.com/verte-zerg/tuipe/internal/stats"
+	"github.com/verte-zerg/tuipe/internal/hooks"
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/internal/leaderboard"
 	"github.com/verte-zerg/tuipe/internal/statsui"
-	"github.com/verte-zerg/tuipe/internal/store"
 	"github.com/verte-zerg/tuipe/internal/tui"
 	"github.com/verte-zerg/tuipe/internal/wordfreq"
 	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
 )
 
 const (
-	defaultLang        = "en"
-	defaultWords       = 25
-	defaultCaps        = 0.5
-	defaultPunct       = 0.5
-	defaultWeakTop     = 8
-	defaultWeakFactor  = 2.0
-	defaultWeakWindow  = 20
-	defaultCurveWindow = 20
-	defaultWordlistSz  = 10000
-)
+	defaultLang         = "en"
+	defaultWords        = 25
+	defaultCaps         = 0.5
+	defaultPunct        = 0.5
+	defaultWeakTop      = 8
+	defaultWeakFactor   = 2.0
+	defaultWeakWindow   = 20
+	defaultCurveWindow  = 20
+	defaultWordlistSz   = 10000
+	defaultNgramTop     = 300
+	defaultStoreTextMax = 4096
+	defaultHesitationMs = 500
+	defaultBackupKeep   = 10
+	defaultContentWidth = 0.70
+	defaultPlotHeight   = 10
+
+	defaultCodeCamel     = 0.4
+	defaultCodeSnake     = 0.4
+	defaultCodeScreaming = 0.2
+	defaultCodeOps       = 0.25
 
-const defaultPunctSet = ".,!?;:\"'{}()[]-=/<>`"
+	defaultShiftMid = 0.3
+	defaultShiftAll = 0.15
+)
 
 var (
 	practiceLang       string
+	practiceList       string
 	practiceWords      int
 	practiceCaps       float64
 	practicePunct      float64
@@ -50,16 +71,80 @@ var (
 	practiceWeakTop    int
 	practiceWeakFactor float64
 	practiceWeakWindow int
+	practiceCoverage   int
+	practiceSRS        bool
+	practiceReview     bool
+	practiceKeyboard   string
+	practiceLayout     string
+	practiceMode       string
+	practiceCorpus     string
+	practiceExclude    string
+	practiceOnly       string
+	practiceRows       string
+	practiceRepeat     int
+	practiceSentences  bool
+	practiceCamel      float64
+	practiceSnake      float64
+	practiceScreaming  float64
+	practiceOps        float64
+	practiceShiftMid   float64
+	practiceShiftAll   float64
+	practiceResults    bool
+	practiceHesitation int
+	practiceAccAlert   float64
+	practiceAccFloor   float64
+	practiceTrend      bool
+	practiceGoal       int
+	practiceDaily      bool
+	practiceZen        bool
+	practiceGhost      bool
+	practiceRecordEnv  bool
+	practiceStoreText  bool
+	practiceStoreMax   int
+	practiceStoreKeys  bool
+	practiceIncomplete bool
+	practiceApostrophe bool
+	practiceEquivs     string
+	practicePreset     string
 
 	statsLang        string
 	statsSince       string
 	statsLast        int
 	statsCurveWindow int
 	statsChars       string
+	statsFoldCase    bool
+	statsIncomplete  bool
+
+	statsExcludeOutliers    bool
+	statsOutlierMaxWPM      float64
+	statsOutlierMinDuration time.Duration
+
+	statsMode       string
+	statsFocusWeak  bool
+	statsAppVersion string
+	statsKeyboard   string
+	statsLayout     string
+	statsWordList   string
+
+	statsRefresh int
 
-	wordlistLang  string
-	wordlistSize  int
-	wordlistForce bool
+	wordlistLang     string
+	wordlistSize     int
+	wordlistForce    bool
+	wordlistList     string
+	wordlistRank     string
+	wordlistPreset   string
+	wordlistVersion  string
+	wordlistTranslit bool
+	wordlistRules    wordlist.Rules
+	wordlistBigrams  bool
+	wordlistNgramTop int
+
+	rootDBPath      string
+	rootUILang      string
+	rootAccessible  bool
+	rootWPM         string
+	rootWordlistDir string
 )
 
 func main() {
@@ -76,43 +161,285 @@ func newRootCmd() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: false,
 		RunE:          runPracticeCmd,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			migrateLegacyDirs()
+			return applyUILang()
+		},
 	}
 
-	rootCmd.Flags().StringVar(&practiceLang, "lang", defaultLang, "language code (default: en)")
+	rootCmd.PersistentFlags().StringVar(&rootDBPath, "db", "", "database path (env TUIPE_DB, config [paths] db)")
+	rootCmd.PersistentFlags().StringVar(&rootUILang, "ui-lang", "", "language of UI text: "+strings.Join(i18n.Langs(), ", ")+" (config [ui] lang, default: from LANG)")
+	rootCmd.PersistentFlags().BoolVar(&rootAccessible, "accessible", false, "screen-reader mode: plain text without alt screen, plots or color-only cues (config [ui] accessible)")
+	rootCmd.PersistentFlags().StringVar(&rootWPM, "wpm", "", "speed formula: chars (5 characters a word), words (words typed) or cpm (config [ui] wpm, default: chars)")
+	rootCmd.PersistentFlags().StringVar(&rootWordlistDir, "wordlist-dir", "", "wordlist directory (env TUIPE_WORDLISTS, config [paths] wordlists)")
+
+	rootCmd.Flags().StringVar(&practiceLang, "lang", defaultLang, "language code, several like en,de to interleave their lists, or auto to detect it from --corpus (default: en)")
+	rootCmd.Flags().StringVar(&practiceList, "list", wordlist.DefaultList, "named wordlist for the language (e.g. common, coding)")
 	rootCmd.Flags().IntVar(&practiceWords, "words", defaultWords, "words per text")
 	rootCmd.Flags().Float64Var(&practiceCaps, "caps", defaultCaps, "probability of capitalized first letter (0-1)")
 	rootCmd.Flags().Float64Var(&practicePunct, "punct", defaultPunct, "punctuation probability per word (0-1)")
-	rootCmd.Flags().StringVar(&practicePunctSet, "punct-set", defaultPunctSet, "punctuation set")
+	rootCmd.Flags().StringVar(&practicePunctSet, "punct-set", "", "punctuation set (default: per-language set)")
 	rootCmd.Flags().BoolVar(&practiceFocusWeak, "focus-weak", false, "bias practice toward weak characters")
 	rootCmd.Flags().IntVar(&practiceWeakTop, "weak-top", defaultWeakTop, "number of weak characters to focus on")
 	rootCmd.Flags().Float64Var(&practiceWeakFactor, "weak-factor", defaultWeakFactor, "weight factor for weak characters")
 	rootCmd.Flags().IntVar(&practiceWeakWindow, "weak-window", defaultWeakWindow, "number of recent sessions to compute weak chars")
+	rootCmd.Flags().IntVar(&practiceCoverage, "coverage", 0, "favor characters typed fewer than this many times in the last --weak-window sessions (0 = off)")
+	rootCmd.Flags().BoolVar(&practiceSRS, "srs", false, "resurface previously mistyped characters and words on a spaced-repetition schedule")
+	rootCmd.Flags().BoolVar(&practiceReview, "review", false, "build texts mostly from the mistake bank of recently mistyped words")
+	rootCmd.Flags().StringVar(&practiceKeyboard, "keyboard", "", "physical keyboard recorded with each session")
+	rootCmd.Flags().StringVar(&practiceLayout, "layout", "", "keyboard layout recorded with each session and used by --rows (e.g. qwerty, colemak)")
+	rootCmd.Flags().StringVar(&practiceMode, "mode", model.ModeWords, "practice mode: words, markov (sentence-like text), code (identifiers and operators) or shift (capital-letter drill)")
+	rootCmd.Flags().StringVar(&practiceCorpus, "corpus", "", "text file whose word pairs drive markov mode (default: wordlist frequencies)")
+	rootCmd.Flags().StringVar(&practiceExclude, "exclude-chars", "", "drop words containing any of these characters")
+	rootCmd.Flags().StringVar(&practiceOnly, "only-chars", "", "only use words made entirely of these characters")
+	rootCmd.Flags().StringVar(&practiceRows, "rows", "", "only use words typeable on these keyboard rows of --layout (top, home, bottom; e.g. top,home)")
+	rootCmd.Flags().IntVar(&practiceRepeat, "repeat-window", generator.DefaultRepeatWindow, "words a new word must differ from (0 allows immediate repeats)")
+	rootCmd.Flags().BoolVar(&practiceSentences, "sentence-style", false, "shape text into sentences: capitals after . ? ! and clause punctuation")
+	rootCmd.Flags().Float64Var(&practiceCamel, "code-camel", defaultCodeCamel, "code mode: relative weight of camelCase identifiers")
+	rootCmd.Flags().Float64Var(&practiceSnake, "code-snake", defaultCodeSnake, "code mode: relative weight of snake_case identifiers")
+	rootCmd.Flags().Float64Var(&practiceScreaming, "code-screaming", defaultCodeScreaming, "code mode: relative weight of SCREAMING_CASE identifiers")
+	rootCmd.Flags().Float64Var(&practiceOps, "code-ops", defaultCodeOps, "code mode: probability a token is an operator (0-1)")
+	rootCmd.Flags().Float64Var(&practiceShiftMid, "shift-mid", defaultShiftMid, "shift mode: probability a word gets a capital inside it (0-1)")
+	rootCmd.Flags().Float64Var(&practiceShiftAll, "shift-all", defaultShiftAll, "shift mode: probability a word is in ALL CAPS (0-1)")
+	rootCmd.Flags().BoolVar(&practiceResults, "results-screen", false, "show a results screen after each text (turned on by --ghost or a set --hesitation)")
+	rootCmd.Flags().Float64Var(&practiceAccAlert, "accuracy-alert", 0, "turn the footer red while accuracy over the last 20 keys is below this (0-1, 0 = off)")
+	rootCmd.Flags().Float64Var(&practiceAccFloor, "accuracy-floor", 0, "start a new text when accuracy over the last 20 keys drops below this (0-1, 0 = off)")
+	rootCmd.Flags().BoolVar(&practiceZen, "zen", false, "start with the footer hidden, showing only the text (esc toggles it)")
+	rootCmd.Flags().BoolVar(&practiceTrend, "footer-trend", false, "show a sparkline of the last 20 session speeds in the footer")
+	rootCmd.Flags().IntVar(&practiceGoal, "goal-minutes", 0, "daily practice goal in minutes shown in the footer and `tuipe today` (0 = off)")
+	rootCmd.Flags().BoolVar(&practiceGhost, "ghost", false, "review the text on the results screen with the wrong keys typed shown faintly under it (turns on --results-screen)")
+	rootCmd.Flags().IntVar(&practiceHesitation, "hesitation", defaultHesitationMs, "pause in ms highlighted on the results rhythm strip (0 = no strip; setting it turns on --results-screen)")
+	rootCmd.Flags().BoolVar(&practiceRecordEnv, "record-env", false, "save the terminal, OS and active practice options with each session")
+	rootCmd.Flags().BoolVar(&practiceStoreText, "store-text", false, "save target and typed text with each session")
+	rootCmd.Flags().IntVar(&practiceStoreMax, "store-text-max", defaultStoreTextMax, "max bytes of text saved per session (0 = no cap)")
+	rootCmd.Flags().BoolVar(&practiceStoreKeys, "store-keys", false, "save each session's keystrokes for the stats replay tab (needs the text within --store-text-max)")
+	rootCmd.Flags().BoolVar(&practiceIncomplete, "save-incomplete", false, "save the current text as an incomplete session on quit")
+	rootCmd.Flags().BoolVar(&practiceApostrophe, "loose-apostrophe", true, "accept ' for ’ and ’ for ' while typing")
+	rootCmd.Flags().StringVar(&practiceEquivs, "equivalents", model.DefaultEquivalents, "space-separated groups of characters that count as the same key (\"\" = exact matches)")
+	rootCmd.Flags().StringVar(&practicePreset, "preset", "", "apply the practice settings of a [preset.<name>] config block")
+	rootCmd.Flags().BoolVar(&practiceDaily, "daily", false, "practice today's daily challenge: the same text for everyone with the same settings")
 
 	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newDBCmd())
+	rootCmd.AddCommand(newImportCmd())
+	rootCmd.AddCommand(newServeCmd())
 	rootCmd.AddCommand(newLangsCmd())
+	rootCmd.AddCommand(newRemindCmd())
+	rootCmd.AddCommand(newReplayCmd(rootCmd))
+	rootCmd.AddCommand(newReportCmd())
 	rootCmd.AddCommand(newStatsCmd())
+	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newTodayCmd())
 	rootCmd.AddCommand(newWordlistCmd())
 
 	return rootCmd
 }
 
 func runPracticeCmd(cmd *cobra.Command, _ []string) error {
+	_, err := runPractice(cmd, false)
+	return err
+}
+
+// runPractice starts the practice TUI with the practice flags of cmd. With record set it
+// quits after the first completed text, whose recording the returned model holds.
+func runPractice(cmd *cobra.Command, record bool) (*tui.Model, error) {
+	gen := generator.New()
+	setup, err := loadPractice(cmd, gen)
+	if err != nil {
+		return nil, err
+	}
+	cfg := setup.Config
+
+	st, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	weakSet := map[rune]struct{}{}
+	weakNoticePrinted := false
+	if cfg.FocusWeak {
+		aggs, err := st.GetWeakChars(context.Background(), cfg.WeakWindow, cfg.Lang)
+		if err != nil {
+			logErrln(i18n.T("practice.err.load_weak", err))
+		} else {
+			weakSet = stats.SelectWeakChars(aggs, cfg.WeakTop)
+			if len(weakSet) == 0 {
+				logErrln(i18n.T("practice.no_weak_stats"))
+				weakNoticePrinted = true
+			}
+		}
+	}
+
+	tui.SetTheme(cfg.Theme)
+	practiceModel := tui.NewModel(cfg, st, gen, setup.Source, setup.WordListPath, weakSet, weakNoticePrinted)
+	practiceModel.WatchConfig(config.DefaultConfigPath(), func() (tui.Reload, error) {
+		resetUnchangedFlags(cmd)
+		return loadPractice(cmd, gen)
+	})
+	hooksCfg, err := loadHooksConfig()
+	if err != nil {
+		return nil, err
+	}
+	var sessionHooks []tui.SessionHook
+	if hooksCfg.Enabled() {
+		sessionHooks = append(sessionHooks, func(id int64, session model.SessionStats, chars []model.CharStats) error {
+			return hooks.Run(context.Background(), hooksCfg, hooks.NewPayload(id, session, chars))
+		})
+	}
+	board, err := loadLeaderboardConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Daily && board.Submit {
+		client := leaderboard.NewClient(board.URL)
+		sessionHooks = append(sessionHooks, func(_ int64, session model.SessionStats, _ []model.CharStats) error {
+			return submitChallenge(context.Background(), client, board, session)
+		})
+	}
+	if len(sessionHooks) > 0 {
+		practiceModel.SetSessionHook(func(id int64, session model.SessionStats, chars []model.CharStats) error {
+			var errs []error
+			for _, hook := range sessionHooks {
+				if err := hook(id, session, chars); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		})
+	}
+	if record {
+		practiceModel.RecordReplay()
+	}
+	var opts []tea.ProgramOption
+	if !cfg.Accessible {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	program := tea.NewProgram(practiceModel, opts...)
+	_, runErr := program.Run()
+	for _, err := range practiceModel.WaitHooks() {
+		logErrf("%v\n", err)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("failed to run TUI: %w", runErr)
+	}
+	return practiceModel, nil
+}
+
+// applyUILang selects the UI language from --ui-lang, then [ui] lang, then the locale.
+func applyUILang() error {
+	if rootUILang != "" {
+		return i18n.SetLang(strings.TrimSpace(rootUILang))
+	}
+	// An unreadable config or bad [ui] lang is reported by config check and the commands
+	// that load the config; the locale is used meanwhile.
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err == nil && fileCfg.UI.Lang != nil && i18n.Supported(strings.TrimSpace(*fileCfg.UI.Lang)) {
+		return i18n.SetLang(strings.TrimSpace(*fileCfg.UI.Lang))
+	}
+	return i18n.SetLang("")
+}
+
+// loadHooksConfig reads the [hooks] section.
+func loadHooksConfig() (hooks.Config, error) {
 	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return hooks.Config{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	var cfg hooks.Config
+	if v := fileCfg.Hooks.OnSession; v != nil {
+		cfg.Command = strings.TrimSpace(*v)
+	}
+	if v := fileCfg.Hooks.Webhook; v != nil {
+		cfg.Webhook = strings.TrimSpace(*v)
+	}
+	if v := fileCfg.Hooks.Timeout; v != nil {
+		if *v < 1 {
+			return hooks.Config{}, fmt.Errorf("[hooks] timeout must be >= 1")
+		}
+		cfg.Timeout = time.Duration(*v) * time.Second
+	}
+	return cfg, nil
+}
+
+// loadPractice layers config file values under the practice flags of cmd and builds the
+// text source for them on gen.
+func loadPractice(cmd *cobra.Command, gen *generator.Generator) (tui.Reload, error) {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return tui.Reload{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	practice, err := fileCfg.PracticeWithPreset(strings.TrimSpace(practicePreset))
+	if err != nil {
+		return tui.Reload{}, err
+	}
+	applyStringConfig(cmd, "lang", &practiceLang, practice.Lang)
+	applyStringConfig(cmd, "corpus", &practiceCorpus, practice.Corpus)
+	lang := practiceLang
+	if strings.EqualFold(strings.TrimSpace(lang), wordlist.AutoLang) {
+		if lang, err = detectCorpusLang(practiceCorpus); err != nil {
+			return tui.Reload{}, err
+		}
+	}
+	if strings.Contains(lang, ",") {
+		lang = strings.Join(wordlist.SplitLangs(lang), ",")
+	}
+	applyStringConfig(cmd, "list", &practiceList, practice.List)
+	applyIntConfig(cmd, "words", &practiceWords, practice.Words)
+	applyFloatConfig(cmd, "caps", &practiceCaps, practice.CapsPct)
+	applyFloatConfig(cmd, "punct", &practicePunct, practice.PunctPct)
+	applyStringConfig(cmd, "punct-set", &practicePunctSet, practice.PunctSet)
+	if practicePunctSet == "" {
+		practicePunctSet = generator.PunctSetForLang(lang, fileCfg.PunctSets)
+	}
+	applyBoolConfig(cmd, "focus-weak", &practiceFocusWeak, practice.FocusWeak)
+	applyIntConfig(cmd, "weak-top", &practiceWeakTop, practice.WeakTop)
+	applyFloatConfig(cmd, "weak-factor", &practiceWeakFactor, practice.WeakFactor)
+	applyIntConfig(cmd, "weak-window", &practiceWeakWindow, practice.WeakWindow)
+	applyIntConfig(cmd, "coverage", &practiceCoverage, practice.Coverage)
+	applyBoolConfig(cmd, "srs", &practiceSRS, practice.SRS)
+	applyBoolConfig(cmd, "review", &practiceReview, practice.Review)
+	applyStringConfig(cmd, "keyboard", &practiceKeyboard, practice.Keyboard)
+	applyStringConfig(cmd, "layout", &practiceLayout, practice.Layout)
+	applyStringConfig(cmd, "mode", &practiceMode, practice.Mode)
+	applyStringConfig(cmd, "exclude-chars", &practiceExclude, practice.ExcludeChars)
+	applyStringConfig(cmd, "only-chars", &practiceOnly, practice.OnlyChars)
+	applyStringConfig(cmd, "rows", &practiceRows, practice.Rows)
+	applyIntConfig(cmd, "repeat-window", &practiceRepeat, practice.RepeatWindow)
+	applyBoolConfig(cmd, "sentence-style", &practiceSentences, practice.SentenceStyle)
+	applyFloatConfig(cmd, "code-camel", &practiceCamel, practice.CodeCamel)
+	applyFloatConfig(cmd, "code-snake", &practiceSnake, practice.CodeSnake)
+	applyFloatConfig(cmd, "code-screaming", &practiceScreaming, practice.CodeScreaming)
+	applyFloatConfig(cmd, "code-ops", &practiceOps, practice.CodeOps)
+	applyFloatConfig(cmd, "shift-mid", &practiceShiftMid, practice.ShiftMid)
+	applyFloatConfig(cmd, "shift-all", &practiceShiftAll, practice.ShiftAll)
+	applyBoolConfig(cmd, "results-screen", &practiceResults, practice.ResultsScreen)
+	applyIntConfig(cmd, "hesitation", &practiceHesitation, practice.Hesitation)
+	applyBoolConfig(cmd, "ghost", &practiceGhost, practice.Ghost)
+	applyFloatConfig(cmd, "accuracy-alert", &practiceAccAlert, practice.AccuracyAlert)
+	applyFloatConfig(cmd, "accuracy-floor", &practiceAccFloor, practice.AccuracyFloor)
+	applyBoolConfig(cmd, "footer-trend", &practiceTrend, practice.FooterTrend)
+	applyIntConfig(cmd, "goal-minutes", &practiceGoal, practice.GoalMinutes)
+	applyBoolConfig(cmd, "zen", &practiceZen, practice.Zen)
+	applyBoolConfig(cmd, "record-env", &practiceRecordEnv, practice.RecordEnv)
+	applyBoolConfig(cmd, "store-text", &practiceStoreText, practice.StoreText)
+	applyIntConfig(cmd, "store-text-max", &practiceStoreMax, practice.StoreTextMax)
+	applyBoolConfig(cmd, "store-keys", &practiceStoreKeys, practice.StoreKeys)
+	applyBoolConfig(cmd, "save-incomplete", &practiceIncomplete, practice.SaveIncomplete)
+	applyBoolConfig(cmd, "loose-apostrophe", &practiceApostrophe, practice.LooseApostrophe)
+	applyStringConfig(cmd, "equivalents", &practiceEquivs, practice.Equivalents)
+	if err := resolveResultsScreen(cmd, practice); err != nil {
+		return tui.Reload{}, err
 	}
-	applyStringConfig(cmd, "lang", &practiceLang, fileCfg.Practice.Lang)
-	applyIntConfig(cmd, "words", &practiceWords, fileCfg.Practice.Words)
-	applyFloatConfig(cmd, "caps", &practiceCaps, fileCfg.Practice.CapsPct)
-	applyFloatConfig(cmd, "punct", &practicePunct, fileCfg.Practice.PunctPct)
-	applyStringConfig(cmd, "punct-set", &practicePunctSet, fileCfg.Practice.PunctSet)
-	applyBoolConfig(cmd, "focus-weak", &practiceFocusWeak, fileCfg.Practice.FocusWeak)
-	applyIntConfig(cmd, "weak-top", &practiceWeakTop, fileCfg.Practice.WeakTop)
-	applyFloatConfig(cmd, "weak-factor", &practiceWeakFactor, fileCfg.Practice.WeakFactor)
-	applyIntConfig(cmd, "weak-window", &practiceWeakWindow, fileCfg.Practice.WeakWindow)
 
 	cfg := model.Config{
-		Lang:       practiceLang,
+		Mode:       strings.TrimSpace(practiceMode),
+		Lang:       lang,
+		List:       strings.TrimSpace(practiceList),
 		Words:      practiceWords,
 		CapsPct:    practiceCaps,
 		PunctPct:   practicePunct,
@@ -121,76 +448,140 @@ func runPracticeCmd(cmd *cobra.Command, _ []string) error {
 		WeakTop:    practiceWeakTop,
 		WeakFactor: practiceWeakFactor,
 		WeakWindow: practiceWeakWindow,
+		Coverage:   practiceCoverage,
+		SRS:        practiceSRS,
+		Review:     practiceReview,
+		Keyboard:   strings.TrimSpace(practiceKeyboard),
+		Layout:     strings.TrimSpace(practiceLayout),
+		Corpus:     strings.TrimSpace(practiceCorpus),
+
+		ExcludeChars: practiceExclude,
+		OnlyChars:    practiceOnly,
+		Rows:         strings.TrimSpace(practiceRows),
+
+		RepeatWindow:  practiceRepeat,
+		SentenceStyle: practiceSentences,
+
+		CodeCamel:     practiceCamel,
+		CodeSnake:     practiceSnake,
+		CodeScreaming: practiceScreaming,
+		CodeOps:       practiceOps,
+
+		ShiftMid: practiceShiftMid,
+		ShiftAll: practiceShiftAll,
+
+		ResultsScreen:  practiceResults,
+		HesitationMs:   practiceHesitation,
+		AccuracyAlert:  practiceAccAlert,
+		AccuracyFloor:  practiceAccFloor,
+		Ghost:          practiceGhost,
+		FooterTrend:    practiceTrend,
+		GoalMinutes:    practiceGoal,
+		Zen:            practiceZen,
+		RecordEnv:      practiceRecordEnv,
+		StoreText:      practiceStoreText,
+		StoreTextMax:   practiceStoreMax,
+		StoreKeys:      practiceStoreKeys,
+		SaveIncomplete: practiceIncomplete,
+
+		LooseApostrophe: practiceApostrophe,
+		Equivalents:     practiceEquivs,
+	}
+	if practiceDaily {
+		// The daily challenge is the same text for everyone, so nothing adapts it to
+		// the player's history.
+		cfg.Daily = true
+		cfg.FocusWeak, cfg.Coverage, cfg.SRS, cfg.Review = false, 0, false, false
 	}
 
 	if err := validateConfig(cfg); err != nil {
-		return err
+		return tui.Reload{}, err
 	}
-
-	wordPath := resolveWordListPath(cfg)
-	wordsList, err := wordlist.LoadWords(wordPath)
+	if v := fileCfg.UI.ContentWidth; v != nil {
+		if *v <= 0 || *v > 1 {
+			return tui.Reload{}, fmt.Errorf("[ui] content-width must be > 0 and <= 1")
+		}
+		cfg.ContentWidth = *v
+	}
+	cfg.Accessible = resolveAccessible(cmd, fileCfg)
+	cfg.Bidi, err = resolveBidi(fileCfg)
 	if err != nil {
-		return wordListLoadError(cfg.Lang, wordPath, err)
+		return tui.Reload{}, err
 	}
-
-	storePath := config.DefaultDBPath()
-	st, err := store.Open(storePath)
+	cfg.WPMFormula, err = resolveWPMFormula(cmd, fileCfg)
 	if err != nil {
-		return fmt.Errorf("failed to open db: %w", err)
+		return tui.Reload{}, err
+	}
+	cfg.Theme, err = resolveTheme(fileCfg)
+	if err != nil {
+		return tui.Reload{}, err
 	}
-	defer func() {
-		if cerr := st.Close(); cerr != nil {
-			logErrf("failed to close db: %v\n", cerr)
-		}
-	}()
-
-	punctRunes := []rune(cfg.PunctSet)
 
-	weakSet := map[rune]struct{}{}
-	weakNoticePrinted := false
-	if cfg.FocusWeak {
-		aggs, err := st.GetWeakChars(context.Background(), cfg.WeakWindow, cfg.Lang)
+	wordsList, wordPath, listMeta, err := loadPracticeWords(fileCfg, cfg.Lang, cfg.List)
+	if err != nil {
+		return tui.Reload{}, err
+	}
+	cfg.WordListMeta = listMeta.String()
+	cfg.WordListID = wordlist.ID(listMeta.List, wordsList)
+	charFilter := wordlist.FilterChars(cfg.ExcludeChars, cfg.OnlyChars)
+	if cfg.Rows != "" {
+		rowFilter, err := rowsFilter(cfg.Layout, cfg.Rows)
 		if err != nil {
-			logErrf("failed to load weak chars: %v\n", err)
-		} else {
-			weakSet = stats.SelectWeakChars(aggs, cfg.WeakTop)
-			if len(weakSet) == 0 {
-				logErrln("no stats available for weak-char focus yet; using normal generator")
-				weakNoticePrinted = true
-			}
+			return tui.Reload{}, err
+		}
+		charFilter = wordlist.Both(charFilter, rowFilter)
+	}
+	if cfg.ExcludeChars != "" || cfg.OnlyChars != "" || cfg.Rows != "" {
+		wordsList = wordlist.Filter(wordsList, charFilter)
+		if len(wordsList) == 0 {
+			return tui.Reload{}, fmt.Errorf("no words in %s left after --exclude-chars/--only-chars/--rows", wordPath)
 		}
 	}
+	if cfg.Coverage > 0 {
+		cfg.Alphabet = wordlist.Alphabet(wordsList)
+	}
+	chain, err := loadChain(cfg, wordsList, charFilter)
+	if err != nil {
+		return tui.Reload{}, err
+	}
 
-	gen := generator.New()
-	model := tui.NewModel(cfg, st, gen, wordsList, wordPath, punctRunes, weakSet, weakNoticePrinted)
-	program := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := program.Run(); err != nil {
-		return fmt.Errorf("failed to run TUI: %w", err)
+	var punctRunes []rune
+	for _, r := range cfg.PunctSet {
+		if charFilter(string(r)) {
+			punctRunes = append(punctRunes, r)
+		}
 	}
-	return nil
+	weights, err := punctWeights(fileCfg.PunctWeights)
+	if err != nil {
+		return tui.Reload{}, err
+	}
+	punctRunes = generator.WeightPunct(punctRunes, weights)
+
+	gen.SetRepeatWindow(cfg.RepeatWindow)
+	gen.SetSentenceStyle(cfg.SentenceStyle)
+	source := newTextSource(cfg, gen, wordsList, chain, punctRunes)
+	return tui.Reload{Config: cfg, Source: source, WordListPath: wordPath}, nil
 }
 
 func newConfigCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Create/open config file",
 		Args:  cobra.NoArgs,
 		RunE:  runConfigCmd,
 	}
+	cmd.AddCommand(newConfigCheckCmd())
+	cmd.AddCommand(newConfigShowCmd())
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigInitCmd())
+	return cmd
 }
 
-func runConfigCmd(_ *cobra.Command, _ []string) error {
+func runConfigCmd(cmd *cobra.Command, _ []string) error {
 	path := config.DefaultConfigPath()
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
-	if _, err := os.Stat(path); err != nil {
-		if !os.IsNotExist(err) {
-			return fmt.Errorf("failed to stat config: %w", err)
-		}
-		if err := os.WriteFile(path, []byte(defaultConfigTemplate()), 0o644); err != nil {
-			return fmt.Errorf("failed to write config: %w", err)
-		}
+	if err := ensureConfigFile(cmd.Root(), path); err != nil {
+		return err
 	}
 
 	editor := strings.TrimSpace(os.Getenv("EDITOR"))
@@ -201,11 +592,11 @@ func runConfigCmd(_ *cobra.Command, _ []string) error {
 	if len(parts) == 0 {
 		return fmt.Errorf("editor command is empty")
 	}
-	cmd := exec.Command(parts[0], append(parts[1:], path)...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	editorCmd := exec.Command(parts[0], append(parts[1:], path)...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
 		return fmt.Errorf("failed to open editor: %w", err)
 	}
 	return nil
@@ -221,62 +612,183 @@ func newLangsCmd() *cobra.Command {
 }
 
 func runLangsCmd(cmd *cobra.Command, _ []string) error {
-	wordlistDir := config.DefaultWordListDir()
-	entries, err := os.ReadDir(wordlistDir)
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
 	if err != nil {
-		if os.IsNotExist(err) {
-			logErrf("No wordlists found. Download with: tuipe wordlist --lang <code>\n")
-			return fmt.Errorf("wordlist directory does not exist")
-		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	wordlistDir := config.ResolveWordListDir(rootWordlistDir, fileCfg)
+	installed, err := wordlist.ListInstalled(wordlistDir)
+	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to read wordlist directory: %w", err)
 	}
-	langs := make([]string, 0, len(entries))
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if !strings.HasSuffix(name, ".txt") {
-			continue
-		}
-		if name == "ATTRIBUTION.txt" || name == "LICENSE.txt" || name == "DATA_LICENSE.txt" {
-			continue
-		}
-		langs = append(langs, strings.TrimSuffix(name, ".txt"))
+	if len(installed) == 0 {
+		logErrf("No wordlists downloaded; practice uses the built-in %s list. Download more with: tuipe wordlist --lang <code>\n", wordlist.BuiltinLang)
+		return nil
 	}
-	if len(langs) == 0 {
-		logErrf("No wordlists found. Download with: tuipe wordlist --lang <code>\n")
-		return fmt.Errorf("no wordlists found")
+	langs := make([]string, 0, len(installed))
+	for lang := range installed {
+		langs = append(langs, lang)
 	}
 	sort.Strings(langs)
 	for _, lang := range langs {
-		if _, err := fmt.Fprintln(cmd.OutOrStdout(), lang); err != nil {
+		if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", lang, strings.Join(installed[lang], ", ")); err != nil {
 			return fmt.Errorf("failed to write output: %w", err)
 		}
 	}
 	return nil
 }
 
+// migrateLegacyDirs moves data left in ~/.config and ~/.local/share by older versions
+// to the native Windows/macOS locations. Failures are reported but not fatal: the old
+// directories keep being used until the move succeeds.
+func migrateLegacyDirs() {
+	moved, err := config.MigrateLegacyDirs()
+	for _, move := range moved {
+		logErrf("Moved %s\n", move)
+	}
+	if err != nil {
+		logErrf("failed to migrate tuipe data to the native location: %v\n", err)
+	}
+}
+
+// ensureConfigFile writes the commented template to path unless a config exists.
+func ensureConfigFile(root *cobra.Command, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to stat config: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(defaultConfigTemplate(root)), 0o644); err != nil {
+			return fmt.Errorf("failed to write config: %w", err)
+		}
+	}
+	return nil
+}
+
 func newStatsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "stats",
 		Short: "Show stats",
 		RunE:  runStatsCmd,
 	}
-	cmd.Flags().StringVar(&statsLang, "lang", "", "language filter")
-	cmd.Flags().StringVar(&statsSince, "since", "", "start date (YYYY-MM-DD)")
-	cmd.Flags().IntVar(&statsLast, "last", 0, "limit to last N sessions")
-	cmd.Flags().IntVar(&statsCurveWindow, "curve-window", defaultCurveWindow, "moving average window")
-	cmd.Flags().StringVar(&statsChars, "char", "", "characters for per-char curves")
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&statsLang, "lang", "", "language filter")
+	flags.StringVar(&statsSince, "since", "", "start date (YYYY-MM-DD)")
+	flags.IntVar(&statsLast, "last", 0, "limit to last N sessions")
+	flags.IntVar(&statsCurveWindow, "curve-window", defaultCurveWindow, "moving average window")
+	flags.StringVar(&statsChars, "char", "", "characters for per-char curves")
+	flags.BoolVar(&statsFoldCase, "fold-case", false, "merge upper- and lower-case characters in char stats")
+	flags.BoolVar(&statsIncomplete, "include-incomplete", false, "include sessions quit before the end of the text")
+	flags.BoolVar(&statsExcludeOutliers, "exclude-outliers", false, "exclude outlier sessions from curves and averages")
+	flags.Float64Var(&statsOutlierMaxWPM, "outlier-max-wpm", stats.DefaultOutlierMaxWPM, "sessions above this WPM are outliers")
+	flags.DurationVar(&statsOutlierMinDuration, "outlier-min-duration", stats.DefaultOutlierMinDuration, "sessions shorter than this are outliers")
+	flags.StringVar(&statsMode, "mode", "", "practice mode filter (e.g. words)")
+	flags.BoolVar(&statsFocusWeak, "focus-weak", false, "only sessions with (true) or without (false) weak-char focus")
+	flags.StringVar(&statsAppVersion, "app-version", "", "tuipe version filter")
+	flags.StringVar(&statsKeyboard, "keyboard", "", "keyboard filter")
+	flags.StringVar(&statsLayout, "layout", "", "keyboard layout filter")
+	flags.StringVar(&statsWordList, "wordlist", "", "wordlist filter: a list name or a full ID from tuipe stats show")
+	flags.IntVar(&statsRefresh, "refresh", 0, "seconds between checks for new sessions in the stats UI (0 = off)")
+
+	cmd.AddCommand(newStatsCardCmd())
+	cmd.AddCommand(newStatsExportPlotCmd())
+	cmd.AddCommand(newStatsRebuildCmd())
+	cmd.AddCommand(newStatsShowCmd())
+	cmd.AddCommand(newStatsIgnoreCmd())
+	cmd.AddCommand(newStatsKeyboardsCmd())
+	cmd.AddCommand(newStatsEnvCmd())
 	return cmd
 }
 
-func runStatsCmd(_ *cobra.Command, _ []string) error {
+func runStatsCmd(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadStatsConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	if rootAccessible {
+		return renderAccessibleStats(cmd.OutOrStdout(), st, cfg)
+	}
+	board, err := loadLeaderboardConfig()
+	if err != nil {
+		return err
+	}
+	statsui.SetTheme(cfg.Theme)
+	model := statsui.NewModel(st, cfg)
+	if board.Enabled() {
+		client := leaderboard.NewClient(board.URL)
+		model.SetRankings(func(ctx context.Context, day string) ([]leaderboard.Entry, error) {
+			return client.Rankings(ctx, board.Group, day)
+		}, board.Name)
+	}
+	program := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("failed to run stats TUI: %w", err)
+	}
+	return nil
+}
+
+// renderAccessibleStats prints the stats as plain text in place of the stats TUI: the
+// summary, the learning curves described in words and the character table.
+func renderAccessibleStats(w io.Writer, st *store.Store, cfg model.StatsConfig) error {
+	report, err := stats.BuildReport(context.Background(), st, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load stats: %w", err)
+	}
+	if err := stats.RenderSummaryWithFormula(w, report.Sessions, cfg.WPMFormula); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if err := stats.RenderTrendWithFormula(w, report.Sessions, cfg.WPMFormula, cfg.CurveWindow); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if len(report.Sessions) == 0 {
+		return nil
+	}
+	if err := stats.RenderCharTable(w, report.CharAggsAll); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// resolveAccessible layers [ui] accessible under --accessible and returns the result.
+func resolveAccessible(cmd *cobra.Command, fileCfg config.FileConfig) bool {
+	applyBoolConfig(cmd, "accessible", &rootAccessible, fileCfg.UI.Accessible)
+	return rootAccessible
+}
+
+// loadStatsConfig layers config file values under the stats flags of cmd.
+func loadStatsConfig(cmd *cobra.Command) (model.StatsConfig, error) {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return model.StatsConfig{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	applyStringConfig(cmd, "lang", &statsLang, fileCfg.Stats.Lang)
+	applyIntConfig(cmd, "last", &statsLast, fileCfg.Stats.Last)
+	applyIntConfig(cmd, "curve-window", &statsCurveWindow, fileCfg.Stats.CurveWindow)
+	applyStringConfig(cmd, "char", &statsChars, fileCfg.Stats.Chars)
+	applyBoolConfig(cmd, "exclude-outliers", &statsExcludeOutliers, fileCfg.Stats.ExcludeOutliers)
+	applyBoolConfig(cmd, "fold-case", &statsFoldCase, fileCfg.Stats.FoldCase)
+	applyBoolConfig(cmd, "include-incomplete", &statsIncomplete, fileCfg.Stats.IncludeIncomplete)
+	applyIntConfig(cmd, "refresh", &statsRefresh, fileCfg.Stats.Refresh)
+	resolveAccessible(cmd, fileCfg)
+
 	var sinceTime *time.Time
 	if statsSince != "" {
 		parsed, err := time.ParseInLocation("2006-01-02", statsSince, time.Local)
 		if err != nil {
-			return fmt.Errorf("invalid --since value: %w", err)
+			return model.StatsConfig{}, fmt.Errorf("invalid --since value: %w", err)
 		}
 		sinceTime = &parsed
 	}
@@ -287,25 +799,68 @@ func runStatsCmd(_ *cobra.Command, _ []string) error {
 		Last:        statsLast,
 		CurveWindow: statsCurveWindow,
 		Chars:       statsChars,
-	}
+		FoldCase:    statsFoldCase,
 
-	storePath := config.DefaultDBPath()
-	st, err := store.Open(storePath)
-	if err != nil {
-		return fmt.Errorf("failed to open db: %w", err)
+		ExcludeOutliers:    statsExcludeOutliers,
+		OutlierMaxWPM:      statsOutlierMaxWPM,
+		OutlierMinDuration: statsOutlierMinDuration,
+
+		Mode:       statsMode,
+		AppVersion: statsAppVersion,
+		Keyboard:   statsKeyboard,
+		Layout:     statsLayout,
+		WordList:   statsWordList,
+
+		IncludeIncomplete: statsIncomplete,
+
+		Refresh: time.Duration(statsRefresh) * time.Second,
 	}
-	defer func() {
-		if cerr := st.Close(); cerr != nil {
-			logErrf("failed to close db: %v\n", cerr)
+	if cmd.Flags().Changed("focus-weak") {
+		focusWeak := statsFocusWeak
+		cfg.FocusWeak = &focusWeak
+	}
+	if v := fileCfg.UI.PlotHeight; v != nil {
+		if *v < config.MinPlotHeight {
+			return model.StatsConfig{}, fmt.Errorf("[ui] plot-height must be >= %d", config.MinPlotHeight)
 		}
-	}()
+		cfg.PlotHeight = *v
+	}
+	cfg.Theme, err = resolveTheme(fileCfg)
+	if err != nil {
+		return model.StatsConfig{}, err
+	}
+	cfg.WPMFormula, err = resolveWPMFormula(cmd, fileCfg)
+	if err != nil {
+		return model.StatsConfig{}, err
+	}
+	if cfg.OutlierMaxWPM < 0 {
+		return model.StatsConfig{}, fmt.Errorf("--outlier-max-wpm must be >= 0")
+	}
+	if cfg.OutlierMinDuration < 0 {
+		return model.StatsConfig{}, fmt.Errorf("--outlier-min-duration must be >= 0")
+	}
+	if cfg.Refresh < 0 {
+		return model.StatsConfig{}, fmt.Errorf("--refresh must be >= 0")
+	}
+	return cfg, nil
+}
 
-	model := statsui.NewModel(st, cfg)
-	program := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := program.Run(); err != nil {
-		return fmt.Errorf("failed to run stats TUI: %w", err)
+func openStore() (*store.Store, error) {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
-	return nil
+	opts := store.Options{}
+	path := resolveDBPath(fileCfg)
+	if fileCfg.DB.AutoBackup != nil && *fileCfg.DB.AutoBackup {
+		opts.BackupDir = config.BackupDir(path)
+		opts.BackupKeep = backupKeep(fileCfg)
+	}
+	st, err := store.OpenWithOptions(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db: %w", err)
+	}
+	return st, nil
 }
 
 func newWordlistCmd() *cobra.Command {
@@ -317,23 +872,67 @@ func newWordlistCmd() *cobra.Command {
 	cmd.Flags().StringVar(&wordlistLang, "lang", "", "language code or 'all' (default: en)")
 	cmd.Flags().IntVar(&wordlistSize, "size", defaultWordlistSz, "number of words")
 	cmd.Flags().BoolVar(&wordlistForce, "force", false, "overwrite existing files")
+	cmd.Flags().StringVar(&wordlistList, "list", wordlist.DefaultList, "name of the list to write")
+	cmd.Flags().StringVar(&wordlistPreset, "preset", "", "write a bundled code keyword pack instead ("+strings.Join(wordlist.Presets(), ", ")+")")
+	cmd.Flags().StringVar(&wordlistVersion, "wordfreq-version", "", "pin a wordfreq release (e.g. 3.1.1) instead of the latest")
+	cmd.Flags().BoolVar(&wordlistTranslit, "transliterate", false, "rewrite words in ASCII letters (drop diacritics, romanize Cyrillic)")
+	cmd.Flags().BoolVar(&wordlistRules.Apostrophes, "allow-apostrophes", false, "keep words with apostrophes such as don't")
+	cmd.Flags().BoolVar(&wordlistRules.Hyphens, "allow-hyphens", false, "keep hyphenated words such as co-op")
+	cmd.Flags().IntVar(&wordlistRules.MinLength, "min-length", wordlist.DefaultMinLength, "shortest word kept, in characters")
+	cmd.Flags().IntVar(&wordlistRules.MaxLength, "max-length", wordlist.DefaultMaxLength, "longest word kept, in characters")
+	cmd.Flags().BoolVar(&wordlistBigrams, "bigrams", false, "write letter bigram/trigram frequency tables instead of a word list")
+	cmd.Flags().IntVar(&wordlistNgramTop, "ngram-top", defaultNgramTop, "n-grams kept per table with --bigrams (0 = all)")
+	cmd.Flags().StringVar(&wordlistRank, "rank", "", "frequency rank range FROM:TO to extract instead of the top --size words")
+	cmd.AddCommand(newWordlistLsCmd())
+	cmd.AddCommand(newWordlistRmCmd())
+	cmd.AddCommand(newWordlistInfoCmd())
+	cmd.AddCommand(newWordlistImportCmd())
+	cmd.AddCommand(newWordlistCombineCmd())
 	return cmd
 }
 
-func runWordlistCmd(_ *cobra.Command, _ []string) error {
-	if _, err := config.LoadConfig(config.DefaultConfigPath()); err != nil {
+func runWordlistCmd(cmd *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	listTypeNormalized := "large"
-	wordlistOutDir := config.DefaultWordListDir()
+	wordlistOutDir := config.ResolveWordListDir(rootWordlistDir, fileCfg)
 	if wordlistSize <= 0 {
 		return fmt.Errorf("--size must be greater than 0")
 	}
+	if err := validateListName(wordlistList); err != nil {
+		return err
+	}
+	if wordlistPreset != "" {
+		return writePresetWordlist(cmd, wordlistOutDir)
+	}
+	if wordlistNgramTop < 0 {
+		return fmt.Errorf("--ngram-top must be >= 0")
+	}
+	if err := wordlistRules.Validate(); err != nil {
+		return fmt.Errorf("--min-length/--max-length: %w", err)
+	}
+	rankFrom, rankTo := 1, wordlistSize
+	if wordlistRank != "" {
+		if cmd.Flags().Changed("size") {
+			return fmt.Errorf("--rank and --size cannot be combined")
+		}
+		rankFrom, rankTo, err = parseRankRange(wordlistRank)
+		if err != nil {
+			return err
+		}
+	}
 
 	cacheDir := config.DefaultWordfreqCacheDir()
 	logErrln("Fetching wordfreq metadata...")
-	wheel, err := wordfreq.DownloadLatestWheel(context.Background(), cacheDir)
+	downloadOpts, err := resolveDownloadOptions(fileCfg)
+	if err != nil {
+		return err
+	}
+	downloadOpts.Version = strings.TrimSpace(wordlistVersion)
+	wheel, err := wordfreq.DownloadLatestWheel(context.Background(), cacheDir, downloadOpts)
 	if err != nil {
 		return fmt.Errorf("failed to download wordfreq wheel: %w", err)
 	}
@@ -342,6 +941,11 @@ func runWordlistCmd(_ *cobra.Command, _ []string) error {
 	} else {
 		logErrf("Downloaded wheel %s\n", wheel.Filename)
 	}
+	if wheel.Verified {
+		logErrf("Verified sha256 %s\n", wheel.SHA256)
+	} else {
+		logErrf("Warning: the index published no sha256 for %s; wheel not verified\n", wheel.Filename)
+	}
 	langTypes, err := wordfreq.ListLanguageTypes(wheel.Path)
 	if err != nil {
 		return fmt.Errorf("failed to list languages: %w", err)
@@ -357,12 +961,18 @@ func runWordlistCmd(_ *cobra.Command, _ []string) error {
 	}
 
 	for _, langCode := range langs {
-		outPath := filepath.Join(wordlistOutDir, langCode+".txt")
+		outPath := wordlist.ListPath(wordlistOutDir, langCode, wordlistList)
+		targets := []string{outPath}
+		if wordlistBigrams {
+			targets = []string{wordlist.NgramPath(wordlistOutDir, langCode, 2), wordlist.NgramPath(wordlistOutDir, langCode, 3)}
+		}
 		if !wordlistForce {
-			if _, err := os.Stat(outPath); err == nil {
-				return fmt.Errorf("word list already exists: %s (use --force to overwrite)", outPath)
-			} else if !os.IsNotExist(err) {
-				return fmt.Errorf("failed to stat word list: %w", err)
+			for _, target := range targets {
+				if _, err := os.Stat(target); err == nil {
+					return fmt.Errorf("file already exists: %s (use --force to overwrite)", target)
+				} else if !os.IsNotExist(err) {
+					return fmt.Errorf("failed to stat %s: %w", target, err)
+				}
 			}
 		}
 
@@ -378,7 +988,7 @@ func runWordlistCmd(_ *cobra.Command, _ []string) error {
 		if selectedType != listTypeNormalized {
 			logErrf("Using %s for %s (no %s word list)\n", selectedType, langCode, listTypeNormalized)
 		}
-		words, err := wordfreq.ExtractWordlist(wheel.Path, langCode, selectedType, wordlistSize)
+		words, err := wordfreq.ExtractWordlistRange(wheel.Path, langCode, selectedType, rankFrom, rankTo, wordlistRules)
 		if err != nil {
 			if allRequested {
 				logErrf("Skipping %s (no word list): %v\n", langCode, err)
@@ -386,9 +996,42 @@ func runWordlistCmd(_ *cobra.Command, _ []string) error {
 			}
 			return fmt.Errorf("failed to extract %s word list: %w", langCode, err)
 		}
+		if wordlistTranslit {
+			words = transliterateWords(langCode, words)
+		}
+		if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if wordlistBigrams {
+			for n, target := range targets {
+				if err := writeNgramTable(target, wordlist.CountNgrams(words, n+2, wordlistNgramTop)); err != nil {
+					return fmt.Errorf("failed to write %s: %w", target, err)
+				}
+				logErrf("Wrote %s\n", target)
+			}
+			continue
+		}
 		if err := writeWordList(outPath, words); err != nil {
 			return fmt.Errorf("failed to write %s: %w", outPath, err)
 		}
+		rules := wordlistRules
+		meta := wordlist.Meta{
+			Source:          "wordfreq",
+			Lang:            langCode,
+			List:            wordlistList,
+			WordfreqVersion: wheel.Version,
+			WheelSHA256:     wheel.SHA256,
+			ListType:        selectedType,
+			Rank:            wordlistRank,
+			Filter:          wordlist.FilterName(langCode),
+			Rules:           &rules,
+			Transliterated:  wordlistTranslit,
+			Words:           len(words),
+			CreatedAt:       time.Now().UTC(),
+		}
+		if err := wordlist.WriteMeta(outPath, meta); err != nil {
+			return err
+		}
 		logErrf("Wrote %s\n", outPath)
 	}
 
@@ -399,6 +1042,122 @@ func runWordlistCmd(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// resolveDownloadOptions reads [download] settings for fetching the wordfreq wheel.
+func resolveDownloadOptions(fileCfg config.FileConfig) (wordfreq.DownloadOptions, error) {
+	opts := wordfreq.DownloadOptions{Retries: wordfreq.DefaultRetries}
+	if v := fileCfg.Download.IndexURL; v != nil {
+		opts.IndexURL = strings.TrimSpace(*v)
+	}
+	if v := fileCfg.Download.Proxy; v != nil {
+		opts.Proxy = strings.TrimSpace(*v)
+	}
+	if v := fileCfg.Download.Retries; v != nil {
+		if *v < 0 {
+			return wordfreq.DownloadOptions{}, fmt.Errorf("[download] retries must be >= 0")
+		}
+		opts.Retries = *v
+	}
+	return opts, nil
+}
+
+// resolveTheme reads [theme] colors. Unset colors keep the default palette.
+// resolveBidi reads [ui] bidi; tuipe reorders right-to-left text unless told otherwise.
+func resolveBidi(fileCfg config.FileConfig) (string, error) {
+	if fileCfg.UI.Bidi == nil {
+		return model.BidiApp, nil
+	}
+	bidi := strings.TrimSpace(*fileCfg.UI.Bidi)
+	if bidi != model.BidiApp && bidi != model.BidiTerminal {
+		return "", fmt.Errorf("[ui] bidi must be %s or %s, got %q", model.BidiApp, model.BidiTerminal, bidi)
+	}
+	return bidi, nil
+}
+
+// resolveWPMFormula layers [ui] wpm under --wpm and returns the formula, model.WPMChars
+// when neither is set.
+func resolveWPMFormula(cmd *cobra.Command, fileCfg config.FileConfig) (string, error) {
+	applyStringConfig(cmd, "wpm", &rootWPM, fileCfg.UI.WPM)
+	formula := strings.TrimSpace(rootWPM)
+	if formula == "" {
+		return model.WPMChars, nil
+	}
+	if !slices.Contains(model.WPMFormulas(), formula) {
+		return "", fmt.Errorf("--wpm must be one of %s, got %q", strings.Join(model.WPMFormulas(), ", "), formula)
+	}
+	return formula, nil
+}
+
+func resolveTheme(fileCfg config.FileConfig) (model.Theme, error) {
+	var theme model.Theme
+	if fileCfg.Theme.Palette != nil {
+		palette := strings.TrimSpace(*fileCfg.Theme.Palette)
+		if _, ok := model.LookupPalette(palette); !ok {
+			return model.Theme{}, fmt.Errorf("[theme] palette must be one of %s, got %q", strings.Join(model.PaletteNames(), ", "), palette)
+		}
+		theme.Palette = palette
+	}
+	colors := []struct {
+		name   string
+		value  *string
+		target *string
+	}{
+		{"text", fileCfg.Theme.Text, &theme.Text},
+		{"error", fileCfg.Theme.Error, &theme.Error},
+		{"pending", fileCfg.Theme.Pending, &theme.Pending},
+		{"accent", fileCfg.Theme.Accent, &theme.Accent},
+		{"muted", fileCfg.Theme.Muted, &theme.Muted},
+		{"border", fileCfg.Theme.Border, &theme.Border},
+		{"subtle", fileCfg.Theme.Subtle, &theme.Subtle},
+	}
+	for _, color := range colors {
+		if color.value == nil {
+			continue
+		}
+		value := strings.TrimSpace(*color.value)
+		if !config.ValidColor(value) {
+			return model.Theme{}, fmt.Errorf("[theme] %s must be a hex color like #C89A3A or an ANSI color number, got %q", color.name, value)
+		}
+		*color.target = value
+	}
+	// Unset colors stay empty so the UIs pick the palette variant for the background.
+	return theme, nil
+}
+
+// transliterateWords romanizes words, dropping duplicates that collapse into one spelling.
+func transliterateWords(lang string, words []string) []string {
+	out := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		word = wordlist.Transliterate(lang, word)
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		out = append(out, word)
+	}
+	return out
+}
+
+// parseRankRange parses a FROM:TO frequency rank range such as 1000:5000.
+func parseRankRange(value string) (int, int, error) {
+	fromText, toText, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("--rank must look like FROM:TO (e.g. 1000:5000)")
+	}
+	from, err := strconv.Atoi(strings.TrimSpace(fromText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --rank start %q", fromText)
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(toText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --rank end %q", toText)
+	}
+	if from < 1 || to < from {
+		return 0, 0, fmt.Errorf("--rank must satisfy 1 <= FROM <= TO")
+	}
+	return from, to, nil
+}
+
 func resolveWordlistLangs(lang string, available []string) ([]string, bool, error) {
 	lang = strings.TrimSpace(strings.ToLower(lang))
 	if lang == "" {
@@ -481,6 +1240,28 @@ func writeWordList(path string, words []string) error {
 	return nil
 }
 
+func writeNgramTable(path string, grams []wordlist.Ngram) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := wordlist.WriteNgrams(file, grams); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// resetUnchangedFlags restores flags not given on the command line to their defaults,
+// so config values removed since the last load stop applying.
+func resetUnchangedFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Changed {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+	})
+}
+
 func applyStringConfig(cmd *cobra.Command, name string, target, value *string) {
 	if value == nil {
 		return
@@ -521,33 +1302,72 @@ func applyBoolConfig(cmd *cobra.Command, name string, target, value *bool) {
 	*target = *value
 }
 
-func defaultConfigTemplate() string {
-	return fmt.Sprintf(`# tuipe configuration
-# Uncomment a value to enable it. CLI flags override config values.
-
-[practice]
-# lang = "en"             # Language code (default %q)
-# words = %d              # Words per text
-# caps = %.2f             # Probability of capitalized first letter (0-1)
-# punct = %.2f            # Punctuation probability per word (0-1)
-# punct-set = %q          # Punctuation set
-# focus-weak = false      # Bias practice toward weak characters
-# weak-top = %d           # Number of weak characters to focus on
-# weak-factor = %.1f      # Weight factor for weak characters
-# weak-window = %d        # Number of recent sessions to compute weak chars
-`,
-		defaultLang,
-		defaultWords,
-		defaultCaps,
-		defaultPunct,
-		defaultPunctSet,
-		defaultWeakTop,
-		defaultWeakFactor,
-		defaultWeakWindow,
-	)
+// resolveResultsScreen turns the results screen on for --ghost and a set --hesitation, which
+// only show there, unless --results-screen (or its config key) turns it off explicitly, in
+// which case asking for them is an error.
+func resolveResultsScreen(cmd *cobra.Command, practice config.PracticeConfig) error {
+	var needs []string
+	if practiceGhost {
+		needs = append(needs, "--ghost")
+	}
+	if practiceHesitation > 0 && (cmd.Flags().Changed("hesitation") || practice.Hesitation != nil) {
+		needs = append(needs, "--hesitation")
+	}
+	if len(needs) == 0 || practiceResults {
+		return nil
+	}
+	if cmd.Flags().Changed("results-screen") || practice.ResultsScreen != nil {
+		return fmt.Errorf("%s only show on the results screen, which --results-screen=false turns off", strings.Join(needs, " and "))
+	}
+	practiceResults = true
+	return nil
+}
+
+// defaultConfigTemplate renders the commented config with every option and its default.
+func defaultConfigTemplate(root *cobra.Command) string {
+	defaults := configDefaults(root)
+	defaults["punct-sets.es"] = strconv.Quote(generator.PunctSetForLang("es", nil))
+	return config.Template(defaults)
+}
+
+// rowsFilter keeps words typeable on the --rows of layout.
+func rowsFilter(layout, rows string) (wordlist.FilterFunc, error) {
+	names, err := wordlist.ParseRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("--rows: %w", err)
+	}
+	chars, err := wordlist.RowChars(layout, names)
+	if err != nil {
+		return nil, fmt.Errorf("--rows: %w", err)
+	}
+	return wordlist.FilterChars("", chars), nil
+}
+
+// punctWeights converts [punct-weights] to per-rune weights.
+func punctWeights(raw map[string]int) (map[rune]int, error) {
+	weights := make(map[rune]int, len(raw))
+	for mark, weight := range raw {
+		runes := []rune(mark)
+		if len(runes) != 1 {
+			return nil, fmt.Errorf("[punct-weights] key must be one character, got %q", mark)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("[punct-weights] %q must be >= 0", mark)
+		}
+		weights[runes[0]] = weight
+	}
+	return weights, nil
 }
 
 func validateConfig(cfg model.Config) error {
+	if err := validateListName(cfg.List); err != nil {
+		return err
+	}
+	switch cfg.Mode {
+	case model.ModeWords, model.ModeMarkov, model.ModeCode, model.ModeShift:
+	default:
+		return fmt.Errorf("--mode must be %q, %q, %q or %q", model.ModeWords, model.ModeMarkov, model.ModeCode, model.ModeShift)
+	}
 	if cfg.Words <= 0 {
 		return fmt.Errorf("--words must be > 0")
 	}
@@ -569,22 +1389,195 @@ func validateConfig(cfg model.Config) error {
 	if cfg.WeakWindow < 0 {
 		return fmt.Errorf("--weak-window must be >= 0")
 	}
+	if cfg.AccuracyAlert < 0 || cfg.AccuracyAlert > 1 {
+		return fmt.Errorf("--accuracy-alert must be between 0 and 1")
+	}
+	if cfg.AccuracyFloor < 0 || cfg.AccuracyFloor > 1 {
+		return fmt.Errorf("--accuracy-floor must be between 0 and 1")
+	}
+	if cfg.HesitationMs < 0 {
+		return fmt.Errorf("--hesitation must be >= 0")
+	}
+	if cfg.GoalMinutes < 0 {
+		return fmt.Errorf("--goal-minutes must be >= 0")
+	}
+	if cfg.Coverage < 0 {
+		return fmt.Errorf("--coverage must be >= 0")
+	}
+	if cfg.CodeCamel < 0 || cfg.CodeSnake < 0 || cfg.CodeScreaming < 0 {
+		return fmt.Errorf("--code-camel, --code-snake and --code-screaming must be >= 0")
+	}
+	if cfg.CodeOps < 0 || cfg.CodeOps > 1 {
+		return fmt.Errorf("--code-ops must be between 0 and 1")
+	}
+	if cfg.ShiftMid < 0 || cfg.ShiftMid > 1 || cfg.ShiftAll < 0 || cfg.ShiftAll > 1 {
+		return fmt.Errorf("--shift-mid and --shift-all must be between 0 and 1")
+	}
+	if cfg.RepeatWindow < 0 {
+		return fmt.Errorf("--repeat-window must be >= 0")
+	}
+	if cfg.StoreTextMax < 0 {
+		return fmt.Errorf("--store-text-max must be >= 0")
+	}
 	return nil
 }
 
-func resolveWordListPath(cfg model.Config) string {
-	return config.DefaultWordListPath(cfg.Lang)
+// validateListName rejects list names that would escape the language directory.
+func validateListName(list string) error {
+	if list == "" || strings.ContainsAny(list, `/\`) || list == "." || list == ".." {
+		return fmt.Errorf("--list must be a plain name like %q", wordlist.DefaultList)
+	}
+	return nil
 }
 
-func wordListLoadError(lang, path string, err error) error {
+// newTextSource picks the text source for the practice mode in cfg.
+func newTextSource(cfg model.Config, gen *generator.Generator, words []string, chain *generator.Chain, punctSet []rune) generator.TextSource {
+	style := generator.Style{CapsPct: cfg.CapsPct, PunctPct: cfg.PunctPct, PunctSet: punctSet}
+	source := newModeSource(cfg, gen, words, chain, style)
+	if cfg.Review {
+		return generator.NewBankSource(gen, source, style)
+	}
+	return source
+}
+
+// newModeSource returns the text source of cfg.Mode.
+func newModeSource(cfg model.Config, gen *generator.Generator, words []string, chain *generator.Chain, style generator.Style) generator.TextSource {
+	switch cfg.Mode {
+	case model.ModeMarkov:
+		return generator.NewMarkovSource(gen, chain, style, cfg.WeakFactor)
+	case model.ModeCode:
+		opts := generator.CodeOptions{
+			CamelWeight:     cfg.CodeCamel,
+			SnakeWeight:     cfg.CodeSnake,
+			ScreamingWeight: cfg.CodeScreaming,
+			OpsPct:          cfg.CodeOps,
+		}
+		return generator.NewCodeSource(gen, words, opts, cfg.WeakFactor)
+	case model.ModeShift:
+		opts := generator.ShiftOptions{CapsPct: cfg.CapsPct, MidCapsPct: cfg.ShiftMid, AllCapsPct: cfg.ShiftAll}
+		return generator.NewShiftSource(gen, words, opts, style, cfg.WeakFactor)
+	default:
+		return generator.NewWordSource(gen, words, style, cfg.WeakFactor)
+	}
+}
+
+// loadChain builds the markov chain from --corpus, or from wordlist frequencies without one.
+// Corpus words must pass both the language filter and charFilter.
+func loadChain(cfg model.Config, words []string, charFilter wordlist.FilterFunc) (*generator.Chain, error) {
+	if cfg.Mode != model.ModeMarkov {
+		return nil, nil
+	}
+	if cfg.Corpus == "" {
+		return generator.ChainFromWords(words), nil
+	}
+	path := config.ExpandHome(cfg.Corpus)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open corpus: %w", err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close for read-only corpus.
+			_ = cerr
+		}
+	}()
+	chain, err := generator.BuildChain(file, wordlist.Both(wordlist.FilterForLang(cfg.Lang), charFilter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus %s: %w", path, err)
+	}
+	return chain, nil
+}
+
+// detectCorpusLang picks the language for --lang auto from the --corpus text.
+func detectCorpusLang(corpus string) (string, error) {
+	if strings.TrimSpace(corpus) == "" {
+		return "", fmt.Errorf("--lang auto needs a --corpus text to detect the language from")
+	}
+	path := config.ExpandHome(strings.TrimSpace(corpus))
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open corpus: %w", err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close for read-only corpus.
+			_ = cerr
+		}
+	}()
+	lang, err := wordlist.DetectLang(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect the language of %s: %w", path, err)
+	}
+	return lang, nil
+}
+
+// loadPracticeWords loads the list for lang, falling back to the built-in list. A combined
+// code such as "en,de" interleaves the lists of each language.
+func loadPracticeWords(fileCfg config.FileConfig, lang, list string) ([]string, string, wordlist.Meta, error) {
+	langs := wordlist.SplitLangs(lang)
+	if len(langs) > 1 {
+		lists := make([][]string, 0, len(langs))
+		paths := make([]string, 0, len(langs))
+		for _, part := range langs {
+			words, path, _, err := loadPracticeWords(fileCfg, part, list)
+			if err != nil {
+				return nil, "", wordlist.Meta{}, err
+			}
+			lists = append(lists, words)
+			paths = append(paths, path)
+		}
+		words := wordlist.Interleave(lists...)
+		meta := wordlist.Meta{Source: wordlist.SourceCombined, Lang: lang, List: list, Words: len(words)}
+		return words, strings.Join(paths, ","), meta, nil
+	}
+
+	wordPath := resolveWordListPath(fileCfg, lang, list)
+	words, total, err := wordlist.LoadWords(wordPath)
+	if err != nil {
+		builtin, ok := wordlist.Builtin(lang, list)
+		if !os.IsNotExist(err) || !ok {
+			return nil, "", wordlist.Meta{}, wordListLoadError(lang, list, wordPath, err)
+		}
+		logErrf("No %s wordlist installed; using the built-in list (%d words). Run `tuipe wordlist --lang %s` for the full list.\n",
+			lang, len(builtin), lang)
+		meta := wordlist.Meta{Source: wordlist.SourceBuiltin, Lang: lang, List: wordlist.DefaultList, Words: len(builtin)}
+		return builtin, wordlist.BuiltinPath, meta, nil
+	}
+	meta, err := wordlist.Provenance(wordPath, lang, list, total)
+	if err != nil {
+		return nil, "", wordlist.Meta{}, err
+	}
+	return words, wordPath, meta, nil
+}
+
+func resolveWordListPath(fileCfg config.FileConfig, lang, list string) string {
+	return wordlist.ResolvePath(config.ResolveWordListDir(rootWordlistDir, fileCfg), lang, list)
+}
+
+// resolveDBPath applies --db, TUIPE_DB, and [paths] db over the default database path.
+func resolveDBPath(fileCfg config.FileConfig) string {
+	return config.ResolveDBPath(rootDBPath, fileCfg)
+}
+
+func wordListLoadError(lang, list, path string, err error) error {
 	lines := []string{
 		fmt.Sprintf("failed to load word list: %v", err),
 		fmt.Sprintf("expected word list at: %s", path),
+	}
+	if list != "" && list != wordlist.DefaultList {
+		lines = append(lines,
+			fmt.Sprintf("list %q for language %q not found", list, lang),
+			"Run: tuipe langs",
+			fmt.Sprintf("Add words (one per line) to %s", path),
+		)
+		return fmt.Errorf("%s", strings.Join(lines, "\n"))
+	}
+	lines = append(lines,
 		fmt.Sprintf("language %q not found", lang),
 		"Run: tuipe langs",
 		fmt.Sprintf("Download: tuipe wordlist --lang %s", lang),
 		"Download all: tuipe wordlist --lang all",
-	}
+	)
 	return fmt.Errorf("%s", strings.Join(lines, "\n"))
 }
 
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatsRebuildCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild",
+		Short: "Recompute the pre-aggregated daily stats tables",
+		Args:  cobra.NoArgs,
+		RunE:  runStatsRebuildCmd,
+	}
+}
+
+func runStatsRebuildCmd(_ *cobra.Command, _ []string) error {
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	if err := st.RebuildDailyAggregates(context.Background()); err != nil {
+		return fmt.Errorf("failed to rebuild daily aggregates: %w", err)
+	}
+	logErrln("Rebuilt daily aggregates")
+	return nil
+}
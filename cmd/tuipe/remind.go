@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+var (
+	remindAt   string
+	remindLang string
+)
+
+func newRemindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remind",
+		Short: "Print a nudge when you have not practiced today (for shell startup files)",
+		Long: "Print a one-line reminder when there is no session today and the local time is past\n" +
+			"--at; print nothing otherwise. Add it to ~/.bashrc or ~/.zshrc to be nudged in new shells.",
+		Args: cobra.NoArgs,
+		RunE: runRemindCmd,
+	}
+	cmd.Flags().StringVar(&remindAt, "at", "", "remind only after this local time (HH:MM; empty = any time)")
+	cmd.Flags().StringVar(&remindLang, "lang", "", "only count sessions in this language")
+	return cmd
+}
+
+func runRemindCmd(cmd *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	applyStringConfig(cmd, "at", &remindAt, fileCfg.Remind.At)
+	applyStringConfig(cmd, "lang", &remindLang, fileCfg.Stats.Lang)
+
+	var at time.Duration
+	if remindAt != "" {
+		if at, err = stats.ParseClock(remindAt); err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	now := time.Now()
+	summary, err := loadDaySummary(context.Background(), st, remindLang, now)
+	if err != nil {
+		return err
+	}
+	if !stats.ReminderDue(summary, at, now) {
+		return nil
+	}
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), stats.ReminderMessage(summary)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/internal/tui"
+	"github.com/verte-zerg/tuipe/pkg/replay"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+var (
+	replayOut   string
+	replaySpeed float64
+	replayCast  string
+	replayWidth int
+)
+
+func newReplayCmd(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Record a typed text to a file and play it back",
+	}
+	cmd.AddCommand(newReplayRecordCmd(root))
+	cmd.AddCommand(newReplayPlayCmd())
+	return cmd
+}
+
+func newReplayRecordCmd(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Practice one text and save its keystrokes to a replay file",
+		Long: "Starts practice with the usual flags and quits after the first completed text,\n" +
+			"saving its keystroke timings to --out. The session is stored as usual.",
+		Args: cobra.NoArgs,
+		RunE: runReplayRecordCmd,
+	}
+	cmd.Flags().AddFlagSet(root.Flags())
+	cmd.Flags().StringVarP(&replayOut, "out", "o", "session"+replay.Ext, "replay file to write")
+	return cmd
+}
+
+func newReplayPlayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "play <file" + replay.Ext + ">",
+		Short: "Replay a recorded text, or export it as an asciinema cast",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runReplayPlayCmd,
+	}
+	cmd.Flags().Float64Var(&replaySpeed, "speed", 1, "playback speed multiplier (2 = twice as fast)")
+	cmd.Flags().StringVar(&replayCast, "cast", "", "write an asciinema v2 cast to this file instead of playing")
+	cmd.Flags().IntVar(&replayWidth, "width", replay.DefaultCastWidth, "terminal width of the exported cast")
+	return cmd
+}
+
+func runReplayRecordCmd(cmd *cobra.Command, _ []string) error {
+	practiceModel, err := runPractice(cmd, true)
+	if err != nil {
+		return err
+	}
+	rec, ok := practiceModel.Replay()
+	if !ok {
+		logErrln("no text completed; nothing recorded")
+		return nil
+	}
+	if err := replay.WriteFile(replayOut, rec); err != nil {
+		return err
+	}
+	correct, incorrect := rec.CountsAt(len(rec.Keys))
+	wpm, _, acc := stats.SessionMetrics(correct, incorrect, rec.DurationMs())
+	if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Recorded %.1f WPM · %.1f%% to %s\n", wpm, acc*100, replayOut); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+func runReplayPlayCmd(cmd *cobra.Command, args []string) error {
+	if replaySpeed <= 0 {
+		return fmt.Errorf("--speed must be > 0")
+	}
+	rec, err := replay.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	if replayCast != "" {
+		return writeCastFile(replayCast, rec, replayWidth)
+	}
+
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	contentWidth := defaultContentWidth
+	if v := fileCfg.UI.ContentWidth; v != nil {
+		if *v <= 0 || *v > 1 {
+			return fmt.Errorf("[ui] content-width must be > 0 and <= 1")
+		}
+		contentWidth = *v
+	}
+	bidi, err := resolveBidi(fileCfg)
+	if err != nil {
+		return err
+	}
+	theme, err := resolveTheme(fileCfg)
+	if err != nil {
+		return err
+	}
+	tui.SetTheme(theme)
+	program := tea.NewProgram(tui.NewPlayer(rec, replaySpeed, contentWidth, bidi), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
+	}
+	return nil
+}
+
+func writeCastFile(path string, rec replay.Recording, width int) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s: %w", path, cerr)
+		}
+	}()
+	return replay.WriteCast(file, rec, width)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+const (
+	reportFormatText     = "text"
+	reportFormatMarkdown = "md"
+)
+
+var (
+	reportWeek     bool
+	reportWeeksAgo int
+	reportFormat   string
+	reportLang     string
+)
+
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Print a practice report to pipe into mail or a journal",
+		Args:  cobra.NoArgs,
+		RunE:  runReportCmd,
+	}
+	cmd.Flags().BoolVar(&reportWeek, "week", false, "report on a week (Monday to Sunday) compared with the week before")
+	cmd.Flags().IntVar(&reportWeeksAgo, "weeks-ago", 0, "report on the week this many weeks before the current one")
+	cmd.Flags().StringVar(&reportFormat, "format", reportFormatText, "output format: text or md (Markdown)")
+	cmd.Flags().StringVar(&reportLang, "lang", "", "only count sessions in this language")
+	return cmd
+}
+
+func runReportCmd(cmd *cobra.Command, _ []string) error {
+	if !reportWeek {
+		return fmt.Errorf("--week is required")
+	}
+	if reportFormat != reportFormatText && reportFormat != reportFormatMarkdown {
+		return fmt.Errorf("--format must be %q or %q", reportFormatText, reportFormatMarkdown)
+	}
+	if reportWeeksAgo < 0 {
+		return fmt.Errorf("--weeks-ago must be >= 0")
+	}
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	applyStringConfig(cmd, "lang", &reportLang, fileCfg.Stats.Lang)
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	ctx := context.Background()
+	now := time.Now()
+	start := stats.WeekStart(now).AddDate(0, 0, -7*reportWeeksAgo)
+	previous := start.AddDate(0, 0, -7)
+	sessions, err := st.ListSessions(ctx, model.StatsConfig{Lang: reportLang, Since: &previous})
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+	daily, err := st.ListDailyAggregates(ctx, model.StatsConfig{Lang: reportLang})
+	if err != nil {
+		return fmt.Errorf("failed to load daily aggregates: %w", err)
+	}
+	report := stats.WeeklyReport{
+		Week:     stats.SummarizeWeek(start, sessions),
+		Previous: stats.SummarizeWeek(previous, sessions),
+		Streak:   stats.DailyStreak(daily, now),
+	}
+	if fileCfg.Practice.GoalMinutes != nil {
+		report.GoalMinutes = *fileCfg.Practice.GoalMinutes
+	}
+	if err := stats.RenderWeeklyReport(cmd.OutOrStdout(), report, reportFormat == reportFormatMarkdown); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
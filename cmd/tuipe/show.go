@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+func newStatsShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <session-id>",
+		Short: "Show details of a single session",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runStatsShowCmd,
+	}
+}
+
+func runStatsShowCmd(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid session id %q", args[0])
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	session, err := st.GetSession(context.Background(), id)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if err := stats.RenderSessionDetail(cmd.OutOrStdout(), id, session); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// errNoPracticeToday makes status --check exit with status 1.
+var errNoPracticeToday = errors.New("no practice today")
+
+var (
+	statusFormat string
+	statusLang   string
+	statusCheck  bool
+)
+
+func newStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print a one-line summary for shell prompts and status bars",
+		Long: "Print a single templated line. Placeholders: {last_wpm}, {last_acc}, {today_best},\n" +
+			"{today_sessions}, {streak} and {practiced} (yes/no). With --check the exit status is 1\n" +
+			"when there has been no practice today.",
+		Args: cobra.NoArgs,
+		RunE: runStatusCmd,
+	}
+	cmd.Flags().StringVar(&statusFormat, "format", stats.DefaultStatusFormat, "line template")
+	cmd.Flags().StringVar(&statusLang, "lang", "", "only count sessions in this language")
+	cmd.Flags().BoolVar(&statusCheck, "check", false, "exit with status 1 when there has been no practice today")
+	return cmd
+}
+
+func runStatusCmd(cmd *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	applyStringConfig(cmd, "lang", &statusLang, fileCfg.Stats.Lang)
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	status, err := loadStatus(context.Background(), st, statusLang, time.Now())
+	if err != nil {
+		return err
+	}
+	line, err := stats.FormatStatus(statusFormat, status)
+	if err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), line); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if statusCheck && !status.PracticedToday {
+		// The exit status is the answer; there is nothing to report on stderr.
+		cmd.SilenceErrors = true
+		return errNoPracticeToday
+	}
+	return nil
+}
+
+// loadStatus reads only the latest session, today's sessions and the daily aggregates,
+// so it stays fast on large histories.
+func loadStatus(ctx context.Context, st *store.Store, lang string, now time.Time) (stats.Status, error) {
+	var status stats.Status
+	last, err := st.ListSessionsPage(ctx, model.StatsConfig{Lang: lang}, 0, 1)
+	if err != nil {
+		return status, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	if len(last) > 0 {
+		status.LastWPM, _, status.LastAccuracy = stats.SessionMetrics(last[0].Correct, last[0].Incorrect, last[0].DurationMs)
+	}
+	today, err := loadDaySummary(ctx, st, lang, now)
+	if err != nil {
+		return status, err
+	}
+	status.TodayBestWPM = today.BestWPM
+	status.TodaySessions = today.Sessions
+	status.Streak = today.Streak
+	status.PracticedToday = today.Sessions > 0
+	return status, nil
+}
+
+// loadDaySummary totals the sessions since local midnight and the current streak.
+func loadDaySummary(ctx context.Context, st *store.Store, lang string, now time.Time) (stats.DaySummary, error) {
+	y, m, d := now.Local().Date()
+	startOfDay := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	sessions, err := st.ListSessions(ctx, model.StatsConfig{Lang: lang, Since: &startOfDay})
+	if err != nil {
+		return stats.DaySummary{}, fmt.Errorf("failed to load sessions: %w", err)
+	}
+	summary := stats.SummarizeDay(startOfDay, sessions)
+
+	daily, err := st.ListDailyAggregates(ctx, model.StatsConfig{Lang: lang})
+	if err != nil {
+		return stats.DaySummary{}, fmt.Errorf("failed to load daily aggregates: %w", err)
+	}
+	summary.Streak = stats.DailyStreak(daily, now)
+	return summary, nil
+}
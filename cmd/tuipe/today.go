@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// todayCalendarWeeks is the number of weeks on the `tuipe today --calendar` heatmap.
+const todayCalendarWeeks = 12
+
+var (
+	todayLang     string
+	todayCalendar bool
+)
+
+func newTodayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "today",
+		Short: "Print today's practice summary",
+		Args:  cobra.NoArgs,
+		RunE:  runTodayCmd,
+	}
+	cmd.Flags().StringVar(&todayLang, "lang", "", "only count sessions in this language")
+	cmd.Flags().BoolVar(&todayCalendar, "calendar", false, "also print a heatmap of the last 12 weeks of practice")
+	return cmd
+}
+
+func runTodayCmd(cmd *cobra.Command, _ []string) error {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	applyStringConfig(cmd, "lang", &todayLang, fileCfg.Stats.Lang)
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := st.Close(); cerr != nil {
+			logErrf("failed to close db: %v\n", cerr)
+		}
+	}()
+
+	ctx := context.Background()
+	now := time.Now()
+	summary, err := loadDaySummary(ctx, st, todayLang, now)
+	if err != nil {
+		return err
+	}
+	if fileCfg.Practice.GoalMinutes != nil {
+		summary.GoalMinutes = *fileCfg.Practice.GoalMinutes
+	}
+	if err := stats.RenderDaySummary(cmd.OutOrStdout(), summary); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if !todayCalendar {
+		return nil
+	}
+	daily, err := st.ListDailyAggregates(ctx, model.StatsConfig{Lang: todayLang})
+	if err != nil {
+		return fmt.Errorf("failed to load daily aggregates: %w", err)
+	}
+	if _, err := fmt.Fprintln(cmd.OutOrStdout()); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if err := stats.RenderCalendar(cmd.OutOrStdout(), daily, now, todayCalendarWeeks, summary.GoalMinutes); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,517 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/verte-zerg/tuipe/internal/config"
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+)
+
+var (
+	wordlistRmList   string
+	wordlistRmYes    bool
+	wordlistInfoList string
+
+	wordlistImportMonkeytype string
+	wordlistImportLang       string
+	wordlistImportList       string
+	wordlistImportForce      bool
+
+	wordlistCombineLangs string
+	wordlistCombineOut   string
+	wordlistCombineList  string
+	wordlistCombineForce bool
+)
+
+func newWordlistLsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ls",
+		Short: "List installed wordlists with word counts, size, type and creation date",
+		Args:  cobra.NoArgs,
+		RunE:  runWordlistLsCmd,
+	}
+}
+
+func newWordlistRmCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rm <lang>",
+		Short: "Remove the wordlists of a language",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWordlistRmCmd,
+	}
+	cmd.Flags().StringVar(&wordlistRmList, "list", "", "remove only this named list (default: every list of the language)")
+	cmd.Flags().BoolVarP(&wordlistRmYes, "yes", "y", false, "skip the confirmation prompt")
+	return cmd
+}
+
+func newWordlistInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info <lang>",
+		Short: "Show details and attribution for a wordlist",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWordlistInfoCmd,
+	}
+	cmd.Flags().StringVar(&wordlistInfoList, "list", wordlist.DefaultList, "named list to describe")
+	return cmd
+}
+
+func newWordlistImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a wordlist from another tool's format",
+		Args:  cobra.NoArgs,
+		RunE:  runWordlistImportCmd,
+	}
+	cmd.Flags().StringVar(&wordlistImportMonkeytype, "monkeytype", "", "Monkeytype language JSON file ('-' for stdin)")
+	cmd.Flags().StringVar(&wordlistImportLang, "lang", "", "language code (default: from the file's bcp47 tag)")
+	cmd.Flags().StringVar(&wordlistImportList, "list", "", "list name (default: the file's name field)")
+	cmd.Flags().BoolVar(&wordlistImportForce, "force", false, "overwrite an existing list")
+	return cmd
+}
+
+func newWordlistCombineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "combine",
+		Short: "Interleave the lists of several languages into one",
+		Args:  cobra.NoArgs,
+		RunE:  runWordlistCombineCmd,
+	}
+	cmd.Flags().StringVar(&wordlistCombineLangs, "langs", "", "languages to combine, e.g. en,de")
+	cmd.Flags().StringVar(&wordlistCombineOut, "out", "", "language code to write the result under (default: the languages joined with '-')")
+	cmd.Flags().StringVar(&wordlistCombineList, "list", wordlist.DefaultList, "named list to read from each language and to write")
+	cmd.Flags().BoolVar(&wordlistCombineForce, "force", false, "overwrite an existing list")
+	return cmd
+}
+
+// wordlistEntry is one installed list as shown by `wordlist ls` and `wordlist info`.
+type wordlistEntry struct {
+	lang    string
+	list    string
+	path    string
+	words   int
+	size    int64
+	meta    wordlist.Meta
+	hasMeta bool
+	modTime time.Time
+}
+
+func loadWordlistEntry(dir, lang, list string) (wordlistEntry, error) {
+	path := wordlist.ResolvePath(dir, lang, list)
+	info, err := os.Stat(path)
+	if err != nil {
+		return wordlistEntry{}, err
+	}
+	entry := wordlistEntry{lang: lang, list: list, path: path, size: info.Size(), modTime: info.ModTime()}
+	entry.meta, entry.hasMeta, err = wordlist.ReadMeta(path)
+	if err != nil {
+		return wordlistEntry{}, err
+	}
+	if words, err := wordlist.CountWords(path); err == nil {
+		entry.words = words
+	}
+	return entry, nil
+}
+
+// listType is the wordfreq list type, or "custom" for lists without metadata.
+func (e wordlistEntry) listType() string {
+	if !e.hasMeta {
+		return "custom"
+	}
+	if e.meta.ListType == "" {
+		return "-"
+	}
+	return e.meta.ListType
+}
+
+// created prefers the recorded creation time and falls back to the file mtime.
+func (e wordlistEntry) created() time.Time {
+	if e.hasMeta && !e.meta.CreatedAt.IsZero() {
+		return e.meta.CreatedAt.Local()
+	}
+	return e.modTime
+}
+
+func wordlistDirFromConfig() (string, error) {
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	return config.ResolveWordListDir(rootWordlistDir, fileCfg), nil
+}
+
+func runWordlistLsCmd(cmd *cobra.Command, _ []string) error {
+	dir, err := wordlistDirFromConfig()
+	if err != nil {
+		return err
+	}
+	installed, err := wordlist.ListInstalled(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read wordlist directory: %w", err)
+	}
+	if len(installed) == 0 {
+		logErrf("No wordlists found. Download with: tuipe wordlist --lang <code>\n")
+		return nil
+	}
+	langs := make([]string, 0, len(installed))
+	for lang := range installed {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "LANG\tLIST\tWORDS\tSIZE\tTYPE\tCREATED")
+	for _, lang := range langs {
+		for _, list := range installed[lang] {
+			entry, err := loadWordlistEntry(dir, lang, list)
+			if err != nil {
+				return fmt.Errorf("failed to inspect %s/%s: %w", lang, list, err)
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+				lang, list, entry.words, formatBytes(entry.size), entry.listType(), entry.created().Format("2006-01-02"))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+func runWordlistRmCmd(cmd *cobra.Command, args []string) error {
+	lang := strings.TrimSpace(strings.ToLower(args[0]))
+	if err := validateListName(lang); err != nil {
+		return fmt.Errorf("invalid language %q", args[0])
+	}
+	if wordlistRmList != "" {
+		if err := validateListName(wordlistRmList); err != nil {
+			return err
+		}
+	}
+	dir, err := wordlistDirFromConfig()
+	if err != nil {
+		return err
+	}
+
+	targets := wordlistRemoveTargets(dir, lang, wordlistRmList)
+	if len(targets) == 0 {
+		if wordlistRmList != "" {
+			return fmt.Errorf("no %q wordlist installed for %s", wordlistRmList, lang)
+		}
+		return fmt.Errorf("no wordlists installed for %s", lang)
+	}
+	what := "all " + lang + " wordlists"
+	if wordlistRmList != "" {
+		what = fmt.Sprintf("the %s %q wordlist", lang, wordlistRmList)
+	}
+	if !wordlistRmYes {
+		ok, err := confirm(cmd, fmt.Sprintf("Remove %s? [y/N] ", what))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			logErrln("Aborted")
+			return nil
+		}
+	}
+	for _, target := range targets {
+		if err := os.RemoveAll(target); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", target, err)
+		}
+		logErrf("Removed %s\n", target)
+	}
+	return nil
+}
+
+// wordlistRemoveTargets returns the existing paths that make up the requested lists.
+// Without a list name this is the language directory plus any legacy <lang>.txt file.
+func wordlistRemoveTargets(dir, lang, list string) []string {
+	var candidates []string
+	if list == "" {
+		legacy := wordlist.LegacyPath(dir, lang)
+		candidates = []string{filepath.Join(dir, lang), legacy, wordlist.MetaPath(legacy)}
+	} else {
+		path := wordlist.ResolvePath(dir, lang, list)
+		candidates = []string{path, wordlist.MetaPath(path)}
+	}
+	var targets []string
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			targets = append(targets, path)
+		}
+	}
+	return targets
+}
+
+func runWordlistInfoCmd(cmd *cobra.Command, args []string) error {
+	lang := strings.TrimSpace(strings.ToLower(args[0]))
+	if err := validateListName(wordlistInfoList); err != nil {
+		return err
+	}
+	dir, err := wordlistDirFromConfig()
+	if err != nil {
+		return err
+	}
+	entry, err := loadWordlistEntry(dir, lang, wordlistInfoList)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no %q wordlist installed for %s", wordlistInfoList, lang)
+		}
+		return fmt.Errorf("failed to inspect wordlist: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	version := "-"
+	source := "custom"
+	if entry.hasMeta {
+		source = entry.meta.Source
+		if entry.meta.WordfreqVersion != "" {
+			version = entry.meta.WordfreqVersion
+		}
+	}
+	lines := []string{
+		fmt.Sprintf("Language: %s", entry.lang),
+		fmt.Sprintf("List: %s", entry.list),
+		fmt.Sprintf("Path: %s", entry.path),
+		fmt.Sprintf("Words: %d", entry.words),
+		fmt.Sprintf("Size: %s", formatBytes(entry.size)),
+		fmt.Sprintf("Source: %s", source),
+		fmt.Sprintf("Type: %s", entry.listType()),
+		fmt.Sprintf("Wordfreq version: %s", version),
+		fmt.Sprintf("Created: %s", entry.created().Format("2006-01-02 15:04")),
+	}
+	if entry.meta.Rank != "" {
+		lines = append(lines, fmt.Sprintf("Rank range: %s", entry.meta.Rank))
+	}
+	if entry.meta.Filter != "" {
+		lines = append(lines, fmt.Sprintf("Filter: %s", entry.meta.Filter))
+	}
+	if rules := entry.meta.Rules; rules != nil {
+		lines = append(lines, fmt.Sprintf("Rules: length %d-%d, apostrophes=%t, hyphens=%t",
+			rules.MinLength, rules.MaxLength, rules.Apostrophes, rules.Hyphens))
+	}
+	if entry.meta.Transliterated {
+		lines = append(lines, "Transliterated: yes")
+	}
+	if entry.meta.WheelSHA256 != "" {
+		lines = append(lines, fmt.Sprintf("Wheel sha256: %s", entry.meta.WheelSHA256))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return printAttribution(out, filepath.Join(dir, "ATTRIBUTION.txt"))
+}
+
+func printAttribution(out io.Writer, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read attribution: %w", err)
+	}
+	if _, err := fmt.Fprintf(out, "\nAttribution (%s):\n%s", path, data); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// writePresetWordlist writes a bundled code keyword pack. The list is named after the
+// preset unless --list is given, so it can be picked with e.g. `tuipe --list go-code`.
+func writePresetWordlist(cmd *cobra.Command, dir string) error {
+	words, err := wordlist.Preset(wordlistPreset)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("size") || wordlistRank != "" {
+		return fmt.Errorf("--preset cannot be combined with --size or --rank")
+	}
+	lang := strings.TrimSpace(strings.ToLower(wordlistLang))
+	if lang == "" {
+		lang = wordlist.BuiltinLang
+	}
+	if lang == "all" || strings.Contains(lang, ",") || validateListName(lang) != nil {
+		return fmt.Errorf("--preset needs a single --lang")
+	}
+	list := wordlistPreset
+	if cmd.Flags().Changed("list") {
+		list = wordlistList
+	}
+
+	outPath := wordlist.ListPath(dir, lang, list)
+	if !wordlistForce {
+		if _, err := os.Stat(outPath); err == nil {
+			return fmt.Errorf("word list already exists: %s (use --force to overwrite)", outPath)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to stat word list: %w", err)
+		}
+	}
+	if err := writeWordList(outPath, words); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	meta := wordlist.Meta{
+		Source:    "preset",
+		Lang:      lang,
+		List:      list,
+		ListType:  wordlistPreset,
+		Words:     len(words),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := wordlist.WriteMeta(outPath, meta); err != nil {
+		return err
+	}
+	logErrf("Wrote %s (practice with: tuipe --lang %s --list %s)\n", outPath, lang, list)
+	return nil
+}
+
+func runWordlistImportCmd(cmd *cobra.Command, _ []string) error {
+	if wordlistImportMonkeytype == "" {
+		return fmt.Errorf("--monkeytype <file> is required")
+	}
+	list, err := readMonkeytypeFile(cmd, wordlistImportMonkeytype)
+	if err != nil {
+		return err
+	}
+
+	lang := strings.TrimSpace(strings.ToLower(wordlistImportLang))
+	if lang == "" {
+		lang = list.Lang()
+	}
+	if lang == "" {
+		return fmt.Errorf("the file has no bcp47 language tag; pass --lang")
+	}
+	if validateListName(lang) != nil {
+		return fmt.Errorf("invalid language %q", lang)
+	}
+	name := strings.TrimSpace(wordlistImportList)
+	if name == "" {
+		name = strings.TrimSpace(list.Name)
+	}
+	if name == "" {
+		return fmt.Errorf("the file has no name; pass --list")
+	}
+	if err := validateListName(name); err != nil {
+		return err
+	}
+
+	dir, err := wordlistDirFromConfig()
+	if err != nil {
+		return err
+	}
+	outPath := wordlist.ListPath(dir, lang, name)
+	if !wordlistImportForce {
+		if _, err := os.Stat(outPath); err == nil {
+			return fmt.Errorf("word list already exists: %s (use --force to overwrite)", outPath)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to stat word list: %w", err)
+		}
+	}
+	if err := writeWordList(outPath, list.Words); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	meta := wordlist.Meta{
+		Source:    "monkeytype:" + list.Name,
+		Lang:      lang,
+		List:      name,
+		ListType:  "monkeytype",
+		Words:     len(list.Words),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := wordlist.WriteMeta(outPath, meta); err != nil {
+		return err
+	}
+	logErrf("Imported %d words to %s (practice with: tuipe --lang %s --list %s)\n", len(list.Words), outPath, lang, name)
+	return nil
+}
+
+func readMonkeytypeFile(cmd *cobra.Command, path string) (wordlist.Monkeytype, error) {
+	if path == "-" {
+		return wordlist.ParseMonkeytype(cmd.InOrStdin())
+	}
+	file, err := os.Open(config.ExpandHome(path))
+	if err != nil {
+		return wordlist.Monkeytype{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close for read-only import file.
+			_ = cerr
+		}
+	}()
+	return wordlist.ParseMonkeytype(file)
+}
+
+func runWordlistCombineCmd(_ *cobra.Command, _ []string) error {
+	langs := wordlist.SplitLangs(wordlistCombineLangs)
+	if len(langs) < 2 {
+		return fmt.Errorf("--langs needs at least two languages, e.g. en,de")
+	}
+	if err := validateListName(wordlistCombineList); err != nil {
+		return err
+	}
+	out := strings.TrimSpace(strings.ToLower(wordlistCombineOut))
+	if out == "" {
+		out = strings.Join(langs, "-")
+	}
+	if validateListName(out) != nil || strings.Contains(out, ",") {
+		return fmt.Errorf("invalid --out %q", wordlistCombineOut)
+	}
+
+	fileCfg, err := config.LoadConfig(config.DefaultConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	words, _, _, err := loadPracticeWords(fileCfg, strings.Join(langs, ","), wordlistCombineList)
+	if err != nil {
+		return err
+	}
+	dir := config.ResolveWordListDir(rootWordlistDir, fileCfg)
+	outPath := wordlist.ListPath(dir, out, wordlistCombineList)
+	if !wordlistCombineForce {
+		if _, err := os.Stat(outPath); err == nil {
+			return fmt.Errorf("word list already exists: %s (use --force to overwrite)", outPath)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to stat word list: %w", err)
+		}
+	}
+	if err := writeWordList(outPath, words); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	meta := wordlist.Meta{
+		Source:    wordlist.SourceCombined + ":" + strings.Join(langs, ","),
+		Lang:      out,
+		List:      wordlistCombineList,
+		Filter:    wordlist.FilterName(strings.Join(langs, ",")),
+		Words:     len(words),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := wordlist.WriteMeta(outPath, meta); err != nil {
+		return err
+	}
+	logErrf("Wrote %d words to %s (practice with: tuipe --lang %s)\n", len(words), outPath, out)
+	return nil
+}
@@ -0,0 +1,358 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/internal/leaderboard"
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// MinPlotHeight is the smallest [ui] plot-height that still draws a readable plot.
+const MinPlotHeight = 4
+
+// Issue is a problem found in a config file. Line is 0 when it cannot be located.
+type Issue struct {
+	Line    int
+	Key     string
+	Message string
+}
+
+// String formats the issue as "line N: key: message".
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Key != "" {
+		b.WriteString(i.Key + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// rangeRules validate the values of [section] keys; they return "" when the value is fine.
+var rangeRules = map[string]func(any) string{
+	"practice.list":           plainName,
+	"practice.mode":           oneOf(model.ModeWords, model.ModeMarkov, model.ModeCode, model.ModeShift),
+	"practice.words":          intAtLeast(1),
+	"practice.rows":           keyboardRows,
+	"practice.caps":           fraction,
+	"practice.punct":          fraction,
+	"practice.weak-top":       intAtLeast(0),
+	"practice.weak-factor":    floatAtLeast(0),
+	"practice.weak-window":    intAtLeast(0),
+	"practice.coverage":       intAtLeast(0),
+	"practice.repeat-window":  intAtLeast(0),
+	"practice.code-camel":     floatAtLeast(0),
+	"practice.code-snake":     floatAtLeast(0),
+	"practice.code-screaming": floatAtLeast(0),
+	"practice.code-ops":       fraction,
+	"practice.shift-mid":      fraction,
+	"practice.shift-all":      fraction,
+	"practice.store-text-max": intAtLeast(0),
+	"practice.hesitation":     intAtLeast(0),
+	"practice.accuracy-alert": fraction,
+	"practice.accuracy-floor": fraction,
+	"practice.goal-minutes":   intAtLeast(0),
+	"stats.last":              intAtLeast(0),
+	"stats.curve-window":      intAtLeast(1),
+	"stats.refresh":           intAtLeast(0),
+	"ui.content-width":        positiveFraction,
+	"ui.bidi":                 oneOf(model.BidiApp, model.BidiTerminal),
+	"ui.wpm":                  oneOf(model.WPMFormulas()...),
+	"ui.plot-height":          intAtLeast(MinPlotHeight),
+	"ui.lang":                 uiLang,
+	"db.backup-keep":          intAtLeast(0),
+	"download.retries":        intAtLeast(0),
+	"hooks.timeout":           intAtLeast(1),
+	"remind.at":               clock,
+	"leaderboard.url":         httpURL,
+	"leaderboard.name":        shortName,
+	"leaderboard.group":       shortName,
+	"theme.palette":           oneOf(model.PaletteNames()...),
+	"theme.text":              color,
+	"theme.error":             color,
+	"theme.pending":           color,
+	"theme.accent":            color,
+	"theme.muted":             color,
+	"theme.border":            color,
+	"theme.subtle":            color,
+}
+
+// Check parses the config at path and reports syntax errors, unknown keys and
+// out-of-range values. A missing file has no issues.
+func Check(path string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return checkData(data), nil
+}
+
+// checkData reports the issues of config file contents.
+func checkData(data []byte) []Issue {
+	var cfg FileConfig
+	md, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		return []Issue{decodeIssue(err)}
+	}
+
+	lines := keyLines(data)
+	var issues []Issue
+	for _, key := range md.Undecoded() {
+		name := key.String()
+		issues = append(issues, Issue{Line: lines[name], Key: name, Message: "unknown key"})
+	}
+	check := func(section, ruleSection string, fields []Field) {
+		for _, field := range fields {
+			rule := rangeRules[ruleSection+"."+field.Key]
+			if field.Value == nil || rule == nil {
+				continue
+			}
+			if msg := rule(field.Value); msg != "" {
+				name := section + "." + field.Key
+				issues = append(issues, Issue{Line: lines[name], Key: name, Message: msg})
+			}
+		}
+	}
+	for _, section := range cfg.Sections() {
+		check(section.Name, section.Name, section.Fields)
+	}
+	for _, name := range cfg.PresetNames() {
+		check("preset."+name, "practice", SectionFields(cfg.Presets[name]))
+	}
+	for mark, weight := range cfg.PunctWeights {
+		name := "punct-weights." + mark
+		if utf8.RuneCountInString(mark) != 1 {
+			issues = append(issues, Issue{Line: lines[name], Key: name, Message: fmt.Sprintf("key must be one character, got %q", mark)})
+		} else if weight < 0 {
+			issues = append(issues, Issue{Line: lines[name], Key: name, Message: fmt.Sprintf("must be >= 0, got %d", weight)})
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
+
+// tomlErrorPrefix matches the location prefix of decoder errors:
+// toml: line 3 (last key "practice.words"): ...
+var tomlErrorPrefix = regexp.MustCompile(`^toml: (?:line (\d+))? ?(?:\(last key "([^"]*)"\))?:?\s*`)
+
+// decodeIssue turns a decoder error into an issue, moving its location into Line and Key.
+func decodeIssue(err error) Issue {
+	issue := Issue{Message: err.Error()}
+	var perr toml.ParseError
+	if errors.As(err, &perr) {
+		issue.Line = perr.Position.Line
+		issue.Key = perr.LastKey
+	}
+	if match := tomlErrorPrefix.FindStringSubmatch(issue.Message); match != nil {
+		if line, convErr := strconv.Atoi(match[1]); convErr == nil && issue.Line == 0 {
+			issue.Line = line
+		}
+		if issue.Key == "" {
+			issue.Key = match[2]
+		}
+		issue.Message = issue.Message[len(match[0]):]
+	}
+	return issue
+}
+
+// ValidColor accepts #RGB, #RRGGBB and ANSI color numbers 0-255.
+func ValidColor(value string) bool {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n >= 0 && n <= 255
+	}
+	if !strings.HasPrefix(value, "#") || (len(value) != 4 && len(value) != 7) {
+		return false
+	}
+	_, err := strconv.ParseUint(value[1:], 16, 32)
+	return err == nil
+}
+
+// configLine is a key or table header found while scanning a config file.
+type configLine struct {
+	table     string
+	key       string
+	header    bool
+	commented bool // a "# key = value" line, as written by the template
+}
+
+// scanLines classifies each line of a config file; index i describes line i+1.
+func scanLines(data []byte) []configLine {
+	var lines []configLine
+	table := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		info := configLine{table: table}
+		commented := false
+		if rest, ok := strings.CutPrefix(line, "#"); ok {
+			line, commented = strings.TrimSpace(rest), true
+		}
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "["):
+			if !commented {
+				table = unquoteKey(strings.Trim(strings.SplitN(line, "#", 2)[0], "[] \t"))
+				info.table, info.header = table, true
+			}
+		default:
+			if key, _, ok := strings.Cut(line, "="); ok {
+				info.key = unquoteKey(strings.TrimSpace(key))
+				info.commented = commented
+			}
+		}
+		lines = append(lines, info)
+	}
+	return lines
+}
+
+// keyLines maps dotted keys ("practice.caps") and tables to the line that sets them.
+func keyLines(data []byte) map[string]int {
+	lines := map[string]int{}
+	for i, line := range scanLines(data) {
+		switch {
+		case line.commented:
+		case line.key != "" && line.table != "":
+			lines[line.table+"."+line.key] = i + 1
+		case line.key != "":
+			lines[line.key] = i + 1
+		case line.header:
+			lines[line.table] = i + 1
+		}
+	}
+	return lines
+}
+
+// unquoteKey normalizes a dotted key, dropping quotes and spaces around parts.
+func unquoteKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
+
+func intAtLeast(min int) func(any) string {
+	return func(v any) string {
+		if n, ok := v.(int); ok && n < min {
+			return fmt.Sprintf("must be >= %d, got %d", min, n)
+		}
+		return ""
+	}
+}
+
+func floatAtLeast(min float64) func(any) string {
+	return func(v any) string {
+		if f, ok := v.(float64); ok && f < min {
+			return fmt.Sprintf("must be >= %g, got %g", min, f)
+		}
+		return ""
+	}
+}
+
+func fraction(v any) string {
+	if f, ok := v.(float64); ok && (f < 0 || f > 1) {
+		return fmt.Sprintf("must be between 0 and 1, got %g", f)
+	}
+	return ""
+}
+
+func positiveFraction(v any) string {
+	if f, ok := v.(float64); ok && (f <= 0 || f > 1) {
+		return fmt.Sprintf("must be > 0 and <= 1, got %g", f)
+	}
+	return ""
+}
+
+func uiLang(v any) string {
+	s, _ := v.(string)
+	if s != "" && !i18n.Supported(s) {
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(i18n.Langs(), ", "), s)
+	}
+	return ""
+}
+
+func oneOf(values ...string) func(any) string {
+	return func(v any) string {
+		s, _ := v.(string)
+		for _, value := range values {
+			if s == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(values, ", "), s)
+	}
+}
+
+func plainName(v any) string {
+	s, _ := v.(string)
+	if s == "" || strings.ContainsAny(s, `/\`) || s == "." || s == ".." {
+		return fmt.Sprintf("must be a plain name, got %q", s)
+	}
+	return ""
+}
+
+func keyboardRows(v any) string {
+	s, _ := v.(string)
+	if _, err := wordlist.ParseRows(s); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func httpURL(v any) string {
+	s, _ := v.(string)
+	if s == "" {
+		return ""
+	}
+	if u, err := url.Parse(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Sprintf("must be an http(s) URL, got %q", s)
+	}
+	return ""
+}
+
+func shortName(v any) string {
+	s, _ := v.(string)
+	if n := utf8.RuneCountInString(strings.TrimSpace(s)); n > leaderboard.MaxNameLen {
+		return fmt.Sprintf("must be at most %d characters, got %d", leaderboard.MaxNameLen, n)
+	}
+	return ""
+}
+
+func clock(v any) string {
+	s, _ := v.(string)
+	if s == "" {
+		return ""
+	}
+	if _, err := stats.ParseClock(s); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func color(v any) string {
+	s, _ := v.(string)
+	if !ValidColor(strings.TrimSpace(s)) {
+		return fmt.Sprintf("must be a hex color like #C89A3A or an ANSI color number, got %q", s)
+	}
+	return ""
+}
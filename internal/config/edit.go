@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SetValue writes key = value into the config file at path, keeping the other lines
+// and comments. value is parsed by the type of key and checked like Check does; a
+// commented-out "# key = ..." line from the template is replaced in place.
+func SetValue(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	var cfg FileConfig
+	if _, err := toml.Decode(string(data), &cfg); err != nil {
+		return fmt.Errorf("failed to decode config: %w", err)
+	}
+	field, ok := cfg.Lookup(key)
+	if !ok {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	parsed, err := parseValue(field.Kind, value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	table, name := splitKey(key)
+	var line bytes.Buffer
+	if err := toml.NewEncoder(&line).Encode(map[string]any{name: parsed}); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	updated := setLine(data, table, name, strings.TrimRight(line.String(), "\n"))
+	for _, issue := range checkData(updated) {
+		if issue.Key == key || issue.Key == "" {
+			return fmt.Errorf("%s: %s", key, issue.Message)
+		}
+	}
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// parseValue converts a command-line value to the Go type stored for a key.
+func parseValue(kind reflect.Kind, value string) (any, error) {
+	switch kind {
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", value)
+		}
+		return n, nil
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", value)
+		}
+		return f, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false, got %q", value)
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
+
+// setLine replaces the line that sets table.name with line. Without one it replaces a
+// commented-out template line, then inserts below the table header, then appends the table.
+func setLine(data []byte, table, name, line string) []byte {
+	text := strings.TrimSuffix(string(data), "\n")
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	scanned := scanLines(data)
+	active, commented, header := -1, -1, -1
+	for i, info := range scanned {
+		if info.table != table {
+			continue
+		}
+		switch {
+		case info.header && header < 0:
+			header = i
+		case info.key == name && !info.commented:
+			active = i
+		case info.key == name && commented < 0:
+			commented = i
+		}
+	}
+	switch {
+	case active >= 0:
+		lines[active] = line
+	case commented >= 0:
+		lines[commented] = line
+	case header >= 0:
+		lines = append(lines[:header+1], append([]string{line}, lines[header+1:]...)...)
+	default:
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+table+"]", line)
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Field is one key of a config section. Value is nil when the key is not set.
+type Field struct {
+	Key   string
+	Doc   string
+	Kind  reflect.Kind
+	Value any
+}
+
+// Section is a table of the config file, e.g. [practice].
+type Section struct {
+	Name   string
+	Doc    string
+	Fields []Field
+}
+
+// Sections lists the fixed tables of the config in file order. Map tables such as
+// [punct-sets] and [preset.<name>] are not included.
+func (c FileConfig) Sections() []Section {
+	var sections []Section
+	v := reflect.ValueOf(c)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if v.Field(i).Kind() != reflect.Struct {
+			continue
+		}
+		sections = append(sections, Section{
+			Name:   tomlName(t.Field(i)),
+			Doc:    t.Field(i).Tag.Get("doc"),
+			Fields: SectionFields(v.Field(i).Interface()),
+		})
+	}
+	return sections
+}
+
+// SectionFields lists the keys of a section struct such as PracticeConfig in
+// declaration order.
+func SectionFields(section any) []Field {
+	v := reflect.ValueOf(section)
+	t := v.Type()
+	fields := make([]Field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := Field{
+			Key:  tomlName(t.Field(i)),
+			Doc:  t.Field(i).Tag.Get("doc"),
+			Kind: t.Field(i).Type.Elem().Kind(),
+		}
+		if ptr := v.Field(i); !ptr.IsNil() {
+			field.Value = ptr.Elem().Interface()
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Lookup finds a dotted key such as "practice.words", "preset.code.mode" or
+// "punct-sets.es" or "punct-weights.,". It reports false for keys the config does not know.
+func (c FileConfig) Lookup(key string) (Field, bool) {
+	table, name := splitKey(key)
+	if table == "" {
+		return Field{}, false
+	}
+	if table == "punct-sets" {
+		field := Field{Key: name, Kind: reflect.String}
+		if value, ok := c.PunctSets[name]; ok {
+			field.Value = value
+		}
+		return field, true
+	}
+	if table == "punct-weights" {
+		field := Field{Key: name, Kind: reflect.Int}
+		if value, ok := c.PunctWeights[name]; ok {
+			field.Value = value
+		}
+		return field, true
+	}
+	var fields []Field
+	if preset, ok := strings.CutPrefix(table, "preset."); ok {
+		fields = SectionFields(c.Presets[preset])
+	} else {
+		for _, section := range c.Sections() {
+			if section.Name == table {
+				fields = section.Fields
+			}
+		}
+	}
+	for _, field := range fields {
+		if field.Key == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// splitKey splits "preset.code.mode" into the table "preset.code" and the key "mode".
+func splitKey(key string) (string, string) {
+	i := strings.LastIndex(key, ".")
+	if i <= 0 || i == len(key)-1 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+func tomlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+	return name
+}
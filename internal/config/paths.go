@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables that override data locations.
+const (
+	EnvDB        = "TUIPE_DB"
+	EnvWordlists = "TUIPE_WORDLISTS"
+)
+
+// ResolveDBPath picks the database path from the flag value, TUIPE_DB, [paths] db, then the XDG default.
+func ResolveDBPath(flagValue string, cfg FileConfig) string {
+	return resolvePath(flagValue, EnvDB, cfg.Paths.DB, DefaultDBPath())
+}
+
+// ResolveWordListDir picks the wordlist directory from the flag value, TUIPE_WORDLISTS, [paths] wordlists, then the XDG default.
+func ResolveWordListDir(flagValue string, cfg FileConfig) string {
+	return resolvePath(flagValue, EnvWordlists, cfg.Paths.Wordlists, DefaultWordListDir())
+}
+
+// BackupDir returns the backups directory that sits next to the database file.
+func BackupDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+func resolvePath(flagValue, env string, fileValue *string, fallback string) string {
+	if flagValue != "" {
+		return ExpandHome(flagValue)
+	}
+	if v := os.Getenv(env); v != "" {
+		return ExpandHome(v)
+	}
+	if fileValue != nil && *fileValue != "" {
+		return ExpandHome(*fileValue)
+	}
+	return fallback
+}
+
+// ExpandHome replaces a leading ~ with the user home directory.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
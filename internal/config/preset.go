@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PresetNames returns the names of the [preset.<name>] blocks, sorted.
+func (c FileConfig) PresetNames() []string {
+	names := make([]string, 0, len(c.Presets))
+	for name := range c.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PracticeWithPreset returns the [practice] settings with the values set in
+// [preset.<name>] laid over them. An empty name returns [practice] unchanged.
+func (c FileConfig) PracticeWithPreset(name string) (PracticeConfig, error) {
+	if name == "" {
+		return c.Practice, nil
+	}
+	preset, ok := c.Presets[name]
+	if !ok {
+		if len(c.Presets) == 0 {
+			return PracticeConfig{}, fmt.Errorf("unknown preset %q: no [preset.<name>] blocks in config", name)
+		}
+		return PracticeConfig{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(c.PresetNames(), ", "))
+	}
+	merged := c.Practice
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(preset)
+	for i := 0; i < src.NumField(); i++ {
+		if field := src.Field(i); !field.IsNil() {
+			dst.Field(i).Set(field)
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Template renders a commented config file listing every key of every section.
+// defaults maps "section.key" to the TOML literal shown for the key; keys without a
+// default are shown as empty strings. "punct-sets.es" fills the [punct-sets] example.
+func Template(defaults map[string]string) string {
+	var b strings.Builder
+	b.WriteString("# tuipe configuration\n")
+	b.WriteString("# Uncomment a value to enable it. CLI flags override config values.\n")
+	for _, section := range (FileConfig{}).Sections() {
+		lines := make([]string, 0, len(section.Fields))
+		width := 0
+		for _, field := range section.Fields {
+			value, ok := defaults[section.Name+"."+field.Key]
+			if !ok {
+				value = `""`
+			}
+			line := fmt.Sprintf("# %s = %s", field.Key, value)
+			width = max(width, len(line))
+			lines = append(lines, line)
+		}
+		b.WriteString("\n")
+		if section.Doc != "" {
+			fmt.Fprintf(&b, "# %s\n", section.Doc)
+		}
+		fmt.Fprintf(&b, "[%s]\n", section.Name)
+		for i, line := range lines {
+			fmt.Fprintf(&b, "%-*s  # %s\n", width, line, section.Fields[i].Doc)
+		}
+	}
+
+	b.WriteString(`
+# Named presets take any [practice] key and are applied with --preset NAME.
+# [preset.code]
+# mode = "code"
+# list = "go-code"
+#
+# [preset.speed]
+# words = 50
+# caps = 0.0
+# punct = 0.0
+
+[punct-sets]
+# Default punctuation per language code, used when punct-set is not set.
+`)
+	if example, ok := defaults["punct-sets.es"]; ok {
+		fmt.Fprintf(&b, "# es = %s\n", example)
+	}
+	b.WriteString(`
+[punct-weights]
+# How often each mark is drawn relative to the others (default 1 for every mark; 0 leaves it out).
+# "," = 5
+# "." = 3
+`)
+	return b.String()
+}
@@ -10,20 +10,152 @@ import (
 
 // FileConfig represents the TOML configuration file.
 type FileConfig struct {
-	Practice PracticeConfig `toml:"practice"`
+	Practice    PracticeConfig    `toml:"practice" doc:"Practice settings (tuipe). Flags override these values."`
+	Stats       StatsConfig       `toml:"stats" doc:"Defaults for tuipe stats and its subcommands."`
+	DB          DBConfig          `toml:"db" doc:"Database maintenance."`
+	Paths       PathsConfig       `toml:"paths" doc:"Data locations (TUIPE_DB / TUIPE_WORDLISTS and --db / --wordlist-dir win)."`
+	Download    DownloadConfig    `toml:"download" doc:"Wordlist downloads."`
+	UI          UIConfig          `toml:"ui" doc:"Layout of the practice and stats UIs."`
+	Theme       ThemeConfig       `toml:"theme" doc:"Colors: hex values (#RRGGBB) or ANSI color numbers (0-255)."`
+	Hooks       HooksConfig       `toml:"hooks" doc:"Run after every completed session with the session JSON."`
+	Remind      RemindConfig      `toml:"remind" doc:"Defaults for tuipe remind."`
+	Leaderboard LeaderboardConfig `toml:"leaderboard" doc:"Daily challenge leaderboard (tuipe --daily, served by tuipe-server)."`
+
+	// PunctSets overrides the default punctuation set per language code.
+	PunctSets map[string]string `toml:"punct-sets"`
+
+	// PunctWeights sets how often each punctuation mark is drawn relative to the others.
+	PunctWeights map[string]int `toml:"punct-weights"`
+
+	// Presets holds named [preset.<name>] blocks of practice settings applied with --preset.
+	Presets map[string]PracticeConfig `toml:"preset"`
 }
 
 // PracticeConfig maps practice-related settings.
 type PracticeConfig struct {
-	Lang       *string  `toml:"lang"`
-	Words      *int     `toml:"words"`
-	CapsPct    *float64 `toml:"caps"`
-	PunctPct   *float64 `toml:"punct"`
-	PunctSet   *string  `toml:"punct-set"`
-	FocusWeak  *bool    `toml:"focus-weak"`
-	WeakTop    *int     `toml:"weak-top"`
-	WeakFactor *float64 `toml:"weak-factor"`
-	WeakWindow *int     `toml:"weak-window"`
+	Lang       *string  `toml:"lang" doc:"Language code, several like en,de to interleave their lists, or auto to detect it from corpus"`
+	List       *string  `toml:"list" doc:"Named wordlist for the language (<lang>/<list>.txt)"`
+	Words      *int     `toml:"words" doc:"Words per text"`
+	CapsPct    *float64 `toml:"caps" doc:"Probability of capitalized first letter (0-1)"`
+	PunctPct   *float64 `toml:"punct" doc:"Punctuation probability per word (0-1)"`
+	PunctSet   *string  `toml:"punct-set" doc:"Punctuation set for every language (empty = per-language default)"`
+	FocusWeak  *bool    `toml:"focus-weak" doc:"Bias practice toward weak characters"`
+	WeakTop    *int     `toml:"weak-top" doc:"Number of weak characters to focus on"`
+	WeakFactor *float64 `toml:"weak-factor" doc:"Weight factor for weak characters"`
+	WeakWindow *int     `toml:"weak-window" doc:"Number of recent sessions to compute weak chars"`
+	Coverage   *int     `toml:"coverage" doc:"Favor characters typed fewer than this many times in the weak window (0 = off)"`
+	SRS        *bool    `toml:"srs" doc:"Resurface previously mistyped characters and words on a spaced-repetition schedule"`
+	Review     *bool    `toml:"review" doc:"Build texts mostly from the mistake bank of recently mistyped words"`
+	Keyboard   *string  `toml:"keyboard" doc:"Physical keyboard recorded with each session"`
+	Layout     *string  `toml:"layout" doc:"Keyboard layout recorded with each session and used by rows"`
+	Mode       *string  `toml:"mode" doc:"Practice mode: words, markov (sentence-like text), code or shift (capital-letter drill)"`
+	Corpus     *string  `toml:"corpus" doc:"Text file whose word pairs drive markov mode"`
+
+	ExcludeChars *string `toml:"exclude-chars" doc:"Drop words containing any of these characters"`
+	OnlyChars    *string `toml:"only-chars" doc:"Only use words made entirely of these characters"`
+	Rows         *string `toml:"rows" doc:"Only use words typeable on these keyboard rows of the layout (e.g. \"top,home\")"`
+
+	RepeatWindow  *int  `toml:"repeat-window" doc:"Words a new word must differ from (0 allows immediate repeats)"`
+	SentenceStyle *bool `toml:"sentence-style" doc:"Shape text into sentences (capitals after . ? !, clause punctuation)"`
+
+	CodeCamel     *float64 `toml:"code-camel" doc:"Code mode: relative weight of camelCase identifiers"`
+	CodeSnake     *float64 `toml:"code-snake" doc:"Code mode: relative weight of snake_case identifiers"`
+	CodeScreaming *float64 `toml:"code-screaming" doc:"Code mode: relative weight of SCREAMING_CASE identifiers"`
+	CodeOps       *float64 `toml:"code-ops" doc:"Code mode: probability a token is an operator (0-1)"`
+
+	ShiftMid *float64 `toml:"shift-mid" doc:"Shift mode: probability a word gets a capital inside it (0-1)"`
+	ShiftAll *float64 `toml:"shift-all" doc:"Shift mode: probability a word is in ALL CAPS (0-1)"`
+
+	ResultsScreen *bool `toml:"results-screen" doc:"Show a results screen after each text (turned on by ghost or a set hesitation)"`
+	Hesitation    *int  `toml:"hesitation" doc:"Pause in ms highlighted on the results rhythm strip (0 = no strip; setting it turns on results-screen)"`
+	Ghost         *bool `toml:"ghost" doc:"Review the text on the results screen with the wrong keys typed shown faintly under it (turns on results-screen)"`
+
+	AccuracyAlert  *float64 `toml:"accuracy-alert" doc:"Turn the footer red while accuracy over the last 20 keys is below this (0-1, 0 = off)"`
+	AccuracyFloor  *float64 `toml:"accuracy-floor" doc:"Start a new text when accuracy over the last 20 keys drops below this (0-1, 0 = off)"`
+	FooterTrend    *bool    `toml:"footer-trend" doc:"Show a sparkline of the last 20 session speeds in the footer"`
+	GoalMinutes    *int     `toml:"goal-minutes" doc:"Daily practice goal in minutes shown in the footer and tuipe today (0 = off)"`
+	Zen            *bool    `toml:"zen" doc:"Start with the footer hidden, showing only the text (esc toggles it)"`
+	RecordEnv      *bool    `toml:"record-env" doc:"Save the terminal, OS and active practice options with each session"`
+	StoreText      *bool    `toml:"store-text" doc:"Save target and typed text with each session"`
+	StoreTextMax   *int     `toml:"store-text-max" doc:"Max bytes of text saved per session (0 = no cap)"`
+	StoreKeys      *bool    `toml:"store-keys" doc:"Save each session's keystrokes for the stats replay tab (needs the text within store-text-max)"`
+	SaveIncomplete *bool    `toml:"save-incomplete" doc:"Save the current text as an incomplete session on quit"`
+
+	LooseApostrophe *bool   `toml:"loose-apostrophe" doc:"Accept ' for ’ and ’ for ' while typing"`
+	Equivalents     *string `toml:"equivalents" doc:"Space-separated groups of characters that count as the same key"`
+}
+
+// StatsConfig maps stats-related settings.
+type StatsConfig struct {
+	Lang              *string `toml:"lang" doc:"Default language filter"`
+	Last              *int    `toml:"last" doc:"Limit to the last N sessions (0 = all)"`
+	CurveWindow       *int    `toml:"curve-window" doc:"Moving average window for curves"`
+	Chars             *string `toml:"chars" doc:"Default characters for per-char curves"`
+	ExcludeOutliers   *bool   `toml:"exclude-outliers" doc:"Exclude outlier sessions from curves and averages"`
+	FoldCase          *bool   `toml:"fold-case" doc:"Merge upper- and lower-case characters in char stats"`
+	IncludeIncomplete *bool   `toml:"include-incomplete" doc:"Include sessions quit before the end of the text"`
+	Refresh           *int    `toml:"refresh" doc:"Seconds between checks for new sessions in the stats UI (0 = off)"`
+}
+
+// UIConfig maps layout settings shared by the practice and stats UIs.
+type UIConfig struct {
+	ContentWidth *float64 `toml:"content-width" doc:"Share of the terminal width used for practice text (0-1)"`
+	PlotHeight   *int     `toml:"plot-height" doc:"Rows per curve plot in the stats UI"`
+	Lang         *string  `toml:"lang" doc:"Language of UI text: en, ru or de (empty = from LANG)"`
+	Accessible   *bool    `toml:"accessible" doc:"Screen-reader mode: plain text without alt screen, plots or color-only cues"`
+	Bidi         *string  `toml:"bidi" doc:"Who reorders right-to-left text: app, or terminal when it implements bidi"`
+	WPM          *string  `toml:"wpm" doc:"Speed formula: chars (5 characters a word), words (words typed) or cpm"`
+}
+
+// ThemeConfig maps UI colors. Values are hex (#RRGGBB) or ANSI color numbers.
+type ThemeConfig struct {
+	Palette *string `toml:"palette" doc:"Built-in palette for unset colors: default or colorblind"`
+	Text    *string `toml:"text" doc:"Correct text and highlighted values"`
+	Error   *string `toml:"error" doc:"Mistakes"`
+	Pending *string `toml:"pending" doc:"Text not typed yet and card titles"`
+	Accent  *string `toml:"accent" doc:"Current word, titles and active tab"`
+	Muted   *string `toml:"muted" doc:"Status bar and headers"`
+	Border  *string `toml:"border" doc:"Borders"`
+	Subtle  *string `toml:"subtle" doc:"Secondary text in the stats UI"`
+}
+
+// HooksConfig maps post-session hooks.
+type HooksConfig struct {
+	OnSession *string `toml:"on-session" doc:"Shell command; gets the session JSON on stdin and TUIPE_* variables"`
+	Webhook   *string `toml:"webhook" doc:"URL that receives the session JSON in a POST request"`
+	Timeout   *int    `toml:"timeout" doc:"Seconds a hook may run before it is stopped"`
+}
+
+// RemindConfig maps practice reminder settings.
+type RemindConfig struct {
+	At *string `toml:"at" doc:"Local time (HH:MM) after which tuipe remind nudges when you have not practiced today"`
+}
+
+// LeaderboardConfig maps the daily challenge leaderboard settings.
+type LeaderboardConfig struct {
+	URL    *string `toml:"url" doc:"Base URL of the tuipe-server leaderboard"`
+	Name   *string `toml:"name" doc:"Name shown to your friends on the leaderboard"`
+	Group  *string `toml:"group" doc:"Board shared with your friends; everyone using the same group sees each other"`
+	Submit *bool   `toml:"submit" doc:"Submit daily challenge results (opt-in; only name, scores and the settings hash are sent)"`
+}
+
+// DBConfig maps database maintenance settings.
+type DBConfig struct {
+	AutoBackup *bool `toml:"auto-backup" doc:"Back up the database before schema migrations"`
+	BackupKeep *int  `toml:"backup-keep" doc:"Number of backups to keep (0 = keep all)"`
+}
+
+// PathsConfig overrides data locations.
+type PathsConfig struct {
+	DB        *string `toml:"db" doc:"Database path"`
+	Wordlists *string `toml:"wordlists" doc:"Wordlist directory"`
+}
+
+// DownloadConfig maps wordlist download settings.
+type DownloadConfig struct {
+	IndexURL *string `toml:"index-url" doc:"PyPI JSON API base or mirror for wordlist downloads"`
+	Proxy    *string `toml:"proxy" doc:"Proxy URL (default: HTTP_PROXY / HTTPS_PROXY)"`
+	Retries  *int    `toml:"retries" doc:"Retries with exponential backoff for failed requests"`
 }
 
 // LoadConfig reads a TOML config from the given path. Missing file is not an error.
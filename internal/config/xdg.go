@@ -1,56 +1,178 @@
-// Package config provides XDG path helpers.
+// Package config provides platform path helpers.
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
-// XDGConfigHome returns the XDG config home or a default fallback.
-func XDGConfigHome() string {
+// appDir is the directory tuipe keeps inside the config and data homes.
+const appDir = "tuipe"
+
+// ConfigHome returns $XDG_CONFIG_HOME when set, otherwise the platform config home:
+// ~/.config on Linux and BSD, %APPDATA% on Windows and ~/Library/Application Support
+// on macOS. A tuipe directory still under ~/.config is used until it is migrated.
+func ConfigHome() string {
 	if v := os.Getenv("XDG_CONFIG_HOME"); v != "" {
 		return v
 	}
-	home, err := os.UserHomeDir()
-	if err != nil || home == "" {
-		return "."
-	}
-	return filepath.Join(home, ".config")
+	return nativeOrLegacy(nativeConfigHome(), legacyHome(".config"))
 }
 
-// XDGDataHome returns the XDG data home or a default fallback.
-func XDGDataHome() string {
+// DataHome returns $XDG_DATA_HOME when set, otherwise the platform data home:
+// ~/.local/share on Linux and BSD, %LOCALAPPDATA% on Windows and
+// ~/Library/Application Support on macOS. A tuipe directory still under
+// ~/.local/share is used until it is migrated.
+func DataHome() string {
 	if v := os.Getenv("XDG_DATA_HOME"); v != "" {
 		return v
 	}
+	return nativeOrLegacy(nativeDataHome(), legacyHome(".local", "share"))
+}
+
+func nativeConfigHome() string {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		if dir, err := os.UserConfigDir(); err == nil && dir != "" {
+			return dir
+		}
+	}
+	return legacyHome(".config")
+}
+
+func nativeDataHome() string {
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+			return dir
+		}
+	case "darwin":
+		if dir, err := os.UserConfigDir(); err == nil && dir != "" {
+			return dir
+		}
+	}
+	return legacyHome(".local", "share")
+}
+
+// legacyHome returns the XDG-style fallback under the home directory that older
+// versions used on every platform.
+func legacyHome(parts ...string) string {
 	home, err := os.UserHomeDir()
 	if err != nil || home == "" {
 		return "."
 	}
-	return filepath.Join(home, ".local", "share")
+	return filepath.Join(append([]string{home}, parts...)...)
+}
+
+// nativeOrLegacy keeps using the legacy home while only it holds a tuipe directory.
+func nativeOrLegacy(native, legacy string) string {
+	if native == legacy || dirExists(filepath.Join(native, appDir)) {
+		return native
+	}
+	if dirExists(filepath.Join(legacy, appDir)) {
+		return legacy
+	}
+	return native
+}
+
+// MigrateLegacyDirs moves tuipe directories left in ~/.config and ~/.local/share to
+// the native locations on Windows and macOS. Entries already present at the new
+// location are left in place. It returns the directories that were moved.
+func MigrateLegacyDirs() ([]string, error) {
+	pairs := []struct {
+		env            string
+		native, legacy string
+	}{
+		{"XDG_CONFIG_HOME", nativeConfigHome(), legacyHome(".config")},
+		{"XDG_DATA_HOME", nativeDataHome(), legacyHome(".local", "share")},
+	}
+	var moved []string
+	for _, pair := range pairs {
+		if os.Getenv(pair.env) != "" || pair.native == pair.legacy {
+			continue
+		}
+		from := filepath.Join(pair.legacy, appDir)
+		to := filepath.Join(pair.native, appDir)
+		if !dirExists(from) {
+			continue
+		}
+		n, err := moveEntries(from, to)
+		if n > 0 {
+			moved = append(moved, fmt.Sprintf("%s -> %s", from, to))
+		}
+		if err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
+
+// moveEntries moves from to to in one rename when to does not exist yet, so a failed
+// move leaves the old directory in use. Otherwise it renames each entry of from into
+// to and removes from once it is empty. It returns the number of entries moved.
+func moveEntries(from, to string) (int, error) {
+	if !dirExists(to) {
+		if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+			return 0, fmt.Errorf("failed to create %s: %w", filepath.Dir(to), err)
+		}
+		if err := os.Rename(from, to); err != nil {
+			return 0, fmt.Errorf("failed to move %s to %s: %w", from, to, err)
+		}
+		return 1, nil
+	}
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", from, err)
+	}
+	n := 0
+	for _, entry := range entries {
+		target := filepath.Join(to, entry.Name())
+		if _, err := os.Lstat(target); err == nil {
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return n, fmt.Errorf("failed to stat %s: %w", target, err)
+		}
+		if err := os.Rename(filepath.Join(from, entry.Name()), target); err != nil {
+			return n, fmt.Errorf("failed to move %s to %s: %w", entry.Name(), to, err)
+		}
+		n++
+	}
+	if err := os.Remove(from); err != nil {
+		// Best-effort: entries that already existed at the new location stay behind.
+		_ = err
+	}
+	return n, nil
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
 // DefaultWordListPath builds the default word list path for a language.
 func DefaultWordListPath(lang string) string {
-	return filepath.Join(XDGConfigHome(), "tuipe", "wordlists", lang+".txt")
+	return filepath.Join(ConfigHome(), appDir, "wordlists", lang+".txt")
 }
 
 // DefaultWordListDir returns the default directory for word lists.
 func DefaultWordListDir() string {
-	return filepath.Join(XDGConfigHome(), "tuipe", "wordlists")
+	return filepath.Join(ConfigHome(), appDir, "wordlists")
 }
 
 // DefaultDBPath returns the default path for the SQLite database.
 func DefaultDBPath() string {
-	return filepath.Join(XDGDataHome(), "tuipe", "tuipe.db")
+	return filepath.Join(DataHome(), appDir, "tuipe.db")
 }
 
 // DefaultWordfreqCacheDir returns the cache directory for wordfreq wheels.
 func DefaultWordfreqCacheDir() string {
-	return filepath.Join(XDGDataHome(), "tuipe", "wordfreq")
+	return filepath.Join(DataHome(), appDir, "wordfreq")
 }
 
 // DefaultConfigPath returns the default TOML config path.
 func DefaultConfigPath() string {
-	return filepath.Join(XDGConfigHome(), "tuipe", "config.toml")
+	return filepath.Join(ConfigHome(), appDir, "config.toml")
 }
@@ -0,0 +1,37 @@
+// Package grapheme splits text into user-perceived characters (extended grapheme
+// clusters), the units tuipe compares, scores and moves the cursor by.
+package grapheme
+
+import "github.com/rivo/uniseg"
+
+// Split returns the grapheme clusters of s in order, so "é" written as e + U+0301 or an
+// Indic consonant with its vowel sign is one element.
+func Split(s string) []string {
+	clusters := make([]string, 0, len(s))
+	state := -1
+	for s != "" {
+		var cluster string
+		cluster, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// Width returns the number of terminal columns cluster occupies.
+func Width(cluster string) int {
+	return uniseg.StringWidth(cluster)
+}
+
+// LineBreaks reports, for each cluster of s as returned by Split, whether a line may be
+// wrapped after it under the Unicode line breaking rules: after spaces, between Chinese
+// and Japanese characters, but not before closing punctuation such as "。" or "」".
+func LineBreaks(s string) []bool {
+	breaks := make([]bool, 0, len(s))
+	state := -1
+	for s != "" {
+		var boundaries int
+		_, s, boundaries, state = uniseg.StepString(s, state)
+		breaks = append(breaks, boundaries&uniseg.MaskLine != uniseg.LineDontBreak)
+	}
+	return breaks
+}
@@ -0,0 +1,58 @@
+package grapheme
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"ab c", []string{"a", "b", " ", "c"}},
+		{"café", []string{"c", "a", "f", "é"}},
+		{"नमस्ते", []string{"न", "म", "स्", "ते"}},
+		{"👍🏽!", []string{"👍🏽", "!"}},
+		{"", []string{}},
+	}
+	for _, c := range cases {
+		got := Split(c.text)
+		if strings.Join(got, "|") != strings.Join(c.want, "|") || len(got) != len(c.want) {
+			t.Fatalf("Split(%q) = %q, want %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	cases := map[string]int{"a": 1, "é": 1, "日": 2, "👍🏽": 2}
+	for cluster, want := range cases {
+		if got := Width(cluster); got != want {
+			t.Fatalf("Width(%q) = %d, want %d", cluster, got, want)
+		}
+	}
+}
+
+func TestLineBreaks(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"ab cd", "..|.|"},
+		{"日本語。です", "||.|||"},
+		{"「東京」", ".|.|"},
+	}
+	for _, c := range cases {
+		var got strings.Builder
+		for _, ok := range LineBreaks(c.text) {
+			if ok {
+				got.WriteByte('|')
+			} else {
+				got.WriteByte('.')
+			}
+		}
+		if got.String() != c.want {
+			t.Fatalf("LineBreaks(%q) = %s, want %s", c.text, got.String(), c.want)
+		}
+	}
+}
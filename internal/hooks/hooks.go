@@ -0,0 +1,178 @@
+// Package hooks runs user-configured commands and webhooks after practice sessions.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// DefaultTimeout bounds how long a hook may run.
+const DefaultTimeout = 10 * time.Second
+
+// waitDelay bounds how long a timed-out command may keep its output open.
+const waitDelay = time.Second
+
+// Config selects what runs after every completed session.
+type Config struct {
+	// Command is run through the shell with the session JSON on stdin.
+	Command string
+	// Webhook receives the session JSON in a POST request.
+	Webhook string
+	Timeout time.Duration
+}
+
+// Enabled reports whether any hook is configured.
+func (c Config) Enabled() bool {
+	return c.Command != "" || c.Webhook != ""
+}
+
+// Payload is the session document passed to hooks.
+type Payload struct {
+	ID         int64         `json:"id"`
+	StartedAt  time.Time     `json:"started_at"`
+	EndedAt    time.Time     `json:"ended_at"`
+	Lang       string        `json:"lang"`
+	Mode       string        `json:"mode"`
+	WPM        float64       `json:"wpm"`
+	WPMFormula string        `json:"wpm_formula,omitempty"`
+	Accuracy   float64       `json:"accuracy"`
+	Correct    int           `json:"correct"`
+	Incorrect  int           `json:"incorrect"`
+	DurationMs int64         `json:"duration_ms"`
+	Words      int           `json:"words"`
+	FocusWeak  bool          `json:"focus_weak"`
+	Keyboard   string        `json:"keyboard,omitempty"`
+	Layout     string        `json:"layout,omitempty"`
+	Chars      []CharPayload `json:"chars"`
+}
+
+// CharPayload holds the stats of one character in a Payload.
+type CharPayload struct {
+	Char      string `json:"char"`
+	Correct   int    `json:"correct"`
+	Incorrect int    `json:"incorrect"`
+}
+
+// NewPayload builds the hook document for a saved session. WPM is the speed under the
+// session's formula.
+func NewPayload(id int64, s model.SessionStats, chars []model.CharStats) Payload {
+	_, _, acc := stats.SessionMetrics(s.CorrectNonSpace, s.IncorrectNonSpace, s.DurationMs)
+	p := Payload{
+		ID:         id,
+		StartedAt:  s.StartedAt,
+		EndedAt:    s.EndedAt,
+		Lang:       s.Lang,
+		Mode:       s.Mode,
+		WPM:        stats.Speed(s.WPMFormula, s.CorrectNonSpace, s.WordsTyped, s.DurationMs),
+		WPMFormula: s.WPMFormula,
+		Accuracy:   acc,
+		Correct:    s.CorrectNonSpace,
+		Incorrect:  s.IncorrectNonSpace,
+		DurationMs: s.DurationMs,
+		Words:      s.Words,
+		FocusWeak:  s.FocusWeak,
+		Keyboard:   s.Keyboard,
+		Layout:     s.Layout,
+		Chars:      make([]CharPayload, 0, len(chars)),
+	}
+	for _, c := range chars {
+		p.Chars = append(p.Chars, CharPayload{Char: c.Char, Correct: c.Correct, Incorrect: c.Incorrect})
+	}
+	return p
+}
+
+// Run executes the command and posts the webhook of cfg. Both run even when one fails;
+// their errors are joined.
+func Run(ctx context.Context, cfg Config, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var errs []error
+	if cfg.Command != "" {
+		if err := runCommand(ctx, cfg.Command, body, p); err != nil {
+			errs = append(errs, fmt.Errorf("on-session hook failed: %w", err))
+		}
+	}
+	if cfg.Webhook != "" {
+		if err := postWebhook(ctx, cfg.Webhook, body); err != nil {
+			errs = append(errs, fmt.Errorf("webhook failed: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runCommand runs command through the shell. The session JSON is on stdin and the main
+// values are also exported as TUIPE_* variables. Stdout is discarded so the TUI stays intact.
+func runCommand(ctx context.Context, command string, body []byte, p Payload) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	cmd.Stdin = bytes.NewReader(body)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// Children of the shell outlive it and hold stderr open; stop waiting for them.
+	cmd.WaitDelay = waitDelay
+	cmd.Env = append(os.Environ(),
+		"TUIPE_SESSION_ID="+strconv.FormatInt(p.ID, 10),
+		"TUIPE_LANG="+p.Lang,
+		"TUIPE_WPM="+strconv.FormatFloat(p.WPM, 'f', 2, 64),
+		"TUIPE_ACCURACY="+strconv.FormatFloat(p.Accuracy*100, 'f', 2, 64),
+		"TUIPE_DURATION_MS="+strconv.FormatInt(p.DurationMs, 10),
+	)
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func postWebhook(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			// Best-effort close after the status check.
+			_ = cerr
+		}
+	}()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		// Best-effort drain so the connection can be reused.
+		_ = err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func testPayload() Payload {
+	end := time.Unix(600, 0).UTC()
+	return NewPayload(7, model.SessionStats{
+		StartedAt:         end.Add(-time.Minute),
+		EndedAt:           end,
+		Lang:              "en",
+		Mode:              model.ModeWords,
+		CorrectNonSpace:   150,
+		IncorrectNonSpace: 50,
+		DurationMs:        60000,
+		Words:             30,
+		WordsTyped:        30,
+		WPMFormula:        model.WPMChars,
+	}, []model.CharStats{{Char: "a", Correct: 3, Incorrect: 1}})
+}
+
+// writeScript writes a shell script that saves its stdin and environment under dir.
+func writeScript(t *testing.T, dir string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts run through sh")
+	}
+	path := filepath.Join(dir, "hook.sh")
+	script := "#!/bin/sh\ncat > \"" + dir + "/stdin.json\"\nenv > \"" + dir + "/env\"\n"
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	return path
+}
+
+func TestNewPayloadUsesSessionFormula(t *testing.T) {
+	s := model.SessionStats{CorrectNonSpace: 150, IncorrectNonSpace: 50, DurationMs: 60000, WordsTyped: 20}
+	tests := []struct {
+		formula string
+		want    float64
+	}{
+		{"", 30},
+		{model.WPMChars, 30},
+		{model.WPMWords, 20},
+		{model.WPMCPM, 150},
+	}
+	for _, tt := range tests {
+		s.WPMFormula = tt.formula
+		p := NewPayload(1, s, nil)
+		if p.WPM != tt.want || p.WPMFormula != tt.formula {
+			t.Errorf("formula %q: expected wpm %v, got %v (%q)", tt.formula, tt.want, p.WPM, p.WPMFormula)
+		}
+		if p.Accuracy != 0.75 {
+			t.Errorf("formula %q: expected accuracy 0.75, got %v", tt.formula, p.Accuracy)
+		}
+	}
+}
+
+func TestRunCommandPassesPayloadAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir)
+	p := testPayload()
+	body, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := runCommand(context.Background(), script, body, p); err != nil {
+		t.Fatalf("run command: %v", err)
+	}
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	if err != nil {
+		t.Fatalf("read stdin: %v", err)
+	}
+	var got Payload
+	if err := json.Unmarshal(stdin, &got); err != nil {
+		t.Fatalf("decode stdin %q: %v", stdin, err)
+	}
+	if got.ID != 7 || got.Lang != "en" || got.WPM != 30 || len(got.Chars) != 1 || got.Chars[0].Char != "a" {
+		t.Fatalf("unexpected payload on stdin: %+v", got)
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, "env"))
+	if err != nil {
+		t.Fatalf("read env: %v", err)
+	}
+	for _, want := range []string{
+		"TUIPE_SESSION_ID=7",
+		"TUIPE_LANG=en",
+		"TUIPE_WPM=30.00",
+		"TUIPE_ACCURACY=75.00",
+		"TUIPE_DURATION_MS=60000",
+	} {
+		if !strings.Contains("\n"+string(env), "\n"+want+"\n") {
+			t.Errorf("expected %s in the hook environment", want)
+		}
+	}
+}
+
+func TestRunCommandReportsStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands run through sh")
+	}
+	err := runCommand(context.Background(), "echo boom >&2; exit 3", nil, Payload{})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected the stderr in the error, got %v", err)
+	}
+}
+
+func TestPostWebhook(t *testing.T) {
+	var method, contentType string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	if err := postWebhook(context.Background(), srv.URL, []byte(`{"id":7}`)); err != nil {
+		t.Fatalf("post webhook: %v", err)
+	}
+	if method != http.MethodPost || contentType != "application/json" || string(body) != `{"id":7}` {
+		t.Fatalf("unexpected request: %s %q %q", method, contentType, body)
+	}
+}
+
+func TestPostWebhookRejectsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := postWebhook(context.Background(), srv.URL, []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("expected a 500 error, got %v", err)
+	}
+}
+
+func TestRunSendsCommandAndWebhook(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir)
+	var posted Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Errorf("decode webhook body: %v", err)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	if err := Run(context.Background(), Config{Command: script, Webhook: srv.URL}, testPayload()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if posted.ID != 7 || posted.WPMFormula != model.WPMChars {
+		t.Fatalf("unexpected webhook payload: %+v", posted)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stdin.json")); err != nil {
+		t.Fatalf("expected the command to run: %v", err)
+	}
+}
+
+func TestRunJoinsErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands run through sh")
+	}
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := Run(context.Background(), Config{Command: "exit 1", Webhook: srv.URL}, testPayload())
+	if err == nil || !strings.Contains(err.Error(), "on-session hook failed") || !strings.Contains(err.Error(), "webhook failed") {
+		t.Fatalf("expected both failures, got %v", err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected the webhook to run after the failed command, got %d requests", hits)
+	}
+}
+
+func TestRunStopsAtTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands run through sh")
+	}
+	start := time.Now()
+	err := Run(context.Background(), Config{Command: "sleep 5", Timeout: 100 * time.Millisecond}, testPayload())
+	if err == nil {
+		t.Fatal("expected the timeout to stop the command")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("expected the command to stop at the timeout, took %v", elapsed)
+	}
+}
@@ -0,0 +1,125 @@
+package i18n
+
+var de = map[string]string{
+	"practice.progress":            "Fortschritt %d%%",
+	"practice.last":                "Zuletzt %.1f %s · %.1f%%",
+	"practice.all_time":            "Gesamt %.1f %s · %.1f%%",
+	"practice.trend":               "Verlauf %s",
+	"practice.goal":                "Ziel %d/%d Min.",
+	"practice.goal_met":            "Ziel %d Min. erreicht",
+	"practice.daily":               "Tagesaufgabe %s",
+	"practice.weak_suggest":        "„%s“ ist zu %.0f%% genau — Tab richtet die nächsten Texte auf schwache Zeichen aus",
+	"practice.slow_down":           "Genauigkeit %.0f%% · langsamer",
+	"practice.config_reloaded":     "Konfiguration neu geladen",
+	"practice.config_not_reloaded": "Konfiguration nicht neu geladen: %v",
+	"practice.no_weak_stats":       "noch keine Statistik für den Fokus auf schwache Zeichen; normaler Generator wird verwendet",
+	"practice.no_mistakes":         "die Fehlerbank ist leer; Wiederholungstexte nutzen den normalen Generator, bis Wörter falsch getippt werden",
+	"practice.floor_restart":       "Genauigkeit %.0f%% unter %.0f%%: neuer Text",
+	"practice.paste_rejected":      "Einfügen ignoriert: Text bitte selbst tippen",
+	"practice.err.load_stats":      "Sitzungsstatistik konnte nicht geladen werden: %v",
+	"practice.err.save_session":    "Sitzung konnte nicht gespeichert werden: %v",
+	"practice.err.load_weak":       "schwache Zeichen konnten nicht geladen werden: %v",
+	"practice.err.load_review":     "Wiederholungselemente konnten nicht geladen werden: %v",
+	"practice.err.save_review":     "Wiederholungselemente konnten nicht gespeichert werden: %v",
+	"practice.err.load_mistakes":   "Fehlerbank konnte nicht geladen werden: %v",
+	"practice.err.save_mistakes":   "Fehlerbank konnte nicht gespeichert werden: %v",
+	"practice.err.load_streak":     "Sitzungen für die Serie konnten nicht geladen werden: %v",
+	"practice.err.load_state":      "App-Zustand konnte nicht geladen werden: %v",
+	"practice.err.save_state":      "App-Zustand konnte nicht gespeichert werden: %v",
+
+	"results.title":         "Sitzung abgeschlossen",
+	"results.help":          "enter/leertaste: nächster Text  s: teilen  ctrl+c: beenden",
+	"results.help_drill":    "enter/leertaste: nächster Text  d: Schwächste üben  s: teilen  ctrl+c: beenden",
+	"results.worst":         "Am schwächsten: %s",
+	"results.shift":         "Umschalt %.1f%% · am schwächsten: %s",
+	"results.rhythm":        "Pausen über %d ms: %d · längste %s vor %s",
+	"results.rhythm_in":     "%s in %s",
+	"results.rhythm_steady": "Keine Pausen über %d ms",
+	"results.copied":        "Karte in die Zwischenablage kopiert",
+	"results.no_clipboard":  "Zwischenablage nicht verfügbar; Karte oben kopieren",
+	"results.weak_suggest":  "Deine Genauigkeit bei „%s“ liegt bei %.0f%% — f drücken, um die nächsten Texte auf schwache Zeichen auszurichten",
+	"results.weak_enabled":  "Fokus auf schwache Zeichen für die nächsten Texte an (--focus-weak, um ihn zu behalten)",
+
+	"weak.title":       "Schwächenfokus: schwächste Zeichen der letzten %d Sitzungen",
+	"weak.title_drill": "Übung: schwächste Zeichen des letzten Textes",
+	"weak.row":         "%s  %.1f%%  (%d getippt)",
+	"weak.empty":       "Noch keine Statistik; der Text ist nicht gewichtet",
+	"weak.help":        "tab/esc: zurück zum Text",
+
+	"replay.status": "Wiedergabe %.1fs · %.1f WPM · %.1f%%",
+	"replay.help":   "leertaste: Pause  r: neu starten  q: beenden",
+	"replay.paused": "pausiert  leertaste: weiter  r: neu starten  q: beenden",
+	"replay.done":   "fertig  r: neu starten  q: beenden",
+
+	"accessible.text":     "Text: %s",
+	"accessible.typed":    "Getippt: %s",
+	"accessible.mistakes": "Fehler: %d",
+	"accessible.mistake":  "Fehler in Wort %d: erwartet %s, getippt %s",
+	"accessible.space":    "Leerzeichen",
+	"accessible.tab":      "Tabulator",
+	"accessible.done":     "Text fertig: %.1f %s, %.1f%% Genauigkeit",
+	"accessible.result":   "%.1f %s, %.1f%% Genauigkeit, %s",
+
+	"stats.tab.overview":      "Übersicht",
+	"stats.tab.char_table":    "Zeichentabelle",
+	"stats.tab.char_curves":   "Zeichenkurven",
+	"stats.tab.sessions":      "Sitzungen",
+	"stats.help":              "Bereich: left/right  Scrollen: up/down/pgup/pgdn  Fenster: -/=  Ausreißer: o  Groß/klein: c  Unvollständig: i  Neu laden: r  Einstellungen: /  Beenden: q",
+	"stats.help.char_table":   "Bereich: left/right  Scrollen: up/down/pgup/pgdn  Gruppieren: a  Fenster: -/=  Ausreißer: o  Groß/klein: c  Unvollständig: i  Neu laden: r  Einstellungen: /  Beenden: q",
+	"stats.help.char_curves":  "Bereich: left/right  Scrollen: up/down/pgup/pgdn  Zeichen: enter  Fenster: -/=  Ausreißer: o  Groß/klein: c  Unvollständig: i  Neu laden: r  Einstellungen: /  Beenden: q",
+	"stats.help.sessions":     "Bereich: left/right  Auswahl: up/down  Scrollen: pgup/pgdn  Ignorieren: x  Seite: [/]  Fenster: -/=  Ausreißer: o  Groß/klein: c  Unvollständig: i  Neu laden: r  Einstellungen: /  Beenden: q",
+	"stats.help.filter":       "tab/shift+tab: nächstes Feld  enter: anwenden  esc: abbrechen  beenden: q",
+	"stats.tab.replay":        "Wiedergabe",
+	"stats.help.replay":       "Bereich: left/right  Abspielen/Pause: space  Schritt: ,/.  Springen: </>  Anfang/Ende: g/G  Tempo: s  Sitzung: [/]  Neu laden: r  Beenden: q",
+	"stats.replay.title":      "Sitzung #%d · %s · %s (%d von %d)",
+	"stats.replay.loading":    "Wiedergabe wird geladen...",
+	"stats.replay.failed":     "Wiedergabe konnte nicht geladen werden: %v",
+	"stats.replay.none":       "Keine Sitzungen mit gespeicherten Tastenanschlägen. Mit --store-keys üben, um sie aufzuzeichnen.",
+	"stats.replay.status":     "%.1f WPM  %.1f%% Genauigkeit  %gx",
+	"stats.replay.playing":    "läuft",
+	"stats.replay.paused":     "pausiert",
+	"stats.replay.done":       "fertig",
+	"stats.tab.rankings":      "Rangliste",
+	"stats.help.rankings":     "Bereich: left/right  Scrollen: up/down/pgup/pgdn  Tag: [/]  Neu laden: r  Beenden: q",
+	"stats.rankings.title":    "Tagesaufgabe %s",
+	"stats.rankings.loading":  "Rangliste wird geladen...",
+	"stats.rankings.failed":   "Rangliste konnte nicht geladen werden: %v",
+	"stats.rankings.empty":    "Noch keine Ergebnisse für diese Aufgabe.",
+	"stats.rankings.settings": "%s · %s · %d Wörter (Einstellungen %s)",
+	"stats.rankings.rank":     "#",
+	"stats.rankings.name":     "Name",
+	"stats.rankings.wpm":      "WPM",
+	"stats.rankings.accuracy": "Genauigkeit",
+	"stats.settings":          "Einstellungen (enter zum Anwenden, esc zum Abbrechen)",
+	"stats.filter.lang":       "Sprache: ",
+	"stats.filter.since":      "Seit (YYYY-MM-DD): ",
+	"stats.filter.last":       "Letzte: ",
+	"stats.filter.window":     "Kurvenfenster: ",
+	"stats.loading":           "Lädt...",
+	"stats.loading_stats":     "Statistik wird geladen...",
+	"stats.load_failed":       "Statistik konnte nicht geladen werden.",
+	"stats.no_sessions":       "Keine Sitzungen gefunden.",
+	"stats.no_char_stats":     "Keine Zeichenstatistik gefunden.",
+	"stats.daily_note":        "Große Historie: Kurven nutzen Tageswerte. Mit einem Limit der letzten Sitzungen (/) gibt es Einzelansichten.",
+	"stats.page":              "Seite %d/%d (Sitzungen %d-%d von %d)",
+	"stats.scoring_mixed":     "Die Sitzungen wurden nach %d verschiedenen Regeln bewertet (%s); Tempo und Genauigkeit sind eventuell nicht vergleichbar.",
+	"stats.scoring_versions":  "Aufgezeichnet von tuipe %s.",
+	"stats.card.sessions":     "Sitzungen",
+	"stats.card.avg_speed":    "Ø %s",
+	"stats.card.best_speed":   "Beste %s",
+	"stats.card.avg_acc":      "Ø Genauigkeit",
+	"stats.card.first_key":    "Erste Taste",
+	"stats.card.space":        "Leertaste",
+	"stats.err.ignore":        "Sitzung konnte nicht geändert werden: %v",
+	"stats.err.sessions":      "Sitzungen konnten nicht angezeigt werden: %v",
+	"stats.err.curves":        "Kurven konnten nicht gezeichnet werden: %v",
+	"stats.err.load_chars":    "Zeichenkurven konnten nicht geladen werden: %s",
+	"stats.err.char_curves":   "Zeichenkurven konnten nicht gezeichnet werden: %v",
+	"stats.err.char_group":    "Zeichen konnten nicht gruppiert werden: %v",
+	"stats.chars":             "Zeichen: %s",
+	"stats.chars_prompt":      "Zeichen: ",
+	"stats.no_chars":          "Keine Zeichen gewählt. Enter drücken, um Zeichen festzulegen.",
+	"stats.select_chars":      "Zeichen auswählen",
+	"stats.select_hint":       "Zeichen eingeben (ohne Kommas). Leerzeichen werden ignoriert.",
+	"stats.select_keys":       "Enter zum Anwenden / Esc zum Abbrechen",
+}
@@ -0,0 +1,125 @@
+package i18n
+
+var en = map[string]string{
+	"practice.progress":            "Progress %d%%",
+	"practice.last":                "Last %.1f %s · %.1f%%",
+	"practice.all_time":            "All-time %.1f %s · %.1f%%",
+	"practice.trend":               "Trend %s",
+	"practice.goal":                "Goal %d/%d min",
+	"practice.goal_met":            "Goal %d min met",
+	"practice.daily":               "Daily %s",
+	"practice.weak_suggest":        "'%s' is %.0f%% accurate — tab focuses the next texts on weak characters",
+	"practice.slow_down":           "Accuracy %.0f%% · slow down",
+	"practice.config_reloaded":     "config reloaded",
+	"practice.config_not_reloaded": "config not reloaded: %v",
+	"practice.no_weak_stats":       "no stats available for weak-char focus yet; using normal generator",
+	"practice.no_mistakes":         "the mistake bank is empty; review texts use the normal generator until you mistype some words",
+	"practice.floor_restart":       "accuracy %.0f%% under the %.0f%% floor: new text",
+	"practice.paste_rejected":      "paste ignored: type the text yourself",
+	"practice.err.load_stats":      "failed to load session stats: %v",
+	"practice.err.save_session":    "failed to save session: %v",
+	"practice.err.load_weak":       "failed to load weak chars: %v",
+	"practice.err.load_review":     "failed to load review items: %v",
+	"practice.err.save_review":     "failed to save review items: %v",
+	"practice.err.load_mistakes":   "failed to load the mistake bank: %v",
+	"practice.err.save_mistakes":   "failed to save the mistake bank: %v",
+	"practice.err.load_streak":     "failed to load sessions for streak: %v",
+	"practice.err.load_state":      "failed to load app state: %v",
+	"practice.err.save_state":      "failed to save app state: %v",
+
+	"results.title":         "Session complete",
+	"results.help":          "enter/space: next text  s: share  ctrl+c: quit",
+	"results.help_drill":    "enter/space: next text  d: drill worst  s: share  ctrl+c: quit",
+	"results.worst":         "Worst: %s",
+	"results.shift":         "Shift %.1f%% · weakest: %s",
+	"results.rhythm":        "Pauses over %d ms: %d · longest %s before %s",
+	"results.rhythm_in":     "%s in %s",
+	"results.rhythm_steady": "No pauses over %d ms",
+	"results.copied":        "Share card copied to clipboard",
+	"results.no_clipboard":  "Clipboard unavailable; copy the card above",
+	"results.weak_suggest":  "Your '%s' accuracy is %.0f%% — press f to focus the next texts on weak characters",
+	"results.weak_enabled":  "Weak-char focus on for the next texts (--focus-weak to keep it)",
+
+	"weak.title":       "Weak focus: weakest characters of the last %d sessions",
+	"weak.title_drill": "Drill: worst characters of the last text",
+	"weak.row":         "%s  %.1f%%  (%d typed)",
+	"weak.empty":       "No stats yet; the text is not biased",
+	"weak.help":        "tab/esc: back to the text",
+
+	"replay.status": "Replay %.1fs · %.1f WPM · %.1f%%",
+	"replay.help":   "space: pause  r: restart  q: quit",
+	"replay.paused": "paused  space: resume  r: restart  q: quit",
+	"replay.done":   "done  r: restart  q: quit",
+
+	"accessible.text":     "Text: %s",
+	"accessible.typed":    "Typed: %s",
+	"accessible.mistakes": "Mistakes: %d",
+	"accessible.mistake":  "Mistake in word %d: expected %s, typed %s",
+	"accessible.space":    "space",
+	"accessible.tab":      "tab",
+	"accessible.done":     "Text complete: %.1f %s, %.1f%% accuracy",
+	"accessible.result":   "%.1f %s, %.1f%% accuracy, %s",
+
+	"stats.tab.overview":      "Overview",
+	"stats.tab.char_table":    "Char Table",
+	"stats.tab.char_curves":   "Char Curves",
+	"stats.tab.sessions":      "Sessions",
+	"stats.help":              "Nav: left/right  Scroll: up/down/pgup/pgdn  Window: -/=  Outliers: o  Case: c  Incomplete: i  Refresh: r  Settings: /  Quit: q",
+	"stats.help.char_table":   "Nav: left/right  Scroll: up/down/pgup/pgdn  Group: a  Window: -/=  Outliers: o  Case: c  Incomplete: i  Refresh: r  Settings: /  Quit: q",
+	"stats.help.char_curves":  "Nav: left/right  Scroll: up/down/pgup/pgdn  Edit chars: enter  Window: -/=  Outliers: o  Case: c  Incomplete: i  Refresh: r  Settings: /  Quit: q",
+	"stats.help.sessions":     "Nav: left/right  Select: up/down  Scroll: pgup/pgdn  Ignore: x  Page: [/]  Window: -/=  Outliers: o  Case: c  Incomplete: i  Refresh: r  Settings: /  Quit: q",
+	"stats.help.filter":       "tab/shift+tab: next field  enter: apply  esc: cancel  quit: q",
+	"stats.tab.replay":        "Replay",
+	"stats.help.replay":       "Nav: left/right  Play/pause: space  Step: ,/.  Seek: </>  Start/end: g/G  Speed: s  Session: [/]  Refresh: r  Quit: q",
+	"stats.replay.title":      "Session #%d · %s · %s (%d of %d)",
+	"stats.replay.loading":    "Loading replay...",
+	"stats.replay.failed":     "Failed to load replay: %v",
+	"stats.replay.none":       "No sessions with stored keystrokes. Practice with --store-keys to record them.",
+	"stats.replay.status":     "%.1f WPM  %.1f%% accuracy  %gx",
+	"stats.replay.playing":    "playing",
+	"stats.replay.paused":     "paused",
+	"stats.replay.done":       "done",
+	"stats.tab.rankings":      "Rankings",
+	"stats.help.rankings":     "Nav: left/right  Scroll: up/down/pgup/pgdn  Day: [/]  Refresh: r  Quit: q",
+	"stats.rankings.title":    "Daily challenge %s",
+	"stats.rankings.loading":  "Loading rankings...",
+	"stats.rankings.failed":   "Failed to load rankings: %v",
+	"stats.rankings.empty":    "No results for this challenge yet.",
+	"stats.rankings.settings": "%s · %s · %d words (settings %s)",
+	"stats.rankings.rank":     "#",
+	"stats.rankings.name":     "Name",
+	"stats.rankings.wpm":      "WPM",
+	"stats.rankings.accuracy": "Accuracy",
+	"stats.settings":          "Settings (enter to apply, esc to cancel)",
+	"stats.filter.lang":       "Lang: ",
+	"stats.filter.since":      "Since (YYYY-MM-DD): ",
+	"stats.filter.last":       "Last: ",
+	"stats.filter.window":     "Curve window: ",
+	"stats.loading":           "Loading...",
+	"stats.loading_stats":     "Loading stats...",
+	"stats.load_failed":       "Failed to load stats.",
+	"stats.no_sessions":       "No sessions found.",
+	"stats.no_char_stats":     "No character stats found.",
+	"stats.daily_note":        "Large history: curves use daily aggregates. Set a last-N limit (/) for per-session views.",
+	"stats.page":              "Page %d/%d (sessions %d-%d of %d)",
+	"stats.scoring_mixed":     "Sessions were scored under %d different rules (%s); speeds and accuracy may not be comparable.",
+	"stats.scoring_versions":  "Recorded by tuipe %s.",
+	"stats.card.sessions":     "Sessions",
+	"stats.card.avg_speed":    "Avg %s",
+	"stats.card.best_speed":   "Best %s",
+	"stats.card.avg_acc":      "Avg Acc",
+	"stats.card.first_key":    "First Key",
+	"stats.card.space":        "Space",
+	"stats.err.ignore":        "Failed to update session: %v",
+	"stats.err.sessions":      "Failed to render sessions: %v",
+	"stats.err.curves":        "Failed to render curves: %v",
+	"stats.err.load_chars":    "Failed to load character curves: %s",
+	"stats.err.char_curves":   "Failed to render character curves: %v",
+	"stats.err.char_group":    "Failed to group characters: %v",
+	"stats.chars":             "Chars: %s",
+	"stats.chars_prompt":      "Chars: ",
+	"stats.no_chars":          "No characters selected. Press Enter to set chars.",
+	"stats.select_chars":      "Select Characters",
+	"stats.select_hint":       "Type characters (no commas). Spaces are ignored.",
+	"stats.select_keys":       "Enter to apply / Esc to cancel",
+}
@@ -0,0 +1,85 @@
+// Package i18n translates the user-facing strings of the TUIs.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Default is the catalog used for unknown languages and missing keys.
+const Default = "en"
+
+// catalogs maps a UI language code to its messages. Every catalog has the keys of en.
+var catalogs = map[string]map[string]string{
+	"en": en,
+	"ru": ru,
+	"de": de,
+}
+
+var current = catalogs[Default]
+
+// Langs returns the supported UI language codes.
+func Langs() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Supported reports whether lang has a catalog.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// SetLang selects the UI language; an empty lang is detected from the environment.
+func SetLang(lang string) error {
+	if lang == "" {
+		lang = Detect()
+	}
+	catalog, ok := catalogs[lang]
+	if !ok {
+		return fmt.Errorf("unsupported UI language %q (supported: %s)", lang, strings.Join(Langs(), ", "))
+	}
+	current = catalog
+	return nil
+}
+
+// Detect returns the UI language named by LC_ALL, LC_MESSAGES or LANG (for example
+// de_DE.UTF-8), or Default when none of them names a supported language.
+func Detect() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		lang := strings.ToLower(value)
+		if i := strings.IndexAny(lang, "_.@"); i >= 0 {
+			lang = lang[:i]
+		}
+		if Supported(lang) {
+			return lang
+		}
+		return Default
+	}
+	return Default
+}
+
+// T returns the message for key in the selected language, formatted with args.
+func T(key string, args ...any) string {
+	msg, ok := current[key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
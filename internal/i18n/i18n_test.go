@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, msg := range en {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			want := verbPattern.FindAllString(msg, -1)
+			if got := verbPattern.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, ok := en[key]; !ok {
+				t.Errorf("%s: key %q is not in en", lang, key)
+			}
+		}
+	}
+}
+
+func TestSetLangAndDetect(t *testing.T) {
+	t.Cleanup(func() { current = catalogs[Default] })
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if err := SetLang(""); err != nil {
+		t.Fatalf("SetLang: %v", err)
+	}
+	if got := T("practice.progress", 40); got != "Fortschritt 40%" {
+		t.Fatalf("expected German text from LANG, got %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Fatalf("expected unknown keys to fall through, got %q", got)
+	}
+	if err := SetLang("xx"); err == nil {
+		t.Fatalf("expected an error for an unsupported language")
+	}
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	if got := Detect(); got != Default {
+		t.Fatalf("expected %q for an unsupported locale, got %q", Default, got)
+	}
+}
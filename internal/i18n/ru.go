@@ -0,0 +1,125 @@
+package i18n
+
+var ru = map[string]string{
+	"practice.progress":            "Прогресс %d%%",
+	"practice.last":                "Последний %.1f %s · %.1f%%",
+	"practice.all_time":            "За всё время %.1f %s · %.1f%%",
+	"practice.trend":               "Тренд %s",
+	"practice.goal":                "Цель %d/%d мин",
+	"practice.goal_met":            "Цель %d мин выполнена",
+	"practice.daily":               "Задание дня %s",
+	"practice.weak_suggest":        "Точность для «%s» — %.0f%%; Tab — следующие тексты на слабые символы",
+	"practice.slow_down":           "Точность %.0f%% · не спешите",
+	"practice.config_reloaded":     "конфиг перезагружен",
+	"practice.config_not_reloaded": "конфиг не перезагружен: %v",
+	"practice.no_weak_stats":       "для фокуса на слабых символах пока нет статистики; используется обычный генератор",
+	"practice.no_mistakes":         "банк ошибок пуст; пока вы не ошибётесь в словах, тексты повторения строит обычный генератор",
+	"practice.floor_restart":       "точность %.0f%% ниже порога %.0f%%: новый текст",
+	"practice.paste_rejected":      "вставка проигнорирована: наберите текст сами",
+	"practice.err.load_stats":      "не удалось загрузить статистику сессий: %v",
+	"practice.err.save_session":    "не удалось сохранить сессию: %v",
+	"practice.err.load_weak":       "не удалось загрузить слабые символы: %v",
+	"practice.err.load_review":     "не удалось загрузить элементы повторения: %v",
+	"practice.err.save_review":     "не удалось сохранить элементы повторения: %v",
+	"practice.err.load_mistakes":   "не удалось загрузить банк ошибок: %v",
+	"practice.err.save_mistakes":   "не удалось сохранить банк ошибок: %v",
+	"practice.err.load_streak":     "не удалось загрузить сессии для серии: %v",
+	"practice.err.load_state":      "не удалось загрузить состояние приложения: %v",
+	"practice.err.save_state":      "не удалось сохранить состояние приложения: %v",
+
+	"results.title":         "Сессия завершена",
+	"results.help":          "enter/пробел: следующий текст  s: поделиться  ctrl+c: выход",
+	"results.help_drill":    "enter/пробел: следующий текст  d: тренировать худшие  s: поделиться  ctrl+c: выход",
+	"results.worst":         "Хуже всего: %s",
+	"results.shift":         "Shift %.1f%% · хуже всего: %s",
+	"results.rhythm":        "Паузы дольше %d мс: %d · самая долгая %s перед %s",
+	"results.rhythm_in":     "%s в слове %s",
+	"results.rhythm_steady": "Пауз дольше %d мс нет",
+	"results.copied":        "Карточка скопирована в буфер обмена",
+	"results.no_clipboard":  "Буфер обмена недоступен; скопируйте карточку выше",
+	"results.weak_suggest":  "Точность для «%s» — %.0f%%; нажмите f, чтобы следующие тексты упражняли слабые символы",
+	"results.weak_enabled":  "Фокус на слабых символах включён для следующих текстов (--focus-weak, чтобы сохранить)",
+
+	"weak.title":       "Фокус на слабых: худшие символы за последние %d сессий",
+	"weak.title_drill": "Тренировка: худшие символы прошлого текста",
+	"weak.row":         "%s  %.1f%%  (набрано %d)",
+	"weak.empty":       "Статистики пока нет; текст не смещён",
+	"weak.help":        "tab/esc: назад к тексту",
+
+	"replay.status": "Повтор %.1fs · %.1f WPM · %.1f%%",
+	"replay.help":   "пробел: пауза  r: сначала  q: выход",
+	"replay.paused": "пауза  пробел: продолжить  r: сначала  q: выход",
+	"replay.done":   "готово  r: сначала  q: выход",
+
+	"accessible.text":     "Текст: %s",
+	"accessible.typed":    "Набрано: %s",
+	"accessible.mistakes": "Ошибок: %d",
+	"accessible.mistake":  "Ошибка в слове %d: нужно %s, набрано %s",
+	"accessible.space":    "пробел",
+	"accessible.tab":      "табуляция",
+	"accessible.done":     "Текст набран: %.1f %s, точность %.1f%%",
+	"accessible.result":   "%.1f %s, точность %.1f%%, %s",
+
+	"stats.tab.overview":      "Обзор",
+	"stats.tab.char_table":    "Символы",
+	"stats.tab.char_curves":   "Кривые символов",
+	"stats.tab.sessions":      "Сессии",
+	"stats.help":              "Разделы: left/right  Прокрутка: up/down/pgup/pgdn  Окно: -/=  Выбросы: o  Регистр: c  Незавершённые: i  Обновить: r  Настройки: /  Выход: q",
+	"stats.help.char_table":   "Разделы: left/right  Прокрутка: up/down/pgup/pgdn  Группы: a  Окно: -/=  Выбросы: o  Регистр: c  Незавершённые: i  Обновить: r  Настройки: /  Выход: q",
+	"stats.help.char_curves":  "Разделы: left/right  Прокрутка: up/down/pgup/pgdn  Символы: enter  Окно: -/=  Выбросы: o  Регистр: c  Незавершённые: i  Обновить: r  Настройки: /  Выход: q",
+	"stats.help.sessions":     "Разделы: left/right  Выбор: up/down  Прокрутка: pgup/pgdn  Игнорировать: x  Страница: [/]  Окно: -/=  Выбросы: o  Регистр: c  Незавершённые: i  Обновить: r  Настройки: /  Выход: q",
+	"stats.help.filter":       "tab/shift+tab: следующее поле  enter: применить  esc: отмена  выход: q",
+	"stats.tab.replay":        "Повтор",
+	"stats.help.replay":       "Разделы: left/right  Пуск/пауза: space  Шаг: ,/.  Перемотка: </>  Начало/конец: g/G  Скорость: s  Сессия: [/]  Обновить: r  Выход: q",
+	"stats.replay.title":      "Сессия #%d · %s · %s (%d из %d)",
+	"stats.replay.loading":    "Загрузка повтора...",
+	"stats.replay.failed":     "Не удалось загрузить повтор: %v",
+	"stats.replay.none":       "Нет сессий с сохранёнными нажатиями. Тренируйтесь с --store-keys, чтобы записывать их.",
+	"stats.replay.status":     "%.1f WPM  точность %.1f%%  %gx",
+	"stats.replay.playing":    "воспроизведение",
+	"stats.replay.paused":     "пауза",
+	"stats.replay.done":       "готово",
+	"stats.tab.rankings":      "Рейтинг",
+	"stats.help.rankings":     "Разделы: left/right  Прокрутка: up/down/pgup/pgdn  День: [/]  Обновить: r  Выход: q",
+	"stats.rankings.title":    "Задание дня %s",
+	"stats.rankings.loading":  "Загрузка рейтинга...",
+	"stats.rankings.failed":   "Не удалось загрузить рейтинг: %v",
+	"stats.rankings.empty":    "Для этого задания пока нет результатов.",
+	"stats.rankings.settings": "%s · %s · %d слов (настройки %s)",
+	"stats.rankings.rank":     "#",
+	"stats.rankings.name":     "Имя",
+	"stats.rankings.wpm":      "WPM",
+	"stats.rankings.accuracy": "Точность",
+	"stats.settings":          "Настройки (enter — применить, esc — отмена)",
+	"stats.filter.lang":       "Язык: ",
+	"stats.filter.since":      "С даты (YYYY-MM-DD): ",
+	"stats.filter.last":       "Последние: ",
+	"stats.filter.window":     "Окно кривых: ",
+	"stats.loading":           "Загрузка...",
+	"stats.loading_stats":     "Загрузка статистики...",
+	"stats.load_failed":       "Не удалось загрузить статистику.",
+	"stats.no_sessions":       "Сессии не найдены.",
+	"stats.no_char_stats":     "Статистика по символам не найдена.",
+	"stats.daily_note":        "Большая история: кривые строятся по дням. Задайте лимит последних сессий (/) для посессионного вида.",
+	"stats.page":              "Страница %d/%d (сессии %d-%d из %d)",
+	"stats.scoring_mixed":     "Сессии оценены по %d разным правилам (%s); скорость и точность могут быть несравнимы.",
+	"stats.scoring_versions":  "Записано tuipe %s.",
+	"stats.card.sessions":     "Сессии",
+	"stats.card.avg_speed":    "Средн. %s",
+	"stats.card.best_speed":   "Лучший %s",
+	"stats.card.avg_acc":      "Точность",
+	"stats.card.first_key":    "Первая клавиша",
+	"stats.card.space":        "Пробел",
+	"stats.err.ignore":        "Не удалось изменить сессию: %v",
+	"stats.err.sessions":      "Не удалось показать сессии: %v",
+	"stats.err.curves":        "Не удалось построить кривые: %v",
+	"stats.err.load_chars":    "Не удалось загрузить кривые символов: %s",
+	"stats.err.char_curves":   "Не удалось построить кривые символов: %v",
+	"stats.err.char_group":    "Не удалось сгруппировать символы: %v",
+	"stats.chars":             "Символы: %s",
+	"stats.chars_prompt":      "Символы: ",
+	"stats.no_chars":          "Символы не выбраны. Нажмите Enter, чтобы выбрать.",
+	"stats.select_chars":      "Выбор символов",
+	"stats.select_hint":       "Введите символы (без запятых). Пробелы игнорируются.",
+	"stats.select_keys":       "Enter — применить / Esc — отмена",
+}
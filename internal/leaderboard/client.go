@@ -0,0 +1,91 @@
+package leaderboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds one request to the leaderboard server.
+const DefaultTimeout = 10 * time.Second
+
+// Client talks to a leaderboard server.
+type Client struct {
+	base string
+	http *http.Client
+}
+
+// NewClient returns a client for the server at base, e.g. https://typing.example.org.
+func NewClient(base string) *Client {
+	return &Client{base: strings.TrimRight(base, "/"), http: &http.Client{Timeout: DefaultTimeout}}
+}
+
+// Submit sends r. The server keeps it only when it beats the player's earlier result
+// for the same challenge and settings.
+func (c *Client) Submit(ctx context.Context, r Result) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/api/results", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to submit result: %w", err)
+	}
+	defer closeBody(resp.Body)
+	return checkStatus(resp)
+}
+
+// Rankings returns the ranked results of group for the challenge of day.
+func (c *Client) Rankings(ctx context.Context, group, day string) ([]Entry, error) {
+	query := url.Values{"group": {group}, "day": {day}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/api/rankings?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rankings: %w", err)
+	}
+	defer closeBody(resp.Body)
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse rankings: %w", err)
+	}
+	return entries, nil
+}
+
+// checkStatus turns a non-2xx response into an error carrying the server's message.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxBodyBytes)).Decode(&body); err == nil && body.Error != "" {
+		return fmt.Errorf("leaderboard returned %s: %s", resp.Status, body.Error)
+	}
+	return fmt.Errorf("leaderboard returned %s", resp.Status)
+}
+
+func closeBody(body io.ReadCloser) {
+	drain(body)
+	if err := body.Close(); err != nil {
+		// Best-effort close after the response was read.
+		_ = err
+	}
+}
@@ -0,0 +1,102 @@
+// Package leaderboard submits anonymized daily challenge results to a self-hosted
+// leaderboard server, and implements that server.
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNameLen bounds player and group names.
+const MaxNameLen = 32
+
+// maxWPM rejects results no human could type.
+const maxWPM = 400
+
+// Result is the best run of one player at one daily challenge. It holds no session
+// details beyond the scores and the settings needed to tell comparable runs apart.
+type Result struct {
+	// Group is the board shared by a circle of friends.
+	Group string `json:"group"`
+	// Day is the UTC day of the challenge, e.g. 2024-05-10.
+	Day string `json:"day"`
+	// Params is the generator params hash; only runs with the same hash typed the same text.
+	Params     string  `json:"params"`
+	Name       string  `json:"name"`
+	Lang       string  `json:"lang"`
+	Mode       string  `json:"mode"`
+	Words      int     `json:"words"`
+	WPM        float64 `json:"wpm"`
+	Accuracy   float64 `json:"accuracy"`
+	DurationMs int64   `json:"duration_ms"`
+	Version    string  `json:"version,omitempty"`
+}
+
+// Entry is a ranked Result. Rank starts at 1 within each Params group.
+type Entry struct {
+	Rank int `json:"rank"`
+	Result
+}
+
+// Validate reports the first field of r a server must not accept.
+func (r Result) Validate() error {
+	switch {
+	case r.Group == "" || utf8.RuneCountInString(r.Group) > MaxNameLen:
+		return fmt.Errorf("group must be 1-%d characters", MaxNameLen)
+	case r.Name == "" || utf8.RuneCountInString(r.Name) > MaxNameLen:
+		return fmt.Errorf("name must be 1-%d characters", MaxNameLen)
+	case !validDay(r.Day):
+		return fmt.Errorf("invalid day %q", r.Day)
+	case r.Params == "":
+		return errors.New("params is required")
+	case r.WPM < 0 || r.WPM > maxWPM:
+		return fmt.Errorf("wpm must be between 0 and %d", maxWPM)
+	case r.Accuracy < 0 || r.Accuracy > 1:
+		return errors.New("accuracy must be between 0 and 1")
+	case r.DurationMs <= 0:
+		return errors.New("duration_ms must be > 0")
+	}
+	return nil
+}
+
+// Better reports whether r beats other: faster, then more accurate.
+func (r Result) Better(other Result) bool {
+	if r.WPM != other.WPM {
+		return r.WPM > other.WPM
+	}
+	return r.Accuracy > other.Accuracy
+}
+
+// Rank orders results by Params, then best first, and numbers them within each
+// Params group.
+func Rank(results []Result) []Entry {
+	sorted := append([]Result(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Params != b.Params {
+			return a.Params < b.Params
+		}
+		if a.Better(b) || b.Better(a) {
+			return a.Better(b)
+		}
+		return a.Name < b.Name
+	})
+	entries := make([]Entry, 0, len(sorted))
+	rank := 0
+	for i, r := range sorted {
+		rank++
+		if i > 0 && sorted[i-1].Params != r.Params {
+			rank = 1
+		}
+		entries = append(entries, Entry{Rank: rank, Result: r})
+	}
+	return entries
+}
+
+func validDay(day string) bool {
+	_, err := time.Parse("2006-01-02", day)
+	return err == nil
+}
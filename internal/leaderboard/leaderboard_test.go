@@ -0,0 +1,108 @@
+package leaderboard
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func result(name, params string, wpm, acc float64) Result {
+	return Result{Group: "friends", Day: "2024-05-10", Params: params, Name: name, Lang: "en", Mode: "words", Words: 25, WPM: wpm, Accuracy: acc, DurationMs: 30000}
+}
+
+func TestRankGroupsBySettings(t *testing.T) {
+	entries := Rank([]Result{
+		result("bob", "aaa", 70, 0.99),
+		result("eve", "bbb", 90, 0.95),
+		result("ann", "aaa", 80, 0.97),
+		result("cid", "aaa", 70, 1),
+	})
+	want := []struct {
+		name string
+		rank int
+	}{{"ann", 1}, {"cid", 2}, {"bob", 3}, {"eve", 1}}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i].Name != w.name || entries[i].Rank != w.rank {
+			t.Fatalf("entry %d: expected %s #%d, got %s #%d", i, w.name, w.rank, entries[i].Name, entries[i].Rank)
+		}
+	}
+}
+
+func TestServerKeepsBestResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	srv, err := NewServer(path)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := NewClient(ts.URL + "/")
+	ctx := context.Background()
+
+	for _, r := range []Result{result("ann", "aaa", 80, 0.97), result("ann", "aaa", 60, 1), result("bob", "aaa", 85, 0.9)} {
+		if err := client.Submit(ctx, r); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+	if err := client.Submit(ctx, result("", "aaa", 50, 1)); err == nil {
+		t.Fatalf("expected a result without a name to be rejected")
+	}
+
+	// A restarted server reads the saved results back.
+	srv, err = NewServer(path)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	ts2 := httptest.NewServer(srv)
+	defer ts2.Close()
+	entries, err := NewClient(ts2.URL).Rankings(ctx, "friends", "2024-05-10")
+	if err != nil {
+		t.Fatalf("Rankings failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name != "bob" || entries[1].Name != "ann" || entries[1].WPM != 80 {
+		t.Fatalf("unexpected rankings: %+v", entries)
+	}
+	if entries, err := NewClient(ts2.URL).Rankings(ctx, "others", "2024-05-10"); err != nil || len(entries) != 0 {
+		t.Fatalf("expected no rankings for another group, got %+v, %v", entries, err)
+	}
+}
+
+func TestServerKeepsResultsWhenSaveFails(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	srv, err := NewServer(filepath.Join(dir, "results.json"))
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	client := NewClient(ts.URL)
+	ctx := context.Background()
+
+	if err := client.Submit(ctx, result("ann", "aaa", 80, 0.97)); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	// Without its directory the file can no longer be written.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("remove dir: %v", err)
+	}
+	for _, r := range []Result{result("ann", "aaa", 90, 0.97), result("bob", "aaa", 85, 0.9)} {
+		if err := client.Submit(ctx, r); err == nil {
+			t.Fatalf("expected %s's result to fail to save", r.Name)
+		}
+	}
+	entries, err := client.Rankings(ctx, "friends", "2024-05-10")
+	if err != nil {
+		t.Fatalf("Rankings failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "ann" || entries[0].WPM != 80 {
+		t.Fatalf("expected only the saved result, got %+v", entries)
+	}
+}
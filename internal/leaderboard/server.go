@@ -0,0 +1,175 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// maxBodyBytes bounds a submitted result.
+const maxBodyBytes = 4096
+
+// Server keeps the best result per player, group, day and params, optionally
+// persisted to a JSON file.
+type Server struct {
+	mu      sync.Mutex
+	path    string
+	results map[resultKey]Result
+	mux     *http.ServeMux
+}
+
+type resultKey struct {
+	group, day, params, name string
+}
+
+// NewServer creates a server that saves its results to path, loading the results
+// already there. An empty path keeps them in memory only.
+func NewServer(path string) (*Server, error) {
+	s := &Server{path: path, results: map[resultKey]Result{}, mux: http.NewServeMux()}
+	if path != "" {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+	}
+	s.mux.HandleFunc("POST /api/results", s.handleSubmit)
+	s.mux.HandleFunc("GET /api/rankings", s.handleRankings)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var result Result
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&result); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid result: %w", err))
+		return
+	}
+	if err := result.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := resultKey{result.Group, result.Day, result.Params, result.Name}
+	prev, ok := s.results[key]
+	if ok && !result.Better(prev) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.results[key] = result
+	if err := s.save(); err != nil {
+		// Keep memory in line with the file, so a failed submission is not ranked.
+		if ok {
+			s.results[key] = prev
+		} else {
+			delete(s.results, key)
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleRankings(w http.ResponseWriter, r *http.Request) {
+	group := r.URL.Query().Get("group")
+	day := r.URL.Query().Get("day")
+	if group == "" || !validDay(day) {
+		writeError(w, http.StatusBadRequest, errors.New("group and day (YYYY-MM-DD) are required"))
+		return
+	}
+	s.mu.Lock()
+	var results []Result
+	for key, result := range s.results {
+		if key.group == group && key.day == day {
+			results = append(results, result)
+		}
+	}
+	s.mu.Unlock()
+	writeJSON(w, Rank(results))
+}
+
+func (s *Server) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	var results []Result
+	if err := json.Unmarshal(data, &results); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	for _, r := range results {
+		s.results[resultKey{r.Group, r.Day, r.Params, r.Name}] = r
+	}
+	return nil
+}
+
+// save writes all results to a temporary file renamed over path, so a crash never
+// leaves a truncated file behind. The caller holds s.mu.
+func (s *Server) save() error {
+	if s.path == "" {
+		return nil
+	}
+	results := make([]Result, 0, len(s.results))
+	for _, r := range s.results {
+		results = append(results, r)
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to save results: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		// Best-effort write; the client has likely gone away.
+		_ = err
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if encErr := json.NewEncoder(w).Encode(map[string]string{"error": err.Error()}); encErr != nil {
+		// Best-effort write; the client has likely gone away.
+		_ = encErr
+	}
+}
+
+// drain reads the rest of body so the connection can be reused.
+func drain(body io.Reader) {
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		// Best-effort drain.
+		_ = err
+	}
+}
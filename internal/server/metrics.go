@@ -0,0 +1,84 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// handleMetrics writes Prometheus metrics. Totals come from the daily tables, so
+// incomplete sessions are not counted.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	totals, err := s.store.ListLangTotals(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	last, err := s.store.ListSessionsPage(ctx, model.StatsConfig{}, 0, 1)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	daily, err := s.store.ListDailyAggregates(ctx, model.StatsConfig{})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	var b strings.Builder
+	writeMetricHeader(&b, "tuipe_sessions_total", "counter", "Completed practice sessions.")
+	for _, t := range totals {
+		writeMetric(&b, "tuipe_sessions_total", langLabel(t.Lang), float64(t.Sessions))
+	}
+	writeMetricHeader(&b, "tuipe_practice_seconds_total", "counter", "Time spent typing in completed sessions.")
+	for _, t := range totals {
+		writeMetric(&b, "tuipe_practice_seconds_total", langLabel(t.Lang), float64(t.DurationMs)/1000)
+	}
+	writeMetricHeader(&b, "tuipe_chars_total", "counter", "Typed non-space characters by result.")
+	for _, t := range totals {
+		writeMetric(&b, "tuipe_chars_total", langLabel(t.Lang)+`,result="correct"`, float64(t.Correct))
+		writeMetric(&b, "tuipe_chars_total", langLabel(t.Lang)+`,result="incorrect"`, float64(t.Incorrect))
+	}
+	if len(last) > 0 {
+		_, _, acc := stats.SessionMetrics(last[0].Correct, last[0].Incorrect, last[0].DurationMs)
+		writeMetricHeader(&b, "tuipe_last_wpm", "gauge", "WPM of the latest session.")
+		writeMetric(&b, "tuipe_last_wpm", "", stats.SessionSpeed(last[0].WPMFormula, last[0]))
+		writeMetricHeader(&b, "tuipe_last_accuracy_ratio", "gauge", "Accuracy of the latest session (0-1).")
+		writeMetric(&b, "tuipe_last_accuracy_ratio", "", acc)
+		writeMetricHeader(&b, "tuipe_last_session_timestamp_seconds", "gauge", "End time of the latest session.")
+		writeMetric(&b, "tuipe_last_session_timestamp_seconds", "", float64(last[0].EndedAt.Unix()))
+	}
+	writeMetricHeader(&b, "tuipe_streak_days", "gauge", "Consecutive days with practice.")
+	writeMetric(&b, "tuipe_streak_days", "", float64(stats.DailyStreak(daily, time.Now())))
+
+	w.Header().Set("Content-Type", metricsContentType)
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		// Best-effort write; the client has likely gone away.
+		_ = err
+	}
+}
+
+func writeMetricHeader(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeMetric(b *strings.Builder, name, labels string, value float64) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+	fmt.Fprintf(b, "%s %s\n", name, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func langLabel(lang string) string {
+	return "lang=" + strconv.Quote(lang)
+}
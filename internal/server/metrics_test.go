@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMetricsExposition(t *testing.T) {
+	srv, _ := newTestServer(t)
+	rec := get(t, srv, "/metrics")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != metricsContentType {
+		t.Fatalf("expected %q, got %q", metricsContentType, ct)
+	}
+
+	// Incomplete sessions stay out of the totals and the latest-session gauges; the
+	// seeded days are long past, so the streak is 0.
+	lastEnded := time.Date(2024, 3, 2, 11, 0, 0, 0, time.Local).Unix()
+	want := `# HELP tuipe_sessions_total Completed practice sessions.
+# TYPE tuipe_sessions_total counter
+tuipe_sessions_total{lang="de"} 1
+tuipe_sessions_total{lang="en"} 2
+# HELP tuipe_practice_seconds_total Time spent typing in completed sessions.
+# TYPE tuipe_practice_seconds_total counter
+tuipe_practice_seconds_total{lang="de"} 60
+tuipe_practice_seconds_total{lang="en"} 120
+# HELP tuipe_chars_total Typed non-space characters by result.
+# TYPE tuipe_chars_total counter
+tuipe_chars_total{lang="de",result="correct"} 200
+tuipe_chars_total{lang="de",result="incorrect"} 0
+tuipe_chars_total{lang="en",result="correct"} 250
+tuipe_chars_total{lang="en",result="incorrect"} 50
+# HELP tuipe_last_wpm WPM of the latest session.
+# TYPE tuipe_last_wpm gauge
+tuipe_last_wpm 40
+# HELP tuipe_last_accuracy_ratio Accuracy of the latest session (0-1).
+# TYPE tuipe_last_accuracy_ratio gauge
+tuipe_last_accuracy_ratio 1
+# HELP tuipe_last_session_timestamp_seconds End time of the latest session.
+# TYPE tuipe_last_session_timestamp_seconds gauge
+tuipe_last_session_timestamp_seconds ` + strconv.FormatInt(lastEnded, 10) + `
+# HELP tuipe_streak_days Consecutive days with practice.
+# TYPE tuipe_streak_days gauge
+tuipe_streak_days 0
+`
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("unexpected metrics:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMetricsEmptyStore(t *testing.T) {
+	st := openTestStore(t)
+	rec := get(t, New(st), "/metrics")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	// With no sessions only the streak is reported.
+	want := `# HELP tuipe_sessions_total Completed practice sessions.
+# TYPE tuipe_sessions_total counter
+# HELP tuipe_practice_seconds_total Time spent typing in completed sessions.
+# TYPE tuipe_practice_seconds_total counter
+# HELP tuipe_chars_total Typed non-space characters by result.
+# TYPE tuipe_chars_total counter
+# HELP tuipe_streak_days Consecutive days with practice.
+# TYPE tuipe_streak_days gauge
+tuipe_streak_days 0
+`
+	if got := rec.Body.String(); got != want {
+		t.Fatalf("unexpected metrics:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,384 @@
+// Package server exposes the session store as a read-only JSON API and Prometheus metrics.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+const defaultWeakWindow = 20
+
+// Server serves read-only JSON endpoints over a store.
+type Server struct {
+	store *store.Store
+	mux   *http.ServeMux
+}
+
+// New creates a server backed by st.
+func New(st *store.Store) *Server {
+	s := &Server{store: st, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /api/sessions", s.handleSessions)
+	s.mux.HandleFunc("GET /api/sessions/{id}", s.handleSession)
+	s.mux.HandleFunc("GET /api/daily", s.handleDaily)
+	s.mux.HandleFunc("GET /api/chars", s.handleChars)
+	s.mux.HandleFunc("GET /api/weak", s.handleWeak)
+	s.mux.HandleFunc("GET /api/bests", s.handleBests)
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type sessionJSON struct {
+	ID         int64     `json:"id"`
+	EndedAt    time.Time `json:"ended_at"`
+	WPM        float64   `json:"wpm"`
+	WPMFormula string    `json:"wpm_formula,omitempty"`
+	Accuracy   float64   `json:"accuracy"`
+	Correct    int       `json:"correct"`
+	Incorrect  int       `json:"incorrect"`
+	DurationMs int64     `json:"duration_ms"`
+	Mode       string    `json:"mode"`
+	FocusWeak  bool      `json:"focus_weak"`
+	Keyboard   string    `json:"keyboard,omitempty"`
+	Layout     string    `json:"layout,omitempty"`
+	Incomplete bool      `json:"incomplete"`
+}
+
+type sessionDetailJSON struct {
+	ID         int64     `json:"id"`
+	StartedAt  time.Time `json:"started_at"`
+	EndedAt    time.Time `json:"ended_at"`
+	Lang       string    `json:"lang"`
+	WPM        float64   `json:"wpm"`
+	WPMFormula string    `json:"wpm_formula,omitempty"`
+	Accuracy   float64   `json:"accuracy"`
+	Correct    int       `json:"correct"`
+	Incorrect  int       `json:"incorrect"`
+	DurationMs int64     `json:"duration_ms"`
+	Words      int       `json:"words"`
+	WordsTyped int       `json:"words_typed"`
+	Mode       string    `json:"mode"`
+	FocusWeak  bool      `json:"focus_weak"`
+	WeakSet    string    `json:"weak_set,omitempty"`
+	Keyboard   string    `json:"keyboard,omitempty"`
+	Layout     string    `json:"layout,omitempty"`
+	WordList   string    `json:"wordlist,omitempty"`
+	AppVersion string    `json:"app_version,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Incomplete bool      `json:"incomplete"`
+	TargetText string    `json:"target_text,omitempty"`
+	TypedText  string    `json:"typed_text,omitempty"`
+}
+
+type dailyJSON struct {
+	Day        string  `json:"day"`
+	Sessions   int     `json:"sessions"`
+	WPM        float64 `json:"wpm"`
+	Accuracy   float64 `json:"accuracy"`
+	DurationMs int64   `json:"duration_ms"`
+}
+
+type charJSON struct {
+	Char         string  `json:"char"`
+	Correct      int     `json:"correct"`
+	Incorrect    int     `json:"incorrect"`
+	Accuracy     float64 `json:"accuracy"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+type bestJSON struct {
+	Mode    string      `json:"mode"`
+	Session sessionJSON `json:"session"`
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	cfg, err := statsConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	sessions, err := s.store.ListSessions(r.Context(), cfg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]sessionJSON, 0, len(sessions))
+	for _, sess := range sessions {
+		out = append(out, toSessionJSON(sess))
+	}
+	writeJSON(w, out)
+}
+
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid session id %q", r.PathValue("id")))
+		return
+	}
+	sess, err := s.store.GetSession(r.Context(), id)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	_, _, acc := stats.SessionMetrics(sess.CorrectNonSpace, sess.IncorrectNonSpace, sess.DurationMs)
+	writeJSON(w, sessionDetailJSON{
+		ID:         id,
+		StartedAt:  sess.StartedAt,
+		EndedAt:    sess.EndedAt,
+		Lang:       sess.Lang,
+		WPM:        stats.Speed(sess.WPMFormula, sess.CorrectNonSpace, sess.WordsTyped, sess.DurationMs),
+		WPMFormula: sess.WPMFormula,
+		Accuracy:   acc,
+		Correct:    sess.CorrectNonSpace,
+		Incorrect:  sess.IncorrectNonSpace,
+		DurationMs: sess.DurationMs,
+		Words:      sess.Words,
+		WordsTyped: sess.WordsTyped,
+		Mode:       sess.Mode,
+		FocusWeak:  sess.FocusWeak,
+		WeakSet:    sess.WeakSet,
+		Keyboard:   sess.Keyboard,
+		Layout:     sess.Layout,
+		WordList:   sess.WordListID,
+		AppVersion: sess.AppVersion,
+		Source:     sess.Source,
+		Incomplete: sess.Incomplete,
+		TargetText: sess.TargetText,
+		TypedText:  sess.TypedText,
+	})
+}
+
+func (s *Server) handleDaily(w http.ResponseWriter, r *http.Request) {
+	cfg, err := statsConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	days, err := s.dailyAggregates(r.Context(), cfg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]dailyJSON, 0, len(days))
+	for _, day := range days {
+		// A day mixes sessions of every formula, so its speed is five-character WPM.
+		_, _, acc := stats.SessionMetrics(day.Correct, day.Incorrect, day.DurationMs)
+		out = append(out, dailyJSON{
+			Day:        day.Day.Format("2006-01-02"),
+			Sessions:   day.Sessions,
+			WPM:        stats.Speed(model.WPMChars, day.Correct, 0, day.DurationMs),
+			Accuracy:   acc,
+			DurationMs: day.DurationMs,
+		})
+	}
+	writeJSON(w, out)
+}
+
+func (s *Server) handleChars(w http.ResponseWriter, r *http.Request) {
+	cfg, err := statsConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	aggs, err := s.charAggregates(r.Context(), cfg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, toCharsJSON(aggs))
+}
+
+// dailyAggregates reads the daily tables when they can answer cfg, as stats.BuildReport
+// does, and otherwise sums the matching sessions per local day so every filter applies.
+func (s *Server) dailyAggregates(ctx context.Context, cfg model.StatsConfig) ([]model.DailyAggregate, error) {
+	if stats.DailyEligible(cfg) {
+		return s.store.ListDailyAggregates(ctx, cfg)
+	}
+	sessions, err := s.store.ListSessions(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	var days []model.DailyAggregate
+	for _, sess := range sessions {
+		y, m, d := sess.EndedAt.Local().Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+		// Sessions come oldest first, so each day's sessions are adjacent.
+		if len(days) == 0 || !days[len(days)-1].Day.Equal(day) {
+			days = append(days, model.DailyAggregate{Day: day})
+		}
+		agg := &days[len(days)-1]
+		agg.Sessions++
+		agg.Correct += sess.Correct
+		agg.Incorrect += sess.Incorrect
+		agg.DurationMs += sess.DurationMs
+	}
+	return days, nil
+}
+
+// charAggregates reads the daily char tables when they can answer cfg and otherwise sums
+// the char stats of the matching sessions.
+func (s *Server) charAggregates(ctx context.Context, cfg model.StatsConfig) ([]model.CharAggregate, error) {
+	if stats.DailyEligible(cfg) {
+		return s.store.ListDailyCharAggregates(ctx, cfg)
+	}
+	sessions, err := s.store.ListSessions(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(sessions))
+	for i, sess := range sessions {
+		ids[i] = sess.SessionID
+	}
+	return s.store.ListCharAggregatesForSessions(ctx, ids)
+}
+
+func (s *Server) handleWeak(w http.ResponseWriter, r *http.Request) {
+	window, err := intParam(r, "window", defaultWeakWindow)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	top, err := intParam(r, "top", 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	aggs, err := s.store.GetWeakChars(r.Context(), window, r.URL.Query().Get("lang"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := toCharsJSON(aggs)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Accuracy < out[j].Accuracy })
+	if top > 0 && top < len(out) {
+		out = out[:top]
+	}
+	writeJSON(w, out)
+}
+
+func (s *Server) handleBests(w http.ResponseWriter, r *http.Request) {
+	cfg, err := statsConfig(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	sessions, err := s.store.ListSessions(r.Context(), cfg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	bests := stats.PersonalBests(sessions)
+	out := make([]bestJSON, 0, len(bests))
+	for _, pb := range bests {
+		out = append(out, bestJSON{Mode: pb.Mode, Session: toSessionJSON(pb.Session)})
+	}
+	writeJSON(w, out)
+}
+
+// statsConfig reads the lang, since (YYYY-MM-DD), last, mode, wordlist and
+// include_incomplete query parameters.
+func statsConfig(r *http.Request) (model.StatsConfig, error) {
+	q := r.URL.Query()
+	cfg := model.StatsConfig{
+		Lang:              q.Get("lang"),
+		Mode:              q.Get("mode"),
+		WordList:          q.Get("wordlist"),
+		IncludeIncomplete: q.Get("include_incomplete") == "true",
+	}
+	if since := q.Get("since"); since != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", since, time.Local)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid since %q: expected YYYY-MM-DD", since)
+		}
+		cfg.Since = &parsed
+	}
+	last, err := intParam(r, "last", 0)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Last = last
+	return cfg, nil
+}
+
+func intParam(r *http.Request, name string, fallback int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected a non-negative integer", name, raw)
+	}
+	return v, nil
+}
+
+// toSessionJSON reports the speed under the formula the session was recorded with.
+func toSessionJSON(s model.SessionAggregate) sessionJSON {
+	_, _, acc := stats.SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+	return sessionJSON{
+		ID:         s.SessionID,
+		EndedAt:    s.EndedAt,
+		WPM:        stats.SessionSpeed(s.WPMFormula, s),
+		WPMFormula: s.WPMFormula,
+		Accuracy:   acc,
+		Correct:    s.Correct,
+		Incorrect:  s.Incorrect,
+		DurationMs: s.DurationMs,
+		Mode:       s.Mode,
+		FocusWeak:  s.FocusWeak,
+		Keyboard:   s.Keyboard,
+		Layout:     s.Layout,
+		Incomplete: s.Incomplete,
+	}
+}
+
+func toCharsJSON(aggs []model.CharAggregate) []charJSON {
+	out := make([]charJSON, 0, len(aggs))
+	for _, agg := range aggs {
+		c := charJSON{Char: agg.Char, Correct: agg.Correct, Incorrect: agg.Incorrect}
+		if total := agg.Correct + agg.Incorrect; total > 0 {
+			c.Accuracy = float64(agg.Correct) / float64(total)
+		}
+		if agg.LatencyCount > 0 {
+			c.AvgLatencyMs = float64(agg.LatencySumMs) / float64(agg.LatencyCount)
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Char < out[j].Char })
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		// Best-effort write; the client has likely gone away.
+		_ = err
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if encErr := json.NewEncoder(w).Encode(map[string]string{"error": err.Error()}); encErr != nil {
+		// Best-effort write; the client has likely gone away.
+		_ = encErr
+	}
+}
@@ -0,0 +1,383 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// newTestServer seeds three completed sessions (en words on March 1st, en markov and
+// de words on March 2nd) plus an incomplete en session, and returns their IDs in that order.
+func newTestServer(t *testing.T) (*Server, []int64) {
+	t.Helper()
+	st := openTestStore(t)
+	seed := []struct {
+		stats model.SessionStats
+		chars []model.CharStats
+	}{
+		{
+			stats: model.SessionStats{Lang: "en", Mode: model.ModeWords, EndedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local),
+				CorrectNonSpace: 100, DurationMs: 60000, Words: 20, WordsTyped: 20, WordListID: "common@1",
+				TargetText: "hello world", TypedText: "hello world"},
+			chars: []model.CharStats{
+				{Char: "a", Correct: 10, LatencySumMs: 1000, LatencyCount: 10},
+				{Char: "b", Correct: 5, Incorrect: 5, LatencySumMs: 500, LatencyCount: 5},
+			},
+		},
+		{
+			stats: model.SessionStats{Lang: "en", Mode: model.ModeMarkov, EndedAt: time.Date(2024, 3, 2, 10, 0, 0, 0, time.Local),
+				CorrectNonSpace: 150, IncorrectNonSpace: 50, DurationMs: 60000},
+			chars: []model.CharStats{{Char: "a", Correct: 10, LatencySumMs: 1000, LatencyCount: 10}},
+		},
+		{
+			stats: model.SessionStats{Lang: "de", Mode: model.ModeWords, EndedAt: time.Date(2024, 3, 2, 11, 0, 0, 0, time.Local),
+				CorrectNonSpace: 200, DurationMs: 60000, WordListID: "deutsch@2"},
+		},
+		{
+			stats: model.SessionStats{Lang: "en", Mode: model.ModeWords, EndedAt: time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local),
+				CorrectNonSpace: 50, DurationMs: 30000, Incomplete: true},
+		},
+	}
+	ids := make([]int64, 0, len(seed))
+	for _, s := range seed {
+		s.stats.StartedAt = s.stats.EndedAt.Add(-time.Duration(s.stats.DurationMs) * time.Millisecond)
+		id, err := st.InsertSession(context.Background(), s.stats, s.chars)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	return New(st), ids
+}
+
+func openTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+	return st
+}
+
+func get(t *testing.T, srv *Server, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected application/json, got %q", ct)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body.String(), err)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestSessionsFilters(t *testing.T) {
+	srv, ids := newTestServer(t)
+	tests := []struct {
+		query string
+		want  []int64
+	}{
+		{"", ids[:3]},
+		{"?lang=en", ids[:2]},
+		{"?mode=words", []int64{ids[0], ids[2]}},
+		{"?since=2024-03-02", ids[1:3]},
+		{"?last=1", ids[2:3]},
+		{"?wordlist=common", ids[:1]},
+		{"?wordlist=deutsch@2", ids[2:3]},
+		{"?include_incomplete=true", ids},
+		{"?lang=en&mode=words&include_incomplete=true", []int64{ids[0], ids[3]}},
+		{"?lang=fr", []int64{}},
+	}
+	for _, tt := range tests {
+		var out []sessionJSON
+		decode(t, get(t, srv, "/api/sessions"+tt.query), &out)
+		got := make([]int64, 0, len(out))
+		for _, s := range out {
+			got = append(got, s.ID)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: expected sessions %v, got %v", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestSessionsJSONShape(t *testing.T) {
+	srv, ids := newTestServer(t)
+	var out []map[string]any
+	decode(t, get(t, srv, "/api/sessions?lang=en"), &out)
+	if len(out) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(out))
+	}
+	want := []string{"accuracy", "correct", "duration_ms", "ended_at", "focus_weak", "id", "incomplete", "incorrect", "mode", "wpm"}
+	if got := sortedKeys(out[0]); !slices.Equal(got, want) {
+		t.Fatalf("expected keys %v, got %v", want, got)
+	}
+	second := out[1]
+	if second["id"] != float64(ids[1]) || second["wpm"] != 30.0 || second["accuracy"] != 0.75 ||
+		second["correct"] != 150.0 || second["incorrect"] != 50.0 || second["mode"] != model.ModeMarkov {
+		t.Fatalf("unexpected session: %v", second)
+	}
+	ended, err := time.Parse(time.RFC3339, second["ended_at"].(string))
+	if err != nil || !ended.Equal(time.Date(2024, 3, 2, 10, 0, 0, 0, time.Local)) {
+		t.Fatalf("unexpected ended_at %v (%v)", second["ended_at"], err)
+	}
+}
+
+func TestSessionDetail(t *testing.T) {
+	srv, ids := newTestServer(t)
+	var out map[string]any
+	decode(t, get(t, srv, "/api/sessions/"+itoa(ids[0])), &out)
+	if out["id"] != float64(ids[0]) || out["lang"] != "en" || out["wpm"] != 20.0 || out["accuracy"] != 1.0 ||
+		out["words"] != 20.0 || out["words_typed"] != 20.0 || out["wordlist"] != "common@1" ||
+		out["target_text"] != "hello world" || out["typed_text"] != "hello world" {
+		t.Fatalf("unexpected detail: %v", out)
+	}
+	for _, key := range []string{"started_at", "ended_at", "duration_ms", "incomplete"} {
+		if _, ok := out[key]; !ok {
+			t.Fatalf("expected %q in %v", key, out)
+		}
+	}
+	if _, ok := out["weak_set"]; ok {
+		t.Fatalf("expected empty weak_set to be omitted: %v", out)
+	}
+
+	var incomplete sessionDetailJSON
+	decode(t, get(t, srv, "/api/sessions/"+itoa(ids[3])), &incomplete)
+	if !incomplete.Incomplete {
+		t.Fatalf("expected the incomplete session to be served and flagged: %+v", incomplete)
+	}
+}
+
+func TestSessionNotFound(t *testing.T) {
+	srv, _ := newTestServer(t)
+	rec := get(t, srv, "/api/sessions/999")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var out map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil || out["error"] == "" {
+		t.Fatalf("expected an error body, got %q (%v)", rec.Body.String(), err)
+	}
+}
+
+func TestBadParamsReturn400(t *testing.T) {
+	srv, _ := newTestServer(t)
+	for _, path := range []string{
+		"/api/sessions/abc",
+		"/api/sessions?since=yesterday",
+		"/api/sessions?since=2024-13-01",
+		"/api/sessions?last=x",
+		"/api/sessions?last=-1",
+		"/api/daily?since=03/01/2024",
+		"/api/daily?last=1.5",
+		"/api/chars?since=x",
+		"/api/chars?last=x",
+		"/api/weak?window=x",
+		"/api/weak?window=-5",
+		"/api/weak?top=x",
+		"/api/bests?since=x",
+		"/api/bests?last=-1",
+	} {
+		rec := get(t, srv, path)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, rec.Code)
+			continue
+		}
+		var out map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil || out["error"] == "" {
+			t.Errorf("%s: expected an error body, got %q (%v)", path, rec.Body.String(), err)
+		}
+	}
+}
+
+func TestDaily(t *testing.T) {
+	srv, _ := newTestServer(t)
+	var out []dailyJSON
+	decode(t, get(t, srv, "/api/daily?lang=en"), &out)
+	want := []dailyJSON{
+		{Day: "2024-03-01", Sessions: 1, WPM: 20, Accuracy: 1, DurationMs: 60000},
+		{Day: "2024-03-02", Sessions: 1, WPM: 30, Accuracy: 0.75, DurationMs: 60000},
+	}
+	if !slices.Equal(out, want) {
+		t.Fatalf("expected %+v, got %+v", want, out)
+	}
+
+	decode(t, get(t, srv, "/api/daily?since=2024-03-02"), &out)
+	if len(out) != 1 || out[0].Day != "2024-03-02" || out[0].Sessions != 2 || out[0].WPM != 35 {
+		t.Fatalf("expected one day of both languages, got %+v", out)
+	}
+
+	var shape []map[string]any
+	decode(t, get(t, srv, "/api/daily"), &shape)
+	if got, want := sortedKeys(shape[0]), []string{"accuracy", "day", "duration_ms", "sessions", "wpm"}; !slices.Equal(got, want) {
+		t.Fatalf("expected keys %v, got %v", want, got)
+	}
+}
+
+func TestChars(t *testing.T) {
+	srv, _ := newTestServer(t)
+	var out []charJSON
+	decode(t, get(t, srv, "/api/chars?lang=en"), &out)
+	want := []charJSON{
+		{Char: "a", Correct: 20, Accuracy: 1, AvgLatencyMs: 100},
+		{Char: "b", Correct: 5, Incorrect: 5, Accuracy: 0.5, AvgLatencyMs: 100},
+	}
+	if !slices.Equal(out, want) {
+		t.Fatalf("expected %+v, got %+v", want, out)
+	}
+
+	decode(t, get(t, srv, "/api/chars?lang=de"), &out)
+	if len(out) != 0 {
+		t.Fatalf("expected no chars for de, got %+v", out)
+	}
+}
+
+func TestDailyAndCharsApplySessionFilters(t *testing.T) {
+	srv, _ := newTestServer(t)
+	var days []dailyJSON
+	decode(t, get(t, srv, "/api/daily?mode=markov"), &days)
+	if want := []dailyJSON{{Day: "2024-03-02", Sessions: 1, WPM: 30, Accuracy: 0.75, DurationMs: 60000}}; !slices.Equal(days, want) {
+		t.Fatalf("expected the markov day only, got %+v", days)
+	}
+	decode(t, get(t, srv, "/api/daily?last=1"), &days)
+	if len(days) != 1 || days[0].Sessions != 1 || days[0].WPM != 40 {
+		t.Fatalf("expected the latest session's day only, got %+v", days)
+	}
+	decode(t, get(t, srv, "/api/daily?lang=en&include_incomplete=true"), &days)
+	if len(days) != 2 || days[1].Sessions != 2 || days[1].DurationMs != 90000 {
+		t.Fatalf("expected the incomplete session counted, got %+v", days)
+	}
+
+	var chars []charJSON
+	decode(t, get(t, srv, "/api/chars?mode=markov"), &chars)
+	if want := []charJSON{{Char: "a", Correct: 10, Accuracy: 1, AvgLatencyMs: 100}}; !slices.Equal(chars, want) {
+		t.Fatalf("expected the markov session's chars, got %+v", chars)
+	}
+	decode(t, get(t, srv, "/api/chars?lang=en&last=1"), &chars)
+	if len(chars) != 1 || chars[0].Char != "a" || chars[0].Correct != 10 {
+		t.Fatalf("expected the latest en session's chars, got %+v", chars)
+	}
+}
+
+func TestDailyAndCharsFilterByWordList(t *testing.T) {
+	srv, _ := newTestServer(t)
+	var days []dailyJSON
+	decode(t, get(t, srv, "/api/daily?wordlist=common"), &days)
+	if want := []dailyJSON{{Day: "2024-03-01", Sessions: 1, WPM: 20, Accuracy: 1, DurationMs: 60000}}; !slices.Equal(days, want) {
+		t.Fatalf("expected the common list's day only, got %+v", days)
+	}
+	decode(t, get(t, srv, "/api/daily?wordlist=deutsch@2"), &days)
+	if len(days) != 1 || days[0].Day != "2024-03-02" || days[0].Sessions != 1 || days[0].WPM != 40 {
+		t.Fatalf("expected the deutsch list's session only, got %+v", days)
+	}
+	decode(t, get(t, srv, "/api/daily?wordlist=missing"), &days)
+	if len(days) != 0 {
+		t.Fatalf("expected no days for an unknown list, got %+v", days)
+	}
+
+	var chars []charJSON
+	decode(t, get(t, srv, "/api/chars?wordlist=common@1"), &chars)
+	want := []charJSON{
+		{Char: "a", Correct: 10, Accuracy: 1, AvgLatencyMs: 100},
+		{Char: "b", Correct: 5, Incorrect: 5, Accuracy: 0.5, AvgLatencyMs: 100},
+	}
+	if !slices.Equal(chars, want) {
+		t.Fatalf("expected the common list's chars only, got %+v", chars)
+	}
+	decode(t, get(t, srv, "/api/chars?wordlist=deutsch"), &chars)
+	if len(chars) != 0 {
+		t.Fatalf("expected no chars for the deutsch list, got %+v", chars)
+	}
+}
+
+func TestSessionSpeedUsesRecordedFormula(t *testing.T) {
+	st := openTestStore(t)
+	end := time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local)
+	id, err := st.InsertSession(context.Background(), model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: "en",
+		Mode: model.ModeWords, CorrectNonSpace: 200, DurationMs: 60000, WordsTyped: 30, WPMFormula: model.WPMWords}, nil)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	srv := New(st)
+
+	var list []sessionJSON
+	decode(t, get(t, srv, "/api/sessions"), &list)
+	if len(list) != 1 || list[0].WPM != 30 || list[0].WPMFormula != model.WPMWords {
+		t.Fatalf("expected 30 words per minute, got %+v", list)
+	}
+	var detail sessionDetailJSON
+	decode(t, get(t, srv, "/api/sessions/"+itoa(id)), &detail)
+	if detail.WPM != 30 || detail.WPMFormula != model.WPMWords {
+		t.Fatalf("expected 30 words per minute, got %+v", detail)
+	}
+	// Days mix formulas, so they stay in five-character WPM.
+	var days []dailyJSON
+	decode(t, get(t, srv, "/api/daily"), &days)
+	if len(days) != 1 || days[0].WPM != 40 {
+		t.Fatalf("expected 40 five-character WPM, got %+v", days)
+	}
+}
+
+func TestWeak(t *testing.T) {
+	srv, _ := newTestServer(t)
+	var out []charJSON
+	decode(t, get(t, srv, "/api/weak?top=1"), &out)
+	if len(out) != 1 || out[0].Char != "b" {
+		t.Fatalf("expected the least accurate char first, got %+v", out)
+	}
+
+	// The latest completed en session only typed "a".
+	decode(t, get(t, srv, "/api/weak?lang=en&window=1"), &out)
+	if len(out) != 1 || out[0].Char != "a" || out[0].Correct != 10 {
+		t.Fatalf("expected the window to cover one session, got %+v", out)
+	}
+}
+
+func TestBests(t *testing.T) {
+	srv, ids := newTestServer(t)
+	var out []bestJSON
+	decode(t, get(t, srv, "/api/bests"), &out)
+	if len(out) != 2 || out[0].Mode != model.ModeMarkov || out[0].Session.ID != ids[1] ||
+		out[1].Mode != model.ModeWords || out[1].Session.ID != ids[2] || out[1].Session.WPM != 40 {
+		t.Fatalf("unexpected bests: %+v", out)
+	}
+
+	decode(t, get(t, srv, "/api/bests?lang=en&mode=words"), &out)
+	if len(out) != 1 || out[0].Session.ID != ids[0] {
+		t.Fatalf("expected the filtered best to be the first session, got %+v", out)
+	}
+}
+
+func itoa(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,55 @@
+package statsui
+
+import (
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// nextCharGroup cycles the char table through every char, then each grouping.
+func nextCharGroup(group string) string {
+	for i, g := range stats.CharGroups {
+		if g != group {
+			continue
+		}
+		if i+1 < len(stats.CharGroups) {
+			return stats.CharGroups[i+1]
+		}
+		return ""
+	}
+	return stats.CharGroups[0]
+}
+
+// charGroupLayout is the layout hand and row groups are computed from: the layout
+// filter, else the layout most of the shown sessions were typed on.
+func (m *Model) charGroupLayout() string {
+	if m.cfg.Layout != "" {
+		return m.cfg.Layout
+	}
+	counts := map[string]int{}
+	best := ""
+	for _, s := range m.report.Sessions {
+		if s.Layout == "" {
+			continue
+		}
+		counts[s.Layout]++
+		if counts[s.Layout] > counts[best] {
+			best = s.Layout
+		}
+	}
+	return best
+}
+
+// charGroupTitle heads the first column of the grouped char table.
+func (m *Model) charGroupTitle() string {
+	if m.charGroup == stats.CharGroupClass {
+		return "Class"
+	}
+	layout := m.charGroupLayout()
+	if layout == "" {
+		layout = wordlist.DefaultRowLayout
+	}
+	if m.charGroup == stats.CharGroupHand {
+		return "Hand (" + layout + ")"
+	}
+	return "Row (" + layout + ")"
+}
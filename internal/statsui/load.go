@@ -0,0 +1,267 @@
+package statsui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// reloadDebounce delays re-queries so bursts of setting changes trigger a single load.
+const reloadDebounce = 250 * time.Millisecond
+
+// sessionPageSize is the number of sessions shown per page on the Sessions tab.
+const sessionPageSize = 100
+
+// reportLoadedMsg carries a report built in the background.
+type reportLoadedMsg struct {
+	seq            int
+	revision       store.Revision
+	report         stats.Report
+	charSelection  []string
+	charPerSession map[int64]map[string]model.CharAggregate
+	charErr        error
+	page           sessionPage
+	err            error
+}
+
+// sessionPage holds one page of the Sessions tab, newest first.
+type sessionPage struct {
+	index    int
+	total    int
+	sessions []model.SessionAggregate
+}
+
+// sessionPageLoadedMsg carries a Sessions tab page loaded after paging.
+type sessionPageLoadedMsg struct {
+	seq  int
+	page sessionPage
+	err  error
+}
+
+// reloadMsg fires once the debounce delay for load seq has elapsed.
+type reloadMsg struct {
+	seq int
+}
+
+// storeChangedMsg reports a write committed through the UI's own store.
+type storeChangedMsg struct{}
+
+// refreshTickMsg fires every StatsConfig.Refresh to poll the store for new sessions.
+type refreshTickMsg struct{}
+
+// revisionMsg carries the store revision read after a refresh tick.
+type revisionMsg struct {
+	revision store.Revision
+	err      error
+}
+
+// watchStore delivers a storeChangedMsg after the next write signalled on changed.
+func watchStore(changed <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-changed
+		return storeChangedMsg{}
+	}
+}
+
+// refreshTick schedules the next poll, or nothing when periodic refresh is off.
+func refreshTick(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg { return refreshTickMsg{} })
+}
+
+func (m *Model) checkRevision() tea.Cmd {
+	st := m.store
+	return func() tea.Msg {
+		rev, err := st.Revision(context.Background())
+		return revisionMsg{revision: rev, err: err}
+	}
+}
+
+// handleRevision reloads the report when sessions were added or removed since it was built.
+// Errors are ignored; the next tick tries again.
+func (m *Model) handleRevision(msg revisionMsg) tea.Cmd {
+	if msg.err != nil || !m.loaded || m.loading || msg.revision == m.revision {
+		return nil
+	}
+	return m.scheduleReload()
+}
+
+// reloadNow starts loading the report immediately.
+func (m *Model) reloadNow() tea.Cmd {
+	m.loadSeq++
+	return tea.Batch(m.startLoading(), m.loadReport(m.loadSeq))
+}
+
+// scheduleReload starts loading the report after the debounce delay.
+func (m *Model) scheduleReload() tea.Cmd {
+	m.loadSeq++
+	seq := m.loadSeq
+	debounce := tea.Tick(reloadDebounce, func(time.Time) tea.Msg {
+		return reloadMsg{seq: seq}
+	})
+	return tea.Batch(m.startLoading(), debounce)
+}
+
+func (m *Model) startLoading() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	m.loading = true
+	return m.spinner.Tick
+}
+
+func (m *Model) loadReport(seq int) tea.Cmd {
+	st := m.store
+	cfg := m.cfg
+	custom := m.charSelectionCustom
+	selection := append([]string(nil), m.charSelection...)
+	pageIndex := m.sessionPage.index
+	return func() tea.Msg {
+		ctx := context.Background()
+		// Read the revision first so a write racing the load triggers another one.
+		revision, err := st.Revision(ctx)
+		if err != nil {
+			return reportLoadedMsg{seq: seq, err: err}
+		}
+		report, err := stats.BuildReport(ctx, st, cfg)
+		if err != nil {
+			return reportLoadedMsg{seq: seq, err: err}
+		}
+		if !custom {
+			selection = stats.TopCharsByFrequency(report.CharAggsAll, 5)
+		}
+		perSession, charErr := loadCharPerSession(ctx, st, report, selection, cfg.FoldCase)
+		page, err := loadSessionPage(ctx, st, cfg, pageIndex)
+		if err != nil {
+			return reportLoadedMsg{seq: seq, err: err}
+		}
+		return reportLoadedMsg{
+			seq:            seq,
+			revision:       revision,
+			report:         report,
+			charSelection:  selection,
+			charPerSession: perSession,
+			charErr:        charErr,
+			page:           page,
+		}
+	}
+}
+
+// moveSessionPage loads the Sessions tab page delta pages away from the current one.
+func (m *Model) moveSessionPage(delta int) tea.Cmd {
+	if !m.loaded || m.errMsg != "" {
+		return nil
+	}
+	index := m.sessionPage.index + delta
+	if index < 0 || index >= sessionPageCount(m.sessionPage.total) {
+		return nil
+	}
+	st := m.store
+	cfg := m.cfg
+	seq := m.loadSeq
+	return func() tea.Msg {
+		page, err := loadSessionPage(context.Background(), st, cfg, index)
+		return sessionPageLoadedMsg{seq: seq, page: page, err: err}
+	}
+}
+
+func (m *Model) handleSessionPageLoaded(msg sessionPageLoadedMsg) {
+	if msg.seq != m.loadSeq {
+		return
+	}
+	if msg.err != nil {
+		m.errMsg = msg.err.Error()
+		return
+	}
+	m.sessionPage = msg.page
+	m.sessionCursor = 0
+	m.renderTabContents()
+	m.viewports[tabSessions].GotoTop()
+}
+
+func (m *Model) handleReportLoaded(msg reportLoadedMsg) {
+	if msg.seq != m.loadSeq {
+		return
+	}
+	m.loading = false
+	m.loaded = true
+	if msg.err != nil {
+		m.errMsg = msg.err.Error()
+		m.charErrMsg = ""
+		for i := range m.viewports {
+			m.viewports[i].SetContent(i18n.T("stats.load_failed"))
+		}
+		m.updateLayout()
+		return
+	}
+	m.errMsg = ""
+	m.revision = msg.revision
+	m.report = msg.report
+	m.charSelection = msg.charSelection
+	m.charPerSession = msg.charPerSession
+	m.sessionPage = msg.page
+	m.clampSessionCursor()
+	m.charErrMsg = ""
+	if msg.charErr != nil {
+		m.charErrMsg = msg.charErr.Error()
+	}
+	m.updateLayout()
+	m.applyReport()
+}
+
+func loadCharPerSession(ctx context.Context, st *store.Store, report stats.Report, selection []string, foldCase bool) (map[int64]map[string]model.CharAggregate, error) {
+	if len(report.Sessions) == 0 || len(selection) == 0 || report.Daily {
+		return nil, nil
+	}
+	chars := selection
+	if foldCase {
+		chars = stats.CaseVariants(chars)
+	}
+	perSession, err := st.ListCharStatsForSessions(ctx, sessionIDs(report.Sessions), chars)
+	if err != nil {
+		return nil, err
+	}
+	if foldCase {
+		perSession = stats.FoldCasePerSession(perSession)
+	}
+	return perSession, nil
+}
+
+// loadSessionPage loads page index of the sessions matching cfg, clamping it to the last page.
+// Ignored sessions are listed so they can be counted again. The rows come from the sessions
+// table even when the report is daily, so each one keeps its session ID.
+func loadSessionPage(ctx context.Context, st *store.Store, cfg model.StatsConfig, index int) (sessionPage, error) {
+	cfg.IncludeIgnored = true
+	total, err := st.CountSessions(ctx, cfg)
+	if err != nil {
+		return sessionPage{}, err
+	}
+	if cfg.Last > 0 && total > cfg.Last {
+		total = cfg.Last
+	}
+	if pages := sessionPageCount(total); index >= pages {
+		index = pages - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	offset := index * sessionPageSize
+	limit := minInt(sessionPageSize, total-offset)
+	sessions, err := st.ListSessionsPage(ctx, cfg, offset, limit)
+	if err != nil {
+		return sessionPage{}, err
+	}
+	return sessionPage{index: index, total: total, sessions: sessions}, nil
+}
+
+func sessionPageCount(total int) int {
+	return maxInt(1, (total+sessionPageSize-1)/sessionPageSize)
+}
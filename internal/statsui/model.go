@@ -3,7 +3,6 @@ package statsui
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -12,54 +11,95 @@ import (
 	"unicode"
 
 	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/verte-zerg/tuipe/internal/model"
-	"github.com/verte-zerg/tuipe/internal/stats"
-	"github.com/verte-zerg/tuipe/internal/store"
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	uitheme "github.com/verte-zerg/tuipe/internal/theme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
 )
 
 const (
 	tabOverview = iota
 	tabCharTable
 	tabCharCurves
+	tabSessions
+	tabReplay
+	// tabRankings exists only after SetRankings.
+	tabRankings
 )
 
 const (
-	plotHeight = 10
+	defaultPlotHeight = 10
 )
 
+// Layout of the stats UI styles; SetTheme adds the theme colors.
 var (
 	activeNavStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#F0F0F0")).
 			Bold(true).
 			Padding(0, 1).
-			Border(lipgloss.RoundedBorder(), true).
-			BorderForeground(lipgloss.Color("#C89A3A"))
+			Border(lipgloss.RoundedBorder(), true)
 	inactiveNavStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#B0B0B0")).
 				Padding(0, 1).
-				Border(lipgloss.RoundedBorder(), true).
-				BorderForeground(lipgloss.Color("#4A4A4A"))
-	headerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#6E6E6E"))
-	errorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF4D4F"))
+				Border(lipgloss.RoundedBorder(), true)
+	headerStyle = lipgloss.NewStyle()
+	errorStyle  = lipgloss.NewStyle()
 	cardStyle   = lipgloss.NewStyle().
 			Padding(0, 1).
-			Border(lipgloss.RoundedBorder(), true).
-			BorderForeground(lipgloss.Color("#4A4A4A"))
-	cardTitleStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#8C8C8C"))
-	cardValueStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F0F0F0")).Bold(true)
-	tableMutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#B8B8B8"))
+			Border(lipgloss.RoundedBorder(), true)
+	cardTitleStyle  = lipgloss.NewStyle()
+	cardValueStyle  = lipgloss.NewStyle().Bold(true)
+	tableMutedStyle = lipgloss.NewStyle()
 	modalStyle      = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder(), true).
-			BorderForeground(lipgloss.Color("#C89A3A")).
 			Padding(1, 2)
+	replayTypedStyle  = lipgloss.NewStyle()
+	replayCursorStyle = lipgloss.NewStyle().Underline(true)
+)
+
+// Colors used outside the package-level styles; SetTheme updates them too.
+var (
+	borderColor lipgloss.TerminalColor
+	headerColor lipgloss.TerminalColor
+	textColor   lipgloss.TerminalColor
 )
 
+func init() {
+	SetTheme(model.Theme{})
+}
+
+// SetTheme replaces the stats UI colors. Call it before starting the program.
+// Under NO_COLOR the active tab is underlined and the inactive ones faint.
+func SetTheme(theme model.Theme) {
+	colors := uitheme.Resolve(theme)
+	borderColor = colors.Border
+	headerColor = colors.Subtle
+	textColor = colors.Text
+	activeNavStyle = activeNavStyle.
+		Foreground(colors.Text).
+		Underline(colors.Mono).
+		BorderForeground(colors.Accent)
+	inactiveNavStyle = inactiveNavStyle.
+		Foreground(colors.Subtle).
+		Faint(colors.Mono).
+		BorderForeground(colors.Border)
+	headerStyle = headerStyle.Foreground(colors.Muted)
+	errorStyle = errorStyle.Foreground(colors.Error).Bold(colors.MarkErrors)
+	cardStyle = cardStyle.BorderForeground(colors.Border)
+	cardTitleStyle = cardTitleStyle.Foreground(colors.Pending)
+	cardValueStyle = cardValueStyle.Foreground(colors.Text)
+	tableMutedStyle = tableMutedStyle.Foreground(colors.Subtle)
+	modalStyle = modalStyle.BorderForeground(colors.Accent)
+	replayTypedStyle = replayTypedStyle.Foreground(colors.Text)
+	replayCursorStyle = replayCursorStyle.Foreground(colors.Pending)
+}
+
 // Model implements the Bubble Tea stats UI.
 type Model struct {
 	store *store.Store
@@ -69,6 +109,16 @@ type Model struct {
 	errMsg     string
 	charErrMsg string
 
+	spinner spinner.Model
+	loading bool
+	loaded  bool
+	loadSeq int
+
+	// revision is the store revision the report was built from; changed is signalled by
+	// the store's OnChange hook.
+	revision store.Revision
+	changed  chan struct{}
+
 	tabs       []string
 	activeTab  int
 	viewports  []viewport.Model
@@ -87,9 +137,24 @@ type Model struct {
 	charSelectionCustom bool
 	charPerSession      map[int64]map[string]model.CharAggregate
 
+	// charGroup is one of stats.CharGroups to sum the char table by, or empty.
+	charGroup    string
+	charGroupErr string
+
 	charInputMode  bool
 	charInput      textinput.Model
 	charInputError string
+
+	sessionPage sessionPage
+	// sessionCursor is the selected row of the Sessions tab; sessionErr is the last
+	// failure to mark it ignored.
+	sessionCursor int
+	sessionErr    error
+
+	// rankings backs the Rankings tab; nil without a leaderboard.
+	rankings *rankings
+
+	replay replayView
 }
 
 type tableLayout struct {
@@ -104,8 +169,16 @@ func NewModel(st *store.Store, cfg model.StatsConfig) *Model {
 	m := &Model{
 		store: st,
 		cfg:   cfg,
-		tabs:  []string{"Overview", "Char Table", "Char Curves"},
+		tabs:  []string{i18n.T("stats.tab.overview"), i18n.T("stats.tab.char_table"), i18n.T("stats.tab.char_curves"), i18n.T("stats.tab.sessions"), i18n.T("stats.tab.replay")},
 	}
+	m.spinner = spinner.New(spinner.WithSpinner(spinner.Dot))
+	m.changed = make(chan struct{}, 1)
+	st.OnChange(func() {
+		select {
+		case m.changed <- struct{}{}:
+		default:
+		}
+	})
 	m.charSelection = parseChars(cfg.Chars)
 	if len(m.charSelection) > 0 {
 		m.charSelectionCustom = true
@@ -114,13 +187,12 @@ func NewModel(st *store.Store, cfg model.StatsConfig) *Model {
 	m.initCharInput()
 	m.initCharTable()
 	m.initViewports()
-	m.refreshReport()
 	return m
 }
 
 // Init implements tea.Model.
 func (m *Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(m.reloadNow(), watchStore(m.changed), refreshTick(m.cfg.Refresh), m.loadRankings())
 }
 
 // Update implements tea.Model.
@@ -132,6 +204,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateLayout()
 		m.renderTabContents()
 		return m, nil
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case reloadMsg:
+		if msg.seq != m.loadSeq {
+			return m, nil
+		}
+		return m, m.loadReport(msg.seq)
+	case reportLoadedMsg:
+		m.handleReportLoaded(msg)
+		return m, nil
+	case sessionPageLoadedMsg:
+		m.handleSessionPageLoaded(msg)
+		return m, nil
+	case sessionIgnoredMsg:
+		m.handleSessionIgnored(msg)
+		return m, nil
+	case rankingsLoadedMsg:
+		m.handleRankingsLoaded(msg)
+		return m, nil
+	case replayListLoadedMsg:
+		return m, m.handleReplayListLoaded(msg)
+	case replayLoadedMsg:
+		m.handleReplayLoaded(msg)
+		return m, nil
+	case replayTickMsg:
+		return m, m.handleReplayTick(msg)
+	case storeChangedMsg:
+		return m, tea.Batch(m.scheduleReload(), watchStore(m.changed))
+	case refreshTickMsg:
+		return m, tea.Batch(m.checkRevision(), refreshTick(m.cfg.Refresh))
+	case revisionMsg:
+		return m, m.handleRevision(msg)
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC || msg.String() == "q" {
 			return m, tea.Quit
@@ -147,25 +256,67 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.charInputMode {
 			return m.updateCharInput(msg)
 		}
+		if m.activeTab == tabReplay {
+			if cmd, ok := m.updateReplay(msg); ok {
+				return m, cmd
+			}
+		}
+		if m.activeTab == tabSessions {
+			if cmd, ok := m.updateSessions(msg); ok {
+				return m, cmd
+			}
+		}
 		switch msg.String() {
 		case "left", "h":
 			m.moveTab(-1)
-			return m, tea.ClearScreen
+			return m, tea.Batch(tea.ClearScreen, m.openReplay())
 		case "right", "l":
 			m.moveTab(1)
-			return m, tea.ClearScreen
+			return m, tea.Batch(tea.ClearScreen, m.openReplay())
 		case "=":
 			m.cfg.CurveWindow = nextCurveWindow(m.cfg.CurveWindow)
-			m.refreshReport()
-			m.updateLayout()
-			return m, nil
+			return m, m.scheduleReload()
 		case "-":
 			m.cfg.CurveWindow = prevCurveWindow(m.cfg.CurveWindow)
-			m.refreshReport()
-			m.updateLayout()
-			return m, nil
+			return m, m.scheduleReload()
+		case "o":
+			m.cfg.ExcludeOutliers = !m.cfg.ExcludeOutliers
+			return m, m.scheduleReload()
+		case "c":
+			m.cfg.FoldCase = !m.cfg.FoldCase
+			return m, m.scheduleReload()
+		case "i":
+			m.cfg.IncludeIncomplete = !m.cfg.IncludeIncomplete
+			return m, m.scheduleReload()
+		case "r":
+			if m.activeTab == tabRankings {
+				return m, m.loadRankings()
+			}
+			return m, m.reloadNow()
 		case "/":
 			return m.startFilter()
+		case "[":
+			switch m.activeTab {
+			case tabSessions:
+				return m, m.moveSessionPage(-1)
+			case tabRankings:
+				return m, m.moveRankingsDay(-1)
+			}
+			return m, nil
+		case "]":
+			switch m.activeTab {
+			case tabSessions:
+				return m, m.moveSessionPage(1)
+			case tabRankings:
+				return m, m.moveRankingsDay(1)
+			}
+			return m, nil
+		case "a":
+			if m.activeTab == tabCharTable {
+				m.charGroup = nextCharGroup(m.charGroup)
+				m.applyReport()
+			}
+			return m, nil
 		case "enter":
 			if m.activeTab == tabCharCurves {
 				return m.startCharInput()
@@ -220,15 +371,16 @@ func (m *Model) initViewports() {
 	m.viewports = make([]viewport.Model, len(m.tabs))
 	for i := range m.viewports {
 		m.viewports[i] = viewport.New(0, 0)
+		m.viewports[i].SetContent(i18n.T("stats.loading_stats"))
 	}
 }
 
 func (m *Model) initInputs() {
 	m.filterInputs = []textinput.Model{
-		newFilterInput("Lang: "),
-		newFilterInput("Since (YYYY-MM-DD): "),
-		newFilterInput("Last: "),
-		newFilterInput("Curve window: "),
+		newFilterInput(i18n.T("stats.filter.lang")),
+		newFilterInput(i18n.T("stats.filter.since")),
+		newFilterInput(i18n.T("stats.filter.last")),
+		newFilterInput(i18n.T("stats.filter.window")),
 	}
 	m.setInputsFromConfig()
 }
@@ -255,8 +407,8 @@ func (m *Model) layoutHeights() (headerHeight, bodyHeight, footerHeight int) {
 }
 
 func (m *Model) initCharInput() {
-	m.charInput = newFilterInput("Chars: ")
-	m.charInput.Prompt = "Chars: "
+	m.charInput = newFilterInput(i18n.T("stats.chars_prompt"))
+	m.charInput.Prompt = i18n.T("stats.chars_prompt")
 	m.charInput.Placeholder = "asdfjkl;"
 }
 
@@ -355,21 +507,65 @@ func (m *Model) renderFilterSummary() string {
 	if m.cfg.Last > 0 {
 		last = strconv.Itoa(m.cfg.Last)
 	}
-	summary := fmt.Sprintf("Settings: lang=%s  since=%s  last=%s  window=%d", lang, since, last, m.cfg.CurveWindow)
+	outliers := "kept"
+	if m.cfg.ExcludeOutliers {
+		outliers = fmt.Sprintf("excluded (%d)", len(m.report.Outliers))
+	}
+	charCase := "split"
+	if m.cfg.FoldCase {
+		charCase = "folded"
+	}
+	summary := fmt.Sprintf("Settings: lang=%s  since=%s  last=%s  window=%d  outliers=%s  case=%s", lang, since, last, m.cfg.CurveWindow, outliers, charCase)
+	if m.cfg.Mode != "" {
+		summary += "  mode=" + m.cfg.Mode
+	}
+	if m.cfg.FocusWeak != nil {
+		summary += fmt.Sprintf("  focus-weak=%t", *m.cfg.FocusWeak)
+	}
+	if m.cfg.AppVersion != "" {
+		summary += "  version=" + m.cfg.AppVersion
+	}
+	if m.cfg.Keyboard != "" {
+		summary += "  keyboard=" + m.cfg.Keyboard
+	}
+	if m.cfg.Layout != "" {
+		summary += "  layout=" + m.cfg.Layout
+	}
+	if m.cfg.WordList != "" {
+		summary += "  wordlist=" + m.cfg.WordList
+	}
+	if m.cfg.IncludeIncomplete {
+		summary += "  incomplete=included"
+	}
+	if m.report.Daily {
+		summary += "  (daily)"
+	}
+	if m.loading {
+		summary = m.spinner.View() + " " + i18n.T("stats.loading") + "  " + summary
+	}
 	summary = truncateLine(summary, m.width)
 	return headerStyle.Render(summary)
 }
 
 func (m *Model) renderHelp() string {
-	help := "Nav: left/right  Scroll: up/down/pgup/pgdn  Window: -/=  Settings: /  Quit: q"
-	if m.activeTab == tabCharCurves {
-		help = "Nav: left/right  Scroll: up/down/pgup/pgdn  Edit chars: enter  Window: -/=  Settings: /  Quit: q"
+	help := i18n.T("stats.help")
+	switch m.activeTab {
+	case tabCharTable:
+		help = i18n.T("stats.help.char_table")
+	case tabCharCurves:
+		help = i18n.T("stats.help.char_curves")
+	case tabSessions:
+		help = i18n.T("stats.help.sessions")
+	case tabReplay:
+		help = i18n.T("stats.help.replay")
+	case tabRankings:
+		help = i18n.T("stats.help.rankings")
 	}
 	return headerStyle.Render(help)
 }
 
 func (m *Model) renderFilterHelp() string {
-	return headerStyle.Render("tab/shift+tab: next field  enter: apply  esc: cancel  quit: q")
+	return headerStyle.Render(i18n.T("stats.help.filter"))
 }
 
 func (m *Model) renderFooter() string {
@@ -383,7 +579,7 @@ func (m *Model) renderFooter() string {
 }
 
 func (m *Model) renderFilterForm() string {
-	lines := []string{"Settings (enter to apply, esc to cancel)"}
+	lines := []string{i18n.T("stats.settings")}
 	for _, input := range m.filterInputs {
 		lines = append(lines, input.View())
 	}
@@ -397,12 +593,19 @@ func (m *Model) renderBody(height int) string {
 	if m.filterMode {
 		return fitLines(m.renderFilterForm(), m.width, height)
 	}
+	if m.activeTab == tabReplay {
+		return fitLines(m.renderReplay(m.width), m.width, height)
+	}
 	if m.activeTab == tabCharTable {
 		switch {
+		case !m.loaded:
+			return fitLines(i18n.T("stats.loading_stats"), m.width, height)
 		case len(m.report.Sessions) == 0:
-			return fitLines("No sessions found.", m.width, height)
+			return fitLines(i18n.T("stats.no_sessions"), m.width, height)
 		case len(m.report.CharAggsAll) == 0:
-			return fitLines("No character stats found.", m.width, height)
+			return fitLines(i18n.T("stats.no_char_stats"), m.width, height)
+		case m.charGroupErr != "":
+			return fitLines(errorStyle.Render(m.charGroupErr), m.width, height)
 		default:
 			view := tableMutedStyle.Render(m.charTable.View())
 			return fitLines(view, m.width, height)
@@ -411,38 +614,37 @@ func (m *Model) renderBody(height int) string {
 	return fitLines(m.viewports[m.activeTab].View(), m.width, height)
 }
 
-func (m *Model) refreshReport() {
-	report, err := stats.BuildReport(context.Background(), m.store, m.cfg)
-	if err != nil {
-		m.errMsg = err.Error()
-		m.charErrMsg = ""
-		for i := range m.viewports {
-			m.viewports[i].SetContent("Failed to load stats.")
-		}
-		return
-	}
-	m.errMsg = ""
-	m.report = report
-	if !m.charSelectionCustom {
-		m.charSelection = stats.TopCharsByFrequency(m.report.CharAggsAll, 5)
-	}
-	m.loadCharPerSession()
+func (m *Model) applyReport() {
 	width := m.width
 	if width <= 0 {
 		width = 80
 	}
 	_, bodyHeight, _ := m.layoutHeights()
-	applyCharTable(m, m.report.Sessions, m.report.CharAggsAll, width, bodyHeight, true)
+	tableAggs := m.report.CharAggsAll
+	if m.report.HasShift {
+		tableAggs = append(append([]model.CharAggregate(nil), tableAggs...), m.report.ShiftAll)
+	}
+	charTitle := "Char"
+	m.charGroupErr = ""
+	if m.charGroup != "" {
+		var err error
+		charTitle = m.charGroupTitle()
+		tableAggs, err = stats.GroupCharAggregates(m.report.CharAggsAll, m.charGroup, m.charGroupLayout())
+		if err != nil {
+			m.charGroupErr = i18n.T("stats.err.char_group", err)
+		}
+	}
+	applyCharTable(m, m.report.Sessions, tableAggs, charTitle, width, bodyHeight, true)
 	m.renderTabContents()
 }
 
 func (m *Model) renderTabContents() {
-	if len(m.viewports) == 0 {
+	if len(m.viewports) == 0 || !m.loaded {
 		return
 	}
 	if m.errMsg != "" {
-		for i := range m.viewports {
-			m.viewports[i].SetContent("Failed to load stats.")
+		for i := range m.viewports[:tabRankings] {
+			m.viewports[i].SetContent(i18n.T("stats.load_failed"))
 		}
 		return
 	}
@@ -450,59 +652,107 @@ func (m *Model) renderTabContents() {
 	if width <= 0 {
 		width = 80
 	}
-	m.viewports[tabOverview].SetContent(renderOverview(m.report.Sessions, m.cfg.CurveWindow, width))
-	m.viewports[tabCharCurves].SetContent(renderCharCurves(m.report.Sessions, m.charSelection, m.charPerSession, m.cfg.CurveWindow, width, m.charErrMsg))
+	m.viewports[tabOverview].SetContent(renderOverview(m.report.Sessions, m.report.SessionCount, m.cfg.WPMFormula, m.cfg.CurveWindow, width, m.plotHeight()))
+	m.viewports[tabSessions].SetContent(renderSessions(m.sessionPage, m.sessionCursor, m.sessionErr, m.report.Outliers, m.cfg.WPMFormula))
+	if m.report.Daily {
+		m.viewports[tabCharCurves].SetContent(i18n.T("stats.daily_note"))
+		return
+	}
+	m.viewports[tabCharCurves].SetContent(renderCharCurves(m.report.Sessions, m.curveChars(), m.charPerSession, m.cfg.CurveWindow, width, m.plotHeight(), m.charErrMsg))
 }
 
-func renderOverview(sessions []model.SessionAggregate, window, width int) string {
+func renderOverview(sessions []model.SessionAggregate, sessionCount int, formula string, window, width, height int) string {
 	if len(sessions) == 0 {
-		return "No sessions found."
+		return i18n.T("stats.no_sessions")
+	}
+	summary := renderSummaryCards(sessions, sessionCount, formula, width)
+	if rules, versions := stats.MixedScoring(sessions); len(rules) > 0 {
+		warning := i18n.T("stats.scoring_mixed", len(rules), strings.Join(rules, "; "))
+		if len(versions) > 1 {
+			warning += " " + i18n.T("stats.scoring_versions", strings.Join(versions, ", "))
+		}
+		summary = errorStyle.Width(width).Render(warning) + "\n\n" + summary
 	}
-	summary := renderSummaryCards(sessions, width)
-	curves := renderCurves(sessions, window, width)
+	curves := renderCurves(sessions, formula, window, width, height)
 	return strings.TrimRight(summary+"\n\n"+curves, "\n")
 }
 
-func renderSummaryCards(sessions []model.SessionAggregate, width int) string {
+func renderSummaryCards(sessions []model.SessionAggregate, sessionCount int, formula string, width int) string {
 	if len(sessions) == 0 {
-		return "No sessions found."
+		return i18n.T("stats.no_sessions")
 	}
-	var totalWPM, totalCPM, totalAcc float64
-	bestWPM := 0.0
+	companion := stats.CompanionFormula(formula)
+	var totalSpeed, totalCompanion, totalAcc float64
+	bestSpeed := 0.0
 	for _, s := range sessions {
-		wpm, cpm, acc := stats.SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
-		totalWPM += wpm
-		totalCPM += cpm
+		_, _, acc := stats.SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		speed := stats.SessionSpeed(formula, s)
+		totalSpeed += speed
+		totalCompanion += stats.SessionSpeed(companion, s)
 		totalAcc += acc
-		if wpm > bestWPM {
-			bestWPM = wpm
+		if speed > bestSpeed {
+			bestSpeed = speed
 		}
 	}
 	count := float64(len(sessions))
+	firstKeyMs, spaceMs := stats.ReactionMetrics(sessions)
 	cards := []string{
-		metricCard("Sessions", fmt.Sprintf("%d", len(sessions))),
-		metricCard("Avg WPM", fmt.Sprintf("%.1f", totalWPM/count)),
-		metricCard("Best WPM", fmt.Sprintf("%.1f", bestWPM)),
-		metricCard("Avg CPM", fmt.Sprintf("%.1f", totalCPM/count)),
-		metricCard("Avg Acc", fmt.Sprintf("%.1f%%", (totalAcc/count)*100)),
+		metricCard(i18n.T("stats.card.sessions"), fmt.Sprintf("%d", sessionCount)),
+		metricCard(i18n.T("stats.card.avg_speed", stats.SpeedUnit(formula)), fmt.Sprintf("%.1f", totalSpeed/count)),
+		metricCard(i18n.T("stats.card.best_speed", stats.SpeedUnit(formula)), fmt.Sprintf("%.1f", bestSpeed)),
+		metricCard(i18n.T("stats.card.avg_speed", stats.SpeedUnit(companion)), fmt.Sprintf("%.1f", totalCompanion/count)),
+		metricCard(i18n.T("stats.card.avg_acc"), fmt.Sprintf("%.1f%%", (totalAcc/count)*100)),
+		metricCard(i18n.T("stats.card.first_key"), fmt.Sprintf("%.0f ms", firstKeyMs)),
+		metricCard(i18n.T("stats.card.space"), fmt.Sprintf("%.0f ms", spaceMs)),
 	}
 	if width < 80 {
 		return strings.Join(cards, "\n")
 	}
 	row1 := lipgloss.JoinHorizontal(lipgloss.Top, cards[0], cards[1], cards[2])
-	row2 := lipgloss.JoinHorizontal(lipgloss.Top, cards[3], cards[4])
+	row2 := lipgloss.JoinHorizontal(lipgloss.Top, cards[3], cards[4], cards[5], cards[6])
 	return lipgloss.JoinVertical(lipgloss.Left, row1, row2)
 }
 
+// renderSessions lists a page of sessions with the row at cursor marked.
+func renderSessions(page sessionPage, cursor int, ignoreErr error, outliers map[int64]struct{}, formula string) string {
+	// RenderSessionList expects oldest first; pages are loaded newest first.
+	sessions := make([]model.SessionAggregate, len(page.sessions))
+	for i, s := range page.sessions {
+		sessions[len(sessions)-1-i] = s
+	}
+	var buf bytes.Buffer
+	if page.total > sessionPageSize {
+		first := page.index*sessionPageSize + 1
+		fmt.Fprintf(&buf, "%s\n\n", i18n.T("stats.page", page.index+1, sessionPageCount(page.total),
+			first, first+len(sessions)-1, page.total))
+	}
+	var list bytes.Buffer
+	if err := stats.RenderSessionListWithFormula(&list, sessions, outliers, formula); err != nil {
+		return i18n.T("stats.err.sessions", err)
+	}
+	rows := strings.Split(strings.TrimRight(list.String(), "\n"), "\n")
+	for i, row := range rows {
+		marker := "  "
+		if len(sessions) > 0 && i == cursor+1 {
+			marker = "> "
+		}
+		buf.WriteString(marker + row + "\n")
+	}
+	if ignoreErr != nil {
+		fmt.Fprintf(&buf, "\n%s\n", errorStyle.Render(i18n.T("stats.err.ignore", ignoreErr)))
+	}
+	return strings.TrimRight(buf.String(), "\n")
+}
+
 func metricCard(label, value string) string {
 	content := fmt.Sprintf("%s\n%s", cardTitleStyle.Render(label), cardValueStyle.Render(value))
 	return cardStyle.Render(content)
 }
 
-func renderCurves(sessions []model.SessionAggregate, window, width int) string {
+func renderCurves(sessions []model.SessionAggregate, formula string, window, width, height int) string {
 	var buf bytes.Buffer
-	if err := stats.RenderCurvesWithSize(&buf, sessions, window, width, plotHeight, true); err != nil {
-		return fmt.Sprintf("Failed to render curves: %v", err)
+	if err := stats.RenderCurvesWithSizeAndFormula(&buf, sessions, formula, window, width, height, true); err != nil {
+		return i18n.T("stats.err.curves", err)
 	}
 	return strings.TrimRight(buf.String(), "\n")
 }
@@ -554,8 +804,8 @@ func buildCharTable(sessions []model.SessionAggregate, aggs []model.CharAggregat
 	return t
 }
 
-func applyCharTable(m *Model, sessions []model.SessionAggregate, aggs []model.CharAggregate, width, height int, force bool) {
-	cols, rows := buildCharTableData(sessions, aggs)
+func applyCharTable(m *Model, sessions []model.SessionAggregate, aggs []model.CharAggregate, charTitle string, width, height int, force bool) {
+	cols, rows := buildCharTableData(sessions, aggs, charTitle)
 	viewportHeight := maxInt(1, height-1)
 	if !force &&
 		m.charLayout.width == width &&
@@ -591,8 +841,8 @@ func charTableStyles() table.Styles {
 	styles := table.DefaultStyles()
 	styles.Header = styles.Header.
 		Border(lipgloss.NormalBorder(), false, false, true, false).
-		BorderForeground(lipgloss.Color("#4A4A4A")).
-		Foreground(lipgloss.Color("#C0C0C0")).
+		BorderForeground(borderColor).
+		Foreground(headerColor).
 		Bold(true).
 		Padding(0, 1).
 		PaddingLeft(0)
@@ -600,7 +850,7 @@ func charTableStyles() table.Styles {
 		Padding(0, 1).
 		PaddingLeft(0)
 	styles.Selected = styles.Cell.
-		Foreground(lipgloss.Color("#F0F0F0")).
+		Foreground(textColor).
 		Bold(true)
 	return styles
 }
@@ -628,9 +878,15 @@ func (m *Model) adjustCharTableHeight(bodyHeight int) int {
 	return height
 }
 
-func buildCharTableData(sessions []model.SessionAggregate, aggs []model.CharAggregate) ([]table.Column, []table.Row) {
+// buildCharTableData lays out the char table; charTitle heads the first column, which
+// widens to fit it and the group labels.
+func buildCharTableData(sessions []model.SessionAggregate, aggs []model.CharAggregate, charTitle string) ([]table.Column, []table.Row) {
+	charWidth := max(4, lipgloss.Width(charTitle))
+	for _, agg := range aggs {
+		charWidth = max(charWidth, lipgloss.Width(charTableLabel(agg.Char)))
+	}
 	columns := []table.Column{
-		{Title: "Char", Width: 4},
+		{Title: charTitle, Width: charWidth},
 		{Title: "Accuracy", Width: 9},
 		{Title: "Avg Latency (ms)", Width: 17},
 		{Title: "Correct", Width: 7},
@@ -652,12 +908,8 @@ func buildCharTableData(sessions []model.SessionAggregate, aggs []model.CharAggr
 		if agg.LatencyCount > 0 {
 			lat = float64(agg.LatencySumMs) / float64(agg.LatencyCount)
 		}
-		charLabel := agg.Char
-		if charLabel == " " {
-			charLabel = "<space>"
-		}
 		rows = append(rows, table.Row{
-			charLabel,
+			charTableLabel(agg.Char),
 			fmt.Sprintf("%.2f%%", acc),
 			fmt.Sprintf("%.1f", lat),
 			fmt.Sprintf("%d", agg.Correct),
@@ -668,20 +920,27 @@ func buildCharTableData(sessions []model.SessionAggregate, aggs []model.CharAggr
 	return columns, rows
 }
 
-func renderCharCurves(sessions []model.SessionAggregate, chars []string, perSession map[int64]map[string]model.CharAggregate, window, width int, errMsg string) string {
+func charTableLabel(ch string) string {
+	if ch == " " {
+		return "<space>"
+	}
+	return ch
+}
+
+func renderCharCurves(sessions []model.SessionAggregate, chars []string, perSession map[int64]map[string]model.CharAggregate, window, width, height int, errMsg string) string {
 	if len(sessions) == 0 {
-		return "No sessions found."
+		return i18n.T("stats.no_sessions")
 	}
 	if errMsg != "" {
-		return fmt.Sprintf("Failed to load character curves: %s", errMsg)
+		return i18n.T("stats.err.load_chars", errMsg)
 	}
 	if len(chars) == 0 {
-		return "No characters selected. Press Enter to set chars."
+		return i18n.T("stats.no_chars")
 	}
-	header := headerStyle.Render(fmt.Sprintf("Chars: %s", strings.Join(chars, ", ")))
+	header := headerStyle.Render(i18n.T("stats.chars", strings.Join(chars, ", ")))
 	var buf bytes.Buffer
-	if err := stats.RenderCharCurvesWithSize(&buf, sessions, perSession, chars, window, width, plotHeight, true); err != nil {
-		return fmt.Sprintf("Failed to render character curves: %v", err)
+	if err := stats.RenderCharCurvesWithSize(&buf, sessions, perSession, chars, window, width, height, true); err != nil {
+		return i18n.T("stats.err.char_curves", err)
 	}
 	return strings.TrimRight(header+"\n"+buf.String(), "\n")
 }
@@ -713,9 +972,8 @@ func (m *Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.filterMode = false
 		m.filterError = ""
-		m.refreshReport()
 		m.updateLayout()
-		return m, nil
+		return m, m.scheduleReload()
 	case tea.KeyTab:
 		return m, m.setFilterIndex(m.filterIndex + 1)
 	case tea.KeyShiftTab:
@@ -736,9 +994,7 @@ func (m *Model) updateCharInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.applyCharInput()
 		m.charInputMode = false
 		m.charInputError = ""
-		m.loadCharPerSession()
-		m.renderTabContents()
-		return m, nil
+		return m, m.reloadNow()
 	}
 	var cmd tea.Cmd
 	m.charInput, cmd = m.charInput.Update(msg)
@@ -807,12 +1063,11 @@ func (m *Model) applyFilter() error {
 		window = parsed
 	}
 
-	m.cfg = model.StatsConfig{
-		Lang:        lang,
-		Since:       since,
-		Last:        last,
-		CurveWindow: window,
-	}
+	m.cfg.Lang = lang
+	m.cfg.Since = since
+	m.cfg.Last = last
+	m.cfg.CurveWindow = window
+	m.sessionPage.index = 0
 	return nil
 }
 
@@ -834,12 +1089,12 @@ func (m *Model) applyCharInput() {
 }
 
 func (m *Model) renderCharModal() string {
-	title := cardValueStyle.Render("Select Characters")
+	title := cardValueStyle.Render(i18n.T("stats.select_chars"))
 	body := []string{
 		title,
 		m.charInput.View(),
-		headerStyle.Render("Type characters (no commas). Spaces are ignored."),
-		headerStyle.Render("Enter to apply / Esc to cancel"),
+		headerStyle.Render(i18n.T("stats.select_hint")),
+		headerStyle.Render(i18n.T("stats.select_keys")),
 	}
 	if m.charInputError != "" {
 		body = append(body, errorStyle.Render(m.charInputError))
@@ -848,18 +1103,21 @@ func (m *Model) renderCharModal() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
 }
 
-func (m *Model) loadCharPerSession() {
-	m.charErrMsg = ""
-	m.charPerSession = nil
-	if len(m.report.Sessions) == 0 || len(m.charSelection) == 0 {
-		return
+func (m *Model) curveChars() []string {
+	if !m.cfg.FoldCase {
+		return m.charSelection
 	}
-	perSession, err := m.store.ListCharStatsForSessions(context.Background(), sessionIDs(m.report.Sessions), m.charSelection)
-	if err != nil {
-		m.charErrMsg = err.Error()
-		return
+	out := make([]string, 0, len(m.charSelection))
+	seen := map[string]struct{}{}
+	for _, ch := range m.charSelection {
+		ch = strings.ToLower(ch)
+		if _, ok := seen[ch]; ok {
+			continue
+		}
+		seen[ch] = struct{}{}
+		out = append(out, ch)
 	}
-	m.charPerSession = perSession
+	return out
 }
 
 func maxInt(a, b int) int {
@@ -1029,3 +1287,10 @@ func truncateLine(s string, width int) string {
 	}
 	return string(runes[:width-3]) + "..."
 }
+
+func (m *Model) plotHeight() int {
+	if m.cfg.PlotHeight > 0 {
+		return m.cfg.PlotHeight
+	}
+	return defaultPlotHeight
+}
@@ -0,0 +1,125 @@
+package statsui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/internal/leaderboard"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+)
+
+// rankingsNameWidth is the width of the name column on the Rankings tab.
+const rankingsNameWidth = leaderboard.MaxNameLen
+
+// RankingsFunc loads the leaderboard of the daily challenge of day (YYYY-MM-DD).
+type RankingsFunc func(ctx context.Context, day string) ([]leaderboard.Entry, error)
+
+// rankings is the state of the Rankings tab.
+type rankings struct {
+	load RankingsFunc
+	// name is the player's own name, marked in the list.
+	name string
+	// daysBack is how many days before today's challenge is shown.
+	daysBack int
+	seq      int
+	loading  bool
+	entries  []leaderboard.Entry
+	err      error
+}
+
+// rankingsLoadedMsg carries the leaderboard loaded for a Rankings tab request.
+type rankingsLoadedMsg struct {
+	seq     int
+	entries []leaderboard.Entry
+	err     error
+}
+
+// SetRankings adds a Rankings tab showing the daily challenge leaderboard from load.
+// name is the player's own name on the board.
+func (m *Model) SetRankings(load RankingsFunc, name string) {
+	m.rankings = &rankings{load: load, name: name}
+	m.tabs = append(m.tabs, i18n.T("stats.tab.rankings"))
+	m.initViewports()
+	m.updateLayout()
+}
+
+// rankingsDay is the challenge day shown on the Rankings tab.
+func (m *Model) rankingsDay() string {
+	return generator.ChallengeDay(time.Now().AddDate(0, 0, -m.rankings.daysBack))
+}
+
+// loadRankings fetches the leaderboard of the shown day in the background.
+func (m *Model) loadRankings() tea.Cmd {
+	if m.rankings == nil {
+		return nil
+	}
+	m.rankings.seq++
+	m.rankings.loading = true
+	m.renderRankings()
+	seq, load, day := m.rankings.seq, m.rankings.load, m.rankingsDay()
+	return func() tea.Msg {
+		entries, err := load(context.Background(), day)
+		return rankingsLoadedMsg{seq: seq, entries: entries, err: err}
+	}
+}
+
+// moveRankingsDay shows the challenge delta days later; today's is the latest.
+func (m *Model) moveRankingsDay(delta int) tea.Cmd {
+	back := m.rankings.daysBack - delta
+	if back < 0 {
+		return nil
+	}
+	m.rankings.daysBack = back
+	return m.loadRankings()
+}
+
+func (m *Model) handleRankingsLoaded(msg rankingsLoadedMsg) {
+	if m.rankings == nil || msg.seq != m.rankings.seq {
+		return
+	}
+	m.rankings.loading = false
+	m.rankings.entries = msg.entries
+	m.rankings.err = msg.err
+	m.renderRankings()
+}
+
+func (m *Model) renderRankings() {
+	if m.rankings == nil || len(m.viewports) <= tabRankings {
+		return
+	}
+	m.viewports[tabRankings].SetContent(renderRankings(m.rankings, m.rankingsDay()))
+}
+
+// renderRankings lists the results of day, one ranking per settings hash since only
+// runs with the same settings typed the same text.
+func renderRankings(r *rankings, day string) string {
+	lines := []string{i18n.T("stats.rankings.title", day), ""}
+	switch {
+	case r.loading:
+		return strings.Join(append(lines, i18n.T("stats.rankings.loading")), "\n")
+	case r.err != nil:
+		return strings.Join(append(lines, errorStyle.Render(i18n.T("stats.rankings.failed", r.err))), "\n")
+	case len(r.entries) == 0:
+		return strings.Join(append(lines, i18n.T("stats.rankings.empty")), "\n")
+	}
+	header := fmt.Sprintf("  %4s  %s  %6s  %8s", i18n.T("stats.rankings.rank"), padLine(i18n.T("stats.rankings.name"), rankingsNameWidth), i18n.T("stats.rankings.wpm"), i18n.T("stats.rankings.accuracy"))
+	for i, e := range r.entries {
+		if i == 0 || r.entries[i-1].Params != e.Params {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, headerStyle.Render(i18n.T("stats.rankings.settings", e.Lang, e.Mode, e.Words, e.Params)), header)
+		}
+		marker := " "
+		if e.Name == r.name {
+			marker = ">"
+		}
+		lines = append(lines, fmt.Sprintf("%s %4d  %s  %6.1f  %7.1f%%", marker, e.Rank, padLine(e.Name, rankingsNameWidth), e.WPM, e.Accuracy*100))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,332 @@
+package statsui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/replay"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// replayFrameInterval is how often a playing replay advances.
+const replayFrameInterval = 30 * time.Millisecond
+
+// replaySeekStep is how far < and > move the replay.
+const replaySeekStep = 5 * time.Second
+
+// replaySpeeds are the playback speeds s cycles through.
+var replaySpeeds = []float64{1, 2, 4, 0.5}
+
+// replayView is the state of the Replay tab.
+type replayView struct {
+	loaded bool
+	seq    int
+	err    error
+
+	// ids are the sessions with a keystroke log, newest first; index is the shown one.
+	ids   []int64
+	index int
+
+	rec    replay.Recording
+	target []string
+	hasRec bool
+
+	keys    int
+	elapsed time.Duration
+	playing bool
+	ticking bool
+	speed   int
+	last    time.Time
+}
+
+type replayListLoadedMsg struct {
+	seq int
+	ids []int64
+	err error
+}
+
+type replayLoadedMsg struct {
+	seq int
+	rec replay.Recording
+	err error
+}
+
+type replayTickMsg time.Time
+
+// openReplay loads the replayable sessions the first time the Replay tab is shown.
+func (m *Model) openReplay() tea.Cmd {
+	if m.activeTab != tabReplay || m.replay.loaded {
+		return nil
+	}
+	return m.loadReplayList()
+}
+
+func (m *Model) loadReplayList() tea.Cmd {
+	m.replay.seq++
+	m.replay.loaded = true
+	m.replay.err = nil
+	seq, st, cfg := m.replay.seq, m.store, m.cfg
+	return func() tea.Msg {
+		ids, err := st.ListReplayIDs(context.Background(), cfg)
+		return replayListLoadedMsg{seq: seq, ids: ids, err: err}
+	}
+}
+
+func (m *Model) loadReplaySession(index int) tea.Cmd {
+	m.replay.seq++
+	m.replay.index = index
+	m.replay.playing = false
+	seq, st, id := m.replay.seq, m.store, m.replay.ids[index]
+	return func() tea.Msg {
+		session, err := st.GetSession(context.Background(), id)
+		if err != nil {
+			return replayLoadedMsg{seq: seq, err: err}
+		}
+		rec, err := replay.FromSession(session)
+		return replayLoadedMsg{seq: seq, rec: rec, err: err}
+	}
+}
+
+func (m *Model) handleReplayListLoaded(msg replayListLoadedMsg) tea.Cmd {
+	if msg.seq != m.replay.seq {
+		return nil
+	}
+	m.replay.ids = msg.ids
+	m.replay.err = msg.err
+	m.replay.hasRec = false
+	if msg.err != nil || len(msg.ids) == 0 {
+		return nil
+	}
+	return m.loadReplaySession(0)
+}
+
+func (m *Model) handleReplayLoaded(msg replayLoadedMsg) {
+	if msg.seq != m.replay.seq {
+		return
+	}
+	m.replay.err = msg.err
+	m.replay.hasRec = msg.err == nil
+	m.replay.rec = msg.rec
+	m.replay.target = grapheme.Split(msg.rec.Target)
+	m.seekReplay(0)
+}
+
+// updateReplay handles the keys of the Replay tab; ok is false for keys it leaves to
+// the rest of the stats UI.
+func (m *Model) updateReplay(msg tea.KeyMsg) (tea.Cmd, bool) {
+	r := &m.replay
+	switch msg.String() {
+	case "r":
+		return m.loadReplayList(), true
+	case "[", "]":
+		next := r.index + 1
+		if msg.String() == "]" {
+			next = r.index - 1
+		}
+		if next < 0 || next >= len(r.ids) {
+			return nil, true
+		}
+		return m.loadReplaySession(next), true
+	}
+	if !r.hasRec {
+		return nil, false
+	}
+	switch msg.String() {
+	case " ":
+		if r.playing {
+			r.playing = false
+			return nil, true
+		}
+		if r.keys >= len(r.rec.Keys) {
+			m.seekReplay(0)
+		}
+		r.playing = true
+		r.last = time.Now()
+		return m.startReplayTicks(), true
+	case ",":
+		m.stepReplay(-1)
+	case ".":
+		m.stepReplay(1)
+	case "<":
+		m.seekReplay(r.elapsed - replaySeekStep)
+	case ">":
+		m.seekReplay(r.elapsed + replaySeekStep)
+	case "g", "home":
+		m.seekReplay(0)
+	case "G", "end":
+		m.seekReplay(time.Duration(r.rec.DurationMs()) * time.Millisecond)
+	case "s":
+		r.speed = (r.speed + 1) % len(replaySpeeds)
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+func (m *Model) startReplayTicks() tea.Cmd {
+	if m.replay.ticking {
+		return nil
+	}
+	m.replay.ticking = true
+	return replayTick()
+}
+
+func replayTick() tea.Cmd {
+	return tea.Tick(replayFrameInterval, func(t time.Time) tea.Msg { return replayTickMsg(t) })
+}
+
+// handleReplayTick advances a playing replay; playback pauses when the tab is left.
+func (m *Model) handleReplayTick(msg replayTickMsg) tea.Cmd {
+	r := &m.replay
+	now := time.Time(msg)
+	if m.activeTab != tabReplay {
+		r.playing = false
+	}
+	if !r.playing {
+		r.ticking = false
+		return nil
+	}
+	m.seekReplay(r.elapsed + time.Duration(float64(now.Sub(r.last))*replaySpeeds[r.speed]))
+	r.last = now
+	if r.keys >= len(r.rec.Keys) {
+		r.playing = false
+		r.ticking = false
+		return nil
+	}
+	return replayTick()
+}
+
+// seekReplay moves the replay clock to at, clamped to the recording, and applies the
+// keystrokes due by then.
+func (m *Model) seekReplay(at time.Duration) {
+	r := &m.replay
+	end := time.Duration(r.rec.DurationMs()) * time.Millisecond
+	at = max(0, min(at, end))
+	r.elapsed = at
+	r.keys = 0
+	for r.keys < len(r.rec.Keys) && r.rec.Keys[r.keys].AtMs <= at.Milliseconds() {
+		r.keys++
+	}
+}
+
+// stepReplay moves the replay by delta keystrokes.
+func (m *Model) stepReplay(delta int) {
+	r := &m.replay
+	r.playing = false
+	keys := max(0, min(r.keys+delta, len(r.rec.Keys)))
+	r.keys = keys
+	r.elapsed = 0
+	if keys > 0 {
+		r.elapsed = time.Duration(r.rec.Keys[keys-1].AtMs) * time.Millisecond
+	}
+}
+
+// renderReplay draws the Replay tab: the session, a scrub bar, the text as typed so
+// far and the running speed and accuracy.
+func (m *Model) renderReplay(width int) string {
+	r := &m.replay
+	switch {
+	case !r.loaded || (len(r.ids) > 0 && !r.hasRec && r.err == nil):
+		return i18n.T("stats.replay.loading")
+	case r.err != nil:
+		return errorStyle.Render(i18n.T("stats.replay.failed", r.err))
+	case len(r.ids) == 0:
+		return i18n.T("stats.replay.none")
+	}
+	rec := r.rec
+	title := i18n.T("stats.replay.title", r.ids[r.index], rec.CreatedAt.Local().Format("2006-01-02 15:04"), rec.Lang, r.index+1, len(r.ids))
+	total := float64(rec.DurationMs()) / 1000
+	clock := fmt.Sprintf(" %.1fs / %.1fs", r.elapsed.Seconds(), total)
+	bar := scrubBar(r.elapsed.Seconds(), total, max(10, width-lipgloss.Width(clock)))
+
+	var atMs int64
+	if r.keys > 0 {
+		atMs = rec.Keys[r.keys-1].AtMs
+	}
+	correct, incorrect := rec.CountsAt(r.keys)
+	wpm, _, acc := stats.SessionMetrics(correct, incorrect, atMs)
+	state := i18n.T("stats.replay.paused")
+	switch {
+	case r.playing:
+		state = i18n.T("stats.replay.playing")
+	case r.keys >= len(rec.Keys):
+		state = i18n.T("stats.replay.done")
+	}
+	status := i18n.T("stats.replay.status", wpm, acc*100, replaySpeeds[r.speed]) + "  " + state
+
+	lines := []string{headerStyle.Render(title), bar + headerStyle.Render(clock), ""}
+	lines = append(lines, replayLines(r.target, rec.InputAt(r.keys), width)...)
+	lines = append(lines, "", headerStyle.Render(status))
+	return strings.Join(lines, "\n")
+}
+
+// scrubBar draws the position at of total seconds on a bar width cells wide.
+func scrubBar(at, total float64, width int) string {
+	inner := width - 2
+	pos := inner
+	if total > 0 {
+		pos = int(at / total * float64(inner))
+	}
+	pos = max(0, min(pos, inner))
+	return "[" + strings.Repeat("=", pos) + strings.Repeat("-", inner-pos) + "]"
+}
+
+// replayLines wraps the target at spaces to width cells and colors it by the input:
+// typed text, mistakes (a mistyped space shows as •, as while typing), the cursor and the rest.
+func replayLines(target, input []string, width int) []string {
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		lineWidth = 0
+	}
+	for start := 0; start < len(target); {
+		end := start
+		wordWidth := 0
+		for end < len(target) && target[end] != " " {
+			wordWidth += grapheme.Width(target[end])
+			end++
+		}
+		if end < len(target) {
+			// Keep the space after the word on its line.
+			end++
+			wordWidth++
+		}
+		if lineWidth > 0 && lineWidth+wordWidth > width {
+			flush()
+		}
+		for i := start; i < end; i++ {
+			line.WriteString(replayCell(target, input, i))
+		}
+		lineWidth += wordWidth
+		start = end
+	}
+	if lineWidth > 0 {
+		flush()
+	}
+	return lines
+}
+
+func replayCell(target, input []string, i int) string {
+	switch {
+	case i < len(input) && input[i] == target[i]:
+		return replayTypedStyle.Render(target[i])
+	case i < len(input) && target[i] == " ":
+		return errorStyle.Render("•")
+	case i < len(input):
+		return errorStyle.Render(target[i])
+	case i == len(input):
+		return replayCursorStyle.Render(target[i])
+	default:
+		return headerStyle.Render(target[i])
+	}
+}
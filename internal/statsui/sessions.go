@@ -0,0 +1,91 @@
+package statsui
+
+import (
+	"context"
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errNoSessionRow rejects ignoring a row that does not stand for one stored session.
+var errNoSessionRow = errors.New("the row is not a stored session")
+
+// sessionIgnoredMsg reports the result of toggling the ignored flag of a session; on
+// success the store's change hook reloads the stats.
+type sessionIgnoredMsg struct {
+	err error
+}
+
+// updateSessions handles the keys of the Sessions tab; ok is false for keys it leaves
+// to the rest of the stats UI.
+func (m *Model) updateSessions(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "up", "k":
+		m.moveSessionCursor(-1)
+	case "down", "j":
+		m.moveSessionCursor(1)
+	case "x":
+		return m.toggleSessionIgnored(), true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// moveSessionCursor selects the session delta rows away and scrolls it into view.
+func (m *Model) moveSessionCursor(delta int) {
+	if len(m.sessionPage.sessions) == 0 {
+		return
+	}
+	m.sessionCursor = max(0, min(m.sessionCursor+delta, len(m.sessionPage.sessions)-1))
+	m.renderTabContents()
+	vp := &m.viewports[tabSessions]
+	line := sessionHeaderLines(m.sessionPage) + m.sessionCursor
+	top := line
+	if m.sessionCursor == 0 {
+		// Show the page and table headers with the first row.
+		top = 0
+	}
+	switch {
+	case top < vp.YOffset:
+		vp.SetYOffset(top)
+	case line >= vp.YOffset+vp.Height:
+		vp.SetYOffset(line - vp.Height + 1)
+	}
+}
+
+// clampSessionCursor keeps the selection on the loaded page.
+func (m *Model) clampSessionCursor() {
+	m.sessionCursor = max(0, min(m.sessionCursor, len(m.sessionPage.sessions)-1))
+}
+
+// toggleSessionIgnored flips the ignored flag of the selected session in the background.
+// Rows without a session ID, such as the per-day entries of a daily report, are refused
+// rather than silently updating nothing.
+func (m *Model) toggleSessionIgnored() tea.Cmd {
+	if !m.loaded || len(m.sessionPage.sessions) == 0 {
+		return nil
+	}
+	s := m.sessionPage.sessions[m.sessionCursor]
+	if s.SessionID == 0 {
+		m.handleSessionIgnored(sessionIgnoredMsg{err: errNoSessionRow})
+		return nil
+	}
+	st := m.store
+	return func() tea.Msg {
+		return sessionIgnoredMsg{err: st.SetSessionIgnored(context.Background(), s.SessionID, !s.Ignored)}
+	}
+}
+
+func (m *Model) handleSessionIgnored(msg sessionIgnoredMsg) {
+	m.sessionErr = msg.err
+	m.renderTabContents()
+}
+
+// sessionHeaderLines is the number of lines above the first session row.
+func sessionHeaderLines(page sessionPage) int {
+	if page.total > sessionPageSize {
+		return 3
+	}
+	return 1
+}
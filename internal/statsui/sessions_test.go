@@ -0,0 +1,73 @@
+package statsui
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+func TestToggleIgnoredRefusesRowsWithoutID(t *testing.T) {
+	m := &Model{loaded: true, sessionPage: sessionPage{total: 1, sessions: []model.SessionAggregate{{EndedAt: time.Unix(600, 0)}}}}
+	if cmd := m.toggleSessionIgnored(); cmd != nil {
+		t.Fatal("expected no store update for a row without a session ID")
+	}
+	if !errors.Is(m.sessionErr, errNoSessionRow) {
+		t.Fatalf("expected errNoSessionRow, got %v", m.sessionErr)
+	}
+}
+
+func TestDailyReportSessionPageKeepsIDs(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	day := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	var last int64
+	for i := range stats.DailyReportThreshold + 1 {
+		end := day.Add(time.Duration(i) * time.Second)
+		last, err = st.InsertSession(ctx, model.SessionStats{StartedAt: end.Add(-time.Second), EndedAt: end, Lang: "en",
+			CorrectNonSpace: 5, DurationMs: 1000}, nil)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+	}
+	report, err := stats.BuildReport(ctx, st, model.StatsConfig{})
+	if err != nil || !report.Daily {
+		t.Fatalf("expected a daily report, got daily=%v (err=%v)", report.Daily, err)
+	}
+
+	page, err := loadSessionPage(ctx, st, model.StatsConfig{}, 0)
+	if err != nil {
+		t.Fatalf("load session page: %v", err)
+	}
+	if len(page.sessions) != sessionPageSize {
+		t.Fatalf("expected a full page of sessions, got %d", len(page.sessions))
+	}
+	if page.sessions[0].SessionID != last {
+		t.Fatalf("expected the newest session %d first, got %d", last, page.sessions[0].SessionID)
+	}
+
+	m := &Model{store: st, loaded: true, report: report, sessionPage: page}
+	cmd := m.toggleSessionIgnored()
+	if cmd == nil {
+		t.Fatal("expected the selected session to be ignored")
+	}
+	if msg := cmd().(sessionIgnoredMsg); msg.err != nil {
+		t.Fatalf("ignore session: %v", msg.err)
+	}
+	session, err := st.GetSession(ctx, last)
+	if err != nil || !session.Ignored {
+		t.Fatalf("expected session %d ignored, got %+v (err=%v)", last, session, err)
+	}
+}
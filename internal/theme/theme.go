@@ -0,0 +1,67 @@
+// Package theme resolves the configured colors for the Bubble Tea UIs.
+package theme
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// Colors are the UI colors of a theme, ready for lipgloss styles.
+type Colors struct {
+	Text    lipgloss.TerminalColor
+	Error   lipgloss.TerminalColor
+	Pending lipgloss.TerminalColor
+	Accent  lipgloss.TerminalColor
+	Muted   lipgloss.TerminalColor
+	Border  lipgloss.TerminalColor
+	Subtle  lipgloss.TerminalColor
+
+	// Mono is set under NO_COLOR: every color is empty, so styles should lean on
+	// attributes such as faint and bold instead.
+	Mono bool
+	// MarkErrors asks for mistakes to be marked by more than their color.
+	MarkErrors bool
+}
+
+// NoColor reports whether the NO_COLOR convention (https://no-color.org) is in effect.
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// Resolve returns the colors of t. Colors set in t are used as given; the others come
+// from its palette, in the variant matching the terminal background. Under NO_COLOR
+// every color is empty.
+func Resolve(t model.Theme) Colors {
+	if NoColor() {
+		none := lipgloss.NoColor{}
+		return Colors{
+			Text: none, Error: none, Pending: none, Accent: none, Muted: none, Border: none, Subtle: none,
+			Mono:       true,
+			MarkErrors: true,
+		}
+	}
+	palette, ok := model.LookupPalette(t.Palette)
+	if !ok {
+		palette, _ = model.LookupPalette(model.PaletteDefault)
+	}
+	pick := func(value, dark, light string) lipgloss.TerminalColor {
+		if value != "" {
+			return lipgloss.Color(value)
+		}
+		return lipgloss.AdaptiveColor{Dark: dark, Light: light}
+	}
+	d, l := palette.Dark, palette.Light
+	return Colors{
+		Text:       pick(t.Text, d.Text, l.Text),
+		Error:      pick(t.Error, d.Error, l.Error),
+		Pending:    pick(t.Pending, d.Pending, l.Pending),
+		Accent:     pick(t.Accent, d.Accent, l.Accent),
+		Muted:      pick(t.Muted, d.Muted, l.Muted),
+		Border:     pick(t.Border, d.Border, l.Border),
+		Subtle:     pick(t.Subtle, d.Subtle, l.Subtle),
+		MarkErrors: t.Palette == model.PaletteColorblind,
+	}
+}
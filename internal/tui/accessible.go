@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+)
+
+// accessibleProgressStep is how often, in percent of the text, progress is announced.
+const accessibleProgressStep = 25
+
+// announce queues a plain-text line printed above the view in accessible mode, where it
+// stays in the scrollback for screen readers.
+func (m *Model) announce(line string) {
+	if !m.config.Accessible {
+		return
+	}
+	m.announcements = append(m.announcements, line)
+}
+
+// announceKey reports a mistake at pos and progress milestones reached by the keystroke.
+func (m *Model) announceKey(pos int, expected, typed string) {
+	if !m.config.Accessible {
+		return
+	}
+	if typed != expected {
+		m.announce(i18n.T("accessible.mistake", m.wordNumber(pos), describeChar(expected), describeChar(typed)))
+	}
+	progress := len(m.input) * 100 / len(m.target)
+	if progress < 100 && progress/accessibleProgressStep > m.announcedProgress/accessibleProgressStep {
+		m.announce(i18n.T("practice.progress", progress/accessibleProgressStep*accessibleProgressStep))
+	}
+	m.announcedProgress = progress
+}
+
+// flushAnnouncements prints the queued lines above the program.
+func (m *Model) flushAnnouncements() tea.Cmd {
+	if len(m.announcements) == 0 {
+		return nil
+	}
+	text := strings.Join(m.announcements, "\n")
+	m.announcements = nil
+	return tea.Println(text)
+}
+
+// describeChar names characters a screen reader would skip.
+func describeChar(c string) string {
+	switch c {
+	case " ":
+		return i18n.T("accessible.space")
+	case "\t":
+		return i18n.T("accessible.tab")
+	}
+	return c
+}
+
+// renderAccessible shows the text and the typed input as unstyled lines; mistakes are
+// announced as they happen instead of colored.
+func (m *Model) renderAccessible() string {
+	lines := []string{
+		i18n.T("accessible.text", m.targetText()),
+		i18n.T("accessible.typed", m.inputText()),
+	}
+	if m.incorrectNonSpace > 0 {
+		lines = append(lines, i18n.T("accessible.mistakes", m.incorrectNonSpace))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// renderAccessibleResults is the results screen without colors or centering.
+func (m *Model) renderAccessibleResults(speed float64, unit string, acc float64, duration time.Duration) string {
+	lines := []string{
+		i18n.T("results.title"),
+		i18n.T("accessible.result", speed, unit, acc*100, duration.Round(100*time.Millisecond)),
+	}
+	if worst := m.charBreakdown(); worst != "" {
+		lines = append(lines, worst)
+	}
+	if shift := m.shiftSummary(); shift != "" {
+		lines = append(lines, shift)
+	}
+	if rhythm := m.rhythmSummary(); rhythm != "" {
+		lines = append(lines, rhythm)
+	}
+	if suggestion := m.weakSuggestionLine(); suggestion != "" {
+		lines = append(lines, suggestion)
+	}
+	if m.shareCard != "" {
+		lines = append(lines, m.shareCard)
+	}
+	if m.statusMsg != "" {
+		lines = append(lines, m.statusMsg)
+	}
+	lines = append(lines, m.resultsHelp())
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestAccessibleAnnouncesMistakesAndProgress(t *testing.T) {
+	m := &Model{config: model.Config{Accessible: true}, target: grapheme.Split("ab cd")}
+	m.handleRunes([]rune("ab"))
+	m.handleRunes([]rune("x"))
+	want := []string{"Progress 25%", "Mistake in word 1: expected space, typed x", "Progress 50%"}
+	if strings.Join(m.announcements, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected announcements %q, got %q", want, m.announcements)
+	}
+	if cmd := m.flushAnnouncements(); cmd == nil || m.announcements != nil {
+		t.Fatalf("expected flush to print and clear the announcements")
+	}
+	view := m.View()
+	if !containsAll(view, []string{"Text: ab cd", "Typed: abx"}) || strings.Contains(view, "\x1b[") {
+		t.Fatalf("expected a plain-text view, got %q", view)
+	}
+}
+
+func TestAnnouncementsOffByDefault(t *testing.T) {
+	m := &Model{target: grapheme.Split("ab")}
+	m.handleRunes([]rune("x"))
+	if len(m.announcements) != 0 || m.flushAnnouncements() != nil {
+		t.Fatalf("expected no announcements outside accessible mode, got %q", m.announcements)
+	}
+}
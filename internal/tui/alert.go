@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+)
+
+// Live accuracy alert: the rolling accuracy looks at the last accuracyWindow keystrokes
+// of the text and needs at least accuracyMinKeys of them.
+const (
+	accuracyWindow  = 20
+	accuracyMinKeys = 10
+)
+
+// alertStyle renders the footer while the accuracy alert is on; SetTheme sets it.
+var alertStyle lipgloss.Style
+
+// recordHit adds a keystroke to the rolling accuracy and updates the alert. Turning the
+// alert on is announced in accessible mode.
+func (m *Model) recordHit(correct bool) {
+	m.hits = append(m.hits, correct)
+	if len(m.hits) > accuracyWindow {
+		m.hits = m.hits[len(m.hits)-accuracyWindow:]
+	}
+	acc, ok := m.rollingAccuracy()
+	alert := ok && m.config.AccuracyAlert > 0 && acc < m.config.AccuracyAlert
+	if alert && !m.alerting {
+		m.announce(i18n.T("practice.slow_down", acc*100))
+	}
+	m.alerting = alert
+}
+
+// enforceFloor abandons the text for a new one once the rolling accuracy drops below
+// Config.AccuracyFloor, so mistakes are not practiced to the end of a text. With
+// Config.SaveIncomplete the abandoned text is saved as incomplete, like on quit.
+func (m *Model) enforceFloor() bool {
+	acc, ok := m.rollingAccuracy()
+	if m.config.AccuracyFloor <= 0 || !ok || acc >= m.config.AccuracyFloor {
+		return false
+	}
+	if m.config.SaveIncomplete {
+		m.finishSession(true)
+	}
+	m.notice = i18n.T("practice.floor_restart", acc*100, m.config.AccuracyFloor*100)
+	m.announce(m.notice)
+	m.resetSession()
+	return true
+}
+
+// rollingAccuracy is the share of correct recent keystrokes; ok is false until enough
+// keys have been typed.
+func (m *Model) rollingAccuracy() (float64, bool) {
+	if len(m.hits) < accuracyMinKeys {
+		return 0, false
+	}
+	correct := 0
+	for _, hit := range m.hits {
+		if hit {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(m.hits)), true
+}
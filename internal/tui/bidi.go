@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// direction is the bidi class of a character, reduced to what line layout needs.
+type direction int8
+
+const (
+	dirNeutral direction = iota
+	dirLTR
+	dirRTL
+	// dirNumber reads left to right but does not decide the direction of a text.
+	dirNumber
+)
+
+// rtlScripts are the right-to-left writing systems.
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko,
+}
+
+// clusterDirection classifies a grapheme cluster by its first rune.
+func clusterDirection(cluster string) direction {
+	for _, r := range cluster {
+		switch {
+		case unicode.IsDigit(r):
+			return dirNumber
+		case unicode.In(r, rtlScripts...):
+			return dirRTL
+		case unicode.IsLetter(r):
+			return dirLTR
+		}
+		return dirNeutral
+	}
+	return dirNeutral
+}
+
+// bidiLayout says how layoutText places a text.
+type bidiLayout int8
+
+const (
+	// bidiNone lays the text out left to right.
+	bidiNone bidiLayout = iota
+	// bidiAlign right-aligns the lines and leaves reordering to the terminal.
+	bidiAlign
+	// bidiReorder right-aligns the lines and mirrors them into visual order.
+	bidiReorder
+)
+
+// bidiFor picks the layout of target: texts whose first strong character is
+// right-to-left are right-aligned, and reordered unless the terminal does it (mode is
+// model.BidiTerminal).
+func bidiFor(target []string, mode string) bidiLayout {
+	for _, c := range target {
+		switch clusterDirection(c) {
+		case dirLTR:
+			return bidiNone
+		case dirRTL:
+			if mode == model.BidiTerminal {
+				return bidiAlign
+			}
+			return bidiReorder
+		}
+	}
+	return bidiNone
+}
+
+// visualOrder mirrors a line of right-to-left text into the order a terminal without
+// bidi support should print it, so typing progresses from the right edge. Runs of
+// left-to-right characters, such as numbers and Latin words, keep their reading order;
+// neutral characters join such a run only when it continues after them.
+func visualOrder(line []styledChar) []styledChar {
+	n := len(line)
+	ltr := make([]bool, n)
+	prevLTR := false
+	for i, c := range line {
+		switch c.dir {
+		case dirLTR, dirNumber:
+			ltr[i] = true
+			prevLTR = true
+		case dirRTL:
+			prevLTR = false
+		default:
+			ltr[i] = prevLTR && nextStrongIsLTR(line[i+1:])
+		}
+	}
+	out := make([]styledChar, n)
+	for i, c := range line {
+		out[n-1-i] = c
+	}
+	for start := 0; start < n; {
+		if !ltr[n-1-start] {
+			start++
+			continue
+		}
+		end := start
+		for end < n && ltr[n-1-end] {
+			end++
+		}
+		slices.Reverse(out[start:end])
+		start = end
+	}
+	return out
+}
+
+func nextStrongIsLTR(line []styledChar) bool {
+	for _, c := range line {
+		if c.dir != dirNeutral {
+			return c.dir != dirRTL
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestBidiFor(t *testing.T) {
+	cases := []struct {
+		text string
+		mode string
+		want bidiLayout
+	}{
+		{"hello שלום", model.BidiApp, bidiNone},
+		{"שלום hello", model.BidiApp, bidiReorder},
+		{"12 سلام", model.BidiApp, bidiReorder},
+		{"سلام", model.BidiTerminal, bidiAlign},
+		{"...", model.BidiApp, bidiNone},
+	}
+	for _, c := range cases {
+		if got := bidiFor(grapheme.Split(c.text), c.mode); got != c.want {
+			t.Fatalf("bidiFor(%q, %q) = %d, want %d", c.text, c.mode, got, c.want)
+		}
+	}
+}
+
+func TestVisualOrderKeepsLeftToRightRuns(t *testing.T) {
+	cases := map[string]string{
+		"אב גד":       "דג בא",
+		"אב 12 גד":    "דג 12 בא",
+		"אב cd ef גד": "דג cd ef בא",
+		"אב cd, גד":   "דג ,cd בא",
+	}
+	for text, want := range cases {
+		var line []styledChar
+		for _, c := range grapheme.Split(text) {
+			line = append(line, styledChar{s: c, width: grapheme.Width(c), dir: clusterDirection(c)})
+		}
+		if got := renderStyledChars(visualOrder(line)); got != want {
+			t.Fatalf("visualOrder(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestLayoutTextRightAlignsRightToLeftText(t *testing.T) {
+	target := grapheme.Split("אב גד")
+	chars := buildStyledChars(target, nil, findWords(target), 0)
+	view := layoutText(chars, 0, 20, 3, 0.5, "", bidiReorder)
+	lines := strings.Split(view, "\n")
+	if len(lines) == 0 || !strings.HasSuffix(strings.TrimRight(lines[1], " "), "דג בא") {
+		t.Fatalf("expected a right-aligned, mirrored line, got %q", view)
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// resultsWorstChars is the number of worst characters of the text on the results screen.
+const resultsWorstChars = 5
+
+// charBreakdown lists the worst characters of the last text with their accuracy and mean
+// latency. It is empty when no character was typed.
+func (m *Model) charBreakdown() string {
+	worst := statsPkg.WorstChars(m.lastChars, resultsWorstChars)
+	if len(worst) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(worst))
+	for _, agg := range worst {
+		part := fmt.Sprintf("%s %.0f%%", describeChar(agg.Char), charAccuracy(agg)*100)
+		if agg.LatencyCount > 0 {
+			part += fmt.Sprintf(" %.0fms", statsPkg.MeanLatencyMs(agg))
+		}
+		parts = append(parts, part)
+	}
+	return i18n.T("results.worst", strings.Join(parts, "  "))
+}
+
+// canDrill reports whether the next text can focus on the worst characters of the last one.
+func (m *Model) canDrill() bool {
+	if m.config.Daily {
+		return false
+	}
+	if _, ok := m.source.(generator.WeakAware); !ok {
+		return false
+	}
+	return len(statsPkg.WorstChars(m.lastChars, resultsWorstChars)) > 0
+}
+
+// startDrill makes the worst characters of the last text the weak set of the next one.
+// The weak set goes back to normal once the drill text is done.
+func (m *Model) startDrill() {
+	worst := statsPkg.WorstChars(m.lastChars, resultsWorstChars)
+	weakSet := make(map[rune]struct{}, len(worst))
+	for _, agg := range worst {
+		if runes := []rune(agg.Char); len(runes) > 0 {
+			weakSet[runes[0]] = struct{}{}
+		}
+	}
+	m.drilling = true
+	m.weakSet = weakSet
+	m.weakAggs = worst
+	m.applyWeakSet()
+}
+
+// endDrill restores the weak set after a drill text. With weak focus on, the background
+// refresh after the session sets it again.
+func (m *Model) endDrill() {
+	if !m.drilling {
+		return
+	}
+	m.drilling = false
+	m.weakSet = map[rune]struct{}{}
+	m.weakAggs = nil
+	m.applyWeakSet()
+}
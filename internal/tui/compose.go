@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+// deadKeyMarks maps the spacing accents terminals send for unresolved dead keys to the
+// combining marks they stand for.
+var deadKeyMarks = map[rune]rune{
+	'`': '\u0300',
+	'´': '\u0301',
+	'^': '\u0302',
+	'~': '\u0303',
+	'¯': '\u0304',
+	'˘': '\u0306',
+	'˙': '\u0307',
+	'¨': '\u0308',
+	'˚': '\u030A',
+	'˝': '\u030B',
+	'ˇ': '\u030C',
+	'¸': '\u0327',
+	'˛': '\u0328',
+}
+
+// composeInput turns typed runes into the grapheme clusters they spell before they are
+// scored. Combining marks join the letter before them, a dead-key accent joins the letter
+// after it, and a trailing cluster that may still grow into the expected one (its base
+// letter, the start of an Indic cluster or one of its accents) is held back until the
+// next key.
+func (m *Model) composeInput(runes []rune) []string {
+	clusters := grapheme.Split(norm.NFC.String(m.pendingCompose + string(runes)))
+	m.pendingCompose = ""
+
+	out := make([]string, 0, len(clusters))
+	for i := 0; i < len(clusters); i++ {
+		c := clusters[i]
+		expected, ok := m.expectedAt(len(out))
+		if ok && c != expected && i+1 < len(clusters) {
+			if composed, ok := applyDeadKey(c, clusters[i+1]); ok {
+				out = append(out, composed)
+				i++
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+
+	if n := len(out); n > 0 {
+		if expected, ok := m.expectedAt(n - 1); ok && startsComposition(expected, out[n-1]) {
+			m.pendingCompose = out[n-1]
+			out = out[:n-1]
+		}
+	}
+	return out
+}
+
+// expectedAt returns the target cluster offset clusters past the input typed so far.
+func (m *Model) expectedAt(offset int) (string, bool) {
+	pos := len(m.input) + offset
+	if pos >= len(m.target) {
+		return "", false
+	}
+	return m.target[pos], true
+}
+
+// startsComposition reports whether typed is the start of a key sequence composing
+// expected: a prefix of its decomposed form or a dead-key accent it carries.
+func startsComposition(expected, typed string) bool {
+	if typed == expected {
+		return false
+	}
+	parts := []rune(norm.NFD.String(expected))
+	typedParts := []rune(norm.NFD.String(typed))
+	if len(typedParts) < len(parts) && slices.Equal(parts[:len(typedParts)], typedParts) {
+		return true
+	}
+	if len(typedParts) != 1 {
+		return false
+	}
+	mark, ok := deadKeyMarks[typedParts[0]]
+	return ok && slices.Contains(parts[1:], mark)
+}
+
+// applyDeadKey composes letter with the accent of deadKey when they form one precomposed
+// character.
+func applyDeadKey(deadKey, letter string) (string, bool) {
+	accent := []rune(deadKey)
+	if len(accent) != 1 {
+		return "", false
+	}
+	mark, ok := deadKeyMarks[accent[0]]
+	if !ok {
+		return "", false
+	}
+	composed := norm.NFC.String(letter + string(mark))
+	if utf8.RuneCountInString(composed) != 1 {
+		return "", false
+	}
+	return composed, true
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+func TestComposeCombiningMark(t *testing.T) {
+	m := &Model{target: grapheme.Split("éa")}
+	m.handleRunes([]rune("e"))
+	if len(m.input) != 0 || m.pendingCompose != "e" {
+		t.Fatalf("expected the base letter to wait for its mark, got input %q pending %q", m.inputText(), m.pendingCompose)
+	}
+	m.handleRunes([]rune("\u0301"))
+	if m.inputText() != "é" || m.correctNonSpace != 1 || m.incorrectNonSpace != 0 {
+		t.Fatalf("expected a correct é, got input %q (%d correct, %d incorrect)", m.inputText(), m.correctNonSpace, m.incorrectNonSpace)
+	}
+}
+
+func TestComposeDeadKey(t *testing.T) {
+	m := &Model{target: grapheme.Split("êtes")}
+	m.handleRunes([]rune("^"))
+	m.handleRunes([]rune("e"))
+	m.handleRunes([]rune("t"))
+	if m.inputText() != "êt" || m.incorrectNonSpace != 0 {
+		t.Fatalf("expected the dead key to compose ê, got input %q (%d incorrect)", m.inputText(), m.incorrectNonSpace)
+	}
+}
+
+func TestComposeFallsBackToTypedRunes(t *testing.T) {
+	m := &Model{target: grapheme.Split("ê b")}
+	m.handleRunes([]rune("^"))
+	m.handleRunes([]rune(" "))
+	if m.inputText() != "^ " || m.incorrectNonSpace != 1 {
+		t.Fatalf("expected an uncomposable dead key to be scored as typed, got input %q (%d incorrect)", m.inputText(), m.incorrectNonSpace)
+	}
+
+	m = &Model{target: grapheme.Split("éa")}
+	m.handleRunes([]rune("e"))
+	m.handleBackspace()
+	m.handleRunes([]rune("é"))
+	if m.inputText() != "é" || m.incorrectNonSpace != 0 {
+		t.Fatalf("expected backspace to drop the pending letter, got input %q", m.inputText())
+	}
+}
+
+func TestComposeIndicCluster(t *testing.T) {
+	m := &Model{target: grapheme.Split("स्ते स")}
+	for _, key := range "स्ते" {
+		m.handleRunes([]rune{key})
+	}
+	if len(m.input) != 2 || m.inputText() != "स्ते" || m.correctNonSpace != 2 || m.incorrectNonSpace != 0 {
+		t.Fatalf("expected two correct clusters, got %q (%d correct, %d incorrect)", m.input, m.correctNonSpace, m.incorrectNonSpace)
+	}
+	m.handleBackspace()
+	if m.inputText() != "स्" {
+		t.Fatalf("expected backspace to remove a whole cluster, got %q", m.inputText())
+	}
+}
+
+func TestStartsComposition(t *testing.T) {
+	cases := []struct {
+		expected, typed string
+		want            bool
+	}{
+		{"é", "e", true},
+		{"é", "´", true},
+		{"ü", "¨", true},
+		{"स्", "स", true},
+		{"é", "`", false},
+		{"e", "e", false},
+		{"a", "´", false},
+		{"स", "स्", false},
+	}
+	for _, c := range cases {
+		if got := startsComposition(c.expected, c.typed); got != c.want {
+			t.Fatalf("startsComposition(%q, %q) = %v, want %v", c.expected, c.typed, got, c.want)
+		}
+	}
+}
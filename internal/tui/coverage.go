@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// refreshCoverage loads the recent character counts and updates the under-sampled set.
+func (m *Model) refreshCoverage() {
+	m.coverageChars = map[rune]struct{}{}
+	if m.config.Coverage <= 0 {
+		return
+	}
+	aggs, err := m.store.GetWeakChars(context.Background(), m.config.WeakWindow, m.config.Lang)
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_weak", err))
+		return
+	}
+	m.setCoverage(aggs)
+}
+
+// setCoverage picks the characters of the wordlist typed fewer than Config.Coverage
+// times in the weak window (up to weak-top); they join the bias set like weak characters.
+func (m *Model) setCoverage(aggs []model.CharAggregate) {
+	m.coverageChars = map[rune]struct{}{}
+	for _, r := range statsPkg.UnderSampled(m.config.Alphabet, aggs, m.config.Coverage, m.config.WeakTop) {
+		m.coverageChars[r] = struct{}{}
+	}
+}
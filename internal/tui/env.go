@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// sessionEnv describes the terminal and the practice options of the current text, or
+// returns nil unless Config.RecordEnv is set.
+func (m *Model) sessionEnv() *model.SessionEnv {
+	if !m.config.RecordEnv {
+		return nil
+	}
+	return &model.SessionEnv{
+		Term:    os.Getenv("TERM"),
+		OS:      runtime.GOOS,
+		Width:   m.width,
+		Height:  m.height,
+		SSH:     os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "",
+		Options: m.activeOptions(),
+	}
+}
+
+// activeOptions names the practice features that were on for the text.
+func (m *Model) activeOptions() []string {
+	var options []string
+	add := func(on bool, name string) {
+		if on {
+			options = append(options, name)
+		}
+	}
+	add(m.weakFocusActive() && len(m.weakSet) > 0, "focus-weak")
+	add(m.config.SRS, "srs")
+	add(m.config.Review, "review")
+	add(m.config.Coverage > 0, "coverage")
+	add(m.config.AccuracyAlert > 0, "accuracy-alert")
+	add(m.config.AccuracyFloor > 0, "accuracy-floor")
+	add(m.zen, "zen")
+	add(m.config.Accessible, "accessible")
+	return options
+}
@@ -0,0 +1,30 @@
+package tui
+
+import "strings"
+
+// apostrophes is the equivalence class added by the loose-apostrophe option.
+const apostrophes = "'’"
+
+// matchEquivalent returns expected when typed is another character of one of its
+// equivalence classes, so e.g. " is accepted for “. Other input is returned as is.
+func (m *Model) matchEquivalent(expected, typed string) string {
+	if typed == expected || expected == " " {
+		return typed
+	}
+	for _, class := range m.equivalenceClasses() {
+		if strings.Contains(class, expected) && strings.Contains(class, typed) {
+			return expected
+		}
+	}
+	return typed
+}
+
+// equivalenceClasses lists the configured classes: space-separated groups of characters
+// from Config.Equivalents, plus ' and ’ with Config.LooseApostrophe.
+func (m *Model) equivalenceClasses() []string {
+	classes := strings.Fields(m.config.Equivalents)
+	if m.config.LooseApostrophe {
+		classes = append(classes, apostrophes)
+	}
+	return classes
+}
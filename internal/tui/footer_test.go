@@ -3,17 +3,24 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
 )
 
 func TestRenderFooterFormats(t *testing.T) {
 	m := &Model{
-		targetRunes: []rune("abcd"),
-		inputRunes:  []rune("ab"),
-		hasLast:     true,
-		lastWPM:     72.4,
-		lastAcc:     0.978,
-		allWPM:      68.1,
-		allAcc:      0.969,
+		target:    grapheme.Split("abcd"),
+		input:     grapheme.Split("ab"),
+		hasLast:   true,
+		lastSpeed: 72.4,
+		lastAcc:   0.978,
+		allSpeed:  68.1,
+		allAcc:    0.969,
 	}
 	out := m.renderFooter()
 	if out == "" {
@@ -24,6 +31,46 @@ func TestRenderFooterFormats(t *testing.T) {
 	}
 }
 
+func TestAccuracyAlertNeedsRecentMistakes(t *testing.T) {
+	target, words := joinWords([]string{"aaaaaaaaaa", "aaaaaaaaaa"})
+	m := &Model{target: target, words: words, config: model.Config{AccuracyAlert: 0.9, Accessible: true}}
+	m.handleRunes([]rune("aaaaaaaa"))
+	m.handleRunes([]rune("x"))
+	if m.alerting {
+		t.Fatalf("expected no alert before %d keys", accuracyMinKeys)
+	}
+	m.handleRunes([]rune("x"))
+	if !m.alerting || !strings.Contains(m.renderFooter(), "Accuracy 80% · slow down") {
+		t.Fatalf("expected the alert at 80%% rolling accuracy, got %q", m.renderFooter())
+	}
+	m.handleRunes([]rune(" "))
+	if n := strings.Count(strings.Join(m.announcements, "\n"), "slow down"); n != 1 {
+		t.Fatalf("expected the alert to be announced once, got %q", m.announcements)
+	}
+	m.handleRunes([]rune("aaaaaaaaa"))
+	if m.alerting {
+		t.Fatalf("expected the alert to clear once accuracy recovers")
+	}
+}
+
+func TestAccuracyFloorStartsNewText(t *testing.T) {
+	gen := generator.New()
+	m := &Model{
+		config: model.Config{Words: 4, AccuracyFloor: 0.95},
+		gen:    gen,
+		source: generator.NewWordSource(gen, []string{"aaaaa"}, generator.Style{}, 0),
+	}
+	m.resetSession()
+	m.handleRunes([]rune("aaaaa aaaaa aaaa"))
+	if !m.started || m.notice != "" {
+		t.Fatalf("expected accurate typing to keep the text, got notice %q", m.notice)
+	}
+	m.handleRunes([]rune("x"))
+	if m.started || len(m.input) != 0 || !strings.Contains(m.notice, "95% floor") {
+		t.Fatalf("expected a new text under the floor, got input %q and notice %q", m.inputText(), m.notice)
+	}
+}
+
 func containsAll(haystack string, needles []string) bool {
 	for _, needle := range needles {
 		if !strings.Contains(haystack, needle) {
@@ -32,3 +79,83 @@ func containsAll(haystack string, needles []string) bool {
 	}
 	return true
 }
+
+func TestFooterTrendSparkline(t *testing.T) {
+	m := &Model{
+		target: grapheme.Split("abcd"),
+		config: model.Config{FooterTrend: true},
+	}
+	m.setRecentSpeeds([]model.SessionAggregate{
+		{Correct: 300, DurationMs: 60000},
+		{Correct: 200, DurationMs: 60000},
+		{Correct: 100, DurationMs: 60000},
+	})
+	if !strings.Contains(m.renderFooter(), "Trend  +@") {
+		t.Fatalf("expected an oldest-first trend in the footer, got %q", m.renderFooter())
+	}
+	for i := 0; i < footerTrendSessions; i++ {
+		m.pushRecentSpeed(50)
+	}
+	if len(m.recentSpeeds) != footerTrendSessions {
+		t.Fatalf("expected %d speeds, got %d", footerTrendSessions, len(m.recentSpeeds))
+	}
+	m.config.FooterTrend = false
+	if strings.Contains(m.renderFooter(), "Trend") {
+		t.Fatalf("expected no trend without --footer-trend")
+	}
+}
+
+func TestZenModeHidesFooter(t *testing.T) {
+	target, words := joinWords([]string{"abc"})
+	m := &Model{target: target, words: words, width: 40, height: 10}
+	if !strings.Contains(m.View(), "Progress 0%") {
+		t.Fatalf("expected the footer by default")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := m.View(); strings.Contains(view, "Progress") || !strings.Contains(view, "a") {
+		t.Fatalf("expected only the text in zen mode: %q", view)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !strings.Contains(m.View(), "Progress 0%") {
+		t.Fatalf("expected esc to bring the footer back")
+	}
+}
+
+func TestFooterGoalProgress(t *testing.T) {
+	m := &Model{
+		target: grapheme.Split("abcd"),
+		config: model.Config{GoalMinutes: 15},
+	}
+	now := time.Now()
+	m.addTodayPractice(now, 7*60000+30000)
+	if !strings.Contains(m.renderFooter(), "Goal 7/15 min") {
+		t.Fatalf("expected goal progress in the footer, got %q", m.renderFooter())
+	}
+	m.addTodayPractice(now, 8*60000)
+	if !strings.Contains(m.renderFooter(), "Goal 15 min met") {
+		t.Fatalf("expected the goal to be met, got %q", m.renderFooter())
+	}
+	m.addTodayPractice(now.AddDate(0, 0, 1), 60000)
+	if !strings.Contains(m.renderFooter(), "Goal 1/15 min") {
+		t.Fatalf("expected progress to start over on a new day, got %q", m.renderFooter())
+	}
+	m.config.GoalMinutes = 0
+	if strings.Contains(m.renderFooter(), "Goal") {
+		t.Fatalf("expected no goal without --goal-minutes")
+	}
+}
+
+func TestDailyChallengeSeed(t *testing.T) {
+	m := &Model{target: grapheme.Split("abcd"), config: model.Config{Daily: true}}
+	morning := time.Date(2024, 5, 10, 1, 0, 0, 0, time.UTC)
+	evening := time.Date(2024, 5, 10, 23, 0, 0, 0, time.UTC)
+	if m.textSeed(morning) != m.textSeed(evening) || m.textSeed(morning) == m.textSeed(evening.Add(2*time.Hour)) {
+		t.Fatalf("expected one seed per UTC day")
+	}
+	if day, ok := generator.ChallengeOf(m.textSeed(evening), evening.Add(2*time.Hour)); !ok || day != "2024-05-10" {
+		t.Fatalf("expected a text started before midnight to count for its day, got %q", day)
+	}
+	if !strings.Contains(m.renderFooter(), "Daily ") || m.canDrill() {
+		t.Fatalf("expected the daily footer segment and no drills, got %q", m.renderFooter())
+	}
+}
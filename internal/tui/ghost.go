@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+// ghostStyle renders the wrong keys under the results review; SetTheme sets it.
+var ghostStyle lipgloss.Style
+
+// ghostReview draws the finished text wrapped to width with, under every line holding a
+// mistake, the character actually typed in a faint style below the expected one. It is
+// empty unless Config.Ghost is set and the text has a mistake.
+func (m *Model) ghostReview(width int) string {
+	if !m.config.Ghost || width <= 0 || !m.hasMistakes() {
+		return ""
+	}
+	lines := wrapLines(buildStyledChars(m.target, m.input, m.wordRanges(), -1), width)
+	rendered := make([]string, 0, 2*len(lines))
+	for _, line := range lines {
+		rendered = append(rendered, renderStyledChars(line))
+		if ghost := m.ghostLine(line); ghost != "" {
+			rendered = append(rendered, ghost)
+		}
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// ghostLine places the typed character under each mistake of line, or returns "" when
+// the line has none. A typed space shows as _, and a character wider than the expected
+// one as ?.
+func (m *Model) ghostLine(line []styledChar) string {
+	var b strings.Builder
+	found := false
+	for _, c := range line {
+		if c.index >= len(m.input) || m.input[c.index] == m.target[c.index] {
+			b.WriteString(strings.Repeat(" ", c.width))
+			continue
+		}
+		found = true
+		typed := m.input[c.index]
+		if typed == " " {
+			typed = "_"
+		}
+		w := grapheme.Width(typed)
+		if w > c.width {
+			typed, w = "?", 1
+		}
+		b.WriteString(ghostStyle.Render(typed))
+		b.WriteString(strings.Repeat(" ", max(c.width-w, 0)))
+	}
+	if !found {
+		return ""
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// hasMistakes reports whether any typed character differs from the text.
+func (m *Model) hasMistakes() bool {
+	for i, typed := range m.input {
+		if i < len(m.target) && typed != m.target[i] {
+			return true
+		}
+	}
+	return false
+}
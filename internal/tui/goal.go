@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// loadTodayPractice sums the practice time since local midnight in any language.
+func (m *Model) loadTodayPractice() {
+	y, mo, d := time.Now().Date()
+	startOfDay := time.Date(y, mo, d, 0, 0, 0, 0, time.Local)
+	daily, err := m.store.ListDailyAggregates(context.Background(), model.StatsConfig{Since: &startOfDay})
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_stats", err))
+		return
+	}
+	m.todayDay = startOfDay.Format("2006-01-02")
+	m.todayMs = 0
+	for _, day := range daily {
+		m.todayMs += day.DurationMs
+	}
+}
+
+// addTodayPractice counts a finished session towards today's goal, starting over
+// when the session ended on a new day.
+func (m *Model) addTodayPractice(endedAt time.Time, durationMs int64) {
+	if day := endedAt.Local().Format("2006-01-02"); day != m.todayDay {
+		m.todayDay = day
+		m.todayMs = 0
+	}
+	m.todayMs += durationMs
+}
+
+// goalSegment is the footer progress towards Config.GoalMinutes; it is empty
+// without a goal.
+func (m *Model) goalSegment() string {
+	if m.config.GoalMinutes <= 0 {
+		return ""
+	}
+	if statsPkg.GoalMet(m.todayMs, m.config.GoalMinutes) {
+		return i18n.T("practice.goal_met", m.config.GoalMinutes)
+	}
+	minutes := int(m.todayMs / time.Minute.Milliseconds())
+	return i18n.T("practice.goal", minutes, m.config.GoalMinutes)
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"sync"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// SessionHook is called in the background after a completed session is saved.
+type SessionHook func(id int64, stats model.SessionStats, chars []model.CharStats) error
+
+type sessionHooks struct {
+	run  SessionHook
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// SetSessionHook registers hook to run after every completed session.
+func (m *Model) SetSessionHook(hook SessionHook) {
+	m.hooks = &sessionHooks{run: hook}
+}
+
+// WaitHooks waits for running hooks and returns their errors. Errors are collected
+// instead of printed because the TUI owns the terminal while hooks run.
+func (m *Model) WaitHooks() []error {
+	if m.hooks == nil {
+		return nil
+	}
+	m.hooks.wg.Wait()
+	m.hooks.mu.Lock()
+	defer m.hooks.mu.Unlock()
+	return m.hooks.errs
+}
+
+func (m *Model) runSessionHook(id int64, stats model.SessionStats, chars []model.CharStats) {
+	h := m.hooks
+	if h == nil {
+		return
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		if err := h.run(id, stats, chars); err != nil {
+			h.mu.Lock()
+			h.errs = append(h.errs, err)
+			h.mu.Unlock()
+		}
+	}()
+}
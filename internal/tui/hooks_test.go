@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSessionHookErrorsAreCollected(t *testing.T) {
+	m := &Model{}
+	if errs := m.WaitHooks(); errs != nil {
+		t.Fatalf("expected no errors without a hook, got %v", errs)
+	}
+	var got []int64
+	m.SetSessionHook(func(id int64, _ model.SessionStats, _ []model.CharStats) error {
+		got = append(got, id)
+		return errors.New("hook failed")
+	})
+	m.runSessionHook(7, model.SessionStats{}, nil)
+	errs := m.WaitHooks()
+	if len(got) != 1 || got[0] != 7 {
+		t.Fatalf("expected hook to run for session 7, got %v", got)
+	}
+	if len(errs) != 1 || errs[0].Error() != "hook failed" {
+		t.Fatalf("expected the hook error to be collected, got %v", errs)
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// mistakeBankSize caps the bank words passed to a review source, heaviest first.
+const mistakeBankSize = 200
+
+// loadMistakes reads the mistake bank of the practice language. In review mode an empty
+// bank is reported once, since texts then come from the normal generator.
+func (m *Model) loadMistakes() {
+	bank, err := m.store.ListMistakeWords(context.Background(), m.config.Lang)
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_mistakes", err))
+		return
+	}
+	m.mistakes = bank
+	if m.config.Review && len(statsPkg.ActiveMistakes(bank, time.Now(), 1)) == 0 {
+		logErrln(i18n.T("practice.no_mistakes"))
+	}
+}
+
+// recordMistakes updates the mistake bank with the words of the finished text.
+func (m *Model) recordMistakes(now time.Time) {
+	words := make([]string, 0, len(m.wordRanges()))
+	for _, w := range m.wordRanges() {
+		words = append(words, m.wordText(w))
+	}
+	changed := statsPkg.RecordMistakes(m.config.Lang, m.mistakes, words, m.missedWords, now)
+	if err := m.store.SaveMistakeWords(context.Background(), changed); err != nil {
+		logErrln(i18n.T("practice.err.save_mistakes", err))
+		return
+	}
+	m.mistakes = mergeMistakes(m.mistakes, changed)
+}
+
+// mergeMistakes applies changed words to bank, dropping those with a zero weight.
+func mergeMistakes(bank, changed []model.MistakeWord) []model.MistakeWord {
+	byWord := make(map[string]model.MistakeWord, len(changed))
+	for _, w := range changed {
+		byWord[w.Word] = w
+	}
+	merged := make([]model.MistakeWord, 0, len(bank)+len(changed))
+	for _, w := range bank {
+		if c, ok := byWord[w.Word]; ok {
+			w = c
+			delete(byWord, w.Word)
+		}
+		if w.Weight > 0 {
+			merged = append(merged, w)
+		}
+	}
+	for _, w := range changed {
+		if _, ok := byWord[w.Word]; ok && w.Weight > 0 {
+			merged = append(merged, w)
+		}
+	}
+	return merged
+}
+
+// applyMistakes passes the heaviest words of the mistake bank to sources built from it.
+func (m *Model) applyMistakes() {
+	bankAware, ok := m.source.(generator.BankAware)
+	if !ok {
+		return
+	}
+	active := statsPkg.ActiveMistakes(m.mistakes, time.Now(), mistakeBankSize)
+	words := make([]string, len(active))
+	weights := make([]float64, len(active))
+	for i, w := range active {
+		words[i] = w.Word
+		weights[i] = w.Weight
+	}
+	bankAware.SetBank(words, weights)
+}
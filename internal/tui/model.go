@@ -5,16 +5,22 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/verte-zerg/tuipe/internal/generator"
-	"github.com/verte-zerg/tuipe/internal/model"
-	statsPkg "github.com/verte-zerg/tuipe/internal/stats"
-	"github.com/verte-zerg/tuipe/internal/store"
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	uitheme "github.com/verte-zerg/tuipe/internal/theme"
+	"github.com/verte-zerg/tuipe/internal/version"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/replay"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+	"github.com/verte-zerg/tuipe/pkg/store"
 )
 
 type charStat struct {
@@ -29,58 +35,148 @@ type Model struct {
 	config            model.Config
 	store             *store.Store
 	gen               *generator.Generator
-	words             []string
+	source            generator.TextSource
 	wordListPath      string
-	punctSet          []rune
 	weakSet           map[rune]struct{}
 	weakNoticePrinted bool
+	// weakStale is set when a finished session should update the weak set or the
+	// under-sampled characters; the query runs in the background (see loadWeakSet).
+	weakStale bool
+	// weakAggs are the recent stats the weak set was picked from, for the tab overlay.
+	weakAggs     []model.CharAggregate
+	showWeakInfo bool
+	// zen hides the footer so only the text is shown; esc toggles it.
+	zen bool
+	// coverageChars are the characters typed fewer than Config.Coverage times recently.
+	coverageChars map[rune]struct{}
 
 	width  int
 	height int
 
-	targetRunes []rune
-	inputRunes  []rune
+	// The text and the input are split into grapheme clusters, the typing units.
+	target         []string
+	input          []string
+	words          []wordRange
+	pendingCompose string
 
 	started       bool
+	shownAt       time.Time
 	startedAt     time.Time
 	prevCorrectAt time.Time
 
+	firstKeyMs        int64
+	spaceLatencySumMs int64
+	spaceLatencyCount int64
+
 	correctNonSpace   int
 	incorrectNonSpace int
-	charStats         map[rune]*charStat
-
-	lastWPM float64
-	lastAcc float64
-	hasLast bool
-
-	allWPM       float64
+	samples           speedSamples
+	charStats         map[string]*charStat
+	missedWords       map[string]struct{}
+	// gaps are the pauses before each typed character; lastKeyAt is when the last came.
+	gaps      []keyGap
+	lastKeyAt time.Time
+	// hits are the latest keystrokes of the text, correct or not, for the accuracy alert.
+	hits     []bool
+	alerting bool
+
+	reviewChars map[rune]struct{}
+	reviewWords []string
+	mistakes    []model.MistakeWord
+
+	// lastSpeed and allSpeed are under the configured WPM formula.
+	lastSpeed float64
+	lastAcc   float64
+	hasLast   bool
+
+	allSpeed     float64
 	allAcc       float64
 	allCorrect   int
 	allIncorrect int
+	allWords     int
 	allDuration  int64
+	// recentSpeeds are the speeds of the latest sessions, oldest first, for the footer trend.
+	recentSpeeds []float64
+	// todayMs is the practice time on todayDay, for the footer goal.
+	todayMs  int64
+	todayDay string
+
+	showResults bool
+	lastSession model.SessionStats
+	lastChars   []model.CharAggregate
+	// drilling is set while the next text focuses on the worst characters of the last one.
+	drilling  bool
+	shareCard string
+	statusMsg string
+	// weakSuggestion is the weak character behind the one-time suggestion to turn on
+	// weak-char focus; weakSuggested is set once no more suggestions are checked.
+	weakSuggestion *model.CharAggregate
+	weakSuggested  bool
+
+	announcements     []string
+	announcedProgress int
+
+	// keyTimes are the arrival times of the latest key events, for paste detection.
+	keyTimes []time.Time
+	// notice is shown in the status bar until the next accepted key.
+	notice string
+
+	watch    *configWatch
+	hooks    *sessionHooks
+	recorder *replayRecorder
+	// keyLog holds the keystrokes of the text for the session with Config.StoreKeys.
+	keyLog []replay.Key
 }
 
+// Practice UI styles; SetTheme sets them from the theme colors.
 var (
-	correctStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#F0F0F0"))
-	incorrectStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF4D4F"))
-	pendingStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#8C8C8C"))
-	currentWordStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#C89A3A"))
-	cursorStyle      = pendingStyle.Underline(true)
-	footerStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#6E6E6E"))
+	correctStyle     lipgloss.Style
+	incorrectStyle   lipgloss.Style
+	pendingStyle     lipgloss.Style
+	currentWordStyle lipgloss.Style
+	cursorStyle      lipgloss.Style
+	footerStyle      lipgloss.Style
 )
 
-// NewModel constructs a typing TUI model.
-func NewModel(cfg model.Config, store *store.Store, gen *generator.Generator, words []string, wordListPath string, punctSet []rune, weakSet map[rune]struct{}, weakNoticePrinted bool) *Model {
+func init() {
+	SetTheme(model.Theme{})
+}
+
+// defaultContentWidth is the share of the terminal width used for the text.
+const defaultContentWidth = 0.70
+
+// SetTheme replaces the practice UI colors. Call it before starting the program.
+// Under NO_COLOR pending text is faint and the current word bold, and mistakes are
+// underlined whenever the palette asks for more than color.
+func SetTheme(theme model.Theme) {
+	colors := uitheme.Resolve(theme)
+	correctStyle = lipgloss.NewStyle().Foreground(colors.Text)
+	incorrectStyle = lipgloss.NewStyle().Foreground(colors.Error).Underline(colors.MarkErrors)
+	pendingStyle = lipgloss.NewStyle().Foreground(colors.Pending).Faint(colors.Mono)
+	currentWordStyle = lipgloss.NewStyle().Foreground(colors.Accent).Bold(colors.Mono)
+	alertStyle = lipgloss.NewStyle().Foreground(colors.Error).Bold(colors.Mono)
+	cursorStyle = pendingStyle.Underline(true)
+	footerStyle = lipgloss.NewStyle().Foreground(colors.Muted).Faint(colors.Mono)
+	ghostStyle = lipgloss.NewStyle().Foreground(colors.Muted).Faint(true)
+	resultsTitleStyle = lipgloss.NewStyle().Foreground(colors.Accent).Bold(true)
+}
+
+// NewModel constructs a typing TUI model. gen must be the generator behind source so
+// each text can be reseeded and its seed recorded.
+func NewModel(cfg model.Config, store *store.Store, gen *generator.Generator, source generator.TextSource, wordListPath string, weakSet map[rune]struct{}, weakNoticePrinted bool) *Model {
 	m := &Model{
 		config:            cfg,
 		store:             store,
 		gen:               gen,
-		words:             words,
+		source:            source,
 		wordListPath:      wordListPath,
-		punctSet:          punctSet,
 		weakSet:           weakSet,
 		weakNoticePrinted: weakNoticePrinted,
+		zen:               cfg.Zen,
 	}
+	m.loadMistakes()
+	m.refreshCoverage()
+	m.refreshReviews()
 	m.resetSession()
 	m.loadFooterStats()
 	return m
@@ -88,7 +184,10 @@ func NewModel(cfg model.Config, store *store.Store, gen *generator.Generator, wo
 
 // Init implements tea.Model.
 func (m *Model) Init() tea.Cmd {
-	return nil
+	if m.watch != nil {
+		return tea.Batch(sampleTick(), configTick())
+	}
+	return sampleTick()
 }
 
 // Update implements tea.Model.
@@ -98,22 +197,64 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
+	case sampleTickMsg:
+		if m.started {
+			m.samples.extend(time.Since(m.startedAt))
+		}
+		return m, sampleTick()
+	case weakSetMsg:
+		m.handleWeakSet(msg)
+		return m, nil
+	case configTickMsg:
+		m.checkConfig()
+		if !m.started && !m.showResults && m.watch.pending != nil {
+			m.resetSession()
+		}
+		return m, configTick()
 	case tea.KeyMsg:
+		if m.showResults {
+			return m.updateResults(msg)
+		}
+		if m.showWeakInfo {
+			return m.updateWeakInfo(msg)
+		}
 		switch msg.Type {
+		case tea.KeyTab:
+			if m.weakSuggestion != nil {
+				return m, m.acceptWeakSuggestion()
+			}
+			m.openWeakInfo()
+			return m, nil
+		case tea.KeyEsc:
+			m.zen = !m.zen
+			return m, nil
 		case tea.KeyCtrlC:
+			if m.config.SaveIncomplete && len(m.input) > 0 {
+				m.finishSession(true)
+			}
 			return m, tea.Quit
 		case tea.KeyBackspace, tea.KeyDelete:
 			m.handleBackspace()
 			return m, nil
-		case tea.KeySpace:
-			m.handleRunes([]rune{' '})
-			return m, nil
-		case tea.KeyRunes:
-			m.handleRunes(msg.Runes)
-			return m, nil
+		case tea.KeySpace, tea.KeyRunes:
+			if m.isPaste(msg, time.Now()) {
+				m.rejectPaste(msg)
+				return m, m.flushAnnouncements()
+			}
+			m.notice = ""
+			if msg.Type == tea.KeySpace {
+				m.handleRunes([]rune{' '})
+			} else {
+				m.handleRunes(msg.Runes)
+			}
 		default:
 			return m, nil
 		}
+		cmd := tea.Batch(m.flushAnnouncements(), m.loadWeakSet())
+		if m.replayDone() {
+			return m, tea.Sequence(cmd, tea.Quit)
+		}
+		return m, cmd
 	default:
 		return m, nil
 	}
@@ -121,109 +262,218 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model.
 func (m *Model) View() string {
-	if len(m.targetRunes) == 0 {
+	if m.showResults {
+		return m.renderResults()
+	}
+	if len(m.target) == 0 {
 		return ""
 	}
+	if m.showWeakInfo {
+		return m.renderWeakInfo()
+	}
+	if m.config.Accessible {
+		return m.renderAccessible()
+	}
 	cursorIndex := -1
-	if len(m.inputRunes) < len(m.targetRunes) {
-		cursorIndex = len(m.inputRunes)
+	if len(m.input) < len(m.target) {
+		cursorIndex = len(m.input)
+	}
+	styledChars := buildStyledChars(m.target, m.input, m.wordRanges(), cursorIndex)
+	footer := ""
+	if !m.zen {
+		footer = m.renderFooter()
 	}
-	styledRunes := buildStyledRunes(m.targetRunes, m.inputRunes, cursorIndex)
-	if m.width == 0 || m.height == 0 {
-		return renderStyledRunes(styledRunes)
+	return layoutText(styledChars, cursorIndex, m.width, m.height, m.config.ContentWidth, footer, bidiFor(m.target, m.config.Bidi))
+}
+
+// layoutText centers the wrapped text in a width x height screen with footer on the last row.
+// Right-to-left text is right-aligned within the content width and reordered per bidi.
+// Text taller than the screen scrolls to keep the line at cursorIndex in the middle, so
+// rewrapping after a resize never leaves the cursor off-screen.
+func layoutText(styledChars []styledChar, cursorIndex, width, height int, fraction float64, footer string, bidi bidiLayout) string {
+	if width == 0 || height == 0 {
+		return renderStyledChars(styledChars)
+	}
+	if fraction <= 0 || fraction > 1 {
+		fraction = defaultContentWidth
 	}
-	contentWidth := int(float64(m.width) * 0.70)
+	contentWidth := int(float64(width) * fraction)
 	if contentWidth < 1 {
 		contentWidth = 1
 	}
-	wrapped := wrapStyledRunes(styledRunes, contentWidth)
-	content := lipgloss.NewStyle().Width(contentWidth).Render(wrapped)
-	footer := m.renderFooter()
-	if footer == "" || m.height < 3 {
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+	var order func([]styledChar) []styledChar
+	align := lipgloss.Left
+	if bidi != bidiNone {
+		align = lipgloss.Right
+	}
+	if bidi == bidiReorder {
+		order = visualOrder
+	}
+	withFooter := footer != "" && height >= 3
+	bodyHeight := height
+	if withFooter {
+		bodyHeight = height - 1
+	}
+	lines := wrapLines(styledChars, contentWidth)
+	start, end := scrollWindow(len(lines), cursorLine(lines, cursorIndex), bodyHeight)
+	wrapped := renderLines(lines[start:end], order)
+	content := lipgloss.NewStyle().Width(contentWidth).Align(align).Render(wrapped)
+	if !withFooter {
+		return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
 	}
-	bodyHeight := m.height - 1
-	body := lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center, content)
-	footerLine := lipgloss.Place(m.width, 1, lipgloss.Center, lipgloss.Center, footer)
+	body := lipgloss.Place(width, bodyHeight, lipgloss.Center, lipgloss.Center, content)
+	footerLine := lipgloss.Place(width, 1, lipgloss.Center, lipgloss.Center, footer)
 	return body + "\n" + footerLine
 }
 
 func (m *Model) handleBackspace() {
-	if len(m.inputRunes) == 0 {
+	if m.pendingCompose != "" {
+		m.pendingCompose = ""
 		return
 	}
-	m.inputRunes = m.inputRunes[:len(m.inputRunes)-1]
+	if len(m.input) == 0 {
+		return
+	}
+	m.input = m.input[:len(m.input)-1]
+	m.recordKey("", true)
 }
 
 func (m *Model) handleRunes(runes []rune) {
-	for _, r := range runes {
-		if len(m.inputRunes) >= len(m.targetRunes) {
+	for _, typed := range m.composeInput(runes) {
+		if len(m.input) >= len(m.target) {
 			return
 		}
 		if !m.started {
 			m.started = true
 			m.startedAt = time.Now()
+			m.firstKeyMs = m.startedAt.Sub(m.shownAt).Milliseconds()
+		}
+		pos := len(m.input)
+		expected := m.target[pos]
+		variant := typed
+		typed = m.matchEquivalent(expected, variant)
+		m.recordGap(pos, time.Now())
+		m.input = append(m.input, typed)
+		m.recordKey(typed, false)
+		if typed != variant {
+			// An equivalent character counts as correct under the variant actually typed.
+			m.updateStats(variant, variant)
+		} else {
+			m.updateStats(expected, typed)
+		}
+		if typed != expected {
+			m.markMissedWord(pos)
+		}
+		m.announceKey(pos, expected, typed)
+		m.recordHit(typed == expected)
+		if m.enforceFloor() {
+			return
 		}
-		pos := len(m.inputRunes)
-		expected := m.targetRunes[pos]
-		m.inputRunes = append(m.inputRunes, r)
-		m.updateStats(expected, r)
-		if len(m.inputRunes) == len(m.targetRunes) {
-			m.finishSession()
+		if len(m.input) == len(m.target) {
+			m.finishSession(false)
+			m.announce(i18n.T("accessible.done", m.lastSpeed, m.speedUnit(), m.lastAcc*100))
+			if m.finishReplay() {
+				return
+			}
+			if m.config.ResultsScreen {
+				m.showResults = true
+				return
+			}
 			m.resetSession()
 		}
 	}
 }
 
 func (m *Model) loadFooterStats() {
+	m.loadTodayPractice()
 	ctx := context.Background()
-	sessions, err := m.store.ListSessions(ctx, model.StatsConfig{Lang: m.config.Lang})
+	latest, err := m.store.ListSessionsPage(ctx, model.StatsConfig{Lang: m.config.Lang}, 0, footerTrendSessions)
 	if err != nil {
-		logErrf("failed to load session stats: %v\n", err)
+		logErrln(i18n.T("practice.err.load_stats", err))
 		return
 	}
-	if len(sessions) == 0 {
+	if len(latest) == 0 {
 		return
 	}
-	last := sessions[len(sessions)-1]
-	wpm, _, acc := statsPkg.SessionMetrics(last.Correct, last.Incorrect, last.DurationMs)
-	m.lastWPM = wpm
+	totals, err := m.store.GetTotals(ctx, m.config.Lang)
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_stats", err))
+		return
+	}
+	last := latest[0]
+	_, _, acc := statsPkg.SessionMetrics(last.Correct, last.Incorrect, last.DurationMs)
+	m.lastSpeed = statsPkg.SessionSpeed(m.config.WPMFormula, last)
 	m.lastAcc = acc
 	m.hasLast = true
+	m.setRecentSpeeds(latest)
 
-	for _, s := range sessions {
-		m.allCorrect += s.Correct
-		m.allIncorrect += s.Incorrect
-		m.allDuration += s.DurationMs
-	}
+	m.allCorrect = totals.Correct
+	m.allIncorrect = totals.Incorrect
+	m.allWords = totals.Words
+	m.allDuration = totals.DurationMs
 	m.recomputeAllTime()
 }
 
 func (m *Model) recomputeAllTime() {
-	wpm, _, acc := statsPkg.SessionMetrics(m.allCorrect, m.allIncorrect, m.allDuration)
-	m.allWPM = wpm
+	_, _, acc := statsPkg.SessionMetrics(m.allCorrect, m.allIncorrect, m.allDuration)
+	m.allSpeed = statsPkg.Speed(m.config.WPMFormula, m.allCorrect, m.allWords, m.allDuration)
 	m.allAcc = acc
 }
 
+// speedUnit is the unit of speeds under the configured WPM formula.
+func (m *Model) speedUnit() string {
+	return statsPkg.SpeedUnit(m.config.WPMFormula)
+}
+
 func (m *Model) renderFooter() string {
-	if len(m.targetRunes) == 0 {
+	if len(m.target) == 0 {
 		return ""
 	}
 	progress := 0
-	if len(m.targetRunes) > 0 {
-		progress = int(float64(len(m.inputRunes)) / float64(len(m.targetRunes)) * 100)
+	if len(m.target) > 0 {
+		progress = int(float64(len(m.input)) / float64(len(m.target)) * 100)
+	}
+	segments := []string{i18n.T("practice.progress", progress)}
+	style := footerStyle
+	if m.alerting {
+		acc, _ := m.rollingAccuracy()
+		segments = append(segments, i18n.T("practice.slow_down", acc*100))
+		style = alertStyle
 	}
-	segments := []string{fmt.Sprintf("Progress %d%%", progress)}
 	if m.hasLast {
-		segments = append(segments, fmt.Sprintf("Last %.1f WPM · %.1f%%", m.lastWPM, m.lastAcc*100))
+		segments = append(segments, i18n.T("practice.last", m.lastSpeed, m.speedUnit(), m.lastAcc*100))
+	}
+	segments = append(segments, i18n.T("practice.all_time", m.allSpeed, m.speedUnit(), m.allAcc*100))
+	if trend := m.trendSegment(); trend != "" {
+		segments = append(segments, trend)
+	}
+	if m.config.Daily {
+		segments = append(segments, i18n.T("practice.daily", generator.ChallengeDay(time.Now())))
+	}
+	if goal := m.goalSegment(); goal != "" {
+		segments = append(segments, goal)
+	}
+	if status := m.reloadStatus(); status != "" {
+		segments = append(segments, status)
+	}
+	if suggestion := m.weakSuggestionSegment(); suggestion != "" {
+		segments = append(segments, suggestion)
+	}
+	if m.notice != "" {
+		segments = append(segments, m.notice)
 	}
-	segments = append(segments, fmt.Sprintf("All-time %.1f WPM · %.1f%%", m.allWPM, m.allAcc*100))
 	footer := strings.Join(segments, "  ")
-	return footerStyle.Render(footer)
+	return style.Render(footer)
 }
 
-func (m *Model) updateStats(expected, typed rune) {
-	if expected == ' ' {
+func (m *Model) updateStats(expected, typed string) {
+	if expected == " " {
+		// Space latency runs from the last correct character, which stays the
+		// baseline of the next word's first character.
+		if typed == " " && !m.prevCorrectAt.IsZero() {
+			m.spaceLatencySumMs += time.Since(m.prevCorrectAt).Milliseconds()
+			m.spaceLatencyCount++
+		}
 		return
 	}
 	entry := m.charEntry(expected)
@@ -231,6 +481,9 @@ func (m *Model) updateStats(expected, typed rune) {
 		m.correctNonSpace++
 		entry.correct++
 		now := time.Now()
+		if m.started {
+			m.samples.add(now.Sub(m.startedAt))
+		}
 		if !m.prevCorrectAt.IsZero() {
 			delta := now.Sub(m.prevCorrectAt)
 			entry.latencySumMs += delta.Milliseconds()
@@ -243,9 +496,9 @@ func (m *Model) updateStats(expected, typed rune) {
 	entry.incorrect++
 }
 
-func (m *Model) charEntry(expected rune) *charStat {
+func (m *Model) charEntry(expected string) *charStat {
 	if m.charStats == nil {
-		m.charStats = map[rune]*charStat{}
+		m.charStats = map[string]*charStat{}
 	}
 	entry, ok := m.charStats[expected]
 	if !ok {
@@ -256,33 +509,59 @@ func (m *Model) charEntry(expected rune) *charStat {
 }
 
 func (m *Model) resetSession() {
-	m.inputRunes = nil
+	m.input = nil
+	m.pendingCompose = ""
 	m.started = false
+	m.shownAt = time.Now()
 	m.startedAt = time.Time{}
 	m.prevCorrectAt = time.Time{}
+	m.firstKeyMs = 0
+	m.spaceLatencySumMs = 0
+	m.spaceLatencyCount = 0
 	m.correctNonSpace = 0
 	m.incorrectNonSpace = 0
-	m.charStats = map[rune]*charStat{}
-
-	text := m.generateText()
-	m.targetRunes = []rune(text)
+	m.samples = nil
+	m.charStats = map[string]*charStat{}
+	m.missedWords = nil
+	m.gaps = nil
+	m.lastKeyAt = time.Time{}
+	m.hits = nil
+	m.alerting = false
+	m.announcedProgress = 0
+	m.resetReplay()
+
+	m.applyPendingReload()
+	m.gen.Reseed(m.textSeed(time.Now()))
+	// Typed input is composed to NFC, so joinWords normalizes the text too.
+	m.target, m.words = joinWords(m.source.Next(m.config.Words))
 }
 
-func (m *Model) generateText() string {
-	var words []string
-	if m.config.FocusWeak && len(m.weakSet) > 0 {
-		words = m.gen.GenerateWeighted(m.words, m.config.Words, m.config.CapsPct, m.config.PunctPct, m.punctSet, m.weakSet, m.config.WeakFactor)
-	} else {
-		words = m.gen.Generate(m.words, m.config.Words, m.config.CapsPct, m.config.PunctPct, m.punctSet)
+// textSeed is the seed of the next text: the daily challenge of now with
+// Config.Daily, otherwise a fresh one.
+func (m *Model) textSeed(now time.Time) int64 {
+	if m.config.Daily {
+		return generator.ChallengeSeed(generator.ChallengeDay(now))
 	}
-	return strings.Join(words, " ")
+	return now.UnixNano()
 }
 
-func (m *Model) finishSession() {
+// targetText joins the text back into a string.
+func (m *Model) targetText() string {
+	return strings.Join(m.target, "")
+}
+
+// inputText joins the input back into a string.
+func (m *Model) inputText() string {
+	return strings.Join(m.input, "")
+}
+
+// finishSession saves the current session; incomplete marks a text abandoned before its end.
+func (m *Model) finishSession(incomplete bool) {
 	if !m.started {
 		return
 	}
 	endedAt := time.Now()
+	m.samples.extend(endedAt.Sub(m.startedAt))
 	stats := model.SessionStats{
 		StartedAt:         m.startedAt,
 		EndedAt:           endedAt,
@@ -292,15 +571,47 @@ func (m *Model) finishSession() {
 		PunctPct:          m.config.PunctPct,
 		PunctSet:          m.config.PunctSet,
 		WordListPath:      m.wordListPath,
+		WordListMeta:      m.config.WordListMeta,
+		WordListID:        m.config.WordListID,
 		CorrectNonSpace:   m.correctNonSpace,
 		IncorrectNonSpace: m.incorrectNonSpace,
 		DurationMs:        endedAt.Sub(m.startedAt).Milliseconds(),
+		FirstKeyMs:        m.firstKeyMs,
+		SpaceLatencySumMs: m.spaceLatencySumMs,
+		SpaceLatencyCount: m.spaceLatencyCount,
+		Mode:              m.config.Mode,
+		FocusWeak:         (m.config.FocusWeak || m.drilling) && len(m.weakSet) > 0,
+		WeakSet:           formatWeakSet(m.weakSet),
+		Seed:              m.gen.Seed(),
+		WordsTyped:        m.wordsTyped(),
+		AppVersion:        version.String(),
+		Keyboard:          m.config.Keyboard,
+		Layout:            m.config.Layout,
+		Incomplete:        incomplete,
+		WPMFormula:        m.config.WPMFormula,
+		Samples:           m.samples,
+		ParamsHash:        statsPkg.ParamsHash(m.config),
+		Scoring:           statsPkg.ScoringRules(m.config.WPMFormula, m.equivalenceClasses()),
+		Env:               m.sessionEnv(),
+	}
+	if m.config.StoreText {
+		var targetCut, typedCut bool
+		stats.TargetText, targetCut = capText(m.targetText(), m.config.StoreTextMax)
+		stats.TypedText, typedCut = capText(m.inputText(), m.config.StoreTextMax)
+		stats.TextTruncated = targetCut || typedCut
+	}
+	// A keystroke log replays only against the full target text.
+	if keys := m.keystrokes(); keys != "" {
+		if target, cut := capText(m.targetText(), m.config.StoreTextMax); !cut {
+			stats.TargetText = target
+			stats.Keystrokes = keys
+		}
 	}
 
 	charStats := make([]model.CharStats, 0, len(m.charStats))
 	for ch, entry := range m.charStats {
 		charStats = append(charStats, model.CharStats{
-			Char:         string(ch),
+			Char:         ch,
 			Correct:      entry.correct,
 			Incorrect:    entry.incorrect,
 			LatencySumMs: entry.latencySumMs,
@@ -309,20 +620,108 @@ func (m *Model) finishSession() {
 	}
 
 	ctx := context.Background()
-	if _, err := m.store.InsertSession(ctx, stats, charStats); err != nil {
-		logErrf("failed to save session: %v\n", err)
+	id, err := m.store.InsertSession(ctx, stats, charStats)
+	if err != nil {
+		logErrln(i18n.T("practice.err.save_session", err))
+	}
+	if incomplete {
+		return
+	}
+	if err == nil {
+		m.runSessionHook(id, stats, charStats)
+	}
+	m.lastSession = stats
+	m.lastChars = m.lastChars[:0]
+	for _, cs := range charStats {
+		m.lastChars = append(m.lastChars, model.CharAggregate{Char: cs.Char, Correct: cs.Correct, Incorrect: cs.Incorrect, LatencySumMs: cs.LatencySumMs, LatencyCount: cs.LatencyCount})
 	}
-	wpm, _, acc := statsPkg.SessionMetrics(stats.CorrectNonSpace, stats.IncorrectNonSpace, stats.DurationMs)
-	m.lastWPM = wpm
+	_, _, acc := statsPkg.SessionMetrics(stats.CorrectNonSpace, stats.IncorrectNonSpace, stats.DurationMs)
+	m.lastSpeed = statsPkg.Speed(stats.WPMFormula, stats.CorrectNonSpace, stats.WordsTyped, stats.DurationMs)
 	m.lastAcc = acc
 	m.hasLast = true
+	m.pushRecentSpeed(m.lastSpeed)
+	m.addTodayPractice(endedAt, stats.DurationMs)
 	m.allCorrect += stats.CorrectNonSpace
 	m.allIncorrect += stats.IncorrectNonSpace
+	m.allWords += statsPkg.CountedWords(stats.CorrectNonSpace, stats.WordsTyped)
 	m.allDuration += stats.DurationMs
 	m.recomputeAllTime()
 
-	if m.config.FocusWeak {
-		m.refreshWeakSet()
+	m.endDrill()
+	m.recordMistakes(endedAt)
+	m.applyMistakes()
+	m.weakStale = m.config.FocusWeak || m.config.Coverage > 0
+	m.suggestWeakFocus()
+	if m.config.SRS {
+		m.reviewSRS(endedAt, charStats)
+		m.refreshReviews()
+	}
+}
+
+// capText trims s to at most maxBytes without splitting a rune; maxBytes <= 0 means no cap.
+func capText(s string, maxBytes int) (string, bool) {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s, false
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut], true
+}
+
+func formatWeakSet(weakSet map[rune]struct{}) string {
+	runes := make([]rune, 0, len(weakSet))
+	for r := range weakSet {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return string(runes)
+}
+
+// weakSetMsg carries weak-character stats queried off the UI goroutine.
+type weakSetMsg struct {
+	lang string
+	aggs []model.CharAggregate
+	err  error
+}
+
+// loadWeakSet queries the weak characters in the background once a finished session made
+// the weak set stale, so saving a session does not delay the next text.
+func (m *Model) loadWeakSet() tea.Cmd {
+	if !m.weakStale {
+		return nil
+	}
+	m.weakStale = false
+	st, window, lang := m.store, m.config.WeakWindow, m.config.Lang
+	return func() tea.Msg {
+		aggs, err := st.GetWeakChars(context.Background(), window, lang)
+		return weakSetMsg{lang: lang, aggs: aggs, err: err}
+	}
+}
+
+// handleWeakSet applies a weak set loaded by loadWeakSet. A text nobody has started
+// typing yet is regenerated so it already uses the new set.
+func (m *Model) handleWeakSet(msg weakSetMsg) {
+	if (!m.config.FocusWeak && m.config.Coverage <= 0) || msg.lang != m.config.Lang {
+		// The config was reloaded while the query ran.
+		return
+	}
+	if msg.err != nil {
+		logErrln(i18n.T("practice.err.load_weak", msg.err))
+		return
+	}
+	m.setCoverage(msg.aggs)
+	if m.drilling {
+		// The drill keeps its own weak set until its text is done.
+		m.applyWeakSet()
+	} else if m.config.FocusWeak {
+		m.setWeakChars(msg.aggs)
+	} else {
+		m.applyWeakSet()
+	}
+	if !m.started && !m.showResults && !m.replayDone() {
+		m.resetSession()
 	}
 }
 
@@ -330,18 +729,35 @@ func (m *Model) refreshWeakSet() {
 	ctx := context.Background()
 	aggs, err := m.store.GetWeakChars(ctx, m.config.WeakWindow, m.config.Lang)
 	if err != nil {
-		logErrf("failed to load weak chars: %v\n", err)
+		logErrln(i18n.T("practice.err.load_weak", err))
 		return
 	}
+	m.setWeakChars(aggs)
+}
+
+// setWeakChars picks the weak set from weak-character stats and passes it to the source.
+func (m *Model) setWeakChars(aggs []model.CharAggregate) {
+	m.weakAggs = aggs
 	if len(aggs) == 0 {
 		if !m.weakNoticePrinted {
-			logErrln("no stats available for weak-char focus yet; using normal generator")
+			logErrln(i18n.T("practice.no_weak_stats"))
 			m.weakNoticePrinted = true
 		}
 		m.weakSet = map[rune]struct{}{}
+		m.applyWeakSet()
 		return
 	}
 	m.weakSet = statsPkg.SelectWeakChars(aggs, m.config.WeakTop)
+	m.applyWeakSet()
+}
+
+// applyWeakSet passes the weak set, review words and mistake bank to sources that use them.
+func (m *Model) applyWeakSet() {
+	if weakAware, ok := m.source.(generator.WeakAware); ok {
+		weakAware.SetWeakSet(m.biasSet())
+	}
+	m.applyReviewWords()
+	m.applyMistakes()
 }
 
 func logErrf(format string, args ...any) {
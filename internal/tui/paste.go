@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+)
+
+const (
+	// maxBurstRunes is the most runes one key event may carry before it counts as a
+	// paste; input methods commit a few characters at once, pastes many more.
+	maxBurstRunes = 16
+	// burstKeys key events within burstWindow (240 a second) are faster than anyone
+	// types; terminals without bracketed paste deliver pasted text that way.
+	burstKeys   = 12
+	burstWindow = 50 * time.Millisecond
+)
+
+// isPaste reports whether the key event at now is pasted text rather than typing:
+// a bracketed paste, an oversized burst of runes, or part of a run of events arriving
+// faster than humanly possible.
+func (m *Model) isPaste(msg tea.KeyMsg, now time.Time) bool {
+	if msg.Paste || len(msg.Runes) > maxBurstRunes {
+		return true
+	}
+	m.keyTimes = append(m.keyTimes, now)
+	if len(m.keyTimes) > burstKeys {
+		m.keyTimes = m.keyTimes[1:]
+	}
+	return len(m.keyTimes) == burstKeys && now.Sub(m.keyTimes[0]) < burstWindow
+}
+
+// rejectPaste drops pasted input. A paste caught by its speed has already been partly
+// scored, so the text is discarded without saving and a new one starts.
+func (m *Model) rejectPaste(msg tea.KeyMsg) {
+	m.keyTimes = nil
+	m.notice = i18n.T("practice.paste_rejected")
+	m.announce(m.notice)
+	if !msg.Paste && len(msg.Runes) <= maxBurstRunes && m.started {
+		m.resetSession()
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestBracketedPasteIsIgnored(t *testing.T) {
+	m := &Model{target: grapheme.Split("ab cd")}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ab cd"), Paste: true})
+	if len(m.input) != 0 || m.started {
+		t.Fatalf("expected pasted text to be ignored, got input %q", m.inputText())
+	}
+	if !strings.Contains(m.renderFooter(), "paste ignored") {
+		t.Fatalf("expected a paste notice, got %q", m.renderFooter())
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.inputText() != "a" || m.notice != "" {
+		t.Fatalf("expected typing to continue and clear the notice, got input %q notice %q", m.inputText(), m.notice)
+	}
+}
+
+func TestIsPasteDetectsBursts(t *testing.T) {
+	m := &Model{}
+	if !m.isPaste(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(strings.Repeat("a", maxBurstRunes+1))}, time.Now()) {
+		t.Fatalf("expected an oversized burst to count as a paste")
+	}
+	if m.isPaste(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("東京タワー")}, time.Now()) {
+		t.Fatalf("expected an input method commit to count as typing")
+	}
+
+	start := time.Now()
+	m = &Model{}
+	for i := 0; i < 3*burstKeys; i++ {
+		if m.isPaste(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}, start.Add(time.Duration(i)*10*time.Millisecond)) {
+			t.Fatalf("expected 100 keys a second to count as typing (key %d)", i)
+		}
+	}
+	m = &Model{}
+	pasted := false
+	for i := 0; i < burstKeys; i++ {
+		pasted = m.isPaste(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}, start.Add(time.Duration(i)*time.Millisecond))
+	}
+	if !pasted {
+		t.Fatalf("expected %d keys in %s to count as a paste", burstKeys, burstWindow)
+	}
+}
+
+func TestFastPasteDiscardsText(t *testing.T) {
+	gen := generator.New()
+	m := &Model{
+		config: model.Config{Words: 2},
+		gen:    gen,
+		source: generator.NewWordSource(gen, []string{"abcdefgh"}, generator.Style{}, 0),
+	}
+	m.resetSession()
+	for _, r := range "abcdefgh abc" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if m.started || len(m.input) != 0 || m.notice == "" {
+		t.Fatalf("expected the pasted text to be discarded, got input %q", m.inputText())
+	}
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/replay"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// playerFrameInterval is how often the player advances the replay.
+const playerFrameInterval = 30 * time.Millisecond
+
+type playerTickMsg time.Time
+
+// Player replays a recording with its original keystroke timings.
+type Player struct {
+	rec          replay.Recording
+	target       []string
+	speed        float64
+	contentWidth float64
+	bidi         string
+
+	width  int
+	height int
+
+	keys    int
+	elapsed time.Duration
+	last    time.Time
+	paused  bool
+}
+
+// NewPlayer constructs a replay model; speed scales the recorded timings (2 plays twice
+// as fast), contentWidth is the share of the terminal used for the text and bidi is
+// model.BidiApp or model.BidiTerminal.
+func NewPlayer(rec replay.Recording, speed, contentWidth float64, bidi string) *Player {
+	if speed <= 0 {
+		speed = 1
+	}
+	return &Player{
+		rec:          rec,
+		target:       grapheme.Split(rec.Target),
+		speed:        speed,
+		contentWidth: contentWidth,
+		bidi:         bidi,
+	}
+}
+
+// Init implements tea.Model.
+func (p *Player) Init() tea.Cmd {
+	p.last = time.Now()
+	return playerTick()
+}
+
+func playerTick() tea.Cmd {
+	return tea.Tick(playerFrameInterval, func(t time.Time) tea.Msg { return playerTickMsg(t) })
+}
+
+// Update implements tea.Model.
+func (p *Player) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+		return p, nil
+	case playerTickMsg:
+		now := time.Time(msg)
+		if !p.paused {
+			p.advance(now.Sub(p.last))
+		}
+		p.last = now
+		if p.done() {
+			return p, nil
+		}
+		return p, playerTick()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return p, tea.Quit
+		case " ":
+			p.paused = !p.paused
+			return p, nil
+		case "r":
+			restart := p.done()
+			p.keys = 0
+			p.elapsed = 0
+			p.paused = false
+			p.last = time.Now()
+			if restart {
+				return p, playerTick()
+			}
+			return p, nil
+		}
+		return p, nil
+	default:
+		return p, nil
+	}
+}
+
+// advance moves the replay clock by d scaled by the speed and applies due keystrokes.
+func (p *Player) advance(d time.Duration) {
+	p.elapsed += time.Duration(float64(d) * p.speed)
+	for p.keys < len(p.rec.Keys) && p.rec.Keys[p.keys].AtMs <= p.elapsed.Milliseconds() {
+		p.keys++
+	}
+}
+
+func (p *Player) done() bool {
+	return p.keys >= len(p.rec.Keys)
+}
+
+// View implements tea.Model.
+func (p *Player) View() string {
+	input := p.rec.InputAt(p.keys)
+	cursorIndex := -1
+	if len(input) < len(p.target) {
+		cursorIndex = len(input)
+	}
+	styledChars := buildStyledChars(p.target, input, findWords(p.target), cursorIndex)
+	return layoutText(styledChars, cursorIndex, p.width, p.height, p.contentWidth, p.renderFooter(), bidiFor(p.target, p.bidi))
+}
+
+func (p *Player) renderFooter() string {
+	var atMs int64
+	if p.keys > 0 {
+		atMs = p.rec.Keys[p.keys-1].AtMs
+	}
+	correct, incorrect := p.rec.CountsAt(p.keys)
+	wpm, _, acc := statsPkg.SessionMetrics(correct, incorrect, atMs)
+	segments := []string{i18n.T("replay.status", float64(atMs)/1000, wpm, acc*100)}
+	if p.speed != 1 {
+		segments = append(segments, fmt.Sprintf("%gx", p.speed))
+	}
+	switch {
+	case p.done():
+		segments = append(segments, i18n.T("replay.done"))
+	case p.paused:
+		segments = append(segments, i18n.T("replay.paused"))
+	default:
+		segments = append(segments, i18n.T("replay.help"))
+	}
+	return footerStyle.Render(strings.Join(segments, "  "))
+}
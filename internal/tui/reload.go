@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// configPollInterval is how often the config file is checked for changes.
+const configPollInterval = 2 * time.Second
+
+// Reload is a practice setup rebuilt from a changed config file.
+type Reload struct {
+	Config       model.Config
+	Source       generator.TextSource
+	WordListPath string
+}
+
+// Reloader rebuilds the practice setup; flags given on the command line still win.
+type Reloader func() (Reload, error)
+
+type configWatch struct {
+	path    string
+	reload  Reloader
+	modTime time.Time
+	size    int64
+	pending *Reload
+	status  string
+}
+
+type configTickMsg struct{}
+
+// WatchConfig polls the config file at path and, when it changes, applies the result
+// of reload starting with the next text.
+func (m *Model) WatchConfig(path string, reload Reloader) {
+	m.watch = &configWatch{path: path, reload: reload}
+	m.watch.modTime, m.watch.size = statConfig(path)
+}
+
+func statConfig(path string) (time.Time, int64) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, -1
+	}
+	return info.ModTime(), info.Size()
+}
+
+func configTick() tea.Cmd {
+	return tea.Tick(configPollInterval, func(time.Time) tea.Msg { return configTickMsg{} })
+}
+
+// checkConfig rebuilds the setup when the config file changed since the last poll.
+func (m *Model) checkConfig() {
+	modTime, size := statConfig(m.watch.path)
+	if modTime.Equal(m.watch.modTime) && size == m.watch.size {
+		return
+	}
+	m.watch.modTime, m.watch.size = modTime, size
+	reload, err := m.watch.reload()
+	if err != nil {
+		m.watch.pending = nil
+		m.watch.status = i18n.T("practice.config_not_reloaded", err)
+		return
+	}
+	m.watch.pending = &reload
+	m.watch.status = ""
+}
+
+// applyPendingReload swaps in a reloaded setup before a new text is generated.
+func (m *Model) applyPendingReload() {
+	if m.watch == nil || m.watch.pending == nil {
+		return
+	}
+	reload := *m.watch.pending
+	m.watch.pending = nil
+	langChanged := reload.Config.Lang != m.config.Lang
+	footerChanged := langChanged || reload.Config.WPMFormula != m.config.WPMFormula
+	m.config = reload.Config
+	m.source = reload.Source
+	m.wordListPath = reload.WordListPath
+	SetTheme(m.config.Theme)
+	if langChanged {
+		m.loadMistakes()
+	}
+	m.refreshCoverage()
+	if m.config.FocusWeak {
+		m.refreshWeakSet()
+	} else {
+		m.weakSet = map[rune]struct{}{}
+	}
+	m.refreshReviews()
+	if footerChanged {
+		m.hasLast = false
+		m.allCorrect, m.allIncorrect, m.allWords, m.allDuration = 0, 0, 0, 0
+		m.allSpeed, m.allAcc = 0, 0
+		m.recentSpeeds = nil
+		m.loadFooterStats()
+	}
+	m.watch.status = i18n.T("practice.config_reloaded")
+}
+
+// reloadStatus is shown in the status bar until typing starts.
+func (m *Model) reloadStatus() string {
+	if m.watch == nil || m.started {
+		return ""
+	}
+	return m.watch.status
+}
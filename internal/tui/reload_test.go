@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestConfigReloadAppliesOnNextText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[practice]\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	gen := generator.New()
+	m := &Model{
+		config: model.Config{Words: 1},
+		gen:    gen,
+		source: generator.NewWordSource(gen, []string{"ab"}, generator.Style{}, 0),
+	}
+	reloads := 0
+	m.WatchConfig(path, func() (Reload, error) {
+		reloads++
+		return Reload{
+			Config: model.Config{Words: 2},
+			Source: generator.NewWordSource(gen, []string{"cd"}, generator.Style{}, 0),
+		}, nil
+	})
+	m.resetSession()
+	m.handleRunes([]rune("a"))
+
+	m.Update(configTickMsg{})
+	if reloads != 0 {
+		t.Fatalf("expected no reload for an unchanged file, got %d", reloads)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.WriteFile(path, []byte("[practice]\nwords = 2\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("touch config: %v", err)
+	}
+	m.Update(configTickMsg{})
+	if reloads != 1 {
+		t.Fatalf("expected one reload, got %d", reloads)
+	}
+	if m.targetText() != "ab" || m.config.Words != 1 {
+		t.Fatalf("expected the current text to be kept, got %q", m.targetText())
+	}
+
+	m.resetSession()
+	if m.targetText() != "cd cd" || m.config.Words != 2 {
+		t.Fatalf("expected the reloaded setup on the next text, got %q", m.targetText())
+	}
+	if m.reloadStatus() != "config reloaded" {
+		t.Fatalf("expected reload status, got %q", m.reloadStatus())
+	}
+}
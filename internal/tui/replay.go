@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/replay"
+)
+
+type replayRecorder struct {
+	keys     []replay.Key
+	done     bool
+	recorded replay.Recording
+}
+
+// RecordReplay records the keystrokes of the next completed text and quits once it is
+// done; read the result with Replay after the program exits.
+func (m *Model) RecordReplay() {
+	m.recorder = &replayRecorder{}
+}
+
+// Replay returns the recorded text, if one was completed.
+func (m *Model) Replay() (replay.Recording, bool) {
+	if m.recorder == nil || !m.recorder.done {
+		return replay.Recording{}, false
+	}
+	return m.recorder.recorded, true
+}
+
+// recordKey stores a keystroke relative to the first key of the text, for the replay
+// being recorded and for the session's keystroke log with Config.StoreKeys.
+func (m *Model) recordKey(text string, backspace bool) {
+	recording := m.recorder != nil && !m.recorder.done
+	if !recording && !m.config.StoreKeys {
+		return
+	}
+	key := replay.Key{
+		AtMs:      time.Since(m.startedAt).Milliseconds(),
+		Text:      text,
+		Backspace: backspace,
+	}
+	if recording {
+		m.recorder.keys = append(m.recorder.keys, key)
+	}
+	if m.config.StoreKeys {
+		m.keyLog = append(m.keyLog, key)
+	}
+}
+
+// keystrokes encodes the keystroke log of the text for the session, or returns ""
+// when no log was kept.
+func (m *Model) keystrokes() string {
+	if !m.config.StoreKeys || len(m.keyLog) == 0 {
+		return ""
+	}
+	encoded, err := replay.EncodeKeys(m.keyLog)
+	if err != nil {
+		logErrln(i18n.T("practice.err.save_session", err))
+		return ""
+	}
+	return encoded
+}
+
+// finishReplay seals the recording of the completed text; it reports whether the
+// program should quit.
+func (m *Model) finishReplay() bool {
+	if m.recorder == nil {
+		return false
+	}
+	m.recorder.recorded = replay.Recording{
+		Version:   replay.Version,
+		CreatedAt: m.startedAt,
+		Lang:      m.config.Lang,
+		Mode:      m.config.Mode,
+		Keyboard:  m.config.Keyboard,
+		Layout:    m.config.Layout,
+		Target:    m.targetText(),
+		Keys:      m.recorder.keys,
+	}
+	m.recorder.done = true
+	return true
+}
+
+func (m *Model) resetReplay() {
+	m.keyLog = nil
+	if m.recorder != nil && !m.recorder.done {
+		m.recorder.keys = nil
+	}
+}
+
+func (m *Model) replayDone() bool {
+	return m.recorder != nil && m.recorder.done
+}
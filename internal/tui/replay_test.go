@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/replay"
+)
+
+func TestRecordReplayKeepsKeystrokes(t *testing.T) {
+	m := &Model{target: grapheme.Split("ab cd")}
+	m.RecordReplay()
+	m.handleRunes([]rune("ax"))
+	m.handleBackspace()
+	m.handleRunes([]rune("b c"))
+	if _, ok := m.Replay(); ok {
+		t.Fatalf("expected no replay before the text is complete")
+	}
+	if !m.finishReplay() {
+		t.Fatalf("expected finishReplay to ask for quit while recording")
+	}
+	rec, ok := m.Replay()
+	if !ok {
+		t.Fatalf("expected a replay after finishReplay")
+	}
+	if rec.Target != "ab cd" || len(rec.Keys) != 6 || !rec.Keys[2].Backspace {
+		t.Fatalf("unexpected recording: %+v", rec)
+	}
+	if got := strings.Join(rec.InputAt(len(rec.Keys)), ""); got != "ab c" {
+		t.Fatalf("expected replayed input %q, got %q", "ab c", got)
+	}
+}
+
+func TestPlayerAdvancesWithSpeed(t *testing.T) {
+	rec := replay.Recording{
+		Version: replay.Version,
+		Target:  "ab",
+		Keys:    []replay.Key{{AtMs: 0, Text: "a"}, {AtMs: 400, Text: "b"}},
+	}
+	p := NewPlayer(rec, 2, 0, model.BidiApp)
+	p.advance(100 * time.Millisecond)
+	if p.keys != 1 || p.done() {
+		t.Fatalf("expected one key after 200ms of replay time, got %d", p.keys)
+	}
+	p.advance(100 * time.Millisecond)
+	if !p.done() {
+		t.Fatalf("expected replay to finish at 400ms, got %d keys", p.keys)
+	}
+}
+
+func TestStoreKeysLogsSessionKeystrokes(t *testing.T) {
+	m := &Model{target: grapheme.Split("abc"), config: model.Config{StoreKeys: true}}
+	m.handleRunes([]rune("ax"))
+	m.handleBackspace()
+	m.handleRunes([]rune("b"))
+	rec, err := replay.FromSession(model.SessionStats{TargetText: "abc", Keystrokes: m.keystrokes()})
+	if err != nil {
+		t.Fatalf("FromSession: %v", err)
+	}
+	if len(rec.Keys) != 4 || !rec.Keys[2].Backspace {
+		t.Fatalf("unexpected keystroke log: %+v", rec.Keys)
+	}
+	if m.recorder != nil {
+		t.Fatalf("expected the key log not to start a replay recording")
+	}
+}
@@ -0,0 +1,152 @@
+// Package tui provides the Bubble Tea typing interface.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	uitheme "github.com/verte-zerg/tuipe/internal/theme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+var resultsTitleStyle lipgloss.Style
+
+// resultsShiftChars is the number of weakest shifted characters listed on the results screen.
+const resultsShiftChars = 5
+
+func (m *Model) updateResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter, tea.KeySpace:
+		m.showResults = false
+		m.shareCard = ""
+		m.statusMsg = ""
+		m.weakSuggestion = nil
+		m.resetSession()
+		return m, nil
+	case tea.KeyRunes:
+		switch msg.String() {
+		case "s":
+			m.shareResult()
+		case "f":
+			return m, m.acceptWeakSuggestion()
+		case "d":
+			if m.canDrill() {
+				m.startDrill()
+				m.showResults = false
+				m.shareCard = ""
+				m.statusMsg = ""
+				m.weakSuggestion = nil
+				m.resetSession()
+			}
+		}
+		return m, nil
+	default:
+		return m, nil
+	}
+}
+
+func (m *Model) renderResults() string {
+	s := m.lastSession
+	_, _, acc := statsPkg.SessionMetrics(s.CorrectNonSpace, s.IncorrectNonSpace, s.DurationMs)
+	speed := statsPkg.Speed(s.WPMFormula, s.CorrectNonSpace, s.WordsTyped, s.DurationMs)
+	unit := statsPkg.SpeedUnit(s.WPMFormula)
+	duration := time.Duration(s.DurationMs) * time.Millisecond
+	if m.config.Accessible {
+		return m.renderAccessibleResults(speed, unit, acc, duration)
+	}
+	lines := []string{
+		resultsTitleStyle.Render(i18n.T("results.title")),
+		correctStyle.Render(fmt.Sprintf("%.1f %s · %.1f%% · %s", speed, unit, acc*100, duration.Round(100*time.Millisecond))),
+	}
+	if worst := m.charBreakdown(); worst != "" {
+		lines = append(lines, footerStyle.Render(worst))
+	}
+	if shift := m.shiftSummary(); shift != "" {
+		lines = append(lines, footerStyle.Render(shift))
+	}
+	width := rhythmWidth
+	if m.width > 0 {
+		width = min(width, m.width-4)
+	}
+	if strip := m.rhythmStrip(width); strip != "" {
+		lines = append(lines, "", strip, footerStyle.Render(m.rhythmSummary()))
+	}
+	if review := m.ghostReview(width); review != "" {
+		lines = append(lines, "", review)
+	}
+	if suggestion := m.weakSuggestionLine(); suggestion != "" {
+		lines = append(lines, "", correctStyle.Render(suggestion))
+	}
+	if m.shareCard != "" {
+		lines = append(lines, "", m.shareCard)
+	}
+	if m.statusMsg != "" {
+		lines = append(lines, "", footerStyle.Render(m.statusMsg))
+	}
+	lines = append(lines, "", footerStyle.Render(m.resultsHelp()))
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+	if m.width == 0 || m.height == 0 {
+		return content
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// resultsHelp lists the results screen keys; the drill key only when a drill is possible.
+func (m *Model) resultsHelp() string {
+	if m.canDrill() {
+		return i18n.T("results.help_drill")
+	}
+	return i18n.T("results.help")
+}
+
+// shiftSummary describes the last text's accuracy on characters that need Shift: overall
+// and for the weakest of them. It is empty when no such character was typed.
+func (m *Model) shiftSummary() string {
+	shifted := statsPkg.ShiftedChars(m.lastChars)
+	if len(shifted) == 0 {
+		return ""
+	}
+	total, _ := statsPkg.ShiftedAggregate(shifted)
+	parts := make([]string, 0, resultsShiftChars)
+	for _, agg := range shifted[:min(len(shifted), resultsShiftChars)] {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", agg.Char, charAccuracy(agg)*100))
+	}
+	return i18n.T("results.shift", charAccuracy(total)*100, strings.Join(parts, "  "))
+}
+
+func charAccuracy(agg model.CharAggregate) float64 {
+	return float64(agg.Correct) / float64(agg.Correct+agg.Incorrect)
+}
+
+func (m *Model) shareResult() {
+	s := m.lastSession
+	wpm, _, acc := statsPkg.SessionMetrics(s.CorrectNonSpace, s.IncorrectNonSpace, s.DurationMs)
+	card := statsPkg.ShareCard{
+		WPM:      wpm,
+		Accuracy: acc,
+		Date:     s.EndedAt,
+		Lang:     s.Lang,
+	}
+	sessions, err := m.store.ListSessions(context.Background(), model.StatsConfig{})
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_streak", err))
+	} else {
+		card.Streak = statsPkg.Streak(sessions, time.Now())
+	}
+	m.shareCard = statsPkg.RenderShareCard(card, !m.config.Accessible && !uitheme.NoColor())
+	if err := clipboard.WriteAll(statsPkg.RenderShareCard(card, false)); err != nil {
+		m.statusMsg = i18n.T("results.no_clipboard")
+		return
+	}
+	m.statusMsg = i18n.T("results.copied")
+}
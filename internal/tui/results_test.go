@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestResultsScreenContinue(t *testing.T) {
+	gen := generator.New()
+	m := &Model{
+		config:      model.Config{Words: 1, ResultsScreen: true},
+		gen:         gen,
+		source:      generator.NewWordSource(gen, []string{"ab"}, generator.Style{}, 0),
+		showResults: true,
+		lastSession: model.SessionStats{CorrectNonSpace: 50, DurationMs: 60000},
+		statusMsg:   "Share card copied to clipboard",
+	}
+	view := m.View()
+	if !containsAll(view, []string{"Session complete", "10.0 WPM", "s: share"}) {
+		t.Fatalf("results view missing expected segments: %s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if !m.showResults {
+		t.Fatalf("expected other keys to keep the results screen")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showResults {
+		t.Fatalf("expected enter to leave the results screen")
+	}
+	if m.targetText() != "ab" || m.statusMsg != "" {
+		t.Fatalf("expected a fresh session, got target %q status %q", m.targetText(), m.statusMsg)
+	}
+	if strings.Contains(m.View(), "Session complete") {
+		t.Fatalf("expected typing view after continue")
+	}
+}
+
+func TestResultsScreenShowsShiftAccuracy(t *testing.T) {
+	m := &Model{
+		showResults: true,
+		lastSession: model.SessionStats{CorrectNonSpace: 50, DurationMs: 60000},
+		lastChars:   []model.CharAggregate{{Char: "a", Correct: 10}, {Char: "Q", Correct: 1, Incorrect: 1}, {Char: "A", Correct: 3, Incorrect: 1}},
+	}
+	if view := m.View(); !containsAll(view, []string{"Shift 66.7%", "Q 50%  A 75%"}) {
+		t.Fatalf("results view missing shift accuracy: %s", view)
+	}
+	m.lastChars = []model.CharAggregate{{Char: "a", Correct: 10}}
+	if strings.Contains(m.View(), "Shift") {
+		t.Fatalf("expected no shift line without shifted characters")
+	}
+}
+
+func TestResultsScreenShowsRhythm(t *testing.T) {
+	target, words := joinWords([]string{"the", "quick"})
+	ms := time.Millisecond
+	m := &Model{
+		config:      model.Config{HesitationMs: 500},
+		target:      target,
+		words:       words,
+		showResults: true,
+		lastSession: model.SessionStats{CorrectNonSpace: 50, DurationMs: 60000},
+		gaps:        []keyGap{{0, 0}, {1, 120 * ms}, {2, 130 * ms}, {3, 110 * ms}, {4, 1200 * ms}, {5, 600 * ms}},
+	}
+	if view := m.View(); !containsAll(view, []string{"▁", "█", `Pauses over 500 ms: 2 · longest 1.2s before "q" in quick`}) {
+		t.Fatalf("results view missing the rhythm strip: %s", view)
+	}
+	if strip := m.rhythmStrip(3); len([]rune(strip)) != 3 {
+		t.Fatalf("expected the strip to be bucketed to 3 cells, got %q", strip)
+	}
+	m.config.HesitationMs = 0
+	if strings.Contains(m.View(), "Pauses") {
+		t.Fatalf("expected no rhythm strip with --hesitation 0")
+	}
+}
+
+func TestResultsScreenWorstCharsDrill(t *testing.T) {
+	gen := generator.New()
+	m := &Model{
+		config:      model.Config{Words: 1, ResultsScreen: true},
+		gen:         gen,
+		source:      generator.NewWordSource(gen, []string{"ab"}, generator.Style{}, 0),
+		showResults: true,
+		lastSession: model.SessionStats{CorrectNonSpace: 50, DurationMs: 60000},
+		lastChars: []model.CharAggregate{
+			{Char: "a", Correct: 10, LatencySumMs: 1000, LatencyCount: 10},
+			{Char: "b", Correct: 3, Incorrect: 1, LatencySumMs: 600, LatencyCount: 3},
+			{Char: "c", Correct: 10, LatencySumMs: 3000, LatencyCount: 10},
+		},
+	}
+	if view := m.View(); !containsAll(view, []string{"Worst: b 75% 200ms  c 100% 300ms  a 100% 100ms", "d: drill worst"}) {
+		t.Fatalf("results view missing the worst characters: %s", view)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.showResults || !m.drilling {
+		t.Fatalf("expected d to start a drill text")
+	}
+	if len(m.weakSet) != 3 {
+		t.Fatalf("expected the drill weak set to hold the worst characters, got %v", m.weakSet)
+	}
+	m.endDrill()
+	if m.drilling || len(m.weakSet) != 0 {
+		t.Fatalf("expected the weak set to be cleared after the drill, got %v", m.weakSet)
+	}
+}
+
+func TestResultsGhostReview(t *testing.T) {
+	target, words := joinWords([]string{"the", "cat"})
+	m := &Model{
+		config:      model.Config{Ghost: true},
+		target:      target,
+		words:       words,
+		input:       []string{"t", "h", "x", " ", "c", "a", " "},
+		showResults: true,
+		lastSession: model.SessionStats{CorrectNonSpace: 50, DurationMs: 60000},
+	}
+	if got := m.ghostReview(40); got != "the cat\n  x   _" {
+		t.Fatalf("unexpected ghost review %q", got)
+	}
+	if got := m.ghostReview(4); got != "the\n  x\ncat\n  _" {
+		t.Fatalf("expected the ghost to follow wrapped lines, got %q", got)
+	}
+	if !strings.Contains(m.View(), "  x   _") {
+		t.Fatalf("results view missing the ghost review: %s", m.View())
+	}
+	m.input = []string{"t", "h", "e", " ", "c", "a", "t"}
+	if got := m.ghostReview(40); got != "" {
+		t.Fatalf("expected no review without mistakes, got %q", got)
+	}
+}
+
+func TestResultsScreenWeakFocusSuggestion(t *testing.T) {
+	m := &Model{
+		config:         model.Config{ResultsScreen: true, WeakWindow: 20},
+		showResults:    true,
+		lastSession:    model.SessionStats{CorrectNonSpace: 50, DurationMs: 60000},
+		weakSuggestion: &model.CharAggregate{Char: "p", Correct: 42, Incorrect: 8},
+	}
+	if view := m.View(); !strings.Contains(view, "Your 'p' accuracy is 84%") {
+		t.Fatalf("results view missing the weak-focus suggestion: %s", view)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if !m.config.FocusWeak || m.weakSuggestion != nil || cmd == nil || !m.showResults {
+		t.Fatalf("expected f to turn on weak focus and load the weak set, got focus %v suggestion %v", m.config.FocusWeak, m.weakSuggestion)
+	}
+	if view := m.View(); strings.Contains(view, "accuracy is 84%") || !strings.Contains(view, "Weak-char focus on") {
+		t.Fatalf("expected the suggestion replaced by a status line: %s", view)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")}); cmd != nil {
+		t.Fatalf("expected f to do nothing without a suggestion")
+	}
+}
+
+func TestWeakFocusSuggestionWithoutResultsScreen(t *testing.T) {
+	// The default config has no results screen, so the suggestion goes to the status bar.
+	m := &Model{
+		config:         model.Config{WeakWindow: 20},
+		target:         grapheme.Split("abcd"),
+		weakSuggestion: &model.CharAggregate{Char: "p", Correct: 42, Incorrect: 8},
+	}
+	if out := m.renderFooter(); !strings.Contains(out, "'p' is 84% accurate — tab") {
+		t.Fatalf("footer missing the weak-focus suggestion: %s", out)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.config.FocusWeak || m.weakSuggestion != nil || cmd == nil || m.showWeakInfo {
+		t.Fatalf("expected tab to turn on weak focus, got focus %v suggestion %v", m.config.FocusWeak, m.weakSuggestion)
+	}
+	if out := m.renderFooter(); strings.Contains(out, "84%") || !strings.Contains(out, "Weak-char focus on") {
+		t.Fatalf("expected the suggestion replaced by a notice: %s", out)
+	}
+
+	m.weakSuggestion = &model.CharAggregate{Char: "p", Correct: 42, Incorrect: 8}
+	m.weakSuggested = true
+	m.suggestWeakFocus()
+	if m.weakSuggestion != nil {
+		t.Fatal("expected a suggestion left unanswered to end with the next text")
+	}
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+)
+
+// rhythmWidth is the widest the results rhythm strip gets, in cells.
+const rhythmWidth = 60
+
+// rhythmLevels are the block heights of the rhythm strip, shortest first.
+var rhythmLevels = []rune("▁▂▃▄▅▆▇█")
+
+// keyGap is the time before a typed character, measured from the previous one.
+type keyGap struct {
+	pos int
+	gap time.Duration
+}
+
+// recordGap stores the pause before the character typed at pos.
+func (m *Model) recordGap(pos int, now time.Time) {
+	var gap time.Duration
+	if !m.lastKeyAt.IsZero() {
+		gap = now.Sub(m.lastKeyAt)
+	}
+	m.lastKeyAt = now
+	m.gaps = append(m.gaps, keyGap{pos: pos, gap: gap})
+}
+
+// hesitation is the pause highlighted on the rhythm strip; 0 turns the strip off.
+func (m *Model) hesitation() time.Duration {
+	return time.Duration(m.config.HesitationMs) * time.Millisecond
+}
+
+// rhythmStrip draws the pause before every keystroke as a block, taller for longer
+// pauses, with hesitations in the error color. Longer texts are bucketed to fit width,
+// keeping the longest pause of each bucket.
+func (m *Model) rhythmStrip(width int) string {
+	threshold := m.hesitation()
+	if threshold <= 0 || len(m.gaps) == 0 {
+		return ""
+	}
+	width = min(width, len(m.gaps))
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	for col := 0; col < width; col++ {
+		var gap time.Duration
+		for _, g := range m.gaps[col*len(m.gaps)/width : (col+1)*len(m.gaps)/width] {
+			gap = max(gap, g.gap)
+		}
+		// A pause at the threshold is half height; twice the threshold fills the cell.
+		level := min(int(gap*time.Duration(len(rhythmLevels))/(2*threshold)), len(rhythmLevels)-1)
+		block := string(rhythmLevels[level])
+		if gap >= threshold {
+			b.WriteString(incorrectStyle.Render(block))
+		} else {
+			b.WriteString(correctStyle.Render(block))
+		}
+	}
+	return b.String()
+}
+
+// rhythmSummary counts the hesitations and names the character and word before which
+// the longest one happened. It is empty when the strip is off.
+func (m *Model) rhythmSummary() string {
+	threshold := m.hesitation()
+	if threshold <= 0 || len(m.gaps) == 0 {
+		return ""
+	}
+	count := 0
+	longest := m.gaps[0]
+	for _, g := range m.gaps {
+		if g.gap >= threshold {
+			count++
+		}
+		if g.gap > longest.gap {
+			longest = g
+		}
+	}
+	ms := int(threshold / time.Millisecond)
+	if count == 0 {
+		return i18n.T("results.rhythm_steady", ms)
+	}
+	where := fmt.Sprintf("%q", m.target[longest.pos])
+	if i := m.wordAt(longest.pos); i >= 0 {
+		where = i18n.T("results.rhythm_in", where, m.wordText(m.wordRanges()[i]))
+	}
+	return i18n.T("results.rhythm", ms, count, longest.gap.Round(10*time.Millisecond), where)
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sampleInterval is the span of one speed sample.
+const sampleInterval = time.Second
+
+type sampleTickMsg struct{}
+
+// sampleTick keeps the samples current while the typist pauses, so a second without
+// input is recorded as zero rather than skipped.
+func sampleTick() tea.Cmd {
+	return tea.Tick(sampleInterval, func(time.Time) tea.Msg { return sampleTickMsg{} })
+}
+
+// speedSamples counts the correct characters (spaces aside, as in WPM) typed in each
+// second of a session.
+type speedSamples []int
+
+// add counts a correct character typed elapsed after the start of the session.
+func (s *speedSamples) add(elapsed time.Duration) {
+	s.extend(elapsed)
+	(*s)[elapsed/sampleInterval]++
+}
+
+// extend opens empty samples up to the one elapsed falls in.
+func (s *speedSamples) extend(elapsed time.Duration) {
+	for n := int(elapsed/sampleInterval) + 1; len(*s) < n; {
+		*s = append(*s, 0)
+	}
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// markMissedWord records the word around pos of the target text as mistyped.
+func (m *Model) markMissedWord(pos int) {
+	i := m.wordAt(pos)
+	if i < 0 {
+		return
+	}
+	if m.missedWords == nil {
+		m.missedWords = map[string]struct{}{}
+	}
+	m.missedWords[statsPkg.SRSWord(m.wordText(m.wordRanges()[i]))] = struct{}{}
+}
+
+// reviewSRS grades the finished text and stores the updated review schedule.
+func (m *Model) reviewSRS(now time.Time, chars []model.CharStats) {
+	ctx := context.Background()
+	items, err := m.store.ListSRSItems(ctx, m.config.Lang)
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_review", err))
+		return
+	}
+	words := make([]string, 0, len(m.wordRanges()))
+	for _, w := range m.wordRanges() {
+		words = append(words, m.wordText(w))
+	}
+	grades := statsPkg.SRSGrades(chars, words, m.missedWords)
+	if err := m.store.SaveSRSItems(ctx, statsPkg.ScheduleSRS(m.config.Lang, items, grades, now)); err != nil {
+		logErrln(i18n.T("practice.err.save_review", err))
+	}
+}
+
+// refreshReviews loads the characters and words that are due for review. Due characters
+// join the weak set (up to weak-top) and due words are mixed into the next texts.
+func (m *Model) refreshReviews() {
+	m.reviewChars = map[rune]struct{}{}
+	m.reviewWords = nil
+	if m.config.SRS {
+		items, err := m.store.ListSRSItems(context.Background(), m.config.Lang)
+		if err != nil {
+			logErrln(i18n.T("practice.err.load_review", err))
+		}
+		now := time.Now()
+		for _, item := range statsPkg.DueSRS(items, model.SRSChar, now, m.config.WeakTop) {
+			if runes := []rune(item.Item); len(runes) > 0 {
+				m.reviewChars[runes[0]] = struct{}{}
+			}
+		}
+		for _, item := range statsPkg.DueSRS(items, model.SRSWord, now, m.config.Words) {
+			m.reviewWords = append(m.reviewWords, item.Item)
+		}
+	}
+	m.applyWeakSet()
+}
+
+// biasSet is the weak set plus the characters due for review and the under-sampled ones.
+func (m *Model) biasSet() map[rune]struct{} {
+	if len(m.reviewChars) == 0 && len(m.coverageChars) == 0 {
+		return m.weakSet
+	}
+	set := make(map[rune]struct{}, len(m.weakSet)+len(m.reviewChars)+len(m.coverageChars))
+	for _, chars := range []map[rune]struct{}{m.weakSet, m.reviewChars, m.coverageChars} {
+		for r := range chars {
+			set[r] = struct{}{}
+		}
+	}
+	return set
+}
+
+// applyReviewWords passes the due review words to sources that mix them in.
+func (m *Model) applyReviewWords() {
+	if reviewAware, ok := m.source.(generator.ReviewAware); ok {
+		reviewAware.SetReviewWords(m.reviewWords)
+	}
+}
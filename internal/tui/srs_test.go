@@ -0,0 +1,16 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+func TestHandleRunesMarksMissedWords(t *testing.T) {
+	m := &Model{target: grapheme.Split("ab, cd")}
+	m.handleRunes([]rune("ax"))
+	m.handleRunes([]rune(", c"))
+	if _, ok := m.missedWords["ab"]; !ok || len(m.missedWords) != 1 {
+		t.Fatalf("expected only %q to be marked, got %v", "ab", m.missedWords)
+	}
+}
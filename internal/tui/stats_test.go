@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestUpdateStatsTracksSpaceLatency(t *testing.T) {
+	m := &Model{}
+	m.updateStats("a", "a")
+	last := time.Now().Add(-120 * time.Millisecond)
+	m.prevCorrectAt = last
+	m.updateStats(" ", " ")
+	if !m.prevCorrectAt.Equal(last) {
+		t.Fatalf("expected a space to keep the latency baseline of the next character")
+	}
+	if m.spaceLatencyCount != 1 {
+		t.Fatalf("expected 1 space latency sample, got %d", m.spaceLatencyCount)
+	}
+	if m.spaceLatencySumMs < 120 {
+		t.Fatalf("expected space latency >= 120ms, got %d", m.spaceLatencySumMs)
+	}
+	if m.correctNonSpace != 1 {
+		t.Fatalf("expected spaces to stay out of non-space counts, got %d", m.correctNonSpace)
+	}
+
+	m.updateStats(" ", "x")
+	if m.spaceLatencyCount != 1 {
+		t.Fatalf("expected mistyped space to be ignored, got %d samples", m.spaceLatencyCount)
+	}
+}
+
+func TestCapTextKeepsRunesWhole(t *testing.T) {
+	text, cut := capText("héllo", 2)
+	if !cut || text != "h" {
+		t.Fatalf("expected cut before multi-byte rune, got %q (cut=%v)", text, cut)
+	}
+	text, cut = capText("hello", 0)
+	if cut || text != "hello" {
+		t.Fatalf("expected no cap for 0, got %q (cut=%v)", text, cut)
+	}
+}
+
+func TestSpeedSamplesBucketBySecond(t *testing.T) {
+	var s speedSamples
+	s.add(200 * time.Millisecond)
+	s.add(900 * time.Millisecond)
+	s.extend(2500 * time.Millisecond)
+	s.add(3100 * time.Millisecond)
+	if got := []int(s); len(got) != 4 || got[0] != 2 || got[1] != 0 || got[2] != 0 || got[3] != 1 {
+		t.Fatalf("expected samples [2 0 0 1], got %v", got)
+	}
+}
+
+func TestWeakSetAppliesWhenLoaded(t *testing.T) {
+	gen := generator.New()
+	m := &Model{
+		config: model.Config{Words: 1, Lang: "en", FocusWeak: true, WeakTop: 1},
+		gen:    gen,
+		source: generator.NewWordSource(gen, []string{"ab"}, generator.Style{}, 0),
+	}
+	m.resetSession()
+	aggs := []model.CharAggregate{{Char: "a", Correct: 9, Incorrect: 1}, {Char: "b", Correct: 1, Incorrect: 9}}
+
+	m.Update(weakSetMsg{lang: "de", aggs: aggs})
+	if len(m.weakSet) != 0 {
+		t.Fatalf("expected a weak set for another language to be ignored, got %v", m.weakSet)
+	}
+	m.Update(weakSetMsg{lang: "en", aggs: aggs})
+	if _, ok := m.weakSet['b']; !ok || len(m.weakSet) != 1 {
+		t.Fatalf("expected weak set {b}, got %v", m.weakSet)
+	}
+}
+
+func TestEquivalentCharactersMatch(t *testing.T) {
+	target, words := joinWords([]string{"don’t", "it's", "“so”"})
+	cfg := model.Config{LooseApostrophe: true, Equivalents: model.DefaultEquivalents}
+	m := &Model{target: target, words: words, config: cfg}
+	m.handleRunes([]rune("don't it’s \"so"))
+	if m.incorrectNonSpace != 0 || len(m.missedWords) != 0 {
+		t.Fatalf("expected equivalent characters to count as correct, got %d mistakes", m.incorrectNonSpace)
+	}
+	if strings.Join(m.input[:5], "") != "don’t" {
+		t.Fatalf("expected the target character in the input, got %q", strings.Join(m.input, ""))
+	}
+	for char, want := range map[string]int{"'": 1, "’": 1, `"`: 1} {
+		if entry := m.charStats[char]; entry == nil || entry.correct != want {
+			t.Fatalf("expected %d correct under the typed variant %q, got %+v", want, char, entry)
+		}
+	}
+	if entry := m.charStats["“"]; entry != nil {
+		t.Fatalf("expected no entry for the target variant “, got %+v", entry)
+	}
+
+	m = &Model{target: target, words: words}
+	m.handleRunes([]rune("don't"))
+	if m.incorrectNonSpace != 1 {
+		t.Fatalf("expected a strict mismatch without equivalents, got %d", m.incorrectNonSpace)
+	}
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/generator"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+const (
+	// weakSuggestSessions is how many sessions of the language come before weak-char
+	// focus is suggested.
+	weakSuggestSessions = 10
+	// weakSuggestSamples is how often a character must have been typed to be suggested.
+	weakSuggestSamples = 20
+	// weakSuggestAccuracy is the accuracy a character must fall below to be suggested.
+	weakSuggestAccuracy = 0.9
+	// weakSuggestKey is the app state key set once the suggestion was shown.
+	weakSuggestKey = "weak_focus_suggested"
+)
+
+// suggestWeakFocus checks, after a finished session, whether to suggest weak-char focus
+// on the results screen, or in the status bar of the next text without it. The suggestion
+// is made once: the store records it, and weakSuggested stops the checks for the rest of
+// the run. One left in the status bar lasts until the next text is finished.
+func (m *Model) suggestWeakFocus() {
+	m.weakSuggestion = nil
+	if m.weakSuggested || m.config.FocusWeak || m.config.Daily || m.drilling {
+		return
+	}
+	if _, ok := m.source.(generator.WeakAware); !ok {
+		return
+	}
+	ctx := context.Background()
+	_, shown, err := m.store.GetState(ctx, weakSuggestKey)
+	if err != nil || shown {
+		if err != nil {
+			logErrln(i18n.T("practice.err.load_state", err))
+		}
+		m.weakSuggested = true
+		return
+	}
+	count, err := m.store.CountSessions(ctx, model.StatsConfig{Lang: m.config.Lang})
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_stats", err))
+		m.weakSuggested = true
+		return
+	}
+	if count < weakSuggestSessions {
+		return
+	}
+	aggs, err := m.store.GetWeakChars(ctx, m.config.WeakWindow, m.config.Lang)
+	if err != nil {
+		logErrln(i18n.T("practice.err.load_weak", err))
+		m.weakSuggested = true
+		return
+	}
+	agg, ok := statsPkg.WeakFocusSuggestion(aggs, weakSuggestSamples, weakSuggestAccuracy)
+	if !ok {
+		return
+	}
+	m.weakSuggested = true
+	if err := m.store.SetState(ctx, weakSuggestKey, agg.Char); err != nil {
+		logErrln(i18n.T("practice.err.save_state", err))
+	}
+	m.weakSuggestion = &agg
+	if !m.config.ResultsScreen {
+		m.announce(m.weakSuggestionSegment())
+	}
+}
+
+// weakSuggestionLine is the results screen line suggesting weak-char focus, or empty.
+func (m *Model) weakSuggestionLine() string {
+	if m.weakSuggestion == nil {
+		return ""
+	}
+	return i18n.T("results.weak_suggest", describeChar(m.weakSuggestion.Char), charAccuracy(*m.weakSuggestion)*100)
+}
+
+// weakSuggestionSegment is the status bar form of the suggestion, accepted with tab, or empty.
+func (m *Model) weakSuggestionSegment() string {
+	if m.weakSuggestion == nil {
+		return ""
+	}
+	return i18n.T("practice.weak_suggest", describeChar(m.weakSuggestion.Char), charAccuracy(*m.weakSuggestion)*100)
+}
+
+// acceptWeakSuggestion turns weak-char focus on for the next texts; the weak set loads
+// in the background. The confirmation shows on the results screen and in the status bar.
+func (m *Model) acceptWeakSuggestion() tea.Cmd {
+	if m.weakSuggestion == nil {
+		return nil
+	}
+	m.weakSuggestion = nil
+	m.config.FocusWeak = true
+	m.weakStale = true
+	m.statusMsg = i18n.T("results.weak_enabled")
+	m.notice = m.statusMsg
+	return m.loadWeakSet()
+}
@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSetThemeMarksMistakesWithoutColor(t *testing.T) {
+	t.Cleanup(func() { SetTheme(model.Theme{}) })
+
+	t.Setenv("NO_COLOR", "1")
+	SetTheme(model.Theme{Error: "#FF0000"})
+	if !incorrectStyle.GetUnderline() || !pendingStyle.GetFaint() || !currentWordStyle.GetBold() {
+		t.Fatalf("expected NO_COLOR to mark mistakes, pending text and the current word by attributes")
+	}
+
+	t.Setenv("NO_COLOR", "")
+	SetTheme(model.Theme{})
+	if incorrectStyle.GetUnderline() || pendingStyle.GetFaint() {
+		t.Fatalf("expected the default palette to rely on color alone")
+	}
+	SetTheme(model.Theme{Palette: model.PaletteColorblind})
+	if !incorrectStyle.GetUnderline() {
+		t.Fatalf("expected the colorblind palette to underline mistakes")
+	}
+}
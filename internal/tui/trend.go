@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// footerTrendSessions is the number of latest sessions on the footer sparkline.
+const footerTrendSessions = 20
+
+// setRecentSpeeds keeps the speeds of sessions, given newest first, oldest first.
+func (m *Model) setRecentSpeeds(sessions []model.SessionAggregate) {
+	m.recentSpeeds = m.recentSpeeds[:0]
+	for i := len(sessions) - 1; i >= 0; i-- {
+		m.recentSpeeds = append(m.recentSpeeds, statsPkg.SessionSpeed(m.config.WPMFormula, sessions[i]))
+	}
+}
+
+// pushRecentSpeed adds the speed of a finished session, dropping the oldest beyond
+// footerTrendSessions.
+func (m *Model) pushRecentSpeed(speed float64) {
+	m.recentSpeeds = append(m.recentSpeeds, speed)
+	if len(m.recentSpeeds) > footerTrendSessions {
+		m.recentSpeeds = m.recentSpeeds[len(m.recentSpeeds)-footerTrendSessions:]
+	}
+}
+
+// trendSegment is the footer sparkline of recent session speeds; it is empty unless
+// Config.FooterTrend is set and there are at least two sessions to compare.
+func (m *Model) trendSegment() string {
+	if !m.config.FooterTrend || len(m.recentSpeeds) < 2 {
+		return ""
+	}
+	return i18n.T("practice.trend", statsPkg.Sparkline(m.recentSpeeds))
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/verte-zerg/tuipe/internal/i18n"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	statsPkg "github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// weakFocusActive reports whether the text is biased toward a weak set, so tab can
+// explain it.
+func (m *Model) weakFocusActive() bool {
+	return m.config.FocusWeak || m.drilling
+}
+
+// openWeakInfo shows the weak-character overlay. The weak set passed to NewModel comes
+// without its stats, so they are loaded the first time.
+func (m *Model) openWeakInfo() {
+	if !m.weakFocusActive() {
+		return
+	}
+	if m.weakAggs == nil && !m.drilling {
+		aggs, err := m.store.GetWeakChars(context.Background(), m.config.WeakWindow, m.config.Lang)
+		if err != nil {
+			logErrln(i18n.T("practice.err.load_weak", err))
+		}
+		m.weakAggs = aggs
+	}
+	m.showWeakInfo = true
+}
+
+// updateWeakInfo handles keys while the weak-character overlay is open: tab and esc close
+// it and other keys are ignored, so nothing is typed behind it.
+func (m *Model) updateWeakInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyTab, tea.KeyEsc:
+		m.showWeakInfo = false
+	}
+	return m, nil
+}
+
+// weakInfoRows are the targeted weak characters with their recent stats, weakest first.
+// Characters without stats in weakAggs are left out.
+func (m *Model) weakInfoRows() []model.CharAggregate {
+	rows := make([]model.CharAggregate, 0, len(m.weakSet))
+	for _, agg := range m.weakAggs {
+		runes := []rune(agg.Char)
+		if len(runes) == 0 {
+			continue
+		}
+		if _, ok := m.weakSet[runes[0]]; ok {
+			rows = append(rows, agg)
+		}
+	}
+	return statsPkg.WorstChars(rows, -1)
+}
+
+// weakInfoLines describe the weak set: a title naming where it comes from, one line per
+// character with its accuracy and sample count, and the close key.
+func (m *Model) weakInfoLines() []string {
+	title := i18n.T("weak.title", m.config.WeakWindow)
+	if m.drilling {
+		title = i18n.T("weak.title_drill")
+	}
+	lines := []string{title}
+	rows := m.weakInfoRows()
+	if len(rows) == 0 {
+		lines = append(lines, i18n.T("weak.empty"))
+	}
+	for _, agg := range rows {
+		line := i18n.T("weak.row", describeChar(agg.Char), charAccuracy(agg)*100, agg.Correct+agg.Incorrect)
+		if agg.LatencyCount > 0 {
+			line += fmt.Sprintf(" · %.0fms", statsPkg.MeanLatencyMs(agg))
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, i18n.T("weak.help"))
+}
+
+// renderWeakInfo draws the weak-character overlay in place of the text.
+func (m *Model) renderWeakInfo() string {
+	lines := m.weakInfoLines()
+	if m.config.Accessible {
+		return strings.Join(lines, "\n") + "\n"
+	}
+	styled := make([]string, 0, len(lines)+2)
+	styled = append(styled, resultsTitleStyle.Render(lines[0]), "")
+	for _, line := range lines[1 : len(lines)-1] {
+		styled = append(styled, correctStyle.Render(line))
+	}
+	styled = append(styled, "", footerStyle.Render(lines[len(lines)-1]))
+	content := lipgloss.JoinVertical(lipgloss.Left, styled...)
+	if m.width == 0 || m.height == 0 {
+		return content
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestWeakInfoOverlay(t *testing.T) {
+	target, words := joinWords([]string{"abc"})
+	m := &Model{
+		config:  model.Config{FocusWeak: true, WeakWindow: 20},
+		target:  target,
+		words:   words,
+		weakSet: map[rune]struct{}{'q': {}, 'z': {}},
+		weakAggs: []model.CharAggregate{
+			{Char: "a", Correct: 10},
+			{Char: "q", Correct: 9, Incorrect: 3, LatencySumMs: 2700, LatencyCount: 9},
+			{Char: "z", Correct: 1, Incorrect: 1},
+		},
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view := m.View()
+	if !containsAll(view, []string{"last 20 sessions", "z  50.0%  (2 typed)", "q  75.0%  (12 typed) · 300ms"}) {
+		t.Fatalf("overlay missing weak characters: %s", view)
+	}
+	if strings.Contains(view, "a  100.0%") || strings.Index(view, "z  50.0%") > strings.Index(view, "q  75.0%") {
+		t.Fatalf("expected only the weak set, weakest first: %s", view)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if len(m.input) != 0 {
+		t.Fatalf("expected keys to be ignored behind the overlay")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showWeakInfo {
+		t.Fatalf("expected esc to close the overlay")
+	}
+
+	m.config.FocusWeak = false
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.showWeakInfo {
+		t.Fatalf("expected no overlay without weak focus")
+	}
+}
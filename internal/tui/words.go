@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+// joinWords lays words out as the text to type: NFC-normalized clusters separated by
+// spaces, except between two Chinese or Japanese words, which are written without one.
+// It returns the clusters and the range of every word, since words run together in such
+// text and can no longer be found by spaces.
+func joinWords(words []string) ([]string, []wordRange) {
+	var text []string
+	var ranges []wordRange
+	for _, word := range words {
+		clusters := grapheme.Split(norm.NFC.String(word))
+		if len(clusters) == 0 {
+			continue
+		}
+		if len(text) > 0 && !(unspaced(text[len(text)-1]) && unspaced(clusters[0])) {
+			text = append(text, " ")
+		}
+		offset := len(text)
+		for _, w := range findWords(clusters) {
+			ranges = append(ranges, wordRange{start: w.start + offset, end: w.end + offset})
+		}
+		text = append(text, clusters...)
+	}
+	return text, ranges
+}
+
+// unspaced reports whether cluster belongs to a writing system without spaces between
+// words: Han, kana, and CJK or full-width punctuation.
+func unspaced(cluster string) bool {
+	for _, r := range cluster {
+		return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) ||
+			(r >= 0x3000 && r <= 0x30FF) || (r >= 0xFF00 && r <= 0xFFEF)
+	}
+	return false
+}
+
+// wordRanges returns the words of the text; texts not laid out by joinWords (tests,
+// replays) fall back to space-separated words.
+func (m *Model) wordRanges() []wordRange {
+	if m.words == nil {
+		m.words = findWords(m.target)
+	}
+	return m.words
+}
+
+// wordAt returns the index of the word containing pos, or -1 between words.
+func (m *Model) wordAt(pos int) int {
+	for i, w := range m.wordRanges() {
+		if pos >= w.start && pos < w.end {
+			return i
+		}
+	}
+	return -1
+}
+
+// wordNumber returns the 1-based number of the word at pos; a space counts with the
+// word before it.
+func (m *Model) wordNumber(pos int) int {
+	n := 0
+	for _, w := range m.wordRanges() {
+		if w.start <= pos {
+			n++
+		}
+	}
+	return n
+}
+
+// wordsTyped counts the words the input has reached.
+func (m *Model) wordsTyped() int {
+	if len(m.input) == 0 {
+		return 0
+	}
+	return m.wordNumber(len(m.input) - 1)
+}
+
+// wordText returns the text of w.
+func (m *Model) wordText(w wordRange) string {
+	return strings.Join(m.target[w.start:w.end], "")
+}
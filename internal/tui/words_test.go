@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+func TestJoinWordsWithoutSpacesForCJK(t *testing.T) {
+	cases := []struct {
+		words []string
+		text  string
+		count int
+	}{
+		{[]string{"one", "two"}, "one two", 2},
+		{[]string{"東京", "タワー", "です。"}, "東京タワーです。", 3},
+		{[]string{"hello", "世界", "again"}, "hello 世界 again", 3},
+		{[]string{"café", "bar"}, "café bar", 2},
+	}
+	for _, c := range cases {
+		text, words := joinWords(c.words)
+		if strings.Join(text, "") != c.text || len(words) != c.count {
+			t.Fatalf("joinWords(%q) = %q with %d words, want %q with %d", c.words, strings.Join(text, ""), len(words), c.text, c.count)
+		}
+	}
+}
+
+func TestCJKWordsAreTrackedWithoutSpaces(t *testing.T) {
+	target, words := joinWords([]string{"東京", "タワー"})
+	m := &Model{target: target, words: words}
+	m.handleRunes([]rune("東京タ"))
+	m.handleRunes([]rune("x"))
+	if _, ok := m.missedWords["タワー"]; !ok || len(m.missedWords) != 1 {
+		t.Fatalf("expected only %q to be marked, got %v", "タワー", m.missedWords)
+	}
+	if m.wordsTyped() != 2 {
+		t.Fatalf("expected 2 words typed, got %d", m.wordsTyped())
+	}
+	chars := buildStyledChars(m.target, m.input[:2], m.words, 2)
+	if chars[2].s != cursorStyle.Render("タ") || chars[3].s != currentWordStyle.Render("ワ") {
+		t.Fatalf("expected the second word to be current")
+	}
+}
+
+func TestWrapLinesBreaksCJKWithoutSpaces(t *testing.T) {
+	target := grapheme.Split("日本語。です")
+	chars := buildStyledChars(target, nil, findWords(target), -1)
+	var lines []string
+	for _, line := range wrapLines(chars, 6) {
+		lines = append(lines, renderStyledChars(line))
+	}
+	// 語 may not end a line before 。, so it moves down with it.
+	want := []string{"日本", "語。で", "す"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected lines %q, got %q", want, lines)
+	}
+
+	target = grapheme.Split("ab cd ef")
+	chars = buildStyledChars(target, nil, findWords(target), -1)
+	lines = lines[:0]
+	for _, line := range wrapLines(chars, 5) {
+		lines = append(lines, renderStyledChars(line))
+	}
+	if strings.Join(lines, "|") != "ab cd|ef" {
+		t.Fatalf("expected breaks at spaces, got %q", lines)
+	}
+}
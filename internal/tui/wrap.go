@@ -4,48 +4,59 @@ package tui
 import (
 	"strings"
 
-	"github.com/mattn/go-runewidth"
+	"github.com/verte-zerg/tuipe/internal/grapheme"
 )
 
-type styledRune struct {
+// styledChar is one rendered grapheme cluster of the text.
+type styledChar struct {
 	s       string
 	width   int
 	isSpace bool
+	// canBreak allows wrapping the line after the char.
+	canBreak bool
+	dir      direction
+	// index is the position of the char in the text.
+	index int
 }
 
-func buildStyledRunes(targetRunes, inputRunes []rune, cursorIndex int) []styledRune {
-	words := findWords(targetRunes)
+// buildStyledChars styles the text against the input; words are the word ranges of
+// target, used to highlight the current word.
+func buildStyledChars(target, input []string, words []wordRange, cursorIndex int) []styledChar {
 	currentWord := wordForCursor(words, cursorIndex)
+	breaks := grapheme.LineBreaks(strings.Join(target, ""))
 
-	out := make([]styledRune, 0, len(targetRunes))
-	for i, target := range targetRunes {
-		displayed := target
+	out := make([]styledChar, 0, len(target))
+	for i, want := range target {
+		displayed := want
 		style := pendingStyle
-		typed := i < len(inputRunes)
+		typed := i < len(input)
 		if typed {
 			switch {
-			case target == ' ' && inputRunes[i] != ' ':
-				displayed = '•'
+			case want == " " && input[i] != " ":
+				displayed = "•"
 				style = incorrectStyle
-			case inputRunes[i] == target:
+			case input[i] == want:
 				style = correctStyle
 			default:
 				style = incorrectStyle
 			}
-		} else if target != ' ' {
+		} else if want != " " {
 			if currentWord != nil && i >= currentWord.start && i < currentWord.end {
 				style = currentWordStyle
 			} else {
 				style = pendingStyle
 			}
 		}
-		if i == cursorIndex && i >= len(inputRunes) {
+		if i == cursorIndex && i >= len(input) {
 			style = style.Underline(true)
 		}
-		out = append(out, styledRune{
-			s:       style.Render(string(displayed)),
-			width:   runewidth.RuneWidth(displayed),
-			isSpace: target == ' ',
+		out = append(out, styledChar{
+			s:        style.Render(displayed),
+			width:    grapheme.Width(displayed),
+			isSpace:  want == " ",
+			canBreak: want == " " || (i < len(breaks) && breaks[i]),
+			dir:      clusterDirection(want),
+			index:    i,
 		})
 	}
 	return out
@@ -56,11 +67,11 @@ type wordRange struct {
 	end   int
 }
 
-func findWords(targetRunes []rune) []wordRange {
+func findWords(target []string) []wordRange {
 	words := []wordRange{}
 	start := -1
-	for i, r := range targetRunes {
-		if r == ' ' {
+	for i, c := range target {
+		if c == " " {
 			if start != -1 {
 				words = append(words, wordRange{start: start, end: i})
 				start = -1
@@ -72,7 +83,7 @@ func findWords(targetRunes []rune) []wordRange {
 		}
 	}
 	if start != -1 {
-		words = append(words, wordRange{start: start, end: len(targetRunes)})
+		words = append(words, wordRange{start: start, end: len(target)})
 	}
 	return words
 }
@@ -101,53 +112,105 @@ func wordForCursor(words []wordRange, cursorIndex int) *wordRange {
 	return &words[wordIdx]
 }
 
-func renderStyledRunes(runes []styledRune) string {
+func renderStyledChars(chars []styledChar) string {
 	var b strings.Builder
-	for _, item := range runes {
+	for _, item := range chars {
 		b.WriteString(item.s)
 	}
 	return b.String()
 }
 
-func wrapStyledRunes(runes []styledRune, width int) string {
-	if width <= 0 {
-		return renderStyledRunes(runes)
-	}
-	var out strings.Builder
-	line := make([]styledRune, 0, len(runes))
+// wrapLines splits chars into lines of at most width columns, breaking at the last
+// opportunity (a space, or between Chinese and Japanese characters) when possible. Spaces
+// a line breaks at are dropped.
+func wrapLines(chars []styledChar, width int) [][]styledChar {
+	var lines [][]styledChar
+	line := make([]styledChar, 0, len(chars))
 	lineWidth := 0
-	lastSpaceIdx := -1
+	lastBreakIdx := -1
 
-	for i := 0; i < len(runes); {
-		item := runes[i]
+	for i := 0; i < len(chars); {
+		item := chars[i]
 		if lineWidth+item.width > width && len(line) > 0 {
-			if lastSpaceIdx >= 0 {
-				out.WriteString(renderStyledRunes(line[:lastSpaceIdx]))
-				out.WriteRune('\n')
-				line = append([]styledRune{}, line[lastSpaceIdx+1:]...)
+			if item.isSpace {
+				// The line is full right before a space: break there.
+				lines = append(lines, trimSpaces(line))
+				line = []styledChar{}
+				lineWidth = 0
+				lastBreakIdx = -1
+				i++
+				continue
+			}
+			if lastBreakIdx >= 0 {
+				lines = append(lines, trimSpaces(line[:lastBreakIdx+1]))
+				line = append([]styledChar{}, line[lastBreakIdx+1:]...)
 				lineWidth = lineWidthOf(line)
-				lastSpaceIdx = lastSpaceIndex(line)
+				lastBreakIdx = lastBreakIndex(line)
 			} else {
-				out.WriteString(renderStyledRunes(line))
-				out.WriteRune('\n')
-				line = line[:0]
+				lines = append(lines, line)
+				line = []styledChar{}
 				lineWidth = 0
-				lastSpaceIdx = -1
+				lastBreakIdx = -1
 			}
 			continue
 		}
 		line = append(line, item)
 		lineWidth += item.width
-		if item.isSpace {
-			lastSpaceIdx = len(line) - 1
+		if item.canBreak {
+			lastBreakIdx = len(line) - 1
 		}
 		i++
 	}
-	out.WriteString(renderStyledRunes(line))
-	return out.String()
+	return append(lines, line)
+}
+
+// cursorLine returns the line holding the char at cursorIndex. A space dropped at a line
+// break counts with the line after it; a negative index (the text is complete) selects the
+// last line.
+func cursorLine(lines [][]styledChar, cursorIndex int) int {
+	if cursorIndex < 0 {
+		return len(lines) - 1
+	}
+	for i, line := range lines {
+		if len(line) > 0 && line[len(line)-1].index >= cursorIndex {
+			return i
+		}
+	}
+	return len(lines) - 1
+}
+
+// scrollWindow returns the lines [start, end) to show when total lines may not fit in
+// height rows. The cursor line stays in the middle row, except near either end of the
+// text, where the window stops at the first or last line instead of leaving rows empty.
+func scrollWindow(total, cursor, height int) (start, end int) {
+	if height <= 0 || total <= height {
+		return 0, total
+	}
+	start = max(0, min(cursor-height/2, total-height))
+	return start, start + height
+}
+
+// trimSpaces drops the spaces a line ends with.
+func trimSpaces(line []styledChar) []styledChar {
+	for len(line) > 0 && line[len(line)-1].isSpace {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+// renderLines joins the rendered lines, passing each through order when it is set.
+func renderLines(lines [][]styledChar, order func([]styledChar) []styledChar) string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		if order != nil {
+			line = order(line)
+		}
+		rendered[i] = renderStyledChars(line)
+	}
+	return strings.Join(rendered, "\n")
 }
 
-func lineWidthOf(line []styledRune) int {
+func lineWidthOf(line []styledChar) int {
 	total := 0
 	for _, item := range line {
 		total += item.width
@@ -155,9 +218,9 @@ func lineWidthOf(line []styledRune) int {
 	return total
 }
 
-func lastSpaceIndex(line []styledRune) int {
+func lastBreakIndex(line []styledChar) int {
 	for i := len(line) - 1; i >= 0; i-- {
-		if line[i].isSpace {
+		if line[i].canBreak {
 			return i
 		}
 	}
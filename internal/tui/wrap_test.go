@@ -1,88 +1,139 @@
 package tui
 
-import "testing"
+import (
+	"strings"
+	"testing"
 
-func TestBuildStyledRunesCursor(t *testing.T) {
-	target := []rune("ab")
-	input := []rune("a")
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+)
+
+func TestBuildStyledCharsCursor(t *testing.T) {
+	target := grapheme.Split("ab")
+	input := grapheme.Split("a")
 	cursorIndex := len(input)
 
-	runes := buildStyledRunes(target, input, cursorIndex)
-	if len(runes) != 2 {
-		t.Fatalf("expected 2 runes, got %d", len(runes))
+	chars := buildStyledChars(target, input, findWords(target), cursorIndex)
+	if len(chars) != 2 {
+		t.Fatalf("expected 2 chars, got %d", len(chars))
 	}
-	if runes[0].s != correctStyle.Render("a") {
-		t.Fatalf("expected correct style for first rune")
+	if chars[0].s != correctStyle.Render("a") {
+		t.Fatalf("expected correct style for first char")
 	}
-	if runes[1].s != cursorStyle.Render("b") {
-		t.Fatalf("expected cursor style for second rune")
+	if chars[1].s != cursorStyle.Render("b") {
+		t.Fatalf("expected cursor style for second char")
 	}
 }
 
-func TestBuildStyledRunesNoCursorWhenComplete(t *testing.T) {
-	target := []rune("a")
-	input := []rune("a")
+func TestBuildStyledCharsNoCursorWhenComplete(t *testing.T) {
+	target := grapheme.Split("a")
+	input := grapheme.Split("a")
 	cursorIndex := -1
 
-	runes := buildStyledRunes(target, input, cursorIndex)
-	if len(runes) != 1 {
-		t.Fatalf("expected 1 rune, got %d", len(runes))
+	chars := buildStyledChars(target, input, findWords(target), cursorIndex)
+	if len(chars) != 1 {
+		t.Fatalf("expected 1 char, got %d", len(chars))
 	}
-	if runes[0].s != correctStyle.Render("a") {
-		t.Fatalf("expected correct style for completed rune")
+	if chars[0].s != correctStyle.Render("a") {
+		t.Fatalf("expected correct style for completed char")
 	}
 }
 
-func TestBuildStyledRunesKeepsTargetOnMistype(t *testing.T) {
-	target := []rune("ab")
-	input := []rune("ax")
+func TestBuildStyledCharsKeepsTargetOnMistype(t *testing.T) {
+	target := grapheme.Split("ab")
+	input := grapheme.Split("ax")
 	cursorIndex := len(input)
 
-	runes := buildStyledRunes(target, input, cursorIndex)
-	if len(runes) != 2 {
-		t.Fatalf("expected 2 runes, got %d", len(runes))
+	chars := buildStyledChars(target, input, findWords(target), cursorIndex)
+	if len(chars) != 2 {
+		t.Fatalf("expected 2 chars, got %d", len(chars))
 	}
-	if runes[0].s != correctStyle.Render("a") {
-		t.Fatalf("expected correct style for first rune")
+	if chars[0].s != correctStyle.Render("a") {
+		t.Fatalf("expected correct style for first char")
 	}
-	if runes[1].s != incorrectStyle.Render("b") {
-		t.Fatalf("expected incorrect style for second rune")
+	if chars[1].s != incorrectStyle.Render("b") {
+		t.Fatalf("expected incorrect style for second char")
 	}
 }
 
-func TestBuildStyledRunesWordHighlighting(t *testing.T) {
-	target := []rune("one two")
-	input := []rune("o")
+func TestBuildStyledCharsWordHighlighting(t *testing.T) {
+	target := grapheme.Split("one two")
+	input := grapheme.Split("o")
 	cursorIndex := len(input)
 
-	runes := buildStyledRunes(target, input, cursorIndex)
-	if runes[0].s != correctStyle.Render("o") {
-		t.Fatalf("expected correct style for typed rune")
+	chars := buildStyledChars(target, input, findWords(target), cursorIndex)
+	if chars[0].s != correctStyle.Render("o") {
+		t.Fatalf("expected correct style for typed char")
 	}
-	if runes[1].s != currentWordStyle.Render("n") {
+	if chars[1].s != currentWordStyle.Render("n") {
 		t.Fatalf("expected current word style for untyped in current word")
 	}
-	if runes[2].s != currentWordStyle.Render("e") {
+	if chars[2].s != currentWordStyle.Render("e") {
 		t.Fatalf("expected current word style for untyped in current word")
 	}
-	if runes[4].s != pendingStyle.Render("t") {
+	if chars[4].s != pendingStyle.Render("t") {
 		t.Fatalf("expected pending style for next word")
 	}
-	if runes[6].s != pendingStyle.Render("o") {
+	if chars[6].s != pendingStyle.Render("o") {
 		t.Fatalf("expected pending style for next word")
 	}
 }
 
-func TestBuildStyledRunesWrongSpaceDot(t *testing.T) {
-	target := []rune("a b")
-	input := []rune("ax")
+func TestBuildStyledCharsWrongSpaceDot(t *testing.T) {
+	target := grapheme.Split("a b")
+	input := grapheme.Split("ax")
 	cursorIndex := len(input)
 
-	runes := buildStyledRunes(target, input, cursorIndex)
-	if len(runes) != 3 {
-		t.Fatalf("expected 3 runes, got %d", len(runes))
+	chars := buildStyledChars(target, input, findWords(target), cursorIndex)
+	if len(chars) != 3 {
+		t.Fatalf("expected 3 chars, got %d", len(chars))
 	}
-	if runes[1].s != incorrectStyle.Render("•") {
+	if chars[1].s != incorrectStyle.Render("•") {
 		t.Fatalf("expected red dot for wrong space")
 	}
 }
+
+func TestBuildStyledCharsClusters(t *testing.T) {
+	target := grapheme.Split("e\u0301日")
+	input := grapheme.Split("e\u0301")
+
+	chars := buildStyledChars(target, input, findWords(target), len(input))
+	if len(chars) != 2 {
+		t.Fatalf("expected 2 chars, got %d", len(chars))
+	}
+	if chars[0].s != correctStyle.Render("e\u0301") || chars[0].width != 1 {
+		t.Fatalf("expected a correct one-column cluster, got %q (width %d)", chars[0].s, chars[0].width)
+	}
+	if chars[1].width != 2 {
+		t.Fatalf("expected a wide char, got width %d", chars[1].width)
+	}
+}
+
+func TestLayoutTextKeepsCursorLineVisibleAfterResize(t *testing.T) {
+	target := grapheme.Split("aa bb cc dd ee ff gg hh ii jj")
+	cursorIndex := len(grapheme.Split("aa bb cc dd ee ff gg "))
+	chars := buildStyledChars(target, target[:cursorIndex], findWords(target), cursorIndex)
+	for _, width := range []int{10, 20, 6} {
+		view := layoutText(chars, cursorIndex, width, 4, 1, "footer", bidiNone)
+		lines := strings.Split(view, "\n")
+		if len(lines) != 4 {
+			t.Fatalf("width %d: expected the view to fit 4 rows, got %d:\n%s", width, len(lines), view)
+		}
+		if !strings.Contains(view, "hh") || !strings.Contains(lines[3], "footer") {
+			t.Fatalf("width %d: expected the cursor word and footer in view, got:\n%s", width, view)
+		}
+	}
+}
+
+func TestScrollWindowCentersCursor(t *testing.T) {
+	cases := []struct{ total, cursor, height, start, end int }{
+		{3, 2, 5, 0, 3},
+		{10, 0, 3, 0, 3},
+		{10, 5, 3, 4, 7},
+		{10, 9, 3, 7, 10},
+	}
+	for _, tc := range cases {
+		if start, end := scrollWindow(tc.total, tc.cursor, tc.height); start != tc.start || end != tc.end {
+			t.Fatalf("scrollWindow(%d, %d, %d) = [%d, %d), want [%d, %d)", tc.total, tc.cursor, tc.height, start, end, tc.start, tc.end)
+		}
+	}
+}
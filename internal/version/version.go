@@ -0,0 +1,18 @@
+// Package version reports the tuipe build version.
+package version
+
+import "runtime/debug"
+
+// Version is set at build time via -ldflags "-X github.com/verte-zerg/tuipe/internal/version.Version=v1.2.3".
+var Version = "dev"
+
+// String returns the build version, falling back to module build info for go install builds.
+func String() string {
+	if Version != "dev" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return Version
+}
@@ -0,0 +1,104 @@
+package wordfreq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultIndexURL is the PyPI JSON API base used to look up the wordfreq release.
+const DefaultIndexURL = "https://pypi.org/pypi"
+
+// DefaultRetries is how many times a failed request is retried.
+const DefaultRetries = 3
+
+// retryBackoff is the delay before the first retry; it doubles on each attempt.
+var retryBackoff = time.Second
+
+// DownloadOptions configures how the wordfreq wheel is fetched.
+type DownloadOptions struct {
+	// IndexURL is a PyPI JSON API base (a mirror such as a corporate proxy repository).
+	// The release is read from <IndexURL>/wordfreq[/<version>]/json. Empty means DefaultIndexURL.
+	IndexURL string
+	// Proxy is an explicit proxy URL. Empty falls back to HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+	Proxy string
+	// Retries is how many times a failed request is retried with exponential backoff.
+	Retries int
+	// Version pins a wordfreq release; empty means the latest one.
+	Version string
+}
+
+func (o DownloadOptions) endpoint() string {
+	base := strings.TrimRight(strings.TrimSpace(o.IndexURL), "/")
+	if base == "" {
+		base = DefaultIndexURL
+	}
+	if o.Version != "" {
+		return base + "/wordfreq/" + url.PathEscape(o.Version) + "/json"
+	}
+	return base + "/wordfreq/json"
+}
+
+// downloader issues GET requests with proxy support and retries.
+type downloader struct {
+	client  *http.Client
+	retries int
+}
+
+func newDownloader(opts DownloadOptions) (*downloader, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q", opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	retries := opts.Retries
+	if retries < 0 {
+		retries = 0
+	}
+	return &downloader{
+		client:  &http.Client{Timeout: 60 * time.Second, Transport: transport},
+		retries: retries,
+	}, nil
+}
+
+// get fetches url, retrying network errors and 429/5xx responses. The last response
+// is returned as-is so callers can report its status.
+func (d *downloader) get(ctx context.Context, url string) (*http.Response, error) {
+	delay := retryBackoff
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := d.client.Do(req)
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if attempt >= d.retries {
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, nil
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request failed: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
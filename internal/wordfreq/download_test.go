@@ -0,0 +1,114 @@
+package wordfreq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDownloadLatestWheelMirrorAndRetries(t *testing.T) {
+	prevBackoff := retryBackoff
+	retryBackoff = time.Millisecond
+	t.Cleanup(func() { retryBackoff = prevBackoff })
+
+	wheel, err := os.ReadFile(writeTestWheel(t, map[string][]byte{"wordfreq/data/large_en.msgpack": []byte("x")}))
+	if err != nil {
+		t.Fatalf("read wheel: %v", err)
+	}
+	indexCalls := 0
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/simple/wordfreq/json", func(w http.ResponseWriter, _ *http.Request) {
+		indexCalls++
+		if indexCalls < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"info":{"version":"9.9"},"urls":[{"url":"%s/files/wordfreq-9.9-py3-none-any.whl","filename":"wordfreq-9.9-py3-none-any.whl","packagetype":"bdist_wheel"}]}`, server.URL)
+	})
+	mux.HandleFunc("/files/wordfreq-9.9-py3-none-any.whl", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(wheel)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	got, err := DownloadLatestWheel(context.Background(), t.TempDir(), DownloadOptions{IndexURL: server.URL + "/simple/", Retries: 2})
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	if got.Version != "9.9" || got.Cached || indexCalls != 3 {
+		t.Fatalf("unexpected result %+v after %d index calls", got, indexCalls)
+	}
+
+	indexCalls = 0
+	if _, err := DownloadLatestWheel(context.Background(), t.TempDir(), DownloadOptions{IndexURL: server.URL + "/simple", Retries: 1}); err == nil {
+		t.Fatalf("expected failure when retries run out")
+	}
+	if indexCalls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", indexCalls)
+	}
+}
+
+func TestDownloadOptionsRejectInvalidProxy(t *testing.T) {
+	if _, err := DownloadLatestWheel(context.Background(), t.TempDir(), DownloadOptions{Proxy: "not a url"}); err == nil {
+		t.Fatalf("expected invalid proxy error")
+	}
+}
+
+func TestDownloadWheelChecksumAndPin(t *testing.T) {
+	wheel, err := os.ReadFile(writeTestWheel(t, map[string][]byte{"wordfreq/data/large_en.msgpack": []byte("x")}))
+	if err != nil {
+		t.Fatalf("read wheel: %v", err)
+	}
+	sum := sha256.Sum256(wheel)
+	digest := hex.EncodeToString(sum[:])
+
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	serveIndex := func(version, sha string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"info":{"version":%q},"urls":[{"url":"%s/files/w.whl","filename":"wordfreq-%s-py3-none-any.whl","packagetype":"bdist_wheel","digests":{"sha256":%q}}]}`,
+				version, server.URL, version, sha)
+		}
+	}
+	mux.HandleFunc("/pypi/wordfreq/3.0/json", serveIndex("3.0", digest))
+	mux.HandleFunc("/pypi/wordfreq/json", serveIndex("3.1", "00"+digest[2:]))
+	mux.HandleFunc("/files/w.whl", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(wheel)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	opts := DownloadOptions{IndexURL: server.URL + "/pypi", Version: "3.0"}
+	got, err := DownloadLatestWheel(context.Background(), cacheDir, opts)
+	if err != nil {
+		t.Fatalf("pinned download: %v", err)
+	}
+	if got.Version != "3.0" || !got.Verified || got.SHA256 != digest {
+		t.Fatalf("unexpected pinned wheel %+v", got)
+	}
+
+	// A corrupted cache entry is detected and replaced.
+	if err := os.WriteFile(got.Path, []byte("garbage"), 0o644); err != nil {
+		t.Fatalf("corrupt cache: %v", err)
+	}
+	got, err = DownloadLatestWheel(context.Background(), cacheDir, opts)
+	if err != nil || got.Cached || got.SHA256 != digest {
+		t.Fatalf("expected fresh download over corrupted cache, got %+v err=%v", got, err)
+	}
+	got, err = DownloadLatestWheel(context.Background(), cacheDir, opts)
+	if err != nil || !got.Cached {
+		t.Fatalf("expected verified cache hit, got %+v err=%v", got, err)
+	}
+
+	if _, err := DownloadLatestWheel(context.Background(), t.TempDir(), DownloadOptions{IndexURL: server.URL + "/pypi"}); err == nil {
+		t.Fatalf("expected checksum mismatch error")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"archive/zip"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,21 +17,21 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
-	"unicode"
 	"unicode/utf8"
 
 	"github.com/verte-zerg/tuipe/internal/wordlist"
 )
 
-const pypiEndpoint = "https://pypi.org/pypi/wordfreq/json"
-
 // Wheel describes a cached wordfreq wheel.
 type Wheel struct {
 	Version  string
 	Path     string
 	Filename string
 	Cached   bool
+	// SHA256 is the hex digest of the wheel. Verified reports whether it matched the
+	// digest published by the index; mirrors that omit digests leave it false.
+	SHA256   string
+	Verified bool
 }
 type wordEntry struct {
 	word  string
@@ -40,16 +42,22 @@ type pypiResponse struct {
 	Info struct {
 		Version string `json:"version"`
 	} `json:"info"`
-	URLs []struct {
-		URL          string `json:"url"`
-		Filename     string `json:"filename"`
-		Packagetype  string `json:"packagetype"`
-		PythonTarget string `json:"python_version"`
-	} `json:"urls"`
+	URLs []pypiFile `json:"urls"`
+}
+
+type pypiFile struct {
+	URL          string `json:"url"`
+	Filename     string `json:"filename"`
+	Packagetype  string `json:"packagetype"`
+	PythonTarget string `json:"python_version"`
+	Digests      struct {
+		SHA256 string `json:"sha256"`
+	} `json:"digests"`
 }
 
-// DownloadLatestWheel fetches the latest wordfreq wheel into cacheDir.
-func DownloadLatestWheel(ctx context.Context, cacheDir string) (Wheel, error) {
+// DownloadLatestWheel fetches the latest (or opts.Version) wordfreq wheel into cacheDir
+// and verifies it against the sha256 published by the index.
+func DownloadLatestWheel(ctx context.Context, cacheDir string, opts DownloadOptions) (Wheel, error) {
 	if cacheDir == "" {
 		return Wheel{}, fmt.Errorf("cache directory is required")
 	}
@@ -57,7 +65,11 @@ func DownloadLatestWheel(ctx context.Context, cacheDir string) (Wheel, error) {
 		return Wheel{}, fmt.Errorf("failed to create cache dir: %w", err)
 	}
 
-	resp, err := httpRequest(ctx, pypiEndpoint)
+	dl, err := newDownloader(opts)
+	if err != nil {
+		return Wheel{}, err
+	}
+	resp, err := dl.get(ctx, opts.endpoint())
 	if err != nil {
 		return Wheel{}, err
 	}
@@ -77,14 +89,33 @@ func DownloadLatestWheel(ctx context.Context, cacheDir string) (Wheel, error) {
 		return Wheel{}, fmt.Errorf("missing version in pypi response")
 	}
 
-	url, filename := pickWheelURL(payload.URLs)
-	if url == "" || filename == "" {
+	if opts.Version != "" && payload.Info.Version != opts.Version {
+		return Wheel{}, fmt.Errorf("index returned wordfreq %s, expected %s", payload.Info.Version, opts.Version)
+	}
+
+	file, ok := pickWheelFile(payload.URLs)
+	if !ok {
 		return Wheel{}, fmt.Errorf("no suitable wordfreq wheel found")
 	}
+	url, filename := file.URL, filepath.Base(file.Filename)
+	expected := strings.ToLower(file.Digests.SHA256)
+	wheel := Wheel{Version: payload.Info.Version, Filename: filename, Verified: expected != ""}
 
 	destPath := filepath.Join(cacheDir, filename)
+	wheel.Path = destPath
 	if _, err := os.Stat(destPath); err == nil {
-		return Wheel{Version: payload.Info.Version, Path: destPath, Filename: filename, Cached: true}, nil
+		sum, err := fileSHA256(destPath)
+		if err != nil {
+			return Wheel{}, err
+		}
+		if expected == "" || sum == expected {
+			wheel.Cached, wheel.SHA256 = true, sum
+			return wheel, nil
+		}
+		// A corrupted or tampered cache entry is replaced by a fresh download.
+		if err := os.Remove(destPath); err != nil {
+			return Wheel{}, fmt.Errorf("failed to remove corrupted cached wheel: %w", err)
+		}
 	} else if !errors.Is(err, os.ErrNotExist) {
 		return Wheel{}, fmt.Errorf("failed to stat cached wheel: %w", err)
 	}
@@ -99,7 +130,7 @@ func DownloadLatestWheel(ctx context.Context, cacheDir string) (Wheel, error) {
 		_ = os.Remove(tmpPath)
 	}()
 
-	wheelResp, err := httpRequest(ctx, url)
+	wheelResp, err := dl.get(ctx, url)
 	if err != nil {
 		return Wheel{}, err
 	}
@@ -110,9 +141,14 @@ func DownloadLatestWheel(ctx context.Context, cacheDir string) (Wheel, error) {
 		return Wheel{}, fmt.Errorf("unexpected wheel status: %s", wheelResp.Status)
 	}
 
-	if _, err := io.Copy(tmpFile, wheelResp.Body); err != nil {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, hash), wheelResp.Body); err != nil {
 		return Wheel{}, fmt.Errorf("failed to download wheel: %w", err)
 	}
+	wheel.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	if expected != "" && wheel.SHA256 != expected {
+		return Wheel{}, fmt.Errorf("wheel checksum mismatch: got sha256 %s, expected %s", wheel.SHA256, expected)
+	}
 	if err := tmpFile.Close(); err != nil {
 		return Wheel{}, fmt.Errorf("failed to close temp wheel: %w", err)
 	}
@@ -120,11 +156,35 @@ func DownloadLatestWheel(ctx context.Context, cacheDir string) (Wheel, error) {
 		return Wheel{}, fmt.Errorf("failed to move wheel into cache: %w", err)
 	}
 
-	return Wheel{Version: payload.Info.Version, Path: destPath, Filename: filename, Cached: false}, nil
+	return wheel, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open cached wheel: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash cached wheel: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 // ExtractWordlist extracts a word list from the wheel for the given language and type.
 func ExtractWordlist(wheelPath, lang, listType string, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+	return ExtractWordlistRange(wheelPath, lang, listType, 1, limit, wordlist.DefaultRules())
+}
+
+// ExtractWordlistRange extracts the words ranked from..to (1-based, inclusive) by
+// frequency. Ranks count only words that pass rules and the language filter.
+func ExtractWordlistRange(wheelPath, lang, listType string, from, to int, rules wordlist.Rules) ([]string, error) {
 	if wheelPath == "" {
 		return nil, fmt.Errorf("wheel path is required")
 	}
@@ -135,8 +195,11 @@ func ExtractWordlist(wheelPath, lang, listType string, limit int) ([]string, err
 	if listType == "" {
 		return nil, fmt.Errorf("word list type is required")
 	}
-	if limit <= 0 {
-		return nil, fmt.Errorf("limit must be greater than 0")
+	if from < 1 || to < from {
+		return nil, fmt.Errorf("invalid rank range %d:%d", from, to)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
 	}
 
 	entries, err := readWordEntries(wheelPath, lang, listType)
@@ -147,30 +210,32 @@ func ExtractWordlist(wheelPath, lang, listType string, limit int) ([]string, err
 		return entries[i].score > entries[j].score
 	})
 
-	words := make([]string, 0, len(entries))
+	words := make([]string, 0, to-from+1)
 	seen := make(map[string]struct{})
+	rank := 0
 	langFilter := wordlist.FilterForLang(lang)
 	for _, entry := range entries {
-		if _, ok := seen[entry.word]; ok {
+		word := rules.Normalize(entry.word)
+		if _, ok := seen[word]; ok {
 			continue
 		}
-		if !isAlpha(entry.word) {
+		if !rules.Accept(word, langFilter) {
 			continue
 		}
-		length := utf8.RuneCountInString(entry.word)
-		if length < 2 || length > 20 {
+		seen[word] = struct{}{}
+		rank++
+		if rank < from {
 			continue
 		}
-		if !langFilter(entry.word) {
-			continue
-		}
-		seen[entry.word] = struct{}{}
-		words = append(words, entry.word)
-		if len(words) >= limit {
+		words = append(words, word)
+		if rank >= to {
 			break
 		}
 	}
 	if len(words) == 0 {
+		if from > 1 {
+			return nil, fmt.Errorf("no words ranked %d:%d for %s/%s (only %d available)", from, to, lang, listType, rank)
+		}
 		return nil, fmt.Errorf("no words found for %s/%s", lang, listType)
 	}
 	return words, nil
@@ -187,7 +252,7 @@ func WriteAttribution(wheelPath, outDir string) error {
 		"Source: https://github.com/rspeer/wordfreq",
 		"Data license: Creative Commons Attribution-ShareAlike 4.0 International (CC BY-SA 4.0).",
 		"This word list is licensed CC BY-SA 4.0: https://creativecommons.org/licenses/by-sa/4.0/",
-		"Changes were made: filtered to alphabetic words and truncated to the requested size.",
+		"Changes were made: filtered to words of letters (optionally with apostrophes or hyphens) and truncated to the requested size.",
 		"Includes data from Google Books Ngrams (acknowledgement requested by wordfreq): https://books.google.com/ngrams",
 		"Includes data from the Leeds Internet Corpus: https://corpus.leeds.ac.uk/",
 		"For other upstream sources, see the wordfreq project documentation.",
@@ -218,39 +283,21 @@ func WriteAttribution(wheelPath, outDir string) error {
 	return nil
 }
 
-func httpRequest(ctx context.Context, url string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	return resp, nil
-}
-
-func pickWheelURL(urls []struct {
-	URL          string `json:"url"`
-	Filename     string `json:"filename"`
-	Packagetype  string `json:"packagetype"`
-	PythonTarget string `json:"python_version"`
-}) (string, string) {
-	for _, u := range urls {
-		if u.Packagetype != "bdist_wheel" {
+func pickWheelFile(files []pypiFile) (pypiFile, bool) {
+	for _, f := range files {
+		if f.Packagetype != "bdist_wheel" {
 			continue
 		}
-		if strings.HasSuffix(u.Filename, "py3-none-any.whl") {
-			return u.URL, u.Filename
+		if strings.HasSuffix(f.Filename, "py3-none-any.whl") {
+			return f, f.URL != "" && f.Filename != ""
 		}
 	}
-	for _, u := range urls {
-		if u.Packagetype == "bdist_wheel" {
-			return u.URL, u.Filename
+	for _, f := range files {
+		if f.Packagetype == "bdist_wheel" {
+			return f, f.URL != "" && f.Filename != ""
 		}
 	}
-	return "", ""
+	return pypiFile{}, false
 }
 
 func normalizeLang(lang string) string {
@@ -751,15 +798,6 @@ func toStringSlice(v interface{}) ([]string, bool) {
 	}
 }
 
-func isAlpha(word string) bool {
-	for _, r := range word {
-		if !unicode.IsLetter(r) {
-			return false
-		}
-	}
-	return word != ""
-}
-
 func readWheelLicense(wheelPath string) ([]byte, error) {
 	reader, err := zip.OpenReader(wheelPath)
 	if err != nil {
@@ -7,7 +7,10 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/wordlist"
 )
 
 func TestExtractWordlistOrderAndFilter(t *testing.T) {
@@ -54,6 +57,60 @@ func TestExtractWordlistLimit(t *testing.T) {
 	}
 }
 
+func TestExtractWordlistRange(t *testing.T) {
+	data := encodeTestMsgpack([]interface{}{
+		[]interface{}{5.0, []interface{}{"hello", "a", "world"}},
+		[]interface{}{4.0, []interface{}{"again", "go-1", "more"}},
+		[]interface{}{3.0, []interface{}{"words"}},
+	})
+	wheelPath := writeTestWheel(t, map[string][]byte{
+		"wordfreq/data/large_en.msgpack": data,
+	})
+
+	words, err := ExtractWordlistRange(wheelPath, "en", "large", 2, 4, wordlist.DefaultRules())
+	if err != nil {
+		t.Fatalf("ExtractWordlistRange failed: %v", err)
+	}
+	expected := []string{"world", "again", "more"}
+	if len(words) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, words)
+	}
+	for i, word := range expected {
+		if words[i] != word {
+			t.Fatalf("expected %q at index %d, got %q", word, i, words[i])
+		}
+	}
+	if _, err := ExtractWordlistRange(wheelPath, "en", "large", 10, 20, wordlist.DefaultRules()); err == nil {
+		t.Fatalf("expected error for a range past the end of the list")
+	}
+	if _, err := ExtractWordlistRange(wheelPath, "en", "large", 3, 2, wordlist.DefaultRules()); err == nil {
+		t.Fatalf("expected error for an inverted range")
+	}
+}
+
+func TestExtractWordlistRules(t *testing.T) {
+	data := encodeTestMsgpack([]interface{}{
+		[]interface{}{5.0, []interface{}{"don't", "co-op", "the"}},
+		[]interface{}{4.0, []interface{}{"don’t", "a", "-ish"}},
+	})
+	wheelPath := writeTestWheel(t, map[string][]byte{
+		"wordfreq/data/large_en.msgpack": data,
+	})
+
+	rules := wordlist.Rules{Apostrophes: true, Hyphens: true, MinLength: 1, MaxLength: 20}
+	words, err := ExtractWordlistRange(wheelPath, "en", "large", 1, 10, rules)
+	if err != nil {
+		t.Fatalf("ExtractWordlistRange failed: %v", err)
+	}
+	if got := strings.Join(words, ","); got != "don't,co-op,the,a" {
+		t.Fatalf("unexpected words: %s", got)
+	}
+	words, err = ExtractWordlistRange(wheelPath, "en", "large", 1, 10, wordlist.DefaultRules())
+	if err != nil || strings.Join(words, ",") != "the" {
+		t.Fatalf("unexpected default-rule words: %v %v", words, err)
+	}
+}
+
 func encodeTestMsgpack(value interface{}) []byte {
 	var buf bytes.Buffer
 	writeMsgpack(&buf, value)
@@ -0,0 +1,28 @@
+package wordlist
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// BuiltinLang is the language of the list compiled into the binary.
+const BuiltinLang = "en"
+
+// BuiltinPath is recorded as the wordlist path of sessions typed from the built-in list.
+const BuiltinPath = "builtin:" + BuiltinLang
+
+//go:embed builtin_en.txt
+var builtinEN string
+
+// Builtin returns the small embedded list for lang and list, if there is one. It lets a
+// first run work offline before any wordlist has been downloaded.
+func Builtin(lang, list string) ([]string, bool) {
+	if lang != BuiltinLang || (list != "" && list != DefaultList) {
+		return nil, false
+	}
+	words, err := readWords(strings.NewReader(builtinEN))
+	if err != nil {
+		return nil, false
+	}
+	return words, true
+}
@@ -0,0 +1,27 @@
+package wordlist
+
+import "testing"
+
+func TestBuiltinEnglish(t *testing.T) {
+	words, ok := Builtin("en", "")
+	if !ok || len(words) < 500 {
+		t.Fatalf("expected embedded english list, got ok=%v len=%d", ok, len(words))
+	}
+	keep := FilterForLang("en")
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if !keep(word) {
+			t.Fatalf("built-in word %q does not pass the english filter", word)
+		}
+		if _, dup := seen[word]; dup {
+			t.Fatalf("duplicate built-in word %q", word)
+		}
+		seen[word] = struct{}{}
+	}
+	if _, ok := Builtin("en", "coding"); ok {
+		t.Fatalf("expected no built-in coding list")
+	}
+	if _, ok := Builtin("de", ""); ok {
+		t.Fatalf("expected no built-in german list")
+	}
+}
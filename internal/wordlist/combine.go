@@ -0,0 +1,43 @@
+package wordlist
+
+import "strings"
+
+// SplitLangs splits a combined language code such as "en,de" into its trimmed parts.
+func SplitLangs(langs string) []string {
+	var out []string
+	for _, part := range strings.Split(langs, ",") {
+		if part = strings.TrimSpace(strings.ToLower(part)); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// Interleave merges lists round-robin so each keeps its frequency order, dropping
+// words already taken from an earlier list.
+func Interleave(lists ...[]string) []string {
+	total := 0
+	for _, list := range lists {
+		total += len(list)
+	}
+	out := make([]string, 0, total)
+	seen := make(map[string]struct{}, total)
+	for i := 0; len(out) < total; i++ {
+		taken := false
+		for _, list := range lists {
+			if i >= len(list) {
+				continue
+			}
+			taken = true
+			if _, ok := seen[list[i]]; ok {
+				continue
+			}
+			seen[list[i]] = struct{}{}
+			out = append(out, list[i])
+		}
+		if !taken {
+			break
+		}
+	}
+	return out
+}
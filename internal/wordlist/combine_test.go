@@ -0,0 +1,20 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInterleave(t *testing.T) {
+	got := Interleave([]string{"the", "of", "and", "to"}, []string{"der", "die", "and"}, nil)
+	if strings.Join(got, ",") != "the,der,of,die,and,to" {
+		t.Fatalf("unexpected interleave: %v", got)
+	}
+	if langs := SplitLangs(" EN, de,,"); strings.Join(langs, ",") != "en,de" {
+		t.Fatalf("unexpected split: %v", langs)
+	}
+	filter := FilterForLang("en,ru")
+	if !filter("hello") || !filter("привет") || filter("приvет") {
+		t.Fatalf("combined filter should accept either language but not mixed words")
+	}
+}
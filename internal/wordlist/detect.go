@@ -0,0 +1,121 @@
+package wordlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode"
+)
+
+// AutoLang is the language code that asks for detection from the practice text.
+const AutoLang = "auto"
+
+// detectSample is how much text DetectLang reads; the opening of a text is plenty.
+const detectSample = 64 * 1024
+
+// scriptLangs names the language practiced for scripts used by one main language.
+var scriptLangs = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Greek, "el"}, {unicode.Arabic, "ar"}, {unicode.Hebrew, "he"},
+	{unicode.Devanagari, "hi"}, {unicode.Thai, "th"}, {unicode.Hangul, "ko"},
+	{unicode.Georgian, "ka"}, {unicode.Armenian, "hy"},
+}
+
+// DetectLang guesses the language of a text from its dominant writing system and, for
+// Latin and Cyrillic text, from the letters that only some alphabets have: "ñ" points
+// to Spanish, "ї" to Ukrainian. Latin text without such letters is English.
+func DetectLang(r io.Reader) (string, error) {
+	counts := map[rune]int{}
+	letters := 0
+	reader := bufio.NewReader(io.LimitReader(r, detectSample))
+	for {
+		c, _, err := reader.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if unicode.IsLetter(c) {
+			counts[unicode.ToLower(c)]++
+			letters++
+		}
+	}
+	if letters == 0 {
+		return "", fmt.Errorf("no letters to detect a language from")
+	}
+
+	// Kana counts with Han for the dominant script, as in filterSingleScript.
+	scriptCounts := map[*unicode.RangeTable]int{}
+	kana := 0
+	for c, n := range counts {
+		if script := scriptOf(c); script != nil {
+			scriptCounts[script] += n
+		}
+		if unicode.In(c, unicode.Hiragana, unicode.Katakana) {
+			kana += n
+		}
+	}
+	var script *unicode.RangeTable
+	best := 0
+	for _, table := range scripts {
+		if scriptCounts[table] > best {
+			script, best = table, scriptCounts[table]
+		}
+	}
+	switch script {
+	case nil:
+		return "", fmt.Errorf("cannot tell the language of the text")
+	case unicode.Latin:
+		return alphabetLang(counts, []string{"de", "fr", "es", "pt", "it"}, "en"), nil
+	case unicode.Cyrillic:
+		return alphabetLang(counts, []string{"ru", "uk"}, "ru"), nil
+	case unicode.Han:
+		if kana > 0 {
+			return "ja", nil
+		}
+		return "zh", nil
+	}
+	for _, s := range scriptLangs {
+		if s.script == script {
+			return s.lang, nil
+		}
+	}
+	return "", fmt.Errorf("cannot tell the language of the text")
+}
+
+// alphabetLang scores each candidate by the letters of the text that its alphabet has
+// beyond the shared basic letters, minus those it lacks. Ties go to the earlier
+// candidate, and fallback wins when no candidate scores above zero.
+func alphabetLang(counts map[rune]int, candidates []string, fallback string) string {
+	common := runeSet(langAlphabets[candidates[0]])
+	for _, lang := range candidates[1:] {
+		alphabet := runeSet(langAlphabets[lang])
+		for c := range common {
+			if _, ok := alphabet[c]; !ok {
+				delete(common, c)
+			}
+		}
+	}
+	best, bestScore := fallback, 0
+	for _, lang := range candidates {
+		alphabet := runeSet(langAlphabets[lang])
+		score := 0
+		for c, n := range counts {
+			if _, ok := common[c]; ok || c < unicode.MaxASCII {
+				continue
+			}
+			if _, ok := alphabet[c]; ok {
+				score += n
+			} else {
+				score -= n
+			}
+		}
+		if score > bestScore {
+			best, bestScore = lang, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,32 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectLang(t *testing.T) {
+	cases := []struct {
+		text, lang string
+	}{
+		{"The quick brown fox jumps over the lazy dog.", "en"},
+		{"Der Fuß ist größer als die Straße, über alles.", "de"},
+		{"¿Dónde está el niño? Mañana, señor.", "es"},
+		{"Le garçon a été très élégant à la fête.", "fr"},
+		{"Não há coração sem razão.", "pt"},
+		{"Съешь же ещё этих мягких французских булок.", "ru"},
+		{"Їжак їсть яблуко, а ґава співає.", "uk"},
+		{"Καλημέρα κόσμε", "el"},
+		{"東京タワーへ行きます。", "ja"},
+		{"我们今天学习中文。", "zh"},
+	}
+	for _, c := range cases {
+		got, err := DetectLang(strings.NewReader(c.text))
+		if err != nil || got != c.lang {
+			t.Fatalf("DetectLang(%q) = %q, %v; want %q", c.text, got, err, c.lang)
+		}
+	}
+	if _, err := DetectLang(strings.NewReader("12 + 34 = 46")); err == nil {
+		t.Fatalf("expected an error for text without letters")
+	}
+}
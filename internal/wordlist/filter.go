@@ -1,30 +1,154 @@
 // Package wordlist provides word list filtering helpers.
 package wordlist
 
-import "strings"
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
 
 // FilterFunc returns true when a word should be kept.
 type FilterFunc func(string) bool
 
-// FilterForLang returns a language-specific filter for word lists.
+// FilterForLang returns a language-specific filter for word lists. Languages with a
+// known alphabet keep only its letters; others reject tokens that mix writing systems.
+// A combined code such as "en,de" keeps words accepted by any of its languages.
 func FilterForLang(lang string) FilterFunc {
-	switch strings.ToLower(lang) {
-	case "en":
+	lang = strings.ToLower(lang)
+	if strings.Contains(lang, ",") {
+		var filters []FilterFunc
+		for _, part := range SplitLangs(lang) {
+			filters = append(filters, FilterForLang(part))
+		}
+		return Either(filters...)
+	}
+	if lang == "en" {
 		return filterEnglishASCII
-	default:
-		return func(string) bool { return true }
 	}
+	if alphabet, ok := langAlphabets[lang]; ok {
+		return alphabetFilter(alphabet)
+	}
+	return filterSingleScript
+}
+
+// FilterName describes the filter FilterForLang picks for lang, for provenance records.
+func FilterName(lang string) string {
+	lang = strings.ToLower(lang)
+	if strings.Contains(lang, ",") {
+		var names []string
+		for _, part := range SplitLangs(lang) {
+			names = append(names, FilterName(part))
+		}
+		return strings.Join(names, "|")
+	}
+	if lang == "en" {
+		return "ascii"
+	}
+	if _, ok := langAlphabets[lang]; ok {
+		return "alphabet:" + lang
+	}
+	return "single-script"
 }
 
+// filterEnglishASCII keeps lower-case ASCII words. An apostrophe between letters is
+// allowed, so contractions such as don't and o'clock pass.
 func filterEnglishASCII(word string) bool {
 	if word == "" {
 		return false
 	}
 	for i := 0; i < len(word); i++ {
 		ch := word[i]
+		if ch == '\'' && i > 0 && i < len(word)-1 && word[i-1] != '\'' {
+			continue
+		}
 		if ch < 'a' || ch > 'z' {
 			return false
 		}
 	}
 	return true
 }
+
+// FilterChars rejects words containing any rune of exclude and, when only is non-empty,
+// words with runes outside only. Matching ignores case.
+func FilterChars(exclude, only string) FilterFunc {
+	excluded := runeSet(exclude)
+	allowed := runeSet(only)
+	return func(word string) bool {
+		for _, r := range word {
+			r = unicode.ToLower(r)
+			if _, ok := excluded[r]; ok {
+				return false
+			}
+			if len(allowed) > 0 {
+				if _, ok := allowed[r]; !ok {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+// Both returns a filter keeping words accepted by every filter.
+func Both(filters ...FilterFunc) FilterFunc {
+	return func(word string) bool {
+		for _, filter := range filters {
+			if !filter(word) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Either returns a filter keeping words accepted by at least one filter.
+func Either(filters ...FilterFunc) FilterFunc {
+	return func(word string) bool {
+		for _, filter := range filters {
+			if filter(word) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Filter returns the words accepted by keep.
+func Filter(words []string, keep FilterFunc) []string {
+	out := make([]string, 0, len(words))
+	for _, word := range words {
+		if keep(word) {
+			out = append(out, word)
+		}
+	}
+	return out
+}
+
+func runeSet(s string) map[rune]struct{} {
+	set := map[rune]struct{}{}
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		set[unicode.ToLower(r)] = struct{}{}
+	}
+	return set
+}
+
+// Alphabet returns the sorted lower-case letters used by words.
+func Alphabet(words []string) string {
+	seen := map[rune]struct{}{}
+	for _, word := range words {
+		for _, r := range word {
+			if unicode.IsLetter(r) {
+				seen[unicode.ToLower(r)] = struct{}{}
+			}
+		}
+	}
+	letters := make([]rune, 0, len(seen))
+	for r := range seen {
+		letters = append(letters, r)
+	}
+	slices.Sort(letters)
+	return string(letters)
+}
@@ -1,15 +1,90 @@
 package wordlist
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestFilterEnglishASCII(t *testing.T) {
 	filter := FilterForLang("en")
 	if !filter("hello") {
 		t.Fatalf("expected hello to pass english filter")
 	}
-	for _, word := range []string{"résumé", "naïve", "don’t", "co-op"} {
+	for _, word := range []string{"don't", "o'clock"} {
+		if !filter(word) {
+			t.Fatalf("expected contraction %q to pass english filter", word)
+		}
+	}
+	for _, word := range []string{"résumé", "naïve", "don’t", "co-op", "'tis", "dogs'", "don''t"} {
 		if filter(word) {
 			t.Fatalf("expected %q to be rejected", word)
 		}
 	}
 }
+
+func TestFilterChars(t *testing.T) {
+	words := []string{"sad", "Fads", "jade", "lass", "glad"}
+	if got := Filter(words, FilterChars("", "asdfl")); strings.Join(got, ",") != "sad,Fads,lass" {
+		t.Fatalf("unexpected only-chars result: %v", got)
+	}
+	if got := Filter(words, FilterChars("D", "")); strings.Join(got, ",") != "lass" {
+		t.Fatalf("unexpected exclude-chars result: %v", got)
+	}
+	if got := Filter(words, FilterChars("f", "asdfgl")); strings.Join(got, ",") != "sad,lass,glad" {
+		t.Fatalf("unexpected combined result: %v", got)
+	}
+}
+
+func TestFilterForLangScripts(t *testing.T) {
+	cases := []struct {
+		lang   string
+		keep   []string
+		reject []string
+	}{
+		{"de", []string{"straße", "über"}, []string{"façade", "hello1", "mañana"}},
+		{"fr", []string{"garçon", "œuvre", "été"}, []string{"straße", "привет"}},
+		{"es", []string{"mañana", "pingüino"}, []string{"garçon"}},
+		{"ru", []string{"ёлка", "привет"}, []string{"hello", "приvет", "їжак"}},
+		{"uk", []string{"їжак", "ґанок"}, []string{"ёлка", "hello"}},
+		{"el", []string{"καλημέρα"}, []string{"καλημέραx", "abc1"}},
+		{"ja", []string{"東京タワー"}, []string{"東京tower"}},
+		{"fa", []string{"کتاب", "می\u200cروم"}, []string{"کتابbook", "\u200cروم"}},
+		{"he", []string{"שלום"}, []string{"שלוםx"}},
+	}
+	for _, tc := range cases {
+		filter := FilterForLang(tc.lang)
+		for _, word := range tc.keep {
+			if !filter(word) {
+				t.Fatalf("%s: expected %q to be kept", tc.lang, word)
+			}
+		}
+		for _, word := range tc.reject {
+			if filter(word) {
+				t.Fatalf("%s: expected %q to be rejected", tc.lang, word)
+			}
+		}
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	cases := []struct{ lang, in, want string }{
+		{"de", "Straße", "Strasse"},
+		{"de", "über", "ueber"},
+		{"fr", "Été", "Ete"},
+		{"es", "mañana", "manana"},
+		{"ru", "Щука", "Shchuka"},
+		{"uk", "їжак", "yizhak"},
+		{"ja", "東京", "東京"},
+	}
+	for _, tc := range cases {
+		if got := Transliterate(tc.lang, tc.in); got != tc.want {
+			t.Fatalf("Transliterate(%s, %q) = %q, want %q", tc.lang, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestAlphabet(t *testing.T) {
+	if got := Alphabet([]string{"Zoo", "don't", "ab"}); got != "abdnotz" {
+		t.Fatalf("unexpected alphabet %q", got)
+	}
+}
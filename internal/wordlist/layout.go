@@ -0,0 +1,95 @@
+package wordlist
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultList is the list name used when none is requested.
+const DefaultList = "common"
+
+// attributionFiles are written next to the wordlists and are not lists themselves.
+var attributionFiles = map[string]struct{}{
+	"ATTRIBUTION.txt":  {},
+	"LICENSE.txt":      {},
+	"DATA_LICENSE.txt": {},
+}
+
+// ListPath returns where the named list for lang lives: <dir>/<lang>/<list>.txt.
+func ListPath(dir, lang, list string) string {
+	if list == "" {
+		list = DefaultList
+	}
+	return filepath.Join(dir, lang, list+".txt")
+}
+
+// LegacyPath returns the single-file location used before named lists: <dir>/<lang>.txt.
+func LegacyPath(dir, lang string) string {
+	return filepath.Join(dir, lang+".txt")
+}
+
+// ResolvePath returns the file for the named list of lang. The default list falls back
+// to the legacy <lang>.txt file when <lang>/common.txt does not exist.
+func ResolvePath(dir, lang, list string) string {
+	path := ListPath(dir, lang, list)
+	if list != "" && list != DefaultList {
+		return path
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	legacy := LegacyPath(dir, lang)
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy
+	}
+	return path
+}
+
+// ListInstalled maps each language in dir to its sorted list names. A legacy
+// <lang>.txt file counts as the default list.
+func ListInstalled(dir string) (map[string][]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]map[string]struct{}{}
+	add := func(lang, list string) {
+		if found[lang] == nil {
+			found[lang] = map[string]struct{}{}
+		}
+		found[lang][list] = struct{}{}
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() {
+			if _, ok := attributionFiles[name]; ok || !strings.HasSuffix(name, ".txt") {
+				continue
+			}
+			add(strings.TrimSuffix(name, ".txt"), DefaultList)
+			continue
+		}
+		lists, err := os.ReadDir(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		for _, list := range lists {
+			if list.IsDir() || !strings.HasSuffix(list.Name(), ".txt") {
+				continue
+			}
+			add(name, strings.TrimSuffix(list.Name(), ".txt"))
+		}
+	}
+
+	out := make(map[string][]string, len(found))
+	for lang, lists := range found {
+		names := make([]string, 0, len(lists))
+		for name := range lists {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out[lang] = names
+	}
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package wordlist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNamedListsAndLegacyFallback(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel string) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("word\n"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	write("de.txt")
+	write("en/common.txt")
+	write("en/coding.txt")
+	write("ATTRIBUTION.txt")
+
+	if got := ResolvePath(dir, "de", ""); got != filepath.Join(dir, "de.txt") {
+		t.Fatalf("expected legacy fallback for de, got %s", got)
+	}
+	if got := ResolvePath(dir, "en", ""); got != filepath.Join(dir, "en", "common.txt") {
+		t.Fatalf("expected named default list for en, got %s", got)
+	}
+	if got := ResolvePath(dir, "de", "coding"); got != filepath.Join(dir, "de", "coding.txt") {
+		t.Fatalf("expected named list path for de, got %s", got)
+	}
+
+	installed, err := ListInstalled(dir)
+	if err != nil {
+		t.Fatalf("list installed: %v", err)
+	}
+	want := map[string][]string{"de": {"common"}, "en": {"coding", "common"}}
+	if !reflect.DeepEqual(installed, want) {
+		t.Fatalf("unexpected installed lists: %v", installed)
+	}
+}
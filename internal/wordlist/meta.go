@@ -0,0 +1,111 @@
+package wordlist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Meta describes where a wordlist came from. It is stored as JSON next to the list
+// and copied into each session typed from it.
+type Meta struct {
+	Source          string `json:"source"`
+	Lang            string `json:"lang,omitempty"`
+	List            string `json:"list,omitempty"`
+	WordfreqVersion string `json:"wordfreq_version,omitempty"`
+	WheelSHA256     string `json:"wheel_sha256,omitempty"`
+	ListType        string `json:"list_type,omitempty"`
+	Rank            string `json:"rank,omitempty"`
+	// Filter names the language filter applied at generation (see FilterName).
+	Filter         string    `json:"filter,omitempty"`
+	Rules          *Rules    `json:"rules,omitempty"`
+	Transliterated bool      `json:"transliterated,omitempty"`
+	Words          int       `json:"words"`
+	CreatedAt      time.Time `json:"created_at,omitzero"`
+}
+
+// Built-in, hand-made and combined lists have no sidecar of their own; these sources describe them.
+const (
+	SourceBuiltin  = "builtin"
+	SourceCustom   = "custom"
+	SourceCombined = "combined"
+)
+
+// MetaPath returns the sidecar file for the list at listPath: <list>.json.
+func MetaPath(listPath string) string {
+	return strings.TrimSuffix(listPath, ".txt") + ".json"
+}
+
+// WriteMeta stores meta next to the list at listPath.
+func WriteMeta(listPath string, meta Meta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode wordlist meta: %w", err)
+	}
+	if err := os.WriteFile(MetaPath(listPath), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write wordlist meta: %w", err)
+	}
+	return nil
+}
+
+// ReadMeta loads the sidecar of the list at listPath. It reports false when the list
+// has no sidecar, e.g. hand-made lists or lists written by older versions.
+func ReadMeta(listPath string) (Meta, bool, error) {
+	data, err := os.ReadFile(MetaPath(listPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Meta{}, false, nil
+		}
+		return Meta{}, false, fmt.Errorf("failed to read wordlist meta: %w", err)
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Meta{}, false, fmt.Errorf("failed to decode wordlist meta: %w", err)
+	}
+	return meta, true, nil
+}
+
+// Provenance returns the sidecar of the list at listPath, or a "custom" description
+// when there is none. words is the number of words actually loaded.
+func Provenance(listPath, lang, list string, words int) (Meta, error) {
+	meta, ok, err := ReadMeta(listPath)
+	if err != nil {
+		return Meta{}, err
+	}
+	if !ok {
+		meta = Meta{Source: SourceCustom}
+	}
+	if meta.Lang == "" {
+		meta.Lang = lang
+	}
+	if meta.List == "" {
+		meta.List = list
+	}
+	meta.Words = words
+	return meta, nil
+}
+
+// ID identifies a loaded list as <name>@<hash>, the hash fingerprinting its words, so
+// sessions typed from different lists, or from different versions of one list, can be
+// told apart. An empty name is DefaultList.
+func ID(name string, words []string) string {
+	if name == "" {
+		name = DefaultList
+	}
+	sum := sha256.Sum256([]byte(strings.Join(words, "\n")))
+	return name + "@" + hex.EncodeToString(sum[:6])
+}
+
+// String encodes meta as compact JSON for session records.
+func (m Meta) String() string {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
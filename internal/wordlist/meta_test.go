@@ -0,0 +1,53 @@
+package wordlist
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIDFingerprintsWords(t *testing.T) {
+	id := ID("", []string{"a", "b"})
+	if !strings.HasPrefix(id, DefaultList+"@") || len(id) != len(DefaultList)+13 {
+		t.Fatalf("unexpected id %q", id)
+	}
+	if other := ID(DefaultList, []string{"a", "c"}); other == id {
+		t.Fatalf("expected different words to change the id, got %q twice", id)
+	}
+	if same := ID(DefaultList, []string{"a", "b"}); same != id {
+		t.Fatalf("expected a stable id, got %q and %q", id, same)
+	}
+}
+
+func TestMetaRoundTrip(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), "common.txt")
+	if _, ok, err := ReadMeta(listPath); err != nil || ok {
+		t.Fatalf("expected no meta, got ok=%v err=%v", ok, err)
+	}
+
+	want := Meta{
+		Source:          "wordfreq",
+		WordfreqVersion: "3.1.1",
+		ListType:        "large",
+		Words:           10000,
+		CreatedAt:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if err := WriteMeta(listPath, want); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	if got := MetaPath(listPath); got != filepath.Join(filepath.Dir(listPath), "common.json") {
+		t.Fatalf("unexpected meta path %s", got)
+	}
+	got, ok, err := ReadMeta(listPath)
+	if err != nil || !ok {
+		t.Fatalf("read meta: ok=%v err=%v", ok, err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Fatalf("created_at mismatch: %v", got.CreatedAt)
+	}
+	got.CreatedAt = want.CreatedAt
+	if got != want {
+		t.Fatalf("meta mismatch: %+v", got)
+	}
+}
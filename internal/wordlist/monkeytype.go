@@ -0,0 +1,49 @@
+package wordlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Monkeytype is a language file in Monkeytype's JSON format.
+type Monkeytype struct {
+	Name  string   `json:"name"`
+	BCP47 string   `json:"bcp47"`
+	Words []string `json:"words"`
+}
+
+// Lang returns the language code from the BCP 47 tag (en-US -> en), or "" when absent.
+func (m Monkeytype) Lang() string {
+	lang, _, _ := strings.Cut(strings.TrimSpace(m.BCP47), "-")
+	return strings.ToLower(lang)
+}
+
+// ParseMonkeytype reads a Monkeytype language file. Words are trimmed, entries with
+// inner whitespace are dropped, and duplicates keep their first (most frequent) position.
+func ParseMonkeytype(r io.Reader) (Monkeytype, error) {
+	var list Monkeytype
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return Monkeytype{}, fmt.Errorf("failed to decode monkeytype json: %w", err)
+	}
+	words := make([]string, 0, len(list.Words))
+	seen := make(map[string]struct{}, len(list.Words))
+	for _, word := range list.Words {
+		word = strings.TrimSpace(word)
+		if word == "" || strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+			continue
+		}
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return Monkeytype{}, fmt.Errorf("monkeytype list has no words")
+	}
+	list.Words = words
+	return list, nil
+}
@@ -0,0 +1,33 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMonkeytype(t *testing.T) {
+	input := `{
+  "name": "english_10k",
+  "noLazyMode": true,
+  "orderedByFrequency": true,
+  "bcp47": "en-US",
+  "words": ["the", " of ", "the", "", "ice cream", "and"]
+}`
+	list, err := ParseMonkeytype(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if list.Name != "english_10k" || list.Lang() != "en" {
+		t.Fatalf("unexpected header: name=%q lang=%q", list.Name, list.Lang())
+	}
+	if got := strings.Join(list.Words, ","); got != "the,of,and" {
+		t.Fatalf("unexpected words: %s", got)
+	}
+
+	if _, err := ParseMonkeytype(strings.NewReader(`{"name":"empty","words":[]}`)); err == nil {
+		t.Fatalf("expected error for empty list")
+	}
+	if _, err := ParseMonkeytype(strings.NewReader(`not json`)); err == nil {
+		t.Fatalf("expected error for invalid json")
+	}
+}
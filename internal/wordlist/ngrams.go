@@ -0,0 +1,119 @@
+package wordlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Ngram is a letter sequence with its share of all n-grams of the same length.
+type Ngram struct {
+	Text  string
+	Share float64
+}
+
+// NgramPath returns where the n-gram table for lang lives: <dir>/<lang>/bigrams.tsv
+// or trigrams.tsv. The .tsv suffix keeps it out of the wordlists.
+func NgramPath(dir, lang string, n int) string {
+	name := "bigrams.tsv"
+	if n == 3 {
+		name = "trigrams.tsv"
+	}
+	return filepath.Join(dir, lang, name)
+}
+
+// CountNgrams counts letter n-grams in words ordered by frequency. Each word is weighted
+// by 1/rank (Zipf's law), so common words dominate as they do in real text. Sequences
+// crossing an apostrophe or hyphen are skipped. The top results are returned, most
+// frequent first; top <= 0 returns all.
+func CountNgrams(words []string, n, top int) []Ngram {
+	if n < 1 {
+		return nil
+	}
+	weights := map[string]float64{}
+	total := 0.0
+	for i, word := range words {
+		weight := 1 / float64(i+1)
+		runes := []rune(strings.ToLower(word))
+		for j := 0; j+n <= len(runes); j++ {
+			gram := runes[j : j+n]
+			if !allLetters(gram) {
+				continue
+			}
+			weights[string(gram)] += weight
+			total += weight
+		}
+	}
+	out := make([]Ngram, 0, len(weights))
+	for text, weight := range weights {
+		out = append(out, Ngram{Text: text, Share: weight / total})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Share != out[j].Share {
+			return out[i].Share > out[j].Share
+		}
+		return out[i].Text < out[j].Text
+	})
+	if top > 0 && len(out) > top {
+		out = out[:top]
+	}
+	return out
+}
+
+func allLetters(runes []rune) bool {
+	for _, r := range runes {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteNgrams writes one "<ngram>\t<share>" line per n-gram.
+func WriteNgrams(w io.Writer, grams []Ngram) error {
+	bw := bufio.NewWriter(w)
+	for _, g := range grams {
+		if _, err := fmt.Fprintf(bw, "%s\t%.6f\n", g.Text, g.Share); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// LoadNgrams reads a table written by WriteNgrams.
+func LoadNgrams(path string) ([]Ngram, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close for read-only n-gram table.
+			_ = cerr
+		}
+	}()
+
+	var grams []Ngram
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text, share, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "\t")
+		if text == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(share, 64)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid n-gram line %d in %s", line, path)
+		}
+		grams = append(grams, Ngram{Text: text, Share: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return grams, nil
+}
@@ -0,0 +1,41 @@
+package wordlist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountNgramsWeightsByRank(t *testing.T) {
+	grams := CountNgrams([]string{"the", "then", "this", "don't", "a"}, 2, 0)
+	if len(grams) == 0 || grams[0].Text != "th" || grams[1].Text != "he" {
+		t.Fatalf("expected th and he first, got %v", grams)
+	}
+	total := 0.0
+	for _, g := range grams {
+		if g.Text == "n'" || g.Text == "'t" {
+			t.Fatalf("n-grams must not cross apostrophes: %v", grams)
+		}
+		total += g.Share
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Fatalf("shares should sum to 1, got %f", total)
+	}
+	if top := CountNgrams([]string{"the", "then"}, 3, 1); len(top) != 1 || top[0].Text != "the" {
+		t.Fatalf("unexpected top trigram: %v", top)
+	}
+
+	path := filepath.Join(t.TempDir(), "bigrams.tsv")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := WriteNgrams(file, grams); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_ = file.Close()
+	loaded, err := LoadNgrams(path)
+	if err != nil || len(loaded) != len(grams) || loaded[0].Text != "th" {
+		t.Fatalf("round trip failed: %v %v", loaded, err)
+	}
+}
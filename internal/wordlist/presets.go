@@ -0,0 +1,47 @@
+package wordlist
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed presets/*.txt
+var presetFiles embed.FS
+
+// presetSuffix marks the code keyword packs: <language>-code.
+const presetSuffix = "-code"
+
+// Presets returns the names of the bundled keyword packs, e.g. go-code.
+func Presets() []string {
+	entries, err := presetFiles.ReadDir("presets")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".txt")+presetSuffix)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns the keywords and standard library identifiers of a bundled pack.
+func Preset(name string) ([]string, error) {
+	lang, ok := strings.CutSuffix(name, presetSuffix)
+	if !ok || lang == "" || strings.ContainsAny(lang, `/\.`) {
+		return nil, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(Presets(), ", "))
+	}
+	file, err := presetFiles.Open("presets/" + lang + ".txt")
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(Presets(), ", "))
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close for embedded preset.
+			_ = cerr
+		}
+	}()
+	return readWords(file)
+}
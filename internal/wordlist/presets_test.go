@@ -0,0 +1,35 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	names := Presets()
+	if strings.Join(names, ",") != "go-code,javascript-code,python-code,rust-code,sql-code" {
+		t.Fatalf("unexpected presets: %v", names)
+	}
+	for _, name := range names {
+		words, err := Preset(name)
+		if err != nil {
+			t.Fatalf("preset %s: %v", name, err)
+		}
+		seen := make(map[string]struct{}, len(words))
+		for _, word := range words {
+			if _, dup := seen[word]; dup {
+				t.Fatalf("duplicate word %q in %s", word, name)
+			}
+			seen[word] = struct{}{}
+		}
+	}
+	words, err := Preset("go-code")
+	if err != nil || words[0] != "break" {
+		t.Fatalf("unexpected go-code preset: %v %v", words, err)
+	}
+	for _, name := range []string{"cobol-code", "go", "../go-code"} {
+		if _, err := Preset(name); err == nil {
+			t.Fatalf("expected error for preset %q", name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package wordlist
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultRowLayout is the layout whose rows are used when no layout is configured.
+const DefaultRowLayout = "qwerty"
+
+// Rows lists the keyboard rows --rows accepts, top to bottom.
+var Rows = []string{"top", "home", "bottom"}
+
+// layoutRows holds the letter and punctuation keys of each row of the supported layouts.
+var layoutRows = map[string]map[string]string{
+	"qwerty":     {"top": "qwertyuiop[]", "home": "asdfghjkl;'", "bottom": "zxcvbnm,./"},
+	"qwertz":     {"top": "qwertzuiopü+", "home": "asdfghjklöä#", "bottom": "yxcvbnm,.-"},
+	"azerty":     {"top": "azertyuiop^$", "home": "qsdfghjklmù*", "bottom": "wxcvbn,;:!"},
+	"colemak":    {"top": "qwfpgjluy;[]", "home": "arstdhneio'", "bottom": "zxcvbkm,./"},
+	"colemak-dh": {"top": "qwfpbjluy;[]", "home": "arstgmneio'", "bottom": "zxcdvkh,./"},
+	"dvorak":     {"top": "',.pyfgcrl/=", "home": "aoeuidhtns-", "bottom": ";qjkxbmwvz"},
+	"workman":    {"top": "qdrwbjfup;[]", "home": "ashtgyneoi'", "bottom": "zxmcvkl,./"},
+}
+
+// RowLayouts returns the layouts with known rows, sorted.
+func RowLayouts() []string {
+	names := make([]string, 0, len(layoutRows))
+	for name := range layoutRows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseRows splits a comma-separated row selection such as "top,home" and checks each
+// name against Rows.
+func ParseRows(s string) ([]string, error) {
+	var rows []string
+	for _, part := range strings.Split(s, ",") {
+		row := strings.ToLower(strings.TrimSpace(part))
+		if row == "" {
+			continue
+		}
+		known := false
+		for _, name := range Rows {
+			known = known || row == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown row %q (use %s)", row, strings.Join(Rows, ", "))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// RowChars returns the characters typed on rows of layout (DefaultRowLayout when empty),
+// for use as an --only-chars set.
+func RowChars(layout string, rows []string) (string, error) {
+	keys, err := rowKeys(layout)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString(keys[row])
+	}
+	return b.String(), nil
+}
+
+// leftHandKeys is how many keys at the start of each row the left hand types.
+const leftHandKeys = 5
+
+// KeyPosition places a key of a layout on a row and a hand.
+type KeyPosition struct {
+	Row  string
+	Left bool
+}
+
+// KeyPositions maps the row keys of layout (DefaultRowLayout when empty) to their
+// positions. As in touch typing, the first five keys of each row are the left hand's.
+func KeyPositions(layout string) (map[rune]KeyPosition, error) {
+	keys, err := rowKeys(layout)
+	if err != nil {
+		return nil, err
+	}
+	positions := map[rune]KeyPosition{}
+	for _, row := range Rows {
+		for i, r := range []rune(keys[row]) {
+			positions[r] = KeyPosition{Row: row, Left: i < leftHandKeys}
+		}
+	}
+	return positions, nil
+}
+
+func rowKeys(layout string) (map[string]string, error) {
+	name := strings.ToLower(strings.TrimSpace(layout))
+	if name == "" {
+		name = DefaultRowLayout
+	}
+	keys, ok := layoutRows[name]
+	if !ok {
+		return nil, fmt.Errorf("no keyboard rows known for layout %q (known: %s)", layout, strings.Join(RowLayouts(), ", "))
+	}
+	return keys, nil
+}
@@ -0,0 +1,64 @@
+package wordlist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRowCharsFilter(t *testing.T) {
+	rows, err := ParseRows("home")
+	if err != nil {
+		t.Fatalf("parse rows: %v", err)
+	}
+	chars, err := RowChars("", rows)
+	if err != nil {
+		t.Fatalf("row chars: %v", err)
+	}
+	words := []string{"dad", "salad", "flask", "hello", "Glass"}
+	if got := Filter(words, FilterChars("", chars)); strings.Join(got, ",") != "dad,salad,flask,Glass" {
+		t.Fatalf("unexpected home-row result: %v", got)
+	}
+
+	rows, err = ParseRows("top, home")
+	if err != nil {
+		t.Fatalf("parse rows: %v", err)
+	}
+	chars, err = RowChars("colemak", rows)
+	if err != nil {
+		t.Fatalf("row chars: %v", err)
+	}
+	if got := Filter([]string{"the", "stone", "fox", "when"}, FilterChars("", chars)); strings.Join(got, ",") != "the,stone,when" {
+		t.Fatalf("unexpected colemak result: %v", got)
+	}
+}
+
+func TestRowsRejectUnknown(t *testing.T) {
+	if _, err := ParseRows("home,middle"); err == nil {
+		t.Fatalf("expected unknown row to fail")
+	}
+	if _, err := RowChars("bépo", []string{"home"}); err == nil {
+		t.Fatalf("expected unknown layout to fail")
+	}
+}
+
+func TestKeyPositions(t *testing.T) {
+	positions, err := KeyPositions("dvorak")
+	if err != nil {
+		t.Fatalf("key positions: %v", err)
+	}
+	cases := map[rune]KeyPosition{
+		'a': {Row: "home", Left: true},
+		'd': {Row: "home", Left: false},
+		'p': {Row: "top", Left: true},
+		'x': {Row: "bottom", Left: true},
+		'b': {Row: "bottom", Left: false},
+	}
+	for r, want := range cases {
+		if got, ok := positions[r]; !ok || got != want {
+			t.Fatalf("position of %q: expected %+v, got %+v (%v)", r, want, got, ok)
+		}
+	}
+	if _, ok := positions['1']; ok {
+		t.Fatalf("expected digits to have no row position")
+	}
+}
@@ -0,0 +1,89 @@
+package wordlist
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default word length bounds, in runes, applied when generating lists.
+const (
+	DefaultMinLength = 2
+	DefaultMaxLength = 20
+)
+
+// Rules decide which tokens become words when a list is generated.
+type Rules struct {
+	// Apostrophes keeps contractions such as don't; a typographic ’ is stored as '.
+	Apostrophes bool `json:"apostrophes"`
+	// Hyphens keeps compounds such as co-op.
+	Hyphens   bool `json:"hyphens"`
+	MinLength int  `json:"min_length"`
+	MaxLength int  `json:"max_length"`
+}
+
+// DefaultRules accepts letters only, 2 to 20 runes long.
+func DefaultRules() Rules {
+	return Rules{MinLength: DefaultMinLength, MaxLength: DefaultMaxLength}
+}
+
+// Validate reports inconsistent length bounds.
+func (r Rules) Validate() error {
+	if r.MinLength < 1 {
+		return fmt.Errorf("minimum word length must be >= 1")
+	}
+	if r.MaxLength < r.MinLength {
+		return fmt.Errorf("maximum word length must be >= minimum length")
+	}
+	return nil
+}
+
+// Normalize returns word with typographic apostrophes replaced by '.
+func (r Rules) Normalize(word string) string {
+	if !r.Apostrophes {
+		return word
+	}
+	return strings.ReplaceAll(word, "’", "'")
+}
+
+// Accept reports whether a normalized word fits the rules. Apostrophes and hyphens
+// must sit between letter runs, and each run must pass letters (which may be nil).
+func (r Rules) Accept(word string, letters FilterFunc) bool {
+	length := utf8.RuneCountInString(word)
+	if length < r.MinLength || length > r.MaxLength {
+		return false
+	}
+	parts := strings.FieldsFunc(word, r.isSeparator)
+	// FieldsFunc drops empty runs, so a leading, trailing or doubled separator
+	// shows up as too few parts.
+	separators := strings.IndexFunc(word, r.isSeparator) >= 0
+	if len(parts) == 0 || (separators && len(parts) != countFunc(word, r.isSeparator)+1) {
+		return false
+	}
+	for _, part := range parts {
+		for _, c := range part {
+			if !unicode.IsLetter(c) && !unicode.Is(unicode.Mn, c) {
+				return false
+			}
+		}
+		if letters != nil && !letters(part) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rules) isSeparator(c rune) bool {
+	return (r.Apostrophes && c == '\'') || (r.Hyphens && c == '-')
+}
+
+func countFunc(s string, f func(rune) bool) int {
+	n := 0
+	for _, c := range s {
+		if f(c) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,34 @@
+package wordlist
+
+import "testing"
+
+func TestRulesAccept(t *testing.T) {
+	english := FilterForLang("en")
+	strict := DefaultRules()
+	for _, word := range []string{"don't", "co-op", "a", "abcdefghijklmnopqrstu"} {
+		if strict.Accept(word, english) {
+			t.Fatalf("default rules should reject %q", word)
+		}
+	}
+	if !strict.Accept("hello", english) {
+		t.Fatalf("default rules should keep hello")
+	}
+
+	loose := Rules{Apostrophes: true, Hyphens: true, MinLength: 1, MaxLength: 8}
+	for _, word := range []string{"don't", "co-op", "a", "o'clock"} {
+		if !loose.Accept(loose.Normalize(word), english) {
+			t.Fatalf("loose rules should keep %q", word)
+		}
+	}
+	if got := loose.Normalize("don’t"); got != "don't" || !loose.Accept(got, english) {
+		t.Fatalf("expected typographic apostrophe to normalize, got %q", got)
+	}
+	for _, word := range []string{"'tis", "dogs'", "co--op", "co-'op", "-", "naïve-ish", "rock'n'roll"} {
+		if loose.Accept(word, english) {
+			t.Fatalf("loose rules should reject %q", word)
+		}
+	}
+	if err := (Rules{MinLength: 3, MaxLength: 2}).Validate(); err == nil {
+		t.Fatalf("expected invalid bounds error")
+	}
+}
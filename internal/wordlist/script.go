@@ -0,0 +1,134 @@
+package wordlist
+
+import (
+	"strings"
+	"unicode"
+)
+
+// langAlphabets lists the lower-case letters accepted for languages with a known alphabet.
+var langAlphabets = map[string]string{
+	"en": "abcdefghijklmnopqrstuvwxyz",
+	"de": "abcdefghijklmnopqrstuvwxyzäöüß",
+	"fr": "abcdefghijklmnopqrstuvwxyzàâæçéèêëîïôœùûüÿ",
+	"es": "abcdefghijklmnopqrstuvwxyzáéíóúñü",
+	"pt": "abcdefghijklmnopqrstuvwxyzáâãàçéêíóôõú",
+	"it": "abcdefghijklmnopqrstuvwxyzàèéìíîòóùú",
+	"ru": "абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+	"uk": "абвгґдеєжзиіїйклмнопрстуфхцчшщьюя",
+}
+
+const zeroWidthNonJoiner = '\u200c'
+
+// scripts are checked in order to find the writing system of a letter.
+var scripts = []*unicode.RangeTable{
+	unicode.Latin, unicode.Cyrillic, unicode.Greek, unicode.Arabic, unicode.Hebrew,
+	unicode.Devanagari, unicode.Thai, unicode.Hangul, unicode.Han, unicode.Hiragana,
+	unicode.Katakana, unicode.Georgian, unicode.Armenian,
+}
+
+// alphabetFilter keeps words made only of letters from alphabet.
+func alphabetFilter(alphabet string) FilterFunc {
+	allowed := runeSet(alphabet)
+	return func(word string) bool {
+		if word == "" {
+			return false
+		}
+		for _, r := range word {
+			if _, ok := allowed[r]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// filterSingleScript keeps words whose letters all belong to one writing system.
+// Japanese mixes kana and kanji, so Han, Hiragana and Katakana count as one script.
+// A zero-width non-joiner, which Persian writes inside words, is allowed between letters.
+func filterSingleScript(word string) bool {
+	var script *unicode.RangeTable
+	for i, r := range word {
+		if r == zeroWidthNonJoiner && i > 0 && i+len(string(r)) < len(word) {
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r) {
+			return false
+		}
+		current := scriptOf(r)
+		if current == nil {
+			continue
+		}
+		if script == nil {
+			script = current
+		} else if script != current {
+			return false
+		}
+	}
+	return word != ""
+}
+
+func scriptOf(r rune) *unicode.RangeTable {
+	for _, table := range scripts {
+		if unicode.Is(table, r) {
+			if table == unicode.Hiragana || table == unicode.Katakana {
+				return unicode.Han
+			}
+			return table
+		}
+	}
+	return nil
+}
+
+// transliterations map letters to ASCII. German umlauts use their conventional spellings.
+var (
+	germanTranslit = map[rune]string{'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss"}
+	latinTranslit  = map[rune]string{
+		'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+		'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+		'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+		'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'ÿ': "y", 'ß': "ss",
+	}
+	cyrillicTranslit = map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'ё': "yo", 'є': "ye",
+		'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+		'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh",
+		'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e",
+		'ю': "yu", 'я': "ya",
+	}
+)
+
+// Transliterate rewrites word in ASCII letters for typists without the language's layout:
+// Latin diacritics are dropped (German umlauts become ae/oe/ue/ss) and Cyrillic is
+// romanized. Letters without a mapping are kept as they are.
+func Transliterate(lang, word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		lower := unicode.ToLower(r)
+		repl, ok := "", false
+		if lang == "de" {
+			repl, ok = germanTranslit[lower]
+		}
+		if !ok {
+			repl, ok = latinTranslit[lower]
+		}
+		if !ok {
+			repl, ok = cyrillicTranslit[lower]
+		}
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		if lower != r {
+			repl = capitalize(repl)
+		}
+		b.WriteString(repl)
+	}
+	return b.String()
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
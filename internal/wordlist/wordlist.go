@@ -4,15 +4,55 @@ package wordlist
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
-// LoadWords reads one word per line from the provided file path.
-func LoadWords(path string) ([]string, error) {
+// MaxWords is the most words kept from one list. Longer lists are sampled down to it as
+// they are read, which is still far more variety than a practice session can exhaust.
+const MaxWords = 50000
+
+// sampleSeed fixes the sample taken from a list, so a session seed generates the same
+// text on every run.
+const sampleSeed = 1
+
+// cachedList is a loaded list with the size and modification time of the file it came from.
+type cachedList struct {
+	size    int64
+	modTime time.Time
+	words   []string
+	total   int
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]cachedList{}
+)
+
+// LoadWords reads one word per line from the provided file path and returns at most
+// MaxWords of them along with the number of words in the file. Longer lists are sampled
+// while streaming (see sampleWords), so their size does not turn into memory. Lists are
+// cached by path until the file changes; the returned slice is shared and must not be
+// modified.
+func LoadWords(path string) ([]string, int, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if cached, ok := cache[path]; ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+		return cached.words, cached.total, nil
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer func() {
 		if cerr := file.Close(); cerr != nil {
@@ -20,17 +60,69 @@ func LoadWords(path string) ([]string, error) {
 			_ = cerr
 		}
 	}()
+	words, total, err := sampleWords(file, MaxWords)
+	if err != nil {
+		return nil, 0, err
+	}
+	cache[path] = cachedList{size: info.Size(), modTime: info.ModTime(), words: words, total: total}
+	return words, total, nil
+}
 
-	var words []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+// CountWords counts the words in the file at path without keeping them.
+func CountWords(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close for read-only word list.
+			_ = cerr
 		}
-		words = append(words, line)
+	}()
+	total := 0
+	err = scanWords(file, func(string) { total++ })
+	return total, err
+}
+
+// sampleWords reads one word per line and keeps a uniform sample of at most limit words,
+// drawn with reservoir sampling so only the sample is ever held in memory. The sample
+// keeps the order of the list, which ranks words by frequency for wordfreq lists. It
+// also returns the number of words read.
+func sampleWords(r io.Reader, limit int) ([]string, int, error) {
+	type entry struct {
+		line int
+		word string
+	}
+	rnd := rand.New(rand.NewSource(sampleSeed))
+	var reservoir []entry
+	total := 0
+	err := scanWords(r, func(word string) {
+		if len(reservoir) < limit {
+			reservoir = append(reservoir, entry{line: total, word: word})
+		} else if j := rnd.Intn(total + 1); j < limit {
+			reservoir[j] = entry{line: total, word: word}
+		}
+		total++
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, fmt.Errorf("word list is empty")
+	}
+	sort.Slice(reservoir, func(i, j int) bool { return reservoir[i].line < reservoir[j].line })
+	words := make([]string, len(reservoir))
+	for i, e := range reservoir {
+		words[i] = e.word
 	}
-	if err := scanner.Err(); err != nil {
+	return words, total, nil
+}
+
+// readWords reads one word per line, skipping blank lines.
+func readWords(r io.Reader) ([]string, error) {
+	var words []string
+	if err := scanWords(r, func(word string) { words = append(words, word) }); err != nil {
 		return nil, err
 	}
 	if len(words) == 0 {
@@ -38,3 +130,16 @@ func LoadWords(path string) ([]string, error) {
 	}
 	return words, nil
 }
+
+// scanWords calls fn with every non-blank line of r, trimmed.
+func scanWords(r io.Reader, fn func(string)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fn(line)
+	}
+	return scanner.Err()
+}
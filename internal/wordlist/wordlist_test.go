@@ -0,0 +1,60 @@
+package wordlist
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSampleWordsKeepsOrderAndBound(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, "w%04d\n", i)
+	}
+	words, total, err := sampleWords(strings.NewReader(b.String()), 100)
+	if err != nil {
+		t.Fatalf("sampleWords failed: %v", err)
+	}
+	if total != 1000 || len(words) != 100 {
+		t.Fatalf("expected 100 of 1000 words, got %d of %d", len(words), total)
+	}
+	if !slices.IsSorted(words) {
+		t.Fatalf("expected the sample to keep list order: %v", words)
+	}
+	again, _, _ := sampleWords(strings.NewReader(b.String()), 100)
+	if !slices.Equal(words, again) {
+		t.Fatalf("expected the same sample on every read")
+	}
+
+	short, total, err := sampleWords(strings.NewReader("a\n\nb\n"), 100)
+	if err != nil || total != 2 || !slices.Equal(short, []string{"a", "b"}) {
+		t.Fatalf("expected a short list in full, got %v (%d, %v)", short, total, err)
+	}
+	if _, _, err := sampleWords(strings.NewReader("\n"), 100); err == nil {
+		t.Fatalf("expected an error for an empty list")
+	}
+}
+
+func TestLoadWordsReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "common.txt")
+	if err := os.WriteFile(path, []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	words, total, err := LoadWords(path)
+	if err != nil || total != 2 || len(words) != 2 {
+		t.Fatalf("expected 2 words, got %v (%d, %v)", words, total, err)
+	}
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	words, total, err = LoadWords(path)
+	if err != nil || total != 3 || len(words) != 3 {
+		t.Fatalf("expected the cache to pick up the change, got %v (%d, %v)", words, total, err)
+	}
+	if n, err := CountWords(path); err != nil || n != 3 {
+		t.Fatalf("expected CountWords 3, got %d (%v)", n, err)
+	}
+}
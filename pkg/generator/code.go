@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"strings"
+)
+
+// CodeOptions controls identifier and operator generation in code mode.
+// Style weights are relative; when all are zero, camelCase is used.
+type CodeOptions struct {
+	CamelWeight     float64
+	SnakeWeight     float64
+	ScreamingWeight float64
+	// OpsPct is the probability that a token is an operator instead of an identifier.
+	OpsPct float64
+}
+
+// Operators lists the operator sequences used in code mode.
+var Operators = []string{":=", "->", "=>", "!=", "==", "<=", ">=", "&&", "||", "+=", "-=", "::", "<-", "..."}
+
+// maxIdentifierWords is the most words joined into one identifier.
+const maxIdentifierWords = 3
+
+// GenerateCode produces identifiers built from 1-3 words in camelCase, snake_case or
+// SCREAMING_CASE, mixed with operator sequences. A non-empty weakSet biases word choice.
+func (g *Generator) GenerateCode(words []string, count int, opts CodeOptions, weakSet map[rune]struct{}, factor float64) []string {
+	sample := g.uniformSampler(words)
+	if len(weakSet) > 0 {
+		sample = g.weightedSampler(words, weakSet, factor)
+	}
+	recent := newRecentWords(g.repeatWindow)
+	identifier := func() string {
+		parts := make([]string, 1+g.rnd.Intn(maxIdentifierWords))
+		for i := range parts {
+			parts[i] = sample()
+		}
+		return g.joinIdentifier(parts, opts)
+	}
+
+	result := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if opts.OpsPct > 0 && g.rnd.Float64() < opts.OpsPct {
+			result = append(result, recent.pick(func() string {
+				return Operators[g.rnd.Intn(len(Operators))]
+			}))
+			continue
+		}
+		result = append(result, recent.pick(identifier))
+	}
+	return result
+}
+
+func (g *Generator) joinIdentifier(parts []string, opts CodeOptions) string {
+	total := opts.CamelWeight + opts.SnakeWeight + opts.ScreamingWeight
+	if total <= 0 {
+		return camelCase(parts)
+	}
+	r := g.rnd.Float64() * total
+	switch {
+	case r < opts.CamelWeight:
+		return camelCase(parts)
+	case r < opts.CamelWeight+opts.SnakeWeight:
+		return strings.ToLower(strings.Join(parts, "_"))
+	default:
+		return strings.ToUpper(strings.Join(parts, "_"))
+	}
+}
+
+func camelCase(parts []string) string {
+	var b strings.Builder
+	for i, part := range parts {
+		part = strings.ToLower(part)
+		if i > 0 {
+			part = capitalize(part)
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+var (
+	camelToken     = regexp.MustCompile(`^[a-z]+([A-Z][a-z]*){0,2}$`)
+	snakeToken     = regexp.MustCompile(`^[a-z]+(_[a-z]+){0,2}$`)
+	screamingToken = regexp.MustCompile(`^[A-Z]+(_[A-Z]+){0,2}$`)
+)
+
+func TestGenerateCodeTokenShapes(t *testing.T) {
+	words := []string{"user", "id", "count", "max", "value"}
+	cases := []struct {
+		name  string
+		opts  CodeOptions
+		shape *regexp.Regexp
+	}{
+		{"default", CodeOptions{}, camelToken},
+		{"camel", CodeOptions{CamelWeight: 1}, camelToken},
+		{"snake", CodeOptions{SnakeWeight: 1}, snakeToken},
+		{"screaming", CodeOptions{ScreamingWeight: 1}, screamingToken},
+	}
+	for _, tc := range cases {
+		tokens := NewWithSeed(4).GenerateCode(words, 300, tc.opts, nil, 0)
+		if len(tokens) != 300 {
+			t.Fatalf("%s: expected 300 tokens, got %d", tc.name, len(tokens))
+		}
+		for _, token := range tokens {
+			if !tc.shape.MatchString(token) {
+				t.Fatalf("%s: unexpected token %q", tc.name, token)
+			}
+		}
+	}
+}
+
+func TestGenerateCodeMixesOperators(t *testing.T) {
+	words := []string{"alpha", "beta"}
+	opts := CodeOptions{CamelWeight: 1, SnakeWeight: 1, ScreamingWeight: 1, OpsPct: 0.3}
+	tokens := NewWithSeed(8).GenerateCode(words, 1000, opts, nil, 0)
+	ops := 0
+	for _, token := range tokens {
+		switch {
+		case slices.Contains(Operators, token):
+			ops++
+		case camelToken.MatchString(token), snakeToken.MatchString(token), screamingToken.MatchString(token):
+		default:
+			t.Fatalf("unexpected token %q", token)
+		}
+	}
+	if ops < 200 || ops > 400 {
+		t.Fatalf("expected about 30%% operators, got %d of %d", ops, len(tokens))
+	}
+	if none := NewWithSeed(8).GenerateCode(words, 200, CodeOptions{}, nil, 0); slices.ContainsFunc(none, func(token string) bool {
+		return slices.Contains(Operators, token)
+	}) {
+		t.Fatalf("expected no operators with OpsPct 0")
+	}
+}
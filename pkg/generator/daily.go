@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// challengeLayout formats the UTC day of a daily challenge.
+const challengeLayout = "2006-01-02"
+
+// ChallengeDay is the daily challenge running at t. Challenges follow UTC days so
+// everyone gets the same text on the same day.
+func ChallengeDay(t time.Time) string {
+	return t.UTC().Format(challengeLayout)
+}
+
+// ChallengeSeed is the generator seed of the daily challenge of day. With the same
+// settings and wordlist it yields the same text everywhere.
+func ChallengeSeed(day string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("tuipe-daily-" + day))
+	return int64(h.Sum64() &^ (1 << 63))
+}
+
+// ChallengeOf finds the daily challenge a text seeded with seed and started at t
+// belongs to. The day before is tried too, for texts started just before midnight UTC.
+func ChallengeOf(seed int64, t time.Time) (string, bool) {
+	for _, at := range []time.Time{t, t.Add(-24 * time.Hour)} {
+		if day := ChallengeDay(at); ChallengeSeed(day) == seed {
+			return day, true
+		}
+	}
+	return "", false
+}
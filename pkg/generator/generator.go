@@ -0,0 +1,278 @@
+// Package generator builds typing text sequences.
+package generator
+
+import (
+	"math/rand"
+	"time"
+)
+
+// DefaultRepeatWindow forbids a word from following itself.
+const DefaultRepeatWindow = 1
+
+// maxReviewShare caps the share of a text taken by spaced-repetition review words.
+const maxReviewShare = 0.2
+
+// bankShare is the share of a text BankSource draws from its word bank.
+const bankShare = 0.8
+
+// maxRepeatRetries bounds resampling when a word was used too recently; small pools may still repeat.
+const maxRepeatRetries = 20
+
+// Generator produces randomized typing text.
+type Generator struct {
+	rnd           *rand.Rand
+	seed          int64
+	repeatWindow  int
+	sentenceStyle bool
+}
+
+// New returns a Generator seeded with the current time.
+func New() *Generator {
+	return NewWithSeed(time.Now().UnixNano())
+}
+
+// NewWithSeed returns a Generator with a fixed seed for reproducible text.
+func NewWithSeed(seed int64) *Generator {
+	return &Generator{rnd: rand.New(rand.NewSource(seed)), seed: seed, repeatWindow: DefaultRepeatWindow}
+}
+
+// SetRepeatWindow sets how many preceding words a new word must differ from; 0 allows repeats.
+func (g *Generator) SetRepeatWindow(window int) {
+	if window < 0 {
+		window = 0
+	}
+	g.repeatWindow = window
+}
+
+// Reseed restarts the random sequence from seed.
+func (g *Generator) Reseed(seed int64) {
+	g.rnd.Seed(seed)
+	g.seed = seed
+}
+
+// Seed returns the seed of the current random sequence.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// SetSentenceStyle switches between per-word caps/punctuation and sentence-shaped text.
+func (g *Generator) SetSentenceStyle(enabled bool) {
+	g.sentenceStyle = enabled
+}
+
+// style applies caps and punctuation to base words in place.
+func (g *Generator) style(words []string, capsPct, punctPct float64, punctSet []rune) []string {
+	if g.sentenceStyle {
+		return applySentences(g.rnd, words, punctPct, punctSet)
+	}
+	for i, word := range words {
+		word = applyCaps(g.rnd, word, capsPct)
+		words[i] = applyPunct(g.rnd, word, punctPct, punctSet)
+	}
+	return words
+}
+
+// Generate selects words uniformly and applies caps/punctuation rules.
+// Brackets and quotes whose pair is in punctSet wrap the word instead of trailing it.
+func (g *Generator) Generate(words []string, count int, capsPct, punctPct float64, punctSet []rune) []string {
+	return g.style(g.pickWords(g.uniformSampler(words), count), capsPct, punctPct, punctSet)
+}
+
+// GenerateWeighted selects words with a bias toward weak characters.
+func (g *Generator) GenerateWeighted(words []string, count int, capsPct, punctPct float64, punctSet []rune, weakSet map[rune]struct{}, factor float64) []string {
+	return g.style(g.pickWords(g.weightedSampler(words, weakSet, factor), count), capsPct, punctPct, punctSet)
+}
+
+// MixReview replaces random words of text with review words, in order, covering at most
+// maxReviewShare of the text (always at least one word).
+func (g *Generator) MixReview(text, review []string) []string {
+	if len(review) == 0 || len(text) == 0 {
+		return text
+	}
+	n := min(len(review), max(1, int(float64(len(text))*maxReviewShare)))
+	return g.mix(text, review[:n])
+}
+
+// GenerateBank draws count words from bank by weight and styles each word on its own,
+// so they can be mixed into text from another source.
+func (g *Generator) GenerateBank(bank []string, weights []float64, count int, capsPct, punctPct float64, punctSet []rune) []string {
+	words := g.pickWords(g.bankSampler(bank, weights), count)
+	for i, word := range words {
+		word = applyCaps(g.rnd, word, capsPct)
+		words[i] = applyPunct(g.rnd, word, punctPct, punctSet)
+	}
+	return words
+}
+
+// mix puts words at random distinct positions of text, which must be at least as long.
+func (g *Generator) mix(text, words []string) []string {
+	for i, pos := range g.rnd.Perm(len(text))[:len(words)] {
+		text[pos] = words[i]
+	}
+	return text
+}
+
+// bankSampler draws words with probability proportional to their weight.
+func (g *Generator) bankSampler(words []string, weights []float64) func() string {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	return func() string {
+		r := g.rnd.Float64() * total
+		for i, w := range weights {
+			r -= w
+			if r < 0 {
+				return words[i]
+			}
+		}
+		return words[len(words)-1]
+	}
+}
+
+// pickWords draws count words from sample, honoring the repeat window.
+func (g *Generator) pickWords(sample func() string, count int) []string {
+	result := make([]string, 0, count)
+	recent := newRecentWords(g.repeatWindow)
+	for i := 0; i < count; i++ {
+		result = append(result, recent.pick(sample))
+	}
+	return result
+}
+
+func (g *Generator) uniformSampler(words []string) func() string {
+	return func() string {
+		return words[g.rnd.Intn(len(words))]
+	}
+}
+
+// weightedSampler favors words containing characters from weakSet.
+func (g *Generator) weightedSampler(words []string, weakSet map[rune]struct{}, factor float64) func() string {
+	weights := make([]float64, len(words))
+	total := 0.0
+	for i, word := range words {
+		weakCount := 0
+		for _, r := range word {
+			if _, ok := weakSet[r]; ok {
+				weakCount++
+			}
+		}
+		w := 1.0 + float64(weakCount)*factor
+		weights[i] = w
+		total += w
+	}
+	return func() string {
+		r := g.rnd.Float64() * total
+		acc := 0.0
+		idx := 0
+		for j, w := range weights {
+			acc += w
+			if r <= acc {
+				idx = j
+				break
+			}
+		}
+		return words[idx]
+	}
+}
+
+func applyCaps(rnd *rand.Rand, word string, capsPct float64) string {
+	if capsPct <= 0 {
+		return word
+	}
+	if rnd.Float64() > capsPct {
+		return word
+	}
+	return capitalize(word)
+}
+
+func applyPunct(rnd *rand.Rand, word string, punctPct float64, punctSet []rune) string {
+	if punctPct <= 0 || len(punctSet) == 0 {
+		return word
+	}
+	if rnd.Float64() > punctPct {
+		return word
+	}
+	punct := punctSet[rnd.Intn(len(punctSet))]
+	if open, closing, ok := punctPair(punct, punctSet); ok {
+		return string(open) + word + string(closing)
+	}
+	return word + string(punct)
+}
+
+// punctPairs maps opening brackets and quotes to their closing counterpart.
+var punctPairs = map[rune]rune{
+	'(':  ')',
+	'[':  ']',
+	'{':  '}',
+	'<':  '>',
+	'"':  '"',
+	'\'': '\'',
+	'`':  '`',
+	'¿':  '?',
+	'¡':  '!',
+	'«':  '»',
+	'„':  '“',
+}
+
+// punctPair returns the pair p belongs to when both halves are in punctSet.
+func punctPair(p rune, punctSet []rune) (rune, rune, bool) {
+	open, closing := p, rune(0)
+	if c, ok := punctPairs[p]; ok {
+		closing = c
+	} else {
+		for o, c := range punctPairs {
+			if c == p {
+				open, closing = o, c
+				break
+			}
+		}
+	}
+	if closing == 0 || !containsRune(punctSet, open) || !containsRune(punctSet, closing) {
+		return 0, 0, false
+	}
+	return open, closing, true
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, x := range runes {
+		if x == r {
+			return true
+		}
+	}
+	return false
+}
+
+// recentWords remembers the last few picked words to avoid repeats.
+type recentWords struct {
+	window int
+	words  []string
+}
+
+func newRecentWords(window int) *recentWords {
+	return &recentWords{window: window}
+}
+
+// pick draws from sample until it returns a word outside the window, then records it.
+func (r *recentWords) pick(sample func() string) string {
+	word := sample()
+	for i := 0; i < maxRepeatRetries && r.contains(word); i++ {
+		word = sample()
+	}
+	if r.window > 0 {
+		r.words = append(r.words, word)
+		if len(r.words) > r.window {
+			r.words = r.words[1:]
+		}
+	}
+	return word
+}
+
+func (r *recentWords) contains(word string) bool {
+	for _, w := range r.words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
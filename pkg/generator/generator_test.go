@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRepeatWindowKeepsWordsApart(t *testing.T) {
+	words := make([]string, 8)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%d", i)
+	}
+	for _, window := range []int{1, 3, 5} {
+		gen := NewWithSeed(42)
+		gen.SetRepeatWindow(window)
+		text := gen.Generate(words, 500, 0, 0, nil)
+		if len(text) != 500 {
+			t.Fatalf("expected 500 words, got %d", len(text))
+		}
+		for i, word := range text {
+			for j := max(0, i-window); j < i; j++ {
+				if text[j] == word {
+					t.Fatalf("window %d: %q at %d repeats the word at %d", window, word, i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestRepeatWindowZeroAllowsRepeats(t *testing.T) {
+	gen := NewWithSeed(1)
+	gen.SetRepeatWindow(0)
+	text := gen.Generate([]string{"a", "b"}, 100, 0, 0, nil)
+	repeated := false
+	for i := 1; i < len(text); i++ {
+		repeated = repeated || text[i] == text[i-1]
+	}
+	if !repeated {
+		t.Fatalf("expected a window of 0 to allow a word to follow itself: %v", text)
+	}
+}
+
+// balanced reports whether every pair of punctPairs is closed within word.
+func balanced(word string) bool {
+	for open, closing := range punctPairs {
+		if open == closing {
+			if strings.Count(word, string(open))%2 != 0 {
+				return false
+			}
+		} else if strings.Count(word, string(open)) != strings.Count(word, string(closing)) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPunctWrapsWordsInPairs(t *testing.T) {
+	punctSet := []rune(BasePunctSet + "«»¿¡„“")
+	words := []string{"alpha", "beta", "gamma"}
+	wrapped := 0
+	for i, word := range NewWithSeed(5).Generate(words, 1000, 0, 1, punctSet) {
+		if !balanced(word) {
+			t.Fatalf("word %d %q has an unbalanced bracket or quote", i, word)
+		}
+		runes := []rune(word)
+		if closing, ok := punctPairs[runes[0]]; ok {
+			if runes[len(runes)-1] != closing {
+				t.Fatalf("word %d %q does not end with %q", i, word, closing)
+			}
+			wrapped++
+		}
+	}
+	if wrapped == 0 {
+		t.Fatalf("expected some words to be wrapped")
+	}
+}
+
+func TestPunctPairNeedsBothHalves(t *testing.T) {
+	// Without its closing half, an opening bracket trails the word like any mark.
+	for _, word := range NewWithSeed(2).Generate([]string{"word"}, 50, 0, 1, []rune("(")) {
+		if word != "word(" {
+			t.Fatalf("expected a trailing mark, got %q", word)
+		}
+	}
+	for _, word := range NewWithSeed(2).Generate([]string{"word"}, 50, 0, 1, []rune(")(")) {
+		if word != "(word)" {
+			t.Fatalf("expected the word wrapped in brackets, got %q", word)
+		}
+	}
+}
+
+func TestSentencesPairInvertedMarks(t *testing.T) {
+	gen := NewWithSeed(9)
+	gen.SetSentenceStyle(true)
+	text := strings.Join(gen.Generate([]string{"uno", "dos", "tres"}, 400, 0, 0.5, []rune(".,?!¿¡")), " ")
+	if !strings.Contains(text, "¿") {
+		t.Fatalf("expected some questions in %q", text)
+	}
+	if strings.Count(text, "¿") != strings.Count(text, "?") || strings.Count(text, "¡") != strings.Count(text, "!") {
+		t.Fatalf("expected every inverted mark to be closed: %q", text)
+	}
+}
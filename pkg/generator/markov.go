@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// Chain holds word bigram transitions for sentence-like text generation.
+type Chain struct {
+	starts *transitions
+	next   map[string]*transitions
+}
+
+// transitions is a weighted set of candidate words.
+type transitions struct {
+	words   []string
+	weights []float64
+	total   float64
+	index   map[string]int
+}
+
+func newTransitions() *transitions {
+	return &transitions{index: map[string]int{}}
+}
+
+func (t *transitions) add(word string, weight float64) {
+	if i, ok := t.index[word]; ok {
+		t.weights[i] += weight
+	} else {
+		t.index[word] = len(t.words)
+		t.words = append(t.words, word)
+		t.weights = append(t.weights, weight)
+	}
+	t.total += weight
+}
+
+// pick samples a word by weight, scaled by bias when it is non-nil.
+func (t *transitions) pick(rnd *rand.Rand, bias func(string) float64) string {
+	total := t.total
+	if bias != nil {
+		total = 0
+		for i, word := range t.words {
+			total += t.weights[i] * bias(word)
+		}
+	}
+	r := rnd.Float64() * total
+	acc := 0.0
+	for i, word := range t.words {
+		w := t.weights[i]
+		if bias != nil {
+			w *= bias(word)
+		}
+		acc += w
+		if r < acc {
+			return word
+		}
+	}
+	return t.words[len(t.words)-1]
+}
+
+// BuildChain reads a text corpus and records which words follow each other.
+// Words are lower-cased and stripped of surrounding punctuation; words rejected by keep
+// and sentence ends (., ! or ?) break the chain.
+func BuildChain(r io.Reader, keep func(string) bool) (*Chain, error) {
+	chain := &Chain{starts: newTransitions(), next: map[string]*transitions{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	scanner.Split(bufio.ScanWords)
+	prev := ""
+	for scanner.Scan() {
+		token := scanner.Text()
+		word := normalizeToken(token)
+		if word == "" || (keep != nil && !keep(word)) {
+			prev = ""
+			continue
+		}
+		if prev == "" {
+			chain.starts.add(word, 1)
+		} else {
+			next, ok := chain.next[prev]
+			if !ok {
+				next = newTransitions()
+				chain.next[prev] = next
+			}
+			next.add(word, 1)
+		}
+		prev = word
+		if strings.ContainsAny(token[len(token)-1:], ".!?") {
+			prev = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(chain.starts.words) == 0 {
+		return nil, fmt.Errorf("corpus contains no usable words")
+	}
+	return chain, nil
+}
+
+// ChainFromWords builds a chain without transitions that samples a frequency-ordered
+// word list by Zipf weight, for use when no corpus is available.
+func ChainFromWords(words []string) *Chain {
+	chain := &Chain{starts: newTransitions(), next: map[string]*transitions{}}
+	for i, word := range words {
+		chain.starts.add(word, 1/float64(i+1))
+	}
+	return chain
+}
+
+// Transitions reports the number of distinct word pairs in the chain.
+func (c *Chain) Transitions() int {
+	n := 0
+	for _, next := range c.next {
+		n += len(next.words)
+	}
+	return n
+}
+
+// GenerateMarkov walks the chain, restarting at a sentence start when a word has no
+// successors, and applies caps/punctuation rules. A non-empty weakSet biases each
+// choice toward words containing weak characters.
+func (g *Generator) GenerateMarkov(chain *Chain, count int, capsPct, punctPct float64, punctSet []rune, weakSet map[rune]struct{}, factor float64) []string {
+	var bias func(string) float64
+	if len(weakSet) > 0 && factor > 0 {
+		bias = func(word string) float64 {
+			weakCount := 0
+			for _, r := range word {
+				if _, ok := weakSet[r]; ok {
+					weakCount++
+				}
+			}
+			return 1.0 + float64(weakCount)*factor
+		}
+	}
+
+	result := make([]string, 0, count)
+	recent := newRecentWords(g.repeatWindow)
+	prev := ""
+	for i := 0; i < count; i++ {
+		candidates := chain.starts
+		if next, ok := chain.next[prev]; ok {
+			candidates = next
+		}
+		word := recent.pick(func() string {
+			return candidates.pick(g.rnd, bias)
+		})
+		prev = word
+		result = append(result, word)
+	}
+	return g.style(result, capsPct, punctPct, punctSet)
+}
+
+// normalizeToken lower-cases token and trims surrounding punctuation. Apostrophes inside
+// the word are kept, with a typographic ’ written as '.
+func normalizeToken(token string) string {
+	trimmed := strings.TrimFunc(strings.ReplaceAll(token, "’", "'"), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.ToLower(trimmed)
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testCorpus = `The cat sat on the mat. A dog ran to the park!
+The dog sat by the cat, and the cat ran away? A bird sang on the roof.`
+
+func TestGenerateMarkovFollowsChain(t *testing.T) {
+	chain, err := BuildChain(strings.NewReader(testCorpus), nil)
+	if err != nil {
+		t.Fatalf("BuildChain failed: %v", err)
+	}
+	vocab := map[string]struct{}{}
+	for _, token := range strings.Fields(testCorpus) {
+		vocab[normalizeToken(token)] = struct{}{}
+	}
+
+	words := NewWithSeed(7).GenerateMarkov(chain, 200, 0, 0, nil, nil, 0)
+	if len(words) != 200 {
+		t.Fatalf("expected 200 words, got %d", len(words))
+	}
+	for i, word := range words {
+		if word == "" {
+			t.Fatalf("word %d is empty", i)
+		}
+		if _, ok := vocab[word]; !ok {
+			t.Fatalf("word %d %q is not in the corpus", i, word)
+		}
+		if i == 0 {
+			continue
+		}
+		// A word with successors is followed by one of them; others restart at a sentence start.
+		candidates := chain.starts
+		if next, ok := chain.next[words[i-1]]; ok {
+			candidates = next
+		}
+		if !slices.Contains(candidates.words, word) {
+			t.Fatalf("%q does not follow %q in the corpus", word, words[i-1])
+		}
+	}
+	if again := NewWithSeed(7).GenerateMarkov(chain, 200, 0, 0, nil, nil, 0); !slices.Equal(words, again) {
+		t.Fatalf("expected the same seed to give the same text")
+	}
+}
+
+func TestBuildChainRejectsEmptyCorpus(t *testing.T) {
+	if _, err := BuildChain(strings.NewReader("... !!! 123"), nil); err == nil {
+		t.Fatalf("expected an error for a corpus without words")
+	}
+	chain, err := BuildChain(strings.NewReader(testCorpus), func(word string) bool { return word != "cat" })
+	if err != nil {
+		t.Fatalf("BuildChain failed: %v", err)
+	}
+	for _, word := range NewWithSeed(3).GenerateMarkov(chain, 100, 0, 0, nil, nil, 0) {
+		if word == "cat" {
+			t.Fatalf("expected words rejected by keep to be left out")
+		}
+	}
+}
+
+func TestChainFromWordsSamplesList(t *testing.T) {
+	list := []string{"one", "two", "three", "four"}
+	chain := ChainFromWords(list)
+	if chain.Transitions() != 0 {
+		t.Fatalf("expected no transitions, got %d", chain.Transitions())
+	}
+	counts := map[string]int{}
+	for _, word := range NewWithSeed(11).GenerateMarkov(chain, 2000, 0, 0, nil, nil, 0) {
+		if !slices.Contains(list, word) {
+			t.Fatalf("unexpected word %q", word)
+		}
+		counts[word]++
+	}
+	// Zipf weights favor words earlier in the list.
+	if counts["one"] <= counts["four"] {
+		t.Fatalf("expected the first word to be drawn more often than the last, got %v", counts)
+	}
+}
@@ -0,0 +1,59 @@
+package generator
+
+import "strings"
+
+// BasePunctSet is the ASCII punctuation used for languages without a dedicated set.
+const BasePunctSet = ".,!?;:\"'{}()[]-=/<>`"
+
+// langPunctSets extends the base set with language-specific marks.
+var langPunctSets = map[string]string{
+	"de": BasePunctSet + "„“",
+	"es": BasePunctSet + "¿¡",
+	"fr": BasePunctSet + "«»",
+	"it": BasePunctSet + "«»",
+	"ru": BasePunctSet + "«»",
+	"uk": BasePunctSet + "«»",
+}
+
+// PunctSetForLang returns the default punctuation set for lang, preferring overrides
+// keyed by language code. A combined code such as "en,de" unites the sets of its languages.
+func PunctSetForLang(lang string, overrides map[string]string) string {
+	lang = strings.ToLower(lang)
+	if strings.Contains(lang, ",") {
+		var b strings.Builder
+		for _, part := range strings.Split(lang, ",") {
+			for _, r := range PunctSetForLang(strings.TrimSpace(part), overrides) {
+				if !strings.ContainsRune(b.String(), r) {
+					b.WriteRune(r)
+				}
+			}
+		}
+		return b.String()
+	}
+	if set := overrides[lang]; set != "" {
+		return set
+	}
+	if set, ok := langPunctSets[lang]; ok {
+		return set
+	}
+	return BasePunctSet
+}
+
+// WeightPunct repeats each mark of punctSet by its weight (1 when absent), so the uniform
+// pick in applyPunct draws common marks more often. A weight of 0 leaves the mark out.
+func WeightPunct(punctSet []rune, weights map[rune]int) []rune {
+	if len(weights) == 0 {
+		return punctSet
+	}
+	weighted := make([]rune, 0, len(punctSet))
+	for _, r := range punctSet {
+		n, ok := weights[r]
+		if !ok {
+			n = 1
+		}
+		for range n {
+			weighted = append(weighted, r)
+		}
+	}
+	return weighted
+}
@@ -0,0 +1,44 @@
+package generator
+
+import "testing"
+
+func TestWeightPunctZeroExcludesMark(t *testing.T) {
+	set := WeightPunct([]rune(".,!"), map[rune]int{'!': 0})
+	if got := string(set); got != ".," {
+		t.Fatalf("expected \".,\", got %q", got)
+	}
+
+	gen := NewWithSeed(3)
+	for _, word := range gen.Generate([]string{"a", "b", "c", "d"}, 500, 0, 1, set) {
+		if word[len(word)-1] == '!' {
+			t.Fatalf("zero-weight mark drawn in %q", word)
+		}
+	}
+}
+
+func TestWeightPunctSkewsFrequency(t *testing.T) {
+	set := WeightPunct([]rune(".,"), map[rune]int{',': 4})
+	if got := string(set); got != ".,,,," {
+		t.Fatalf("expected \".,,,,\", got %q", got)
+	}
+
+	gen := NewWithSeed(5)
+	counts := map[byte]int{}
+	for _, word := range gen.Generate([]string{"a", "b", "c", "d"}, 2000, 0, 1, set) {
+		counts[word[len(word)-1]]++
+	}
+	// Weight 4 against the default 1: about 1600 commas to 400 periods.
+	if counts[','] < 1400 || counts[','] > 1800 {
+		t.Fatalf("expected ~1600 commas, got %d (periods %d)", counts[','], counts['.'])
+	}
+	if counts[',']+counts['.'] != 2000 {
+		t.Fatalf("expected every word punctuated, got %v", counts)
+	}
+}
+
+func TestWeightPunctWithoutWeightsKeepsSet(t *testing.T) {
+	set := []rune(".,!")
+	if got := string(WeightPunct(set, nil)); got != ".,!" {
+		t.Fatalf("expected set unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"math/rand"
+	"strings"
+	"unicode"
+)
+
+// Sentence lengths in words, drawn uniformly.
+const (
+	minSentenceWords = 4
+	maxSentenceWords = 14
+)
+
+// minClauseWords keeps clause punctuation away from sentence edges.
+const minClauseWords = 2
+
+const (
+	sentenceEnds = ".?!"
+	clauseMarks  = ",;:"
+)
+
+// applySentences groups words into sentences: each starts with a capital and ends with
+// terminal punctuation from punctSet (or '.'), and longer sentences get a clause mark
+// with probability punctPct. Inverted marks in punctSet (¿, ¡) open matching sentences.
+func applySentences(rnd *rand.Rand, words []string, punctPct float64, punctSet []rune) []string {
+	ends := filterPunct(punctSet, sentenceEnds)
+	if len(ends) == 0 {
+		ends = []rune{'.'}
+	}
+	clauses := filterPunct(punctSet, clauseMarks)
+
+	for start := 0; start < len(words); {
+		length := minSentenceWords + rnd.Intn(maxSentenceWords-minSentenceWords+1)
+		end := start + length
+		if end > len(words) || len(words)-end < minSentenceWords {
+			end = len(words)
+		}
+		words[start] = capitalize(words[start])
+		if len(clauses) > 0 && end-start >= 2*minClauseWords+1 && rnd.Float64() < punctPct {
+			at := start + minClauseWords - 1 + rnd.Intn(end-start-2*minClauseWords+1)
+			words[at] += string(clauses[rnd.Intn(len(clauses))])
+		}
+		mark := ends[rnd.Intn(len(ends))]
+		if open, _, ok := punctPair(mark, punctSet); ok && open != mark {
+			// Inverted marks such as ¿ and ¡ open the sentence.
+			words[start] = string(open) + words[start]
+		}
+		words[end-1] += string(mark)
+		start = end
+	}
+	return words
+}
+
+func filterPunct(punctSet []rune, allowed string) []rune {
+	var out []rune
+	for _, r := range punctSet {
+		if strings.ContainsRune(allowed, r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinShiftCaps is the lowest first-letter caps probability used in shift mode.
+const MinShiftCaps = 0.5
+
+// ShiftOptions controls capital-letter drills in shift mode.
+type ShiftOptions struct {
+	// CapsPct is the probability of a capitalized first letter, at least MinShiftCaps.
+	CapsPct float64
+	// MidCapsPct is the probability that a word also gets a capital after its first letter.
+	MidCapsPct float64
+	// AllCapsPct is the probability that a word is written in ALL CAPS instead.
+	AllCapsPct float64
+}
+
+// GenerateShift produces words loaded with Shift combinations: capitalized first letters,
+// capitals inside words and ALL-CAPS tokens, followed by punctuation. A non-empty weakSet
+// biases word choice.
+func (g *Generator) GenerateShift(words []string, count int, opts ShiftOptions, punctPct float64, punctSet []rune, weakSet map[rune]struct{}, factor float64) []string {
+	sample := g.uniformSampler(words)
+	if len(weakSet) > 0 {
+		sample = g.weightedSampler(words, weakSet, factor)
+	}
+	result := g.pickWords(sample, count)
+	capsPct := max(opts.CapsPct, MinShiftCaps)
+	for i, word := range result {
+		if g.rnd.Float64() < opts.AllCapsPct {
+			word = strings.ToUpper(word)
+		} else {
+			word = applyCaps(g.rnd, word, capsPct)
+			if g.rnd.Float64() < opts.MidCapsPct {
+				word = g.capitalizeInside(word)
+			}
+		}
+		result[i] = applyPunct(g.rnd, word, punctPct, punctSet)
+	}
+	return result
+}
+
+// capitalizeInside upper-cases one random lower-case letter after the first rune.
+func (g *Generator) capitalizeInside(word string) string {
+	runes := []rune(word)
+	var candidates []int
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsLower(runes[i]) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return word
+	}
+	i := candidates[g.rnd.Intn(len(candidates))]
+	runes[i] = unicode.ToUpper(runes[i])
+	return string(runes)
+}
@@ -0,0 +1,183 @@
+package generator
+
+// TextSource supplies the words of each practice text.
+type TextSource interface {
+	Next(count int) []string
+}
+
+// WeakAware is implemented by sources that can bias text toward weak characters.
+// An empty set turns the bias off.
+type WeakAware interface {
+	SetWeakSet(weakSet map[rune]struct{})
+}
+
+// ReviewAware is implemented by sources that can mix review words into their text.
+// An empty list turns mixing off.
+type ReviewAware interface {
+	SetReviewWords(words []string)
+}
+
+// BankAware is implemented by sources that build text from a weighted word bank.
+type BankAware interface {
+	SetBank(words []string, weights []float64)
+}
+
+// Style holds the caps and punctuation rules applied by word-based sources.
+type Style struct {
+	CapsPct  float64
+	PunctPct float64
+	PunctSet []rune
+}
+
+// weakBias is embedded by sources that support weak-character focus.
+type weakBias struct {
+	weakSet map[rune]struct{}
+	factor  float64
+}
+
+// SetWeakSet implements WeakAware.
+func (b *weakBias) SetWeakSet(weakSet map[rune]struct{}) {
+	b.weakSet = weakSet
+}
+
+// reviewMix is embedded by sources that support review words.
+type reviewMix struct {
+	review []string
+}
+
+// SetReviewWords implements ReviewAware.
+func (r *reviewMix) SetReviewWords(words []string) {
+	r.review = words
+}
+
+// WordSource samples words from a wordlist.
+type WordSource struct {
+	weakBias
+	reviewMix
+	gen   *Generator
+	words []string
+	style Style
+}
+
+// NewWordSource returns a source drawing from words; factor weights weak characters.
+func NewWordSource(gen *Generator, words []string, style Style, factor float64) *WordSource {
+	return &WordSource{weakBias: weakBias{factor: factor}, gen: gen, words: words, style: style}
+}
+
+// Next implements TextSource.
+func (s *WordSource) Next(count int) []string {
+	var words []string
+	if len(s.weakSet) > 0 {
+		words = s.gen.GenerateWeighted(s.words, count, s.style.CapsPct, s.style.PunctPct, s.style.PunctSet, s.weakSet, s.factor)
+	} else {
+		words = s.gen.Generate(s.words, count, s.style.CapsPct, s.style.PunctPct, s.style.PunctSet)
+	}
+	return s.gen.MixReview(words, s.review)
+}
+
+// MarkovSource walks a word bigram chain.
+type MarkovSource struct {
+	weakBias
+	reviewMix
+	gen   *Generator
+	chain *Chain
+	style Style
+}
+
+// NewMarkovSource returns a source walking chain; factor weights weak characters.
+func NewMarkovSource(gen *Generator, chain *Chain, style Style, factor float64) *MarkovSource {
+	return &MarkovSource{weakBias: weakBias{factor: factor}, gen: gen, chain: chain, style: style}
+}
+
+// Next implements TextSource.
+func (s *MarkovSource) Next(count int) []string {
+	words := s.gen.GenerateMarkov(s.chain, count, s.style.CapsPct, s.style.PunctPct, s.style.PunctSet, s.weakSet, s.factor)
+	return s.gen.MixReview(words, s.review)
+}
+
+// ShiftSource builds capital-letter drills from a wordlist.
+type ShiftSource struct {
+	weakBias
+	reviewMix
+	gen   *Generator
+	words []string
+	opts  ShiftOptions
+	style Style
+}
+
+// NewShiftSource returns a source drilling Shift combinations on words; style supplies
+// the punctuation and factor weights weak characters.
+func NewShiftSource(gen *Generator, words []string, opts ShiftOptions, style Style, factor float64) *ShiftSource {
+	return &ShiftSource{weakBias: weakBias{factor: factor}, gen: gen, words: words, opts: opts, style: style}
+}
+
+// Next implements TextSource.
+func (s *ShiftSource) Next(count int) []string {
+	words := s.gen.GenerateShift(s.words, count, s.opts, s.style.PunctPct, s.style.PunctSet, s.weakSet, s.factor)
+	return s.gen.MixReview(words, s.review)
+}
+
+// BankSource builds texts mostly from a weighted word bank, such as the mistake bank,
+// and fills the rest from another source. With an empty bank it passes the other source
+// through unchanged.
+type BankSource struct {
+	gen     *Generator
+	base    TextSource
+	style   Style
+	words   []string
+	weights []float64
+}
+
+// NewBankSource returns a source mixing bank words into the texts of base.
+func NewBankSource(gen *Generator, base TextSource, style Style) *BankSource {
+	return &BankSource{gen: gen, base: base, style: style}
+}
+
+// SetBank implements BankAware. weights holds one weight per word.
+func (s *BankSource) SetBank(words []string, weights []float64) {
+	s.words = words
+	s.weights = weights
+}
+
+// SetWeakSet implements WeakAware for the wrapped source.
+func (s *BankSource) SetWeakSet(weakSet map[rune]struct{}) {
+	if weakAware, ok := s.base.(WeakAware); ok {
+		weakAware.SetWeakSet(weakSet)
+	}
+}
+
+// SetReviewWords implements ReviewAware for the wrapped source.
+func (s *BankSource) SetReviewWords(words []string) {
+	if reviewAware, ok := s.base.(ReviewAware); ok {
+		reviewAware.SetReviewWords(words)
+	}
+}
+
+// Next implements TextSource.
+func (s *BankSource) Next(count int) []string {
+	text := s.base.Next(count)
+	if len(s.words) == 0 || len(text) == 0 {
+		return text
+	}
+	n := max(1, int(float64(len(text))*bankShare))
+	bank := s.gen.GenerateBank(s.words, s.weights, n, s.style.CapsPct, s.style.PunctPct, s.style.PunctSet)
+	return s.gen.mix(text, bank)
+}
+
+// CodeSource builds programming identifiers and operators from a wordlist.
+type CodeSource struct {
+	weakBias
+	gen   *Generator
+	words []string
+	opts  CodeOptions
+}
+
+// NewCodeSource returns a source building identifiers from words; factor weights weak characters.
+func NewCodeSource(gen *Generator, words []string, opts CodeOptions, factor float64) *CodeSource {
+	return &CodeSource{weakBias: weakBias{factor: factor}, gen: gen, words: words, opts: opts}
+}
+
+// Next implements TextSource.
+func (s *CodeSource) Next(count int) []string {
+	return s.gen.GenerateCode(s.words, count, s.opts, s.weakSet, s.factor)
+}
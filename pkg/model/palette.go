@@ -0,0 +1,71 @@
+package model
+
+import "sort"
+
+// Built-in palettes.
+const (
+	PaletteDefault = "default"
+	// PaletteColorblind uses Okabe-Ito hues that stay distinct with common color vision
+	// deficiencies.
+	PaletteColorblind = "colorblind"
+)
+
+// Palette holds the variants of a built-in theme for dark and light terminal backgrounds.
+type Palette struct {
+	Dark  Theme
+	Light Theme
+}
+
+var palettes = map[string]Palette{
+	PaletteDefault: {
+		Dark: DefaultTheme(),
+		Light: Theme{
+			Text:    "#1F1F1F",
+			Error:   "#C62828",
+			Pending: "#8A8A8A",
+			Accent:  "#9A6B00",
+			Muted:   "#767676",
+			Border:  "#C8C8C8",
+			Subtle:  "#4A4A4A",
+		},
+	},
+	PaletteColorblind: {
+		Dark: Theme{
+			Text:    "#F0F0F0",
+			Error:   "#E69F00",
+			Pending: "#8C8C8C",
+			Accent:  "#56B4E9",
+			Muted:   "#6E6E6E",
+			Border:  "#4A4A4A",
+			Subtle:  "#B8B8B8",
+		},
+		Light: Theme{
+			Text:    "#1F1F1F",
+			Error:   "#D55E00",
+			Pending: "#8A8A8A",
+			Accent:  "#0072B2",
+			Muted:   "#767676",
+			Border:  "#C8C8C8",
+			Subtle:  "#4A4A4A",
+		},
+	},
+}
+
+// PaletteNames lists the built-in palettes.
+func PaletteNames() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupPalette returns the built-in palette called name; empty means PaletteDefault.
+func LookupPalette(name string) (Palette, bool) {
+	if name == "" {
+		name = PaletteDefault
+	}
+	p, ok := palettes[name]
+	return p, ok
+}
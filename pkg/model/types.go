@@ -0,0 +1,383 @@
+// Package model defines shared data structures.
+package model
+
+import "time"
+
+// Practice modes.
+const (
+	// ModeWords is the default practice mode drawing words from a wordlist.
+	ModeWords = "words"
+	// ModeMarkov builds sentence-like text from word bigram transitions.
+	ModeMarkov = "markov"
+	// ModeCode produces programming identifiers and operator sequences.
+	ModeCode = "code"
+	// ModeShift drills Shift combinations: capitals at and inside words and ALL-CAPS tokens.
+	ModeShift = "shift"
+)
+
+// DefaultEquivalents accepts straight quotes for curly ones and - for an en dash.
+const DefaultEquivalents = "“”\" –-"
+
+// Bidi modes: who lays out right-to-left text.
+const (
+	// BidiApp has tuipe reorder right-to-left lines for terminals without bidi support.
+	BidiApp = "app"
+	// BidiTerminal leaves reordering to a terminal that implements bidi itself.
+	BidiTerminal = "terminal"
+)
+
+// WPM formulas: how typing speed is counted and shown.
+const (
+	// WPMChars counts five correct characters as a word, the usual convention.
+	WPMChars = "chars"
+	// WPMWords counts the words actually typed.
+	WPMWords = "words"
+	// WPMCPM shows characters per minute instead of words.
+	WPMCPM = "cpm"
+)
+
+// WPMFormulas lists the accepted WPM formulas.
+func WPMFormulas() []string {
+	return []string{WPMChars, WPMWords, WPMCPM}
+}
+
+// Sources of imported sessions.
+const (
+	SourceMonkeytype = "monkeytype"
+	SourceKeybr      = "keybr"
+)
+
+// Config defines practice settings.
+type Config struct {
+	Mode       string
+	Lang       string
+	List       string
+	Words      int
+	CapsPct    float64
+	PunctPct   float64
+	PunctSet   string
+	FocusWeak  bool
+	WeakTop    int
+	WeakFactor float64
+	WeakWindow int
+	SRS        bool
+	Review     bool
+	Keyboard   string
+	Layout     string
+	Corpus     string
+
+	// WordListMeta is the JSON provenance of the loaded wordlist, recorded per session.
+	WordListMeta string
+	// WordListID identifies the loaded wordlist (see wordlist.ID), recorded per session.
+	WordListID string
+	// Alphabet holds the letters of the loaded wordlist, the characters Coverage balances.
+	Alphabet string
+	// Coverage favors characters typed fewer than this many times in the weak window (0 = off).
+	Coverage int
+
+	ExcludeChars string
+	OnlyChars    string
+	Rows         string
+
+	RepeatWindow  int
+	SentenceStyle bool
+
+	CodeCamel     float64
+	CodeSnake     float64
+	CodeScreaming float64
+	CodeOps       float64
+
+	ShiftMid float64
+	ShiftAll float64
+
+	ResultsScreen bool
+	// AccuracyAlert turns the footer red while the rolling accuracy of the text is below it (0 = off).
+	AccuracyAlert float64
+	// AccuracyFloor abandons the text for a new one when the rolling accuracy drops below it (0 = off).
+	AccuracyFloor float64
+	// HesitationMs is the pause highlighted on the results rhythm strip (0 = no strip).
+	HesitationMs int
+	// Ghost shows the finished text on the results screen with the wrong keys under it.
+	Ghost bool
+	// RecordEnv saves the terminal, OS and active practice options with each session.
+	RecordEnv bool
+	// FooterTrend shows a sparkline of the latest session speeds in the footer.
+	FooterTrend bool
+	// GoalMinutes is the daily practice goal shown in the footer; 0 turns it off.
+	GoalMinutes    int
+	StoreText      bool
+	StoreTextMax   int
+	SaveIncomplete bool
+	// StoreKeys saves the keystroke log and full target text of each session for replays.
+	StoreKeys bool
+
+	// LooseApostrophe counts ' and ’ as the same key.
+	LooseApostrophe bool
+	// Equivalents holds space-separated groups of characters that count as the same key.
+	Equivalents string
+
+	// Daily seeds every text with the daily challenge of the current UTC day.
+	Daily bool
+	// Zen starts practice with the footer hidden, showing only the text.
+	Zen bool
+	// ContentWidth is the fraction of the terminal width used for the practice text.
+	ContentWidth float64
+	// Bidi is BidiApp or BidiTerminal.
+	Bidi string
+	// WPMFormula is WPMChars, WPMWords or WPMCPM; it is recorded per session.
+	WPMFormula string
+	Theme      Theme
+	// Accessible renders plain text for screen readers instead of colors and layout.
+	Accessible bool
+}
+
+// StatsConfig defines filters and options for stats output.
+type StatsConfig struct {
+	Lang        string
+	Since       *time.Time
+	Last        int
+	CurveWindow int
+	Chars       string
+	FoldCase    bool
+
+	ExcludeOutliers    bool
+	OutlierMaxWPM      float64
+	OutlierMinDuration time.Duration
+
+	Mode       string
+	FocusWeak  *bool
+	AppVersion string
+	Keyboard   string
+	Layout     string
+	// WordList matches sessions typed from a wordlist, by its full ID or by its name.
+	WordList string
+
+	IncludeIncomplete bool
+	// IncludeIgnored also matches sessions marked ignored, e.g. to list them.
+	IncludeIgnored bool
+
+	// PlotHeight is the number of rows used for curve plots in the stats UI.
+	PlotHeight int
+	// WPMFormula is the formula speeds are shown in, whatever each session recorded.
+	WPMFormula string
+	// Refresh is how often the stats UI checks the database for new sessions (0 = never).
+	Refresh time.Duration
+	Theme   Theme
+}
+
+// Theme holds the colors shared by the practice and stats UIs. Colors are hex values
+// (#RRGGBB) or ANSI color numbers; empty fields keep the default.
+type Theme struct {
+	// Palette names the built-in palette behind empty fields (PaletteDefault when empty).
+	Palette string
+
+	Text    string
+	Error   string
+	Pending string
+	Accent  string
+	Muted   string
+	Border  string
+	Subtle  string
+}
+
+// DefaultTheme returns the built-in palette.
+func DefaultTheme() Theme {
+	return Theme{
+		Text:    "#F0F0F0",
+		Error:   "#FF4D4F",
+		Pending: "#8C8C8C",
+		Accent:  "#C89A3A",
+		Muted:   "#6E6E6E",
+		Border:  "#4A4A4A",
+		Subtle:  "#B8B8B8",
+	}
+}
+
+// WithDefaults returns t with empty fields taken from DefaultTheme.
+func (t Theme) WithDefaults() Theme {
+	def := DefaultTheme()
+	fill := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	fill(&t.Text, def.Text)
+	fill(&t.Error, def.Error)
+	fill(&t.Pending, def.Pending)
+	fill(&t.Accent, def.Accent)
+	fill(&t.Muted, def.Muted)
+	fill(&t.Border, def.Border)
+	fill(&t.Subtle, def.Subtle)
+	return t
+}
+
+// SessionStats captures a completed typing session.
+type SessionStats struct {
+	StartedAt         time.Time
+	EndedAt           time.Time
+	Lang              string
+	Words             int
+	CapsPct           float64
+	PunctPct          float64
+	PunctSet          string
+	WordListPath      string
+	WordListMeta      string
+	CorrectNonSpace   int
+	IncorrectNonSpace int
+	DurationMs        int64
+	FirstKeyMs        int64
+	SpaceLatencySumMs int64
+	SpaceLatencyCount int64
+	Mode              string
+	FocusWeak         bool
+	WeakSet           string
+	Seed              int64
+	WordsTyped        int
+	AppVersion        string
+	TargetText        string
+	TypedText         string
+	TextTruncated     bool
+	Keyboard          string
+	Layout            string
+	Incomplete        bool
+	// Keystrokes is the JSON keystroke log of the text (replay keys); empty unless
+	// Config.StoreKeys was set and the full target text was stored.
+	Keystrokes string
+	// Ignored keeps the session out of every aggregate and curve without deleting it.
+	Ignored bool
+	// WordListID identifies the wordlist the text came from (see wordlist.ID); empty
+	// for sessions saved before it was recorded.
+	WordListID string
+	// Source names the tool an imported session came from; empty for tuipe sessions.
+	Source string
+	// WPMFormula is the formula the speed was shown in; empty for sessions saved before
+	// the choice was recorded, which used WPMChars.
+	WPMFormula string
+	// Samples counts the correct non-space characters typed in each second of the session.
+	Samples []int
+	// ParamsHash fingerprints the text generator settings (see stats.ParamsHash).
+	ParamsHash string
+	// Scoring describes the scoring rules (see stats.ScoringRules); empty for sessions
+	// saved before they were recorded.
+	Scoring string
+	// Env is where the session was typed; nil unless Config.RecordEnv was set.
+	Env *SessionEnv
+}
+
+// SessionEnv describes the terminal and practice options a session was typed with.
+type SessionEnv struct {
+	Term   string
+	OS     string
+	Width  int
+	Height int
+	// SSH is set when the practice ran in an SSH session.
+	SSH bool
+	// Options lists the practice features that were on, such as "focus-weak".
+	Options []string
+}
+
+// CharStats stores per-character stats for a session.
+type CharStats struct {
+	Char         string
+	Correct      int
+	Incorrect    int
+	LatencySumMs int64
+	LatencyCount int64
+}
+
+// Aggregated per-char stats for selection or reporting.
+
+// CharAggregate aggregates character stats across sessions.
+type CharAggregate struct {
+	Char         string
+	Correct      int
+	Incorrect    int
+	LatencySumMs int64
+	LatencyCount int64
+}
+
+// SessionAggregate summarizes a session for reporting.
+type SessionAggregate struct {
+	SessionID         int64
+	EndedAt           time.Time
+	Correct           int
+	Incorrect         int
+	DurationMs        int64
+	FirstKeyMs        int64
+	SpaceLatencySumMs int64
+	SpaceLatencyCount int64
+	Mode              string
+	FocusWeak         bool
+	Keyboard          string
+	Layout            string
+	Incomplete        bool
+	Ignored           bool
+	WordsTyped        int
+	WPMFormula        string
+	AppVersion        string
+	Scoring           string
+	Env               *SessionEnv
+}
+
+// DailyAggregate summarizes all sessions on one local calendar day.
+type DailyAggregate struct {
+	Day        time.Time
+	Sessions   int
+	Correct    int
+	Incorrect  int
+	DurationMs int64
+}
+
+// Kinds of spaced-repetition items.
+const (
+	SRSChar = "char"
+	SRSWord = "word"
+)
+
+// SRSItem is the spaced-repetition state of a character or word that was mistyped.
+type SRSItem struct {
+	Lang       string
+	Kind       string
+	Item       string
+	Ease       float64
+	Interval   time.Duration
+	Reps       int
+	Lapses     int
+	DueAt      time.Time
+	ReviewedAt time.Time
+}
+
+// MistakeWord is a word of the mistake bank. Weight is as of UpdatedAt and decays over
+// time (see stats.MistakeWeight).
+type MistakeWord struct {
+	Lang      string
+	Word      string
+	Weight    float64
+	UpdatedAt time.Time
+}
+
+// SRSGrade is the outcome of one character or word in a finished session.
+type SRSGrade struct {
+	Kind   string
+	Item   string
+	Passed bool
+}
+
+// Totals sums completed sessions. Words counts words typed, or correct/5 for sessions
+// that did not record them (see stats.CountedWords).
+type Totals struct {
+	Sessions   int
+	Correct    int
+	Incorrect  int
+	Words      int
+	DurationMs int64
+}
+
+// LangTotals sums all completed sessions of one language.
+type LangTotals struct {
+	Lang       string
+	Sessions   int
+	Correct    int
+	Incorrect  int
+	DurationMs int64
+}
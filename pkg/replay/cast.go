@@ -0,0 +1,149 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/stats"
+)
+
+// DefaultCastWidth is the terminal width of exported casts.
+const DefaultCastWidth = 80
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiIncorrect = "\x1b[31m"
+	ansiPending   = "\x1b[90m"
+	ansiCursor    = "\x1b[4m"
+	ansiClear     = "\x1b[2J\x1b[H"
+)
+
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// WriteCast renders r as an asciinema v2 cast, one frame per keystroke, wrapped to width
+// columns (DefaultCastWidth when width <= 0).
+func WriteCast(w io.Writer, r Recording, width int) error {
+	if width <= 0 {
+		width = DefaultCastWidth
+	}
+	target := grapheme.Split(r.Target)
+	breaks := lineBreaks(target, width)
+	header := castHeader{
+		Version: 2,
+		Width:   width,
+		// Text lines, a blank line and the status line.
+		Height: len(breaks) + 3,
+		Title:  fmt.Sprintf("tuipe replay (%s)", r.Lang),
+	}
+	if !r.CreatedAt.IsZero() {
+		header.Timestamp = r.CreatedAt.Unix()
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to encode cast header: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+		return fmt.Errorf("failed to write cast: %w", err)
+	}
+	if err := writeCastFrame(w, r, target, breaks, 0); err != nil {
+		return err
+	}
+	for i := range r.Keys {
+		if err := writeCastFrame(w, r, target, breaks, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCastFrame(w io.Writer, r Recording, target []string, breaks []int, n int) error {
+	var atMs int64
+	if n > 0 {
+		atMs = r.Keys[n-1].AtMs
+	}
+	frame := ansiClear + renderFrame(target, r.InputAt(n), breaks)
+	correct, incorrect := r.CountsAt(n)
+	wpm, _, acc := stats.SessionMetrics(correct, incorrect, atMs)
+	frame += fmt.Sprintf("\r\n\r\n%s%.1fs  %.1f WPM  %.1f%%%s", ansiPending, float64(atMs)/1000, wpm, acc*100, ansiReset)
+	event, err := json.Marshal([]any{float64(atMs) / 1000, "o", frame})
+	if err != nil {
+		return fmt.Errorf("failed to encode cast event: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", event); err != nil {
+		return fmt.Errorf("failed to write cast: %w", err)
+	}
+	return nil
+}
+
+// renderFrame colors input against target and splits it at breaks.
+func renderFrame(target, input []string, breaks []int) string {
+	var b strings.Builder
+	line := 0
+	for i, want := range target {
+		if line < len(breaks) && i == breaks[line] {
+			b.WriteString("\r\n")
+			line++
+			if want == " " {
+				continue
+			}
+		}
+		shown := want
+		style := ansiPending
+		if i < len(input) {
+			switch {
+			case want == " " && input[i] != " ":
+				shown = "•"
+				style = ansiIncorrect
+			case input[i] == want:
+				style = ""
+			default:
+				style = ansiIncorrect
+			}
+		} else if i == len(input) {
+			style = ansiPending + ansiCursor
+		}
+		b.WriteString(style)
+		b.WriteString(shown)
+		if style != "" {
+			b.WriteString(ansiReset)
+		}
+	}
+	return b.String()
+}
+
+// lineBreaks returns the target indexes that start a new line when words are wrapped to
+// width columns. A break on a space drops that space.
+func lineBreaks(target []string, width int) []int {
+	var breaks []int
+	lineStart, lineWidth, lastSpace := 0, 0, -1
+	for i := 0; i < len(target); i++ {
+		w := grapheme.Width(target[i])
+		if lineWidth+w > width && i > lineStart {
+			next := i
+			if lastSpace > lineStart {
+				next = lastSpace
+			}
+			breaks = append(breaks, next)
+			lineStart = next
+			if target[next] == " " {
+				lineStart = next + 1
+			}
+			lineWidth = grapheme.Width(strings.Join(target[lineStart:i], ""))
+			lastSpace = -1
+		}
+		if target[i] == " " {
+			lastSpace = i
+		}
+		lineWidth += w
+	}
+	return breaks
+}
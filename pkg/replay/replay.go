@@ -0,0 +1,204 @@
+// Package replay reads and writes self-contained recordings of a typed text.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// Version is the recording format written by this package.
+const Version = 1
+
+// Ext is the file extension used for recordings.
+const Ext = ".tuipe"
+
+// Recording holds a target text and the keystrokes that typed it.
+type Recording struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Lang      string    `json:"lang"`
+	Mode      string    `json:"mode,omitempty"`
+	Keyboard  string    `json:"keyboard,omitempty"`
+	Layout    string    `json:"layout,omitempty"`
+	Target    string    `json:"target"`
+	Keys      []Key     `json:"keys"`
+}
+
+// Key is one keystroke. AtMs counts from the first keystroke of the text.
+type Key struct {
+	AtMs      int64  `json:"t"`
+	Text      string `json:"k,omitempty"`
+	Backspace bool   `json:"bs,omitempty"`
+}
+
+// DurationMs is the time between the first and the last keystroke.
+func (r Recording) DurationMs() int64 {
+	if len(r.Keys) == 0 {
+		return 0
+	}
+	return r.Keys[len(r.Keys)-1].AtMs
+}
+
+// InputAt returns the typed text after the first n keys as grapheme clusters; a
+// backspace removes the last cluster.
+func (r Recording) InputAt(n int) []string {
+	if n > len(r.Keys) {
+		n = len(r.Keys)
+	}
+	var input []string
+	for _, key := range r.Keys[:n] {
+		if key.Backspace {
+			if len(input) > 0 {
+				input = input[:len(input)-1]
+			}
+			continue
+		}
+		input = append(input, grapheme.Split(key.Text)...)
+	}
+	return input
+}
+
+// CountsAt returns the correct and incorrect non-space keystrokes among the first n keys,
+// counted the way a live session counts them: corrected mistakes still count.
+func (r Recording) CountsAt(n int) (int, int) {
+	if n > len(r.Keys) {
+		n = len(r.Keys)
+	}
+	target := grapheme.Split(r.Target)
+	pos, correct, incorrect := 0, 0, 0
+	for _, key := range r.Keys[:n] {
+		if key.Backspace {
+			if pos > 0 {
+				pos--
+			}
+			continue
+		}
+		for _, typed := range grapheme.Split(key.Text) {
+			if pos >= len(target) {
+				break
+			}
+			switch {
+			case target[pos] == " ":
+			case typed == target[pos]:
+				correct++
+			default:
+				incorrect++
+			}
+			pos++
+		}
+	}
+	return correct, incorrect
+}
+
+// Validate checks that r can be replayed.
+func (r Recording) Validate() error {
+	if r.Version < 1 || r.Version > Version {
+		return fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+	if r.Target == "" {
+		return errors.New("replay has no target text")
+	}
+	var prev int64
+	for i, key := range r.Keys {
+		if key.AtMs < prev {
+			return fmt.Errorf("replay key %d goes back in time", i+1)
+		}
+		if !key.Backspace && key.Text == "" {
+			return fmt.Errorf("replay key %d is empty", i+1)
+		}
+		prev = key.AtMs
+	}
+	return nil
+}
+
+// Write encodes r as JSON.
+func Write(w io.Writer, r Recording) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode replay: %w", err)
+	}
+	return nil
+}
+
+// Read decodes and validates a recording.
+func Read(rd io.Reader) (Recording, error) {
+	var r Recording
+	if err := json.NewDecoder(rd).Decode(&r); err != nil {
+		return Recording{}, fmt.Errorf("failed to decode replay: %w", err)
+	}
+	if err := r.Validate(); err != nil {
+		return Recording{}, err
+	}
+	return r, nil
+}
+
+// WriteFile writes r to path.
+func WriteFile(path string, r Recording) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create replay: %w", err)
+	}
+	if err := Write(file, r); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close replay: %w", err)
+	}
+	return nil
+}
+
+// ReadFile reads a recording from path.
+func ReadFile(path string) (Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Recording{}, fmt.Errorf("failed to open replay: %w", err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			// Best-effort close after read.
+			_ = cerr
+		}
+	}()
+	return Read(file)
+}
+
+// EncodeKeys encodes keys for model.SessionStats.Keystrokes.
+func EncodeKeys(keys []Key) (string, error) {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode keystrokes: %w", err)
+	}
+	return string(data), nil
+}
+
+// FromSession rebuilds the recording of a session saved with its keystroke log.
+func FromSession(s model.SessionStats) (Recording, error) {
+	if s.Keystrokes == "" {
+		return Recording{}, errors.New("session has no stored keystrokes")
+	}
+	r := Recording{
+		Version:   Version,
+		CreatedAt: s.StartedAt,
+		Lang:      s.Lang,
+		Mode:      s.Mode,
+		Keyboard:  s.Keyboard,
+		Layout:    s.Layout,
+		Target:    s.TargetText,
+	}
+	if err := json.Unmarshal([]byte(s.Keystrokes), &r.Keys); err != nil {
+		return Recording{}, fmt.Errorf("failed to decode keystrokes: %w", err)
+	}
+	if err := r.Validate(); err != nil {
+		return Recording{}, err
+	}
+	return r, nil
+}
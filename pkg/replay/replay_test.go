@@ -0,0 +1,113 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/internal/grapheme"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func sampleRecording() Recording {
+	return Recording{
+		Version: Version,
+		Lang:    "en",
+		Target:  "ab cd",
+		Keys: []Key{
+			{AtMs: 0, Text: "a"},
+			{AtMs: 150, Text: "x"},
+			{AtMs: 300, Backspace: true},
+			{AtMs: 420, Text: "b"},
+			{AtMs: 600, Text: " "},
+			{AtMs: 750, Text: "c"},
+			{AtMs: 900, Text: "d"},
+		},
+	}
+}
+
+func TestRecordingRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleRecording()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	rec, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if rec.Target != "ab cd" || len(rec.Keys) != 7 || rec.DurationMs() != 900 {
+		t.Fatalf("unexpected recording after round trip: %+v", rec)
+	}
+	if got := strings.Join(rec.InputAt(3), ""); got != "a" {
+		t.Fatalf("expected input %q after a backspace, got %q", "a", got)
+	}
+	correct, incorrect := rec.CountsAt(len(rec.Keys))
+	if correct != 4 || incorrect != 1 {
+		t.Fatalf("expected 4 correct and 1 incorrect, got %d and %d", correct, incorrect)
+	}
+}
+
+func TestReadRejectsInvalidRecordings(t *testing.T) {
+	cases := map[string]string{
+		"version":   `{"version":9,"target":"a","keys":[]}`,
+		"target":    `{"version":1,"target":"","keys":[]}`,
+		"backwards": `{"version":1,"target":"ab","keys":[{"t":5,"k":"a"},{"t":1,"k":"b"}]}`,
+		"empty key": `{"version":1,"target":"ab","keys":[{"t":5}]}`,
+	}
+	for name, doc := range cases {
+		if _, err := Read(strings.NewReader(doc)); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestWriteCastEmitsFramePerKey(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCast(&buf, sampleRecording(), 3); err != nil {
+		t.Fatalf("WriteCast: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("expected header and 8 frames, got %d lines", len(lines))
+	}
+	var header castHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if header.Version != 2 || header.Width != 3 || header.Height != 4 {
+		t.Fatalf("unexpected header: %+v", header)
+	}
+	var last []any
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil {
+		t.Fatalf("last frame: %v", err)
+	}
+	if last[0].(float64) != 0.9 || last[1] != "o" || !strings.Contains(last[2].(string), "ab\r\ncd") {
+		t.Fatalf("unexpected last frame: %v", last)
+	}
+}
+
+func TestLineBreaksWrapsOnSpaces(t *testing.T) {
+	got := lineBreaks(grapheme.Split("one two three"), 8)
+	if len(got) != 1 || got[0] != 7 {
+		t.Fatalf("expected one break at the second space, got %v", got)
+	}
+}
+
+func TestFromSessionDecodesStoredKeys(t *testing.T) {
+	want := sampleRecording()
+	encoded, err := EncodeKeys(want.Keys)
+	if err != nil {
+		t.Fatalf("EncodeKeys: %v", err)
+	}
+	rec, err := FromSession(model.SessionStats{Lang: "en", TargetText: want.Target, Keystrokes: encoded})
+	if err != nil {
+		t.Fatalf("FromSession: %v", err)
+	}
+	if rec.Target != want.Target || len(rec.Keys) != len(want.Keys) || strings.Join(rec.InputAt(len(rec.Keys)), "") != "ab cd" {
+		t.Fatalf("unexpected recording from session: %+v", rec)
+	}
+	if _, err := FromSession(model.SessionStats{TargetText: want.Target}); err == nil {
+		t.Fatalf("expected a session without keystrokes to be rejected")
+	}
+}
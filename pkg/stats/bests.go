@@ -0,0 +1,35 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// PersonalBest is the fastest session of one mode.
+type PersonalBest struct {
+	Mode     string
+	Session  model.SessionAggregate
+	WPM      float64
+	Accuracy float64
+}
+
+// PersonalBests returns the fastest session per mode (weak-focus practice counts as its own
+// mode), ordered by mode. Ties keep the earlier session.
+func PersonalBests(sessions []model.SessionAggregate) []PersonalBest {
+	best := map[string]PersonalBest{}
+	for _, s := range sessions {
+		wpm, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		mode := sessionMode(s)
+		if cur, ok := best[mode]; ok && wpm <= cur.WPM {
+			continue
+		}
+		best[mode] = PersonalBest{Mode: mode, Session: s, WPM: wpm, Accuracy: acc}
+	}
+	out := make([]PersonalBest, 0, len(best))
+	for _, pb := range best {
+		out = append(out, pb)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Mode < out[j].Mode })
+	return out
+}
@@ -0,0 +1,25 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestPersonalBests(t *testing.T) {
+	bests := PersonalBests([]model.SessionAggregate{
+		{SessionID: 1, Mode: model.ModeWords, Correct: 250, DurationMs: 60000},
+		{SessionID: 2, Mode: model.ModeWords, Correct: 300, DurationMs: 60000},
+		{SessionID: 3, Mode: model.ModeWords, Correct: 300, DurationMs: 60000},
+		{SessionID: 4, Mode: model.ModeWords, FocusWeak: true, Correct: 200, DurationMs: 60000},
+	})
+	if len(bests) != 2 {
+		t.Fatalf("expected 2 modes, got %+v", bests)
+	}
+	if bests[0].Mode != "words" || bests[0].Session.SessionID != 2 || bests[0].WPM != 60 {
+		t.Fatalf("unexpected words best: %+v", bests[0])
+	}
+	if bests[1].Mode != "words+weak" || bests[1].Session.SessionID != 4 {
+		t.Fatalf("unexpected weak best: %+v", bests[1])
+	}
+}
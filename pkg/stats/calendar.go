@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// calendarLevels shade days by practice time, from none to the busiest day.
+const calendarLevels = ".-=+"
+
+// calendarGoalMet marks days that reached the daily goal.
+const calendarGoalMet = '#'
+
+// RenderCalendar prints a heatmap of the last weeks of practice ending with the
+// week of now: one row per weekday, Monday first, one column per week. Days are
+// shaded by practice time relative to the busiest day shown; with goalMinutes > 0,
+// days that reached the goal are marked with '#' instead.
+func RenderCalendar(w io.Writer, daily []model.DailyAggregate, now time.Time, weeks, goalMinutes int) error {
+	if weeks < 1 {
+		weeks = 1
+	}
+	minutes := map[string]int64{}
+	for _, d := range daily {
+		minutes[dayKey(d.Day)] += d.DurationMs
+	}
+	y, m, d := now.Local().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	offset := (int(today.Weekday()) + 6) % 7
+	start := today.AddDate(0, 0, -offset-7*(weeks-1))
+
+	var busiest int64
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if ms := minutes[dayKey(day)]; ms > busiest {
+			busiest = ms
+		}
+	}
+
+	lines := []string{fmt.Sprintf("%s – %s", start.Format("2006-01-02"), today.Format("2006-01-02"))}
+	for row, name := range weekdayNames {
+		var b strings.Builder
+		b.WriteString(name)
+		b.WriteByte(' ')
+		for week := 0; week < weeks; week++ {
+			day := start.AddDate(0, 0, 7*week+row)
+			if day.After(today) {
+				break
+			}
+			b.WriteByte(' ')
+			b.WriteByte(calendarCell(minutes[dayKey(day)], busiest, goalMinutes))
+		}
+		lines = append(lines, b.String())
+	}
+	legend := fmt.Sprintf("%c none  %s more", calendarLevels[0], strings.Join(strings.Split(calendarLevels[1:], ""), " "))
+	if goalMinutes > 0 {
+		legend += fmt.Sprintf("  %c goal of %d min met", calendarGoalMet, goalMinutes)
+	}
+	lines = append(lines, "", legend)
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func calendarCell(ms, busiest int64, goalMinutes int) byte {
+	if ms <= 0 || busiest <= 0 {
+		return calendarLevels[0]
+	}
+	if GoalMet(ms, goalMinutes) {
+		return calendarGoalMet
+	}
+	steps := int64(len(calendarLevels) - 1)
+	level := 1 + (ms*steps-1)/busiest
+	if level > steps {
+		level = steps
+	}
+	return calendarLevels[level]
+}
@@ -0,0 +1,62 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	cardBold  = "\x1b[1m"
+	cardMuted = "\x1b[2m"
+)
+
+// ShareCard holds the values shown on a shareable result card.
+type ShareCard struct {
+	WPM      float64
+	Accuracy float64
+	Streak   int
+	Date     time.Time
+	Lang     string
+}
+
+// RenderShareCard renders a compact bordered card. ANSI styling is added when useColor is set;
+// the plain variant is suitable for pasting into chat.
+func RenderShareCard(card ShareCard, useColor bool) string {
+	title := "tuipe"
+	if card.Lang != "" {
+		title += " [" + card.Lang + "]"
+	}
+	title += " · " + card.Date.Local().Format("2006-01-02")
+	days := "days"
+	if card.Streak == 1 {
+		days = "day"
+	}
+	type line struct {
+		text  string
+		style string
+	}
+	lines := []line{
+		{text: title, style: cardMuted},
+		{text: fmt.Sprintf("%.1f WPM   %.1f%% acc", card.WPM, card.Accuracy*100), style: cardBold},
+		{text: fmt.Sprintf("streak %d %s", card.Streak, days), style: ""},
+	}
+	width := 0
+	for _, l := range lines {
+		if w := displayWidth(l.text); w > width {
+			width = w
+		}
+	}
+	var b strings.Builder
+	b.WriteString("╭" + strings.Repeat("─", width+2) + "╮\n")
+	for _, l := range lines {
+		text := padCell(l.text, width, false)
+		if useColor && l.style != "" {
+			text = l.style + text + colorReset
+		}
+		b.WriteString("│ " + text + " │\n")
+	}
+	b.WriteString("╰" + strings.Repeat("─", width+2) + "╯")
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestRenderShareCardPlain(t *testing.T) {
+	card := RenderShareCard(ShareCard{
+		WPM:      72.44,
+		Accuracy: 0.978,
+		Streak:   1,
+		Date:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local),
+		Lang:     "en",
+	}, false)
+	if strings.Contains(card, "\x1b[") {
+		t.Fatalf("expected no ANSI codes in plain card")
+	}
+	lines := strings.Split(card, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines, got %d", len(lines))
+	}
+	width := displayWidth(lines[0])
+	for _, line := range lines {
+		if displayWidth(line) != width {
+			t.Fatalf("expected aligned card lines, got %q", card)
+		}
+	}
+	if !containsAll(card, []string{"tuipe [en] · 2024-05-01", "72.4 WPM", "97.8% acc", "streak 1 day"}) {
+		t.Fatalf("card missing expected values: %s", card)
+	}
+}
+
+func TestStreak(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.Local)
+	at := func(daysAgo int) model.SessionAggregate {
+		return model.SessionAggregate{EndedAt: now.AddDate(0, 0, -daysAgo)}
+	}
+	if got := Streak([]model.SessionAggregate{at(0), at(1), at(2), at(4)}, now); got != 3 {
+		t.Fatalf("expected streak 3, got %d", got)
+	}
+	if got := Streak([]model.SessionAggregate{at(1), at(2)}, now); got != 2 {
+		t.Fatalf("expected streak 2 without practice today, got %d", got)
+	}
+	if got := Streak([]model.SessionAggregate{at(3)}, now); got != 0 {
+		t.Fatalf("expected broken streak, got %d", got)
+	}
+}
+
+func containsAll(haystack string, needles []string) bool {
+	for _, needle := range needles {
+		if !strings.Contains(haystack, needle) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// ShiftLabel is the pseudo-character used for the aggregate of all shifted keys.
+const ShiftLabel = "<shift>"
+
+// usShiftedSymbols lists symbols that require Shift on a US QWERTY layout.
+const usShiftedSymbols = "~!@#$%^&*()_+{}|:\"<>?"
+
+// IsShifted reports whether typing the character requires the Shift key.
+func IsShifted(ch string) bool {
+	runes := []rune(ch)
+	if len(runes) != 1 {
+		return false
+	}
+	r := runes[0]
+	if unicode.IsUpper(r) {
+		return true
+	}
+	return strings.ContainsRune(usShiftedSymbols, r)
+}
+
+// FoldCase merges upper- and lower-case variants of a character into one lower-case entry.
+func FoldCase(aggs []model.CharAggregate) []model.CharAggregate {
+	if len(aggs) == 0 {
+		return aggs
+	}
+	merged := map[string]model.CharAggregate{}
+	for _, agg := range aggs {
+		key := strings.ToLower(agg.Char)
+		entry := merged[key]
+		entry.Char = key
+		entry.Correct += agg.Correct
+		entry.Incorrect += agg.Incorrect
+		entry.LatencySumMs += agg.LatencySumMs
+		entry.LatencyCount += agg.LatencyCount
+		merged[key] = entry
+	}
+	out := make([]model.CharAggregate, 0, len(merged))
+	for _, agg := range merged {
+		out = append(out, agg)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Char < out[j].Char
+	})
+	return out
+}
+
+// FoldCasePerSession applies FoldCase to per-session character stats.
+func FoldCasePerSession(perSession map[int64]map[string]model.CharAggregate) map[int64]map[string]model.CharAggregate {
+	out := make(map[int64]map[string]model.CharAggregate, len(perSession))
+	for id, chars := range perSession {
+		aggs := make([]model.CharAggregate, 0, len(chars))
+		for _, agg := range chars {
+			aggs = append(aggs, agg)
+		}
+		folded := map[string]model.CharAggregate{}
+		for _, agg := range FoldCase(aggs) {
+			folded[agg.Char] = agg
+		}
+		out[id] = folded
+	}
+	return out
+}
+
+// CaseVariants returns the characters plus their upper-case variants, without duplicates.
+func CaseVariants(chars []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(chars)*2)
+	for _, ch := range chars {
+		for _, variant := range []string{ch, strings.ToLower(ch), strings.ToUpper(ch)} {
+			if _, ok := seen[variant]; ok {
+				continue
+			}
+			seen[variant] = struct{}{}
+			out = append(out, variant)
+		}
+	}
+	return out
+}
+
+// ShiftedAggregate sums stats for every character that requires Shift.
+// It reports false when no shifted characters were typed.
+func ShiftedAggregate(aggs []model.CharAggregate) (model.CharAggregate, bool) {
+	total := model.CharAggregate{Char: ShiftLabel}
+	found := false
+	for _, agg := range aggs {
+		if !IsShifted(agg.Char) {
+			continue
+		}
+		found = true
+		total.Correct += agg.Correct
+		total.Incorrect += agg.Incorrect
+		total.LatencySumMs += agg.LatencySumMs
+		total.LatencyCount += agg.LatencyCount
+	}
+	return total, found
+}
+
+// ShiftedChars returns the stats of the characters that require Shift, least accurate
+// first, so a drill can point at the combinations that fail most.
+func ShiftedChars(aggs []model.CharAggregate) []model.CharAggregate {
+	var shifted []model.CharAggregate
+	for _, agg := range aggs {
+		if IsShifted(agg.Char) && agg.Correct+agg.Incorrect > 0 {
+			shifted = append(shifted, agg)
+		}
+	}
+	sort.Slice(shifted, func(i, j int) bool {
+		ai, aj := accuracy(shifted[i]), accuracy(shifted[j])
+		if ai == aj {
+			return shifted[i].Char < shifted[j].Char
+		}
+		return ai < aj
+	})
+	return shifted
+}
@@ -0,0 +1,55 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestFoldCaseMergesVariants(t *testing.T) {
+	aggs := []model.CharAggregate{
+		{Char: "a", Correct: 3, Incorrect: 1, LatencySumMs: 300, LatencyCount: 3},
+		{Char: "A", Correct: 1, Incorrect: 1, LatencySumMs: 200, LatencyCount: 1},
+		{Char: "b", Correct: 2},
+	}
+	folded := FoldCase(aggs)
+	if len(folded) != 2 {
+		t.Fatalf("expected 2 folded chars, got %d", len(folded))
+	}
+	a := folded[0]
+	if a.Char != "a" || a.Correct != 4 || a.Incorrect != 2 || a.LatencySumMs != 500 || a.LatencyCount != 4 {
+		t.Fatalf("unexpected folded aggregate: %+v", a)
+	}
+}
+
+func TestShiftedAggregate(t *testing.T) {
+	aggs := []model.CharAggregate{
+		{Char: "A", Correct: 1, Incorrect: 1},
+		{Char: "?", Correct: 2},
+		{Char: "a", Correct: 10},
+		{Char: ".", Correct: 5},
+	}
+	shift, ok := ShiftedAggregate(aggs)
+	if !ok {
+		t.Fatalf("expected shifted aggregate")
+	}
+	if shift.Char != ShiftLabel || shift.Correct != 3 || shift.Incorrect != 1 {
+		t.Fatalf("unexpected shifted aggregate: %+v", shift)
+	}
+	if _, ok := ShiftedAggregate([]model.CharAggregate{{Char: "a", Correct: 1}}); ok {
+		t.Fatalf("expected no shifted aggregate for unshifted chars")
+	}
+}
+
+func TestShiftedCharsLeastAccurateFirst(t *testing.T) {
+	shifted := ShiftedChars([]model.CharAggregate{
+		{Char: "a", Correct: 1, Incorrect: 9},
+		{Char: "A", Correct: 9, Incorrect: 1},
+		{Char: "!", Correct: 1, Incorrect: 1},
+		{Char: "Q", Correct: 5, Incorrect: 5},
+		{Char: "B"},
+	})
+	if len(shifted) != 3 || shifted[0].Char != "!" || shifted[1].Char != "Q" || shifted[2].Char != "A" {
+		t.Fatalf("expected [! Q A], got %+v", shifted)
+	}
+}
@@ -0,0 +1,36 @@
+package stats
+
+import "math"
+
+// Consistency scores how steady the per-second speed samples of a session were, from 0
+// (erratic) to 1 (the same speed every second): one minus the coefficient of variation.
+// ok is false for sessions with too few samples to tell.
+func Consistency(samples []int) (score float64, ok bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	var sum float64
+	for _, n := range samples {
+		sum += float64(n)
+	}
+	mean := sum / float64(len(samples))
+	if mean == 0 {
+		return 0, false
+	}
+	var variance float64
+	for _, n := range samples {
+		d := float64(n) - mean
+		variance += d * d
+	}
+	stddev := math.Sqrt(variance / float64(len(samples)))
+	return math.Max(0, 1-stddev/mean), true
+}
+
+// SampleWPM converts per-second samples of correct characters to words per minute.
+func SampleWPM(samples []int) []float64 {
+	out := make([]float64, len(samples))
+	for i, n := range samples {
+		out[i] = float64(n) * 60 / 5
+	}
+	return out
+}
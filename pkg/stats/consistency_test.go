@@ -0,0 +1,21 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConsistency(t *testing.T) {
+	if score, ok := Consistency([]int{5, 5, 5}); !ok || score != 1 {
+		t.Fatalf("expected a steady session to score 1, got %.2f (ok=%v)", score, ok)
+	}
+	if score, ok := Consistency([]int{2, 6}); !ok || math.Abs(score-0.5) > 1e-9 {
+		t.Fatalf("expected 0.5, got %.2f (ok=%v)", score, ok)
+	}
+	if score, _ := Consistency([]int{0, 0, 0, 12}); score != 0 {
+		t.Fatalf("expected erratic sessions to floor at 0, got %.2f", score)
+	}
+	if _, ok := Consistency([]int{4}); ok {
+		t.Fatalf("expected no score from a single sample")
+	}
+}
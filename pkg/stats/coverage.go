@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// UnderSampled returns the characters of alphabet typed fewer than target times in aggs,
+// fewest samples first, at most limit of them (all when limit <= 0). Characters that do
+// not appear in aggs have no samples yet.
+func UnderSampled(alphabet string, aggs []model.CharAggregate, target, limit int) []rune {
+	if target <= 0 {
+		return nil
+	}
+	counts := make(map[rune]int, len(aggs))
+	for _, agg := range aggs {
+		if runes := []rune(agg.Char); len(runes) == 1 {
+			counts[runes[0]] += agg.Correct + agg.Incorrect
+		}
+	}
+	var chars []rune
+	for _, r := range alphabet {
+		if counts[r] < target {
+			chars = append(chars, r)
+		}
+	}
+	sort.SliceStable(chars, func(i, j int) bool { return counts[chars[i]] < counts[chars[j]] })
+	if limit > 0 && len(chars) > limit {
+		chars = chars[:limit]
+	}
+	return chars
+}
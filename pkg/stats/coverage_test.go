@@ -0,0 +1,26 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestUnderSampledFavorsRareCharacters(t *testing.T) {
+	aggs := []model.CharAggregate{
+		{Char: "e", Correct: 120, Incorrect: 3},
+		{Char: "q", Correct: 4, Incorrect: 1},
+		{Char: "x", Correct: 11},
+		{Char: "th", Correct: 1},
+	}
+	got := UnderSampled("eqxz", aggs, 20, 0)
+	if string(got) != "zqx" {
+		t.Fatalf("expected unseen z, then q and x, got %q", string(got))
+	}
+	if got := UnderSampled("eqxz", aggs, 20, 2); string(got) != "zq" {
+		t.Fatalf("expected the limit to keep the rarest, got %q", string(got))
+	}
+	if got := UnderSampled("eqxz", aggs, 0, 0); len(got) != 0 {
+		t.Fatalf("expected no characters with coverage off, got %q", string(got))
+	}
+}
@@ -0,0 +1,156 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// Environment dimensions sessions can be grouped by.
+const (
+	EnvByTerm   = "term"
+	EnvByOS     = "os"
+	EnvBySSH    = "ssh"
+	EnvBySize   = "size"
+	EnvByOption = "option"
+)
+
+// EnvDimensions lists the accepted environment dimensions.
+func EnvDimensions() []string {
+	return []string{EnvByTerm, EnvByOS, EnvBySSH, EnvBySize, EnvByOption}
+}
+
+// EnvSummary aggregates the sessions sharing one value of an environment dimension.
+type EnvSummary struct {
+	Value    string
+	Sessions int
+	AvgSpeed float64
+	AvgAcc   float64
+}
+
+// EnvBreakdown groups sessions with a recorded environment by the values of dimension,
+// most used first, with speeds under formula. Under EnvByOption a session counts toward
+// every option that was on, or "(none)". It also returns the number of sessions without
+// a recorded environment.
+func EnvBreakdown(sessions []model.SessionAggregate, dimension, formula string) ([]EnvSummary, int, error) {
+	if !slices.Contains(EnvDimensions(), dimension) {
+		return nil, 0, fmt.Errorf("unknown environment dimension %q (want one of %s)", dimension, strings.Join(EnvDimensions(), ", "))
+	}
+	groups := map[string]*EnvSummary{}
+	skipped := 0
+	for _, s := range sessions {
+		if s.Env == nil {
+			skipped++
+			continue
+		}
+		_, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		speed := SessionSpeed(formula, s)
+		for _, value := range envValues(*s.Env, dimension) {
+			summary, ok := groups[value]
+			if !ok {
+				summary = &EnvSummary{Value: value}
+				groups[value] = summary
+			}
+			summary.Sessions++
+			summary.AvgSpeed += speed
+			summary.AvgAcc += acc
+		}
+	}
+	out := make([]EnvSummary, 0, len(groups))
+	for _, summary := range groups {
+		summary.AvgSpeed /= float64(summary.Sessions)
+		summary.AvgAcc /= float64(summary.Sessions)
+		out = append(out, *summary)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Sessions != out[j].Sessions {
+			return out[i].Sessions > out[j].Sessions
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out, skipped, nil
+}
+
+// envValues returns the values of dimension for env; terminal widths are bucketed.
+func envValues(env model.SessionEnv, dimension string) []string {
+	switch dimension {
+	case EnvByTerm:
+		return []string{orUnknown(env.Term)}
+	case EnvByOS:
+		return []string{orUnknown(env.OS)}
+	case EnvBySSH:
+		if env.SSH {
+			return []string{"ssh"}
+		}
+		return []string{"local"}
+	case EnvBySize:
+		return []string{widthBucket(env.Width)}
+	}
+	if len(env.Options) == 0 {
+		return []string{"(none)"}
+	}
+	return env.Options
+}
+
+func widthBucket(width int) string {
+	switch {
+	case width <= 0:
+		return "(unset)"
+	case width < 80:
+		return "< 80 cols"
+	case width < 120:
+		return "80-119 cols"
+	case width < 160:
+		return "120-159 cols"
+	}
+	return ">= 160 cols"
+}
+
+// RenderEnvBreakdown prints per-value averages of an environment dimension next to the
+// average of all sessions with a recorded environment, so differences stand out.
+func RenderEnvBreakdown(w io.Writer, sessions []model.SessionAggregate, dimension, formula string) error {
+	summaries, skipped, err := EnvBreakdown(sessions, dimension, formula)
+	if err != nil {
+		return err
+	}
+	if len(summaries) == 0 {
+		_, err := fmt.Fprintln(w, "No sessions with a recorded environment found (practice with --record-env).")
+		return err
+	}
+	var total, count float64
+	for _, s := range sessions {
+		if s.Env != nil {
+			total += SessionSpeed(formula, s)
+			count++
+		}
+	}
+	overall := total / count
+	unit := SpeedUnit(formula)
+	headers := []string{strings.ToUpper(dimension[:1]) + dimension[1:], "Sessions", "Avg " + unit, "vs all", "Avg Acc"}
+	rows := make([][]string, 0, len(summaries))
+	for _, summary := range summaries {
+		rows = append(rows, []string{
+			summary.Value,
+			fmt.Sprintf("%d", summary.Sessions),
+			fmt.Sprintf("%.1f", summary.AvgSpeed),
+			fmt.Sprintf("%+.1f", summary.AvgSpeed-overall),
+			fmt.Sprintf("%.2f%%", summary.AvgAcc*100),
+		})
+	}
+	rightAlign := map[int]bool{1: true, 2: true, 3: true, 4: true}
+	for _, line := range formatTable(headers, rows, rightAlign) {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	if skipped > 0 {
+		if _, err := fmt.Fprintf(w, "\n%d sessions without a recorded environment are not shown.\n", skipped); err != nil {
+			return err
+		}
+	}
+	return nil
+}
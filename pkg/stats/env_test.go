@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestEnvBreakdown(t *testing.T) {
+	sessions := []model.SessionAggregate{
+		{Correct: 300, DurationMs: 60000, Env: &model.SessionEnv{Term: "xterm", Width: 120, Options: []string{"focus-weak", "srs"}}},
+		{Correct: 200, DurationMs: 60000, Env: &model.SessionEnv{Term: "xterm", Width: 90, SSH: true}},
+		{Correct: 100, Incorrect: 100, DurationMs: 60000, Env: &model.SessionEnv{Term: "tmux", Width: 90, SSH: true, Options: []string{"srs"}}},
+		{Correct: 500, DurationMs: 60000},
+	}
+	summaries, skipped, err := EnvBreakdown(sessions, EnvBySSH, model.WPMChars)
+	if err != nil {
+		t.Fatalf("EnvBreakdown: %v", err)
+	}
+	if skipped != 1 || len(summaries) != 2 {
+		t.Fatalf("unexpected breakdown %+v (skipped %d)", summaries, skipped)
+	}
+	if summaries[0].Value != "ssh" || summaries[0].Sessions != 2 || summaries[0].AvgSpeed != 30 || summaries[0].AvgAcc != 0.75 {
+		t.Fatalf("unexpected ssh summary %+v", summaries[0])
+	}
+	options, _, err := EnvBreakdown(sessions, EnvByOption, model.WPMChars)
+	if err != nil {
+		t.Fatalf("EnvBreakdown: %v", err)
+	}
+	if len(options) != 3 || options[0].Value != "srs" || options[0].Sessions != 2 {
+		t.Fatalf("expected sessions to count toward every option, got %+v", options)
+	}
+	sizes, _, _ := EnvBreakdown(sessions, EnvBySize, model.WPMChars)
+	if sizes[0].Value != "80-119 cols" || sizes[0].Sessions != 2 {
+		t.Fatalf("unexpected size buckets %+v", sizes)
+	}
+	if _, _, err := EnvBreakdown(sessions, "shell", model.WPMChars); err == nil {
+		t.Fatalf("expected an error for an unknown dimension")
+	}
+
+	var buf bytes.Buffer
+	if err := RenderEnvBreakdown(&buf, sessions, EnvByTerm, model.WPMChars); err != nil {
+		t.Fatalf("RenderEnvBreakdown: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "xterm") || !strings.Contains(out, "+10.0") || !strings.Contains(out, "1 sessions without") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,155 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+const (
+	exportMargin     = 40
+	exportLegendLine = 18
+)
+
+var exportPalette = []color.RGBA{
+	{R: 0x1f, G: 0x9e, B: 0xb3, A: 0xff},
+	{R: 0xc0, G: 0x3a, B: 0xa8, A: 0xff},
+	{R: 0xc8, G: 0x9a, B: 0x3a, A: 0xff},
+	{R: 0x3a, G: 0xa8, B: 0x4f, A: 0xff},
+	{R: 0x3a, G: 0x6e, B: 0xc8, A: 0xff},
+}
+
+// CurveSeries builds the smoothed WPM and accuracy series used by learning curves.
+func CurveSeries(sessions []model.SessionAggregate, window int) []Series {
+	return CurveSeriesWithFormula(sessions, model.WPMChars, window)
+}
+
+// CurveSeriesWithFormula builds the smoothed speed series under the WPM formula and the
+// accuracy series used by learning curves.
+func CurveSeriesWithFormula(sessions []model.SessionAggregate, formula string, window int) []Series {
+	speeds := make([]float64, len(sessions))
+	accs := make([]float64, len(sessions))
+	for i, s := range sessions {
+		_, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		speeds[i] = SessionSpeed(formula, s)
+		accs[i] = acc * 100
+	}
+	return []Series{
+		{Name: SpeedUnit(formula), Values: MovingAverage(speeds, window)},
+		{Name: "Accuracy", Values: MovingAverage(accs, window)},
+	}
+}
+
+// WriteSVG renders series as an SVG line chart, scaling each series to its own min/max.
+func WriteSVG(w io.Writer, title string, series []Series, width, height int) error {
+	series = filterSeries(series)
+	if len(series) == 0 {
+		return fmt.Errorf("no data to plot")
+	}
+	plotW, plotH := exportPlotSize(width, height, len(series))
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+	if title != "" {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="sans-serif" font-size="16">%s</text>`+"\n", exportMargin, exportMargin/2+6, html.EscapeString(title))
+	}
+	fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="#cccccc"/>`+"\n", exportMargin, exportMargin, plotW, plotH)
+	for i, s := range series {
+		minVal, maxVal := exportRange(s.Values)
+		c := exportPalette[i%len(exportPalette)]
+		points := make([]string, 0, len(s.Values))
+		for j, v := range s.Values {
+			x, y := exportPoint(j, len(s.Values), v, minVal, maxVal, plotW, plotH)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="#%02x%02x%02x" stroke-width="2" points="%s"/>`+"\n", c.R, c.G, c.B, strings.Join(points, " "))
+		legendY := exportMargin + plotH + exportLegendLine*(i+1)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-family="sans-serif" font-size="12" fill="#%02x%02x%02x">%s (min %.2f, max %.2f)</text>`+"\n",
+			exportMargin, legendY, c.R, c.G, c.B, html.EscapeString(s.Name), minVal, maxVal)
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WritePNG renders series as a PNG line chart, scaling each series to its own min/max.
+func WritePNG(w io.Writer, series []Series, width, height int) error {
+	series = filterSeries(series)
+	if len(series) == 0 {
+		return fmt.Errorf("no data to plot")
+	}
+	plotW, plotH := exportPlotSize(width, height, len(series))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+		}
+	}
+	border := color.RGBA{R: 0xcc, G: 0xcc, B: 0xcc, A: 0xff}
+	x0, y0 := exportMargin, exportMargin
+	x1, y1 := exportMargin+plotW, exportMargin+plotH
+	plot := func(c color.RGBA) func(x, y int) {
+		return func(x, y int) { img.SetRGBA(x, y, c) }
+	}
+	drawLine(x0, y0, x1, y0, plot(border))
+	drawLine(x1, y0, x1, y1, plot(border))
+	drawLine(x1, y1, x0, y1, plot(border))
+	drawLine(x0, y1, x0, y0, plot(border))
+	for i, s := range series {
+		minVal, maxVal := exportRange(s.Values)
+		c := exportPalette[i%len(exportPalette)]
+		prevX, prevY := -1, -1
+		for j, v := range s.Values {
+			fx, fy := exportPoint(j, len(s.Values), v, minVal, maxVal, plotW, plotH)
+			px, py := int(math.Round(fx)), int(math.Round(fy))
+			if prevX >= 0 {
+				drawLine(prevX, prevY, px, py, plot(c))
+			} else {
+				img.SetRGBA(px, py, c)
+			}
+			prevX, prevY = px, py
+		}
+		legendY := exportMargin + plotH + exportLegendLine*(i+1) - 6
+		drawLine(exportMargin, legendY, exportMargin+24, legendY, plot(c))
+	}
+	return png.Encode(w, img)
+}
+
+func exportPlotSize(width, height, seriesCount int) (int, int) {
+	plotW := width - 2*exportMargin
+	plotH := height - 2*exportMargin - exportLegendLine*seriesCount
+	if plotW < 1 {
+		plotW = 1
+	}
+	if plotH < 1 {
+		plotH = 1
+	}
+	return plotW, plotH
+}
+
+func exportRange(values []float64) (float64, float64) {
+	minVal, maxVal := seriesMinMaxSingle(values)
+	if math.Abs(maxVal-minVal) < 1e-9 {
+		minVal--
+		maxVal++
+	}
+	return minVal, maxVal
+}
+
+func exportPoint(idx, count int, v, minVal, maxVal float64, plotW, plotH int) (float64, float64) {
+	x := float64(exportMargin)
+	if count > 1 {
+		x += float64(idx) * float64(plotW) / float64(count-1)
+	}
+	pos := (v - minVal) / (maxVal - minVal)
+	y := float64(exportMargin) + (1-pos)*float64(plotH)
+	return x, y
+}
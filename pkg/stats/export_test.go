@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, "Curves <1>", []Series{
+		{Name: "WPM", Values: []float64{40, 45, 50}},
+		{Name: "Accuracy", Values: []float64{95, 96, 97}},
+	}, 400, 200)
+	if err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "<svg") || !strings.HasSuffix(out, "</svg>\n") {
+		t.Fatalf("expected svg document, got %q", out)
+	}
+	if strings.Count(out, "<polyline") != 2 {
+		t.Fatalf("expected 2 polylines")
+	}
+	if !strings.Contains(out, "Curves &lt;1&gt;") {
+		t.Fatalf("expected escaped title")
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, []Series{{Name: "WPM", Values: []float64{1, 3, 2}}}, 200, 120); err != nil {
+		t.Fatalf("WritePNG failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if img.Bounds().Dx() != 200 || img.Bounds().Dy() != 120 {
+		t.Fatalf("unexpected image size: %v", img.Bounds())
+	}
+}
+
+func TestWriteSVGNoData(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, "", nil, 100, 100); err == nil {
+		t.Fatalf("expected error for empty series")
+	}
+}
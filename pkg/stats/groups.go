@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// Groupings of the char table: by character class, by the hand or by the keyboard
+// row that types the key.
+const (
+	CharGroupClass = "class"
+	CharGroupHand  = "hand"
+	CharGroupRow   = "row"
+)
+
+// CharGroups lists the groupings in the order the stats UI cycles through them.
+var CharGroups = []string{CharGroupClass, CharGroupHand, CharGroupRow}
+
+// Pseudo-characters of the group aggregates, shown like ShiftLabel.
+const (
+	groupLetters     = "<letters>"
+	groupDigits      = "<digits>"
+	groupPunctuation = "<punct>"
+	groupSpace       = "<space>"
+	groupLeft        = "<left>"
+	groupRight       = "<right>"
+	groupOther       = "<other>"
+)
+
+// GroupCharAggregates sums aggs per group of grouping. Hand and row groups come from
+// the key positions of layout (see wordlist.KeyPositions); letters count by their
+// lowercase key, and characters off the rows, such as digits, shifted symbols and
+// space, are summed as <other>.
+func GroupCharAggregates(aggs []model.CharAggregate, grouping, layout string) ([]model.CharAggregate, error) {
+	var groupOf func(r rune) string
+	switch grouping {
+	case CharGroupClass:
+		groupOf = charClassGroup
+	case CharGroupHand, CharGroupRow:
+		positions, err := wordlist.KeyPositions(layout)
+		if err != nil {
+			return nil, err
+		}
+		groupOf = func(r rune) string {
+			pos, ok := positions[unicode.ToLower(r)]
+			switch {
+			case !ok:
+				return groupOther
+			case grouping == CharGroupRow:
+				return "<" + pos.Row + ">"
+			case pos.Left:
+				return groupLeft
+			default:
+				return groupRight
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown char grouping %q", grouping)
+	}
+
+	var groups []model.CharAggregate
+	index := map[string]int{}
+	for _, agg := range aggs {
+		r, _ := utf8.DecodeRuneInString(agg.Char)
+		name := groupOf(r)
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, model.CharAggregate{Char: name})
+		}
+		groups[i].Correct += agg.Correct
+		groups[i].Incorrect += agg.Incorrect
+		groups[i].LatencySumMs += agg.LatencySumMs
+		groups[i].LatencyCount += agg.LatencyCount
+	}
+	return groups, nil
+}
+
+func charClassGroup(r rune) string {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsMark(r):
+		return groupLetters
+	case unicode.IsDigit(r):
+		return groupDigits
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return groupPunctuation
+	case r == ' ':
+		return groupSpace
+	default:
+		return groupOther
+	}
+}
@@ -0,0 +1,43 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestGroupCharAggregates(t *testing.T) {
+	aggs := []model.CharAggregate{
+		{Char: "a", Correct: 10, Incorrect: 2, LatencySumMs: 1000, LatencyCount: 10},
+		{Char: "J", Correct: 5, Incorrect: 1, LatencySumMs: 600, LatencyCount: 5},
+		{Char: "q", Correct: 3},
+		{Char: "7", Correct: 4},
+		{Char: ",", Correct: 2, Incorrect: 2},
+		{Char: " ", Correct: 20},
+	}
+	cases := []struct {
+		grouping string
+		want     map[string]int
+	}{
+		{CharGroupClass, map[string]int{"<letters>": 21, "<digits>": 4, "<punct>": 4, "<space>": 20}},
+		{CharGroupHand, map[string]int{"<left>": 15, "<right>": 10, "<other>": 24}},
+		{CharGroupRow, map[string]int{"<home>": 18, "<top>": 3, "<bottom>": 4, "<other>": 24}},
+	}
+	for _, c := range cases {
+		groups, err := GroupCharAggregates(aggs, c.grouping, "qwerty")
+		if err != nil {
+			t.Fatalf("%s: %v", c.grouping, err)
+		}
+		if len(groups) != len(c.want) {
+			t.Fatalf("%s: expected %d groups, got %+v", c.grouping, len(c.want), groups)
+		}
+		for _, g := range groups {
+			if total := g.Correct + g.Incorrect; total != c.want[g.Char] {
+				t.Fatalf("%s: expected %s to total %d, got %d", c.grouping, g.Char, c.want[g.Char], total)
+			}
+		}
+	}
+	if _, err := GroupCharAggregates(aggs, CharGroupRow, "bépo"); err == nil {
+		t.Fatalf("expected an unknown layout to fail")
+	}
+}
@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+func syntheticCharStats(n int) []model.CharStats {
+	chars := make([]model.CharStats, n)
+	for i := range chars {
+		chars[i] = model.CharStats{Char: string(rune(0x4e00 + i)), Correct: i%7 + 1, Incorrect: i % 3, LatencySumMs: int64(i * 10), LatencyCount: int64(i%7 + 1)}
+	}
+	return chars
+}
+
+func TestInsertSessionBatchesLargeCharSets(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	chars := syntheticCharStats(1234)
+	id, err := st.InsertSession(ctx, model.SessionStats{StartedAt: time.Unix(0, 0), EndedAt: time.Unix(60, 0), Lang: "zh"}, chars)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	aggs, err := st.ListCharAggregatesForSessions(ctx, []int64{id})
+	if err != nil {
+		t.Fatalf("list chars: %v", err)
+	}
+	if len(aggs) != len(chars) {
+		t.Fatalf("expected %d char rows, got %d", len(chars), len(aggs))
+	}
+	daily, err := st.ListDailyCharAggregates(ctx, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("list daily chars: %v", err)
+	}
+	if len(daily) != len(chars) {
+		t.Fatalf("expected %d daily char rows, got %d", len(chars), len(daily))
+	}
+}
+
+func benchmarkInsertSession(b *testing.B, charCount int) {
+	st, err := store.Open(filepath.Join(b.TempDir(), "tuipe.db"))
+	if err != nil {
+		b.Fatalf("open store: %v", err)
+	}
+	b.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	chars := syntheticCharStats(charCount)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		end := time.Unix(int64(i)*60, 0)
+		stats := model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: "en", DurationMs: 60000}
+		if _, err := st.InsertSession(ctx, stats, chars); err != nil {
+			b.Fatalf("insert session: %v", err)
+		}
+	}
+}
+
+func BenchmarkInsertSession40Chars(b *testing.B)   { benchmarkInsertSession(b, 40) }
+func BenchmarkInsertSession1000Chars(b *testing.B) { benchmarkInsertSession(b, 1000) }
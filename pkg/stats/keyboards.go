@@ -0,0 +1,91 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// KeyboardSummary aggregates sessions typed on one keyboard and layout.
+type KeyboardSummary struct {
+	Keyboard string
+	Layout   string
+	Sessions int
+	AvgWPM   float64
+	BestWPM  float64
+	AvgAcc   float64
+}
+
+// KeyboardBreakdown groups sessions by keyboard and layout, most used first.
+func KeyboardBreakdown(sessions []model.SessionAggregate) []KeyboardSummary {
+	type key struct{ keyboard, layout string }
+	groups := map[key]*KeyboardSummary{}
+	for _, s := range sessions {
+		k := key{s.Keyboard, s.Layout}
+		summary, ok := groups[k]
+		if !ok {
+			summary = &KeyboardSummary{Keyboard: s.Keyboard, Layout: s.Layout}
+			groups[k] = summary
+		}
+		wpm, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		summary.Sessions++
+		summary.AvgWPM += wpm
+		summary.AvgAcc += acc
+		if wpm > summary.BestWPM {
+			summary.BestWPM = wpm
+		}
+	}
+	out := make([]KeyboardSummary, 0, len(groups))
+	for _, summary := range groups {
+		summary.AvgWPM /= float64(summary.Sessions)
+		summary.AvgAcc /= float64(summary.Sessions)
+		out = append(out, *summary)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Sessions != out[j].Sessions {
+			return out[i].Sessions > out[j].Sessions
+		}
+		if out[i].Keyboard != out[j].Keyboard {
+			return out[i].Keyboard < out[j].Keyboard
+		}
+		return out[i].Layout < out[j].Layout
+	})
+	return out
+}
+
+// RenderKeyboardBreakdown prints per-keyboard averages so setups can be compared.
+func RenderKeyboardBreakdown(w io.Writer, sessions []model.SessionAggregate) error {
+	if len(sessions) == 0 {
+		_, err := fmt.Fprintln(w, "No sessions found.")
+		return err
+	}
+	headers := []string{"Keyboard", "Layout", "Sessions", "Avg WPM", "Best WPM", "Avg Acc"}
+	var rows [][]string
+	for _, summary := range KeyboardBreakdown(sessions) {
+		rows = append(rows, []string{
+			orUnknown(summary.Keyboard),
+			orUnknown(summary.Layout),
+			fmt.Sprintf("%d", summary.Sessions),
+			fmt.Sprintf("%.1f", summary.AvgWPM),
+			fmt.Sprintf("%.1f", summary.BestWPM),
+			fmt.Sprintf("%.2f%%", summary.AvgAcc*100),
+		})
+	}
+	rightAlign := map[int]bool{2: true, 3: true, 4: true, 5: true}
+	for _, line := range formatTable(headers, rows, rightAlign) {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
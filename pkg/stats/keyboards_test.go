@@ -0,0 +1,35 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestKeyboardBreakdown(t *testing.T) {
+	sessions := []model.SessionAggregate{
+		{Keyboard: "split", Layout: "colemak", Correct: 50, DurationMs: 60000},
+		{Keyboard: "split", Layout: "colemak", Correct: 100, DurationMs: 60000},
+		{Keyboard: "laptop", Layout: "qwerty", Correct: 250, DurationMs: 60000},
+	}
+	got := KeyboardBreakdown(sessions)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(got))
+	}
+	if got[0].Keyboard != "split" || got[0].Sessions != 2 {
+		t.Fatalf("expected most used keyboard first, got %+v", got[0])
+	}
+	if got[0].AvgWPM != 15 || got[0].BestWPM != 20 {
+		t.Fatalf("unexpected split averages: %+v", got[0])
+	}
+
+	var buf bytes.Buffer
+	if err := RenderKeyboardBreakdown(&buf, append(sessions, model.SessionAggregate{Correct: 5, DurationMs: 60000})); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.Contains(buf.String(), "(unset)") || !strings.Contains(buf.String(), "colemak") {
+		t.Fatalf("unexpected breakdown:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,75 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// keybrResult is one lesson of a keybr.com data export.
+type keybrResult struct {
+	Layout    string    `json:"layout"`
+	TextType  string    `json:"textType"`
+	TimeStamp time.Time `json:"timeStamp"`
+	Length    int       `json:"length"`
+	Time      int64     `json:"time"`
+	Errors    int       `json:"errors"`
+	Histogram []struct {
+		CodePoint  rune  `json:"codePoint"`
+		HitCount   int   `json:"hitCount"`
+		MissCount  int   `json:"missCount"`
+		TimeToType int64 `json:"timeToType"`
+	} `json:"histogram"`
+}
+
+// ParseKeybr reads a keybr.com JSON data export. Typed characters count as correct and
+// errors as incorrect, so WPM matches keybr's speed; the per-key histogram becomes char
+// stats. The language comes from the layout (en-us -> en), which is also kept as Layout.
+func ParseKeybr(r io.Reader) ([]store.SessionRecord, error) {
+	var results []keybrResult
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode keybr json: %w", err)
+	}
+	records := make([]store.SessionRecord, 0, len(results))
+	for i, res := range results {
+		if res.Time <= 0 || res.TimeStamp.IsZero() {
+			return nil, fmt.Errorf("keybr result %d has no time or timeStamp", i+1)
+		}
+		lang, _, _ := strings.Cut(strings.ToLower(res.Layout), "-")
+		chars := make([]model.CharStats, 0, len(res.Histogram))
+		for _, h := range res.Histogram {
+			if h.HitCount == 0 && h.MissCount == 0 {
+				continue
+			}
+			chars = append(chars, model.CharStats{
+				Char:         string(h.CodePoint),
+				Correct:      h.HitCount,
+				Incorrect:    h.MissCount,
+				LatencySumMs: h.TimeToType * int64(h.HitCount),
+				LatencyCount: int64(h.HitCount),
+			})
+		}
+		endedAt := res.TimeStamp.UTC()
+		records = append(records, store.SessionRecord{
+			ID: int64(i + 1),
+			Session: model.SessionStats{
+				StartedAt:         endedAt.Add(-time.Duration(res.Time) * time.Millisecond),
+				EndedAt:           endedAt,
+				Lang:              lang,
+				CorrectNonSpace:   res.Length,
+				IncorrectNonSpace: res.Errors,
+				DurationMs:        res.Time,
+				Mode:              res.TextType,
+				Layout:            res.Layout,
+				Source:            model.SourceKeybr,
+			},
+			Chars: chars,
+		})
+	}
+	return records, nil
+}
@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestParseKeybr(t *testing.T) {
+	input := `[{"layout":"en-us","textType":"generated","timeStamp":"2024-03-01T12:00:00.000Z",
+		"length":150,"time":30000,"errors":3,"speed":300,
+		"histogram":[{"codePoint":97,"hitCount":10,"missCount":1,"timeToType":200},
+		{"codePoint":98,"hitCount":0,"missCount":0,"timeToType":0}]}]`
+	records, err := ParseKeybr(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseKeybr failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	s := records[0].Session
+	ended := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if !s.EndedAt.Equal(ended) || !s.StartedAt.Equal(ended.Add(-30*time.Second)) || s.DurationMs != 30000 {
+		t.Fatalf("unexpected session times: %+v", s)
+	}
+	if s.Lang != "en" || s.Layout != "en-us" || s.Source != model.SourceKeybr || s.Mode != "generated" {
+		t.Fatalf("unexpected session metadata: %+v", s)
+	}
+	if wpm, _, _ := SessionMetrics(s.CorrectNonSpace, s.IncorrectNonSpace, s.DurationMs); wpm != 60 {
+		t.Fatalf("expected keybr speed 300 cpm as 60 WPM, got %.1f", wpm)
+	}
+	chars := records[0].Chars
+	if len(chars) != 1 || chars[0].Char != "a" || chars[0].Incorrect != 1 || chars[0].LatencySumMs != 2000 {
+		t.Fatalf("unexpected char stats: %+v", chars)
+	}
+}
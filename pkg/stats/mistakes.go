@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+const (
+	// MistakeHalfLife is how long it takes a mistake bank weight to halve.
+	MistakeHalfLife = 7 * 24 * time.Hour
+	// MistakeMinWeight is the weight below which a word leaves the mistake bank.
+	MistakeMinWeight = 0.1
+	// mistakeRecovery scales the weight of a bank word typed without a mistake.
+	mistakeRecovery = 0.5
+)
+
+// MistakeWeight returns the weight of w decayed from w.UpdatedAt to now.
+func MistakeWeight(w model.MistakeWord, now time.Time) float64 {
+	elapsed := now.Sub(w.UpdatedAt)
+	if elapsed <= 0 {
+		return w.Weight
+	}
+	return w.Weight * math.Exp2(-float64(elapsed)/float64(MistakeHalfLife))
+}
+
+// RecordMistakes updates the mistake bank of lang after a finished text. Each mistyped
+// word gains one point on top of its decayed weight and each bank word typed cleanly
+// keeps half of it. Words are normalized with SRSWord; missed holds normalized words.
+// The changed words are returned; those whose weight fell below MistakeMinWeight, touched
+// or not, come back with a zero weight so the caller can drop them.
+func RecordMistakes(lang string, bank []model.MistakeWord, words []string, missed map[string]struct{}, now time.Time) []model.MistakeWord {
+	byWord := make(map[string]model.MistakeWord, len(bank))
+	for _, w := range bank {
+		byWord[w.Word] = w
+	}
+	changed := map[string]model.MistakeWord{}
+	for _, text := range words {
+		word := SRSWord(text)
+		if word == "" {
+			continue
+		}
+		if _, ok := changed[word]; ok {
+			continue
+		}
+		w, inBank := byWord[word]
+		_, miss := missed[word]
+		switch {
+		case miss:
+			if !inBank {
+				w = model.MistakeWord{Lang: lang, Word: word}
+			}
+			w.Weight = MistakeWeight(w, now) + 1
+		case inBank:
+			w.Weight = MistakeWeight(w, now) * mistakeRecovery
+		default:
+			continue
+		}
+		w.UpdatedAt = now
+		changed[word] = w
+	}
+	for _, w := range bank {
+		if c, ok := changed[w.Word]; ok {
+			w = c
+		} else {
+			w.Weight = MistakeWeight(w, now)
+		}
+		if w.Weight < MistakeMinWeight {
+			w.Weight = 0
+			changed[w.Word] = w
+		}
+	}
+
+	result := make([]model.MistakeWord, 0, len(changed))
+	for _, w := range changed {
+		result = append(result, w)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Word < result[j].Word })
+	return result
+}
+
+// ActiveMistakes returns the words of the bank with their decayed weights, heaviest first,
+// leaving out words below MistakeMinWeight. limit <= 0 returns them all.
+func ActiveMistakes(bank []model.MistakeWord, now time.Time, limit int) []model.MistakeWord {
+	active := make([]model.MistakeWord, 0, len(bank))
+	for _, w := range bank {
+		w.Weight = MistakeWeight(w, now)
+		w.UpdatedAt = now
+		if w.Weight >= MistakeMinWeight {
+			active = append(active, w)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].Weight == active[j].Weight {
+			return active[i].Word < active[j].Word
+		}
+		return active[i].Weight > active[j].Weight
+	})
+	if limit > 0 && len(active) > limit {
+		active = active[:limit]
+	}
+	return active
+}
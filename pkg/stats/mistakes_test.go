@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+func TestMistakeWeightDecays(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	w := model.MistakeWord{Word: "the", Weight: 2, UpdatedAt: now}
+	if got := MistakeWeight(w, now.Add(MistakeHalfLife)); math.Abs(got-1) > 1e-9 {
+		t.Fatalf("expected the weight to halve after one half-life, got %f", got)
+	}
+	if got := MistakeWeight(w, now.Add(-time.Hour)); got != 2 {
+		t.Fatalf("expected no decay before UpdatedAt, got %f", got)
+	}
+}
+
+func TestRecordMistakes(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	bank := []model.MistakeWord{
+		{Lang: "en", Word: "world", Weight: 1, UpdatedAt: now.Add(-MistakeHalfLife)},
+		{Lang: "en", Word: "hello", Weight: 2, UpdatedAt: now},
+		{Lang: "en", Word: "stale", Weight: 1, UpdatedAt: now.Add(-5 * MistakeHalfLife)},
+		{Lang: "en", Word: "kept", Weight: 1, UpdatedAt: now},
+	}
+	changed := RecordMistakes("en", bank, []string{"Hello,", "world", "new", "world"}, map[string]struct{}{"world": {}, "new": {}}, now)
+	want := []model.MistakeWord{
+		{Lang: "en", Word: "hello", Weight: 1, UpdatedAt: now},
+		{Lang: "en", Word: "new", Weight: 1, UpdatedAt: now},
+		{Lang: "en", Word: "stale", Weight: 0, UpdatedAt: now.Add(-5 * MistakeHalfLife)},
+		{Lang: "en", Word: "world", Weight: 1.5, UpdatedAt: now},
+	}
+	if len(changed) != len(want) {
+		t.Fatalf("expected %d changed words, got %+v", len(want), changed)
+	}
+	for i := range want {
+		if changed[i].Word != want[i].Word || math.Abs(changed[i].Weight-want[i].Weight) > 1e-9 || !changed[i].UpdatedAt.Equal(want[i].UpdatedAt) {
+			t.Fatalf("changed %d = %+v, want %+v", i, changed[i], want[i])
+		}
+	}
+}
+
+func TestActiveMistakesOrdersByDecayedWeight(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	bank := []model.MistakeWord{
+		{Word: "old", Weight: 3, UpdatedAt: now.Add(-2 * MistakeHalfLife)},
+		{Word: "fresh", Weight: 1, UpdatedAt: now},
+		{Word: "gone", Weight: 1, UpdatedAt: now.Add(-10 * MistakeHalfLife)},
+	}
+	active := ActiveMistakes(bank, now, 0)
+	if len(active) != 2 || active[0].Word != "fresh" || active[1].Word != "old" {
+		t.Fatalf("expected [fresh old], got %+v", active)
+	}
+	if limited := ActiveMistakes(bank, now, 1); len(limited) != 1 || limited[0].Word != "fresh" {
+		t.Fatalf("expected the limit to keep the heaviest word, got %+v", limited)
+	}
+}
+
+func TestStoreMistakeBankRoundTrip(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	if err := st.SaveMistakeWords(ctx, []model.MistakeWord{
+		{Lang: "en", Word: "world", Weight: 1.5, UpdatedAt: now},
+		{Lang: "en", Word: "hello", Weight: 1, UpdatedAt: now},
+		{Lang: "de", Word: "welt", Weight: 1, UpdatedAt: now},
+	}); err != nil {
+		t.Fatalf("save mistakes: %v", err)
+	}
+	if err := st.SaveMistakeWords(ctx, []model.MistakeWord{{Lang: "en", Word: "hello", Weight: 0, UpdatedAt: now}}); err != nil {
+		t.Fatalf("drop mistake: %v", err)
+	}
+	bank, err := st.ListMistakeWords(ctx, "en")
+	if err != nil {
+		t.Fatalf("list mistakes: %v", err)
+	}
+	if len(bank) != 1 || bank[0].Word != "world" || bank[0].Weight != 1.5 || !bank[0].UpdatedAt.Equal(now) {
+		t.Fatalf("expected only world in the en bank, got %+v", bank)
+	}
+}
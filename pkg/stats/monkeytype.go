@@ -0,0 +1,220 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// MonkeytypeColumns is the header of Monkeytype's results CSV export.
+var MonkeytypeColumns = []string{
+	"_id", "isPb", "wpm", "acc", "rawWpm", "consistency", "charStats", "mode", "mode2",
+	"quoteLength", "restartCount", "testDuration", "afkDuration", "incompleteTestSeconds",
+	"punctuation", "numbers", "language", "funbox", "difficulty", "lazyMode", "blindMode",
+	"bailedOut", "tags", "timestamp",
+}
+
+// monkeytypeLanguages maps language codes to Monkeytype language names.
+var monkeytypeLanguages = map[string]string{
+	"en": "english",
+	"de": "german",
+	"fr": "french",
+	"es": "spanish",
+	"pt": "portuguese",
+	"it": "italian",
+	"nl": "dutch",
+	"pl": "polish",
+	"ru": "russian",
+	"uk": "ukrainian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// MonkeytypeLanguage returns the Monkeytype name of a language code, e.g. en -> english.
+// Combined codes use the first language; unknown codes are returned unchanged.
+func MonkeytypeLanguage(lang string) string {
+	if langs := wordlist.SplitLangs(lang); len(langs) > 0 {
+		lang = langs[0]
+	}
+	if name, ok := monkeytypeLanguages[lang]; ok {
+		return name
+	}
+	return lang
+}
+
+// LangFromMonkeytype returns the language code for a Monkeytype language name such as
+// "english" or "german_1k", or "" when it is not known.
+func LangFromMonkeytype(name string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(name)), "_")
+	for code, known := range monkeytypeLanguages {
+		if known == base {
+			return code
+		}
+	}
+	return ""
+}
+
+// ParseMonkeytypeCSV reads a Monkeytype results CSV export. Counts are derived from the
+// recorded wpm and acc so imported sessions keep Monkeytype's WPM and accuracy; the
+// export has no per-character data. Unknown languages keep Monkeytype's name.
+func ParseMonkeytypeCSV(r io.Reader) ([]store.SessionRecord, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monkeytype csv header: %w", err)
+	}
+	col := map[string]int{}
+	for i, name := range header {
+		col[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"wpm", "acc", "testDuration", "timestamp"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("monkeytype csv has no %q column", name)
+		}
+	}
+	field := func(row []string, name string) string {
+		if i, ok := col[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var records []store.SessionRecord
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read monkeytype csv: %w", err)
+		}
+		wpm, err := strconv.ParseFloat(field(row, "wpm"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid wpm %q", line, field(row, "wpm"))
+		}
+		acc, err := strconv.ParseFloat(field(row, "acc"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid acc %q", line, field(row, "acc"))
+		}
+		seconds, err := strconv.ParseFloat(field(row, "testDuration"), 64)
+		if err != nil || seconds <= 0 {
+			return nil, fmt.Errorf("line %d: invalid testDuration %q", line, field(row, "testDuration"))
+		}
+		stamp, err := strconv.ParseInt(field(row, "timestamp"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid timestamp %q", line, field(row, "timestamp"))
+		}
+
+		durationMs := int64(math.Round(seconds * 1000))
+		correct := int(math.Round(wpm * 5 * seconds / 60))
+		incorrect := 0
+		if acc > 0 && acc < 100 {
+			incorrect = int(math.Round(float64(correct) * (100 - acc) / acc))
+		}
+		language := field(row, "language")
+		lang := LangFromMonkeytype(language)
+		if lang == "" {
+			lang = language
+		}
+		mode := field(row, "mode")
+		words := 0
+		if mode == model.ModeWords {
+			words, _ = strconv.Atoi(field(row, "mode2"))
+		}
+		incomplete := field(row, "bailedOut") == "true"
+		wordsTyped := 0
+		if !incomplete {
+			wordsTyped = words
+		}
+		endedAt := time.UnixMilli(stamp).UTC()
+		records = append(records, store.SessionRecord{
+			ID: int64(line),
+			Session: model.SessionStats{
+				StartedAt:         endedAt.Add(-time.Duration(durationMs) * time.Millisecond),
+				EndedAt:           endedAt,
+				Lang:              lang,
+				Words:             words,
+				WordsTyped:        wordsTyped,
+				CorrectNonSpace:   correct,
+				IncorrectNonSpace: incorrect,
+				DurationMs:        durationMs,
+				Mode:              mode,
+				Incomplete:        incomplete,
+				Source:            model.SourceMonkeytype,
+			},
+		})
+	}
+	return records, nil
+}
+
+// WriteMonkeytypeCSV writes sessions in Monkeytype's results CSV layout, oldest first.
+// Every tuipe text is a "words" test with mode2 set to its word count. Consistency is
+// left empty because keystroke timings are not stored, and incomplete sessions are
+// marked as bailed out.
+func WriteMonkeytypeCSV(w io.Writer, records []store.SessionRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(MonkeytypeColumns); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	best := map[string]float64{}
+	for _, rec := range records {
+		s := rec.Session
+		wpm, _, acc := SessionMetrics(s.CorrectNonSpace, s.IncorrectNonSpace, s.DurationMs)
+		rawWPM, _, _ := SessionMetrics(s.CorrectNonSpace+s.IncorrectNonSpace, 0, s.DurationMs)
+		language := MonkeytypeLanguage(s.Lang)
+		isPb := false
+		if !s.Incomplete {
+			key := language + "/" + strconv.Itoa(s.Words)
+			if wpm > best[key] {
+				best[key] = wpm
+				isPb = true
+			}
+		}
+		row := []string{
+			"tuipe-" + strconv.FormatInt(rec.ID, 10),
+			strconv.FormatBool(isPb),
+			formatCSVFloat(wpm),
+			formatCSVFloat(acc * 100),
+			formatCSVFloat(rawWPM),
+			"",
+			fmt.Sprintf("%d;%d;0;0", s.CorrectNonSpace, s.IncorrectNonSpace),
+			model.ModeWords,
+			strconv.Itoa(s.Words),
+			"-1",
+			"0",
+			formatCSVFloat(float64(s.DurationMs) / 1000),
+			"0",
+			"0",
+			strconv.FormatBool(s.PunctPct > 0),
+			"false",
+			language,
+			"none",
+			"normal",
+			"false",
+			"false",
+			strconv.FormatBool(s.Incomplete),
+			"",
+			strconv.FormatInt(s.EndedAt.UnixMilli(), 10),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+func formatCSVFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+func TestWriteMonkeytypeCSV(t *testing.T) {
+	ended := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []store.SessionRecord{
+		{ID: 1, Session: model.SessionStats{Lang: "en", Words: 25, CorrectNonSpace: 250, IncorrectNonSpace: 10, DurationMs: 60000, EndedAt: ended}},
+		{ID: 2, Session: model.SessionStats{Lang: "en", Words: 25, CorrectNonSpace: 200, DurationMs: 60000, PunctPct: 0.2, EndedAt: ended.Add(time.Minute)}},
+		{ID: 3, Session: model.SessionStats{Lang: "de", Words: 25, CorrectNonSpace: 100, DurationMs: 60000, Incomplete: true, EndedAt: ended.Add(2 * time.Minute)}},
+	}
+	var buf bytes.Buffer
+	if err := WriteMonkeytypeCSV(&buf, records); err != nil {
+		t.Fatalf("WriteMonkeytypeCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(rows) != 4 || len(rows[0]) != len(MonkeytypeColumns) {
+		t.Fatalf("expected header and 3 rows of %d columns, got %v", len(MonkeytypeColumns), rows)
+	}
+	col := map[string]int{}
+	for i, name := range rows[0] {
+		col[name] = i
+	}
+	first := rows[1]
+	if first[col["_id"]] != "tuipe-1" || first[col["wpm"]] != "50.00" || first[col["rawWpm"]] != "52.00" {
+		t.Fatalf("unexpected first row: %v", first)
+	}
+	if first[col["acc"]] != "96.15" || first[col["charStats"]] != "250;10;0;0" || first[col["mode2"]] != "25" {
+		t.Fatalf("unexpected first row stats: %v", first)
+	}
+	if first[col["language"]] != "english" || first[col["timestamp"]] != "1709294400000" || first[col["isPb"]] != "true" {
+		t.Fatalf("unexpected first row metadata: %v", first)
+	}
+	if rows[2][col["isPb"]] != "false" || rows[2][col["punctuation"]] != "true" {
+		t.Fatalf("expected a slower non-PB punctuation row, got %v", rows[2])
+	}
+	if rows[3][col["bailedOut"]] != "true" || rows[3][col["isPb"]] != "false" || rows[3][col["language"]] != "german" {
+		t.Fatalf("expected a bailed-out german row, got %v", rows[3])
+	}
+}
+
+func TestParseMonkeytypeCSVRoundTrip(t *testing.T) {
+	ended := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []store.SessionRecord{
+		{ID: 7, Session: model.SessionStats{Lang: "de", Words: 25, CorrectNonSpace: 250, IncorrectNonSpace: 10, DurationMs: 60000, EndedAt: ended}},
+	}
+	var buf bytes.Buffer
+	if err := WriteMonkeytypeCSV(&buf, records); err != nil {
+		t.Fatalf("WriteMonkeytypeCSV failed: %v", err)
+	}
+	parsed, err := ParseMonkeytypeCSV(&buf)
+	if err != nil {
+		t.Fatalf("ParseMonkeytypeCSV failed: %v", err)
+	}
+	if len(parsed) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(parsed))
+	}
+	got := parsed[0].Session
+	if got.Lang != "de" || got.Words != 25 || got.Mode != model.ModeWords || got.Source != model.SourceMonkeytype {
+		t.Fatalf("unexpected session metadata: %+v", got)
+	}
+	if !got.EndedAt.Equal(ended) || got.DurationMs != 60000 || !got.StartedAt.Equal(ended.Add(-time.Minute)) {
+		t.Fatalf("unexpected session times: %+v", got)
+	}
+	if got.CorrectNonSpace != 250 || got.IncorrectNonSpace != 10 {
+		t.Fatalf("expected counts to round-trip, got %d/%d", got.CorrectNonSpace, got.IncorrectNonSpace)
+	}
+}
+
+func TestParseMonkeytypeCSVRejectsMissingColumns(t *testing.T) {
+	if _, err := ParseMonkeytypeCSV(strings.NewReader("wpm,acc\n50,96\n")); err == nil {
+		t.Fatalf("expected an error for a csv without testDuration")
+	}
+}
+
+func TestLangFromMonkeytype(t *testing.T) {
+	for name, want := range map[string]string{"english": "en", "german_1k": "de", "klingon": ""} {
+		if got := LangFromMonkeytype(name); got != want {
+			t.Fatalf("LangFromMonkeytype(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
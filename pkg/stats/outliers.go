@@ -0,0 +1,47 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+const (
+	// DefaultOutlierMaxWPM is the WPM above which a session is treated as an outlier.
+	DefaultOutlierMaxWPM = 250.0
+	// DefaultOutlierMinDuration is the duration below which a session is treated as an outlier.
+	DefaultOutlierMinDuration = 5 * time.Second
+)
+
+// IsOutlier reports whether a session falls outside the configured bounds.
+func IsOutlier(s model.SessionAggregate, cfg model.StatsConfig) bool {
+	if cfg.OutlierMinDuration > 0 && s.DurationMs < cfg.OutlierMinDuration.Milliseconds() {
+		return true
+	}
+	if cfg.OutlierMaxWPM > 0 {
+		wpm, _, _ := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		if wpm > cfg.OutlierMaxWPM {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitOutliers separates sessions into kept sessions and the IDs of excluded outliers.
+// When outlier exclusion is disabled, every session is kept.
+func SplitOutliers(sessions []model.SessionAggregate, cfg model.StatsConfig) ([]model.SessionAggregate, map[int64]struct{}) {
+	outliers := map[int64]struct{}{}
+	if !cfg.ExcludeOutliers {
+		return sessions, outliers
+	}
+	kept := make([]model.SessionAggregate, 0, len(sessions))
+	for _, s := range sessions {
+		if IsOutlier(s, cfg) {
+			outliers[s.SessionID] = struct{}{}
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return kept, outliers
+}
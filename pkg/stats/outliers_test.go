@@ -0,0 +1,36 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSplitOutliers(t *testing.T) {
+	sessions := []model.SessionAggregate{
+		{SessionID: 1, Correct: 250, DurationMs: 60000},
+		{SessionID: 2, Correct: 10, DurationMs: 2000},
+		{SessionID: 3, Correct: 5000, DurationMs: 60000},
+	}
+	cfg := model.StatsConfig{
+		ExcludeOutliers:    true,
+		OutlierMaxWPM:      DefaultOutlierMaxWPM,
+		OutlierMinDuration: 5 * time.Second,
+	}
+	kept, outliers := SplitOutliers(sessions, cfg)
+	if len(kept) != 1 || kept[0].SessionID != 1 {
+		t.Fatalf("unexpected kept sessions: %+v", kept)
+	}
+	for _, id := range []int64{2, 3} {
+		if _, ok := outliers[id]; !ok {
+			t.Fatalf("expected session %d to be an outlier", id)
+		}
+	}
+
+	cfg.ExcludeOutliers = false
+	kept, outliers = SplitOutliers(sessions, cfg)
+	if len(kept) != len(sessions) || len(outliers) != 0 {
+		t.Fatalf("expected no exclusion when disabled, got %d kept, %d outliers", len(kept), len(outliers))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"os"
 	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"golang.org/x/term"
@@ -95,6 +96,18 @@ func plotSeries(w io.Writer, title string, series []Series, width, height int, f
 		width = 1
 	}
 
+	key := plotKey{hash: hashSeries(title, series), width: width, height: height, color: shouldUseColor(w, forceColor)}
+	out, ok := cachedPlot(key)
+	if !ok {
+		out = renderPlot(title, series, width, height, key.color)
+		storePlot(key, out)
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+// renderPlot draws series into a plot of width x height braille cells.
+func renderPlot(title string, series []Series, width, height int, useColor bool) string {
 	scaled := make([]Series, 0, len(series))
 	for _, s := range series {
 		scaled = append(scaled, Series{
@@ -113,14 +126,10 @@ func plotSeries(w io.Writer, title string, series []Series, width, height int, f
 		minMax = append(minMax, seriesMinMaxRange{min: minVal, max: maxVal})
 	}
 
-	seriesCells := make([][][]uint8, 0, len(scaled))
-	for i := 0; i < len(scaled); i++ {
-		seriesCells = append(seriesCells, makeCells(height, width))
-	}
+	grid := gridPool.Get().(*plotGrid)
+	defer gridPool.Put(grid)
+	grid.reset(len(scaled), width, height)
 	for si, s := range scaled {
-		if len(s.Values) == 0 {
-			continue
-		}
 		style := lineStyles[si%len(lineStyles)]
 		prevX, prevY := -1, -1
 		for x, v := range s.Values {
@@ -136,60 +145,53 @@ func plotSeries(w io.Writer, title string, series []Series, width, height int, f
 			if prevX >= 0 {
 				drawLine(prevX, prevY, px, py, func(dx, dy int) {
 					if style.shouldPlot(dx) {
-						setBrailleDot(seriesCells[si], dx, dy)
+						grid.setDot(si, dx, dy)
 					}
 				})
 			} else if style.shouldPlot(px) {
-				setBrailleDot(seriesCells[si], px, py)
+				grid.setDot(si, px, py)
 			}
 			prevX, prevY = px, py
 		}
 	}
 
-	useColor := shouldUseColor(w, forceColor)
 	leftAxisWidth := len(axisLabelTop)
 	axisLabels := makeAxisLabels(height)
 
-	if title != "" {
-		if _, err := fmt.Fprintln(w, title); err != nil {
-			return err
-		}
+	var b strings.Builder
+	// Each braille cell takes three bytes, plus color codes around it.
+	cellBytes := 3
+	if useColor {
+		cellBytes += len(colorPalette[0].code) + len(colorReset)
 	}
-	if _, err := fmt.Fprintln(w, scaleNote); err != nil {
-		return err
+	b.Grow(height * (leftAxisWidth + len(axisSeparator) + width*cellBytes + 1))
+	if title != "" {
+		b.WriteString(title)
+		b.WriteByte('\n')
 	}
+	b.WriteString(scaleNote)
+	b.WriteByte('\n')
 	for i, s := range scaled {
-		if _, err := fmt.Fprintf(w, "%s: min=%.2f max=%.2f\n", s.Name, minMax[i].min, minMax[i].max); err != nil {
-			return err
-		}
+		fmt.Fprintf(&b, "%s: min=%.2f max=%.2f\n", s.Name, minMax[i].min, minMax[i].max)
 	}
 	for y := 0; y < height; y++ {
-		prefix := fmt.Sprintf("%*s%s", leftAxisWidth, axisLabels[y], axisSeparator)
-		var row strings.Builder
-		row.WriteString(prefix)
+		fmt.Fprintf(&b, "%*s%s", leftAxisWidth, axisLabels[y], axisSeparator)
 		for x := 0; x < width; x++ {
-			mask, colorIdx := composeCell(seriesCells, x, y)
+			mask, colorIdx := grid.compose(x, y)
 			ch := brailleFromMask(mask)
 			if useColor && colorIdx >= 0 {
-				color := colorPalette[colorIdx%len(colorPalette)].code
-				row.WriteString(color)
-				row.WriteRune(ch)
-				row.WriteString(colorReset)
+				b.WriteString(colorPalette[colorIdx%len(colorPalette)].code)
+				b.WriteRune(ch)
+				b.WriteString(colorReset)
 			} else {
-				row.WriteRune(ch)
+				b.WriteRune(ch)
 			}
 		}
-		if _, err := fmt.Fprintln(w, row.String()); err != nil {
-			return err
-		}
-	}
-	if _, err := fmt.Fprintln(w, renderLegend(scaled, useColor)); err != nil {
-		return err
-	}
-	if _, err := fmt.Fprintln(w, ""); err != nil {
-		return err
+		b.WriteByte('\n')
 	}
-	return nil
+	b.WriteString(renderLegend(scaled, useColor))
+	b.WriteString("\n\n")
+	return b.String()
 }
 
 func filterSeries(series []Series) []Series {
@@ -270,25 +272,52 @@ func makeAxisLabels(height int) []string {
 	return labels
 }
 
-func makeCells(height, width int) [][]uint8 {
-	cells := make([][]uint8, height)
-	for y := 0; y < height; y++ {
-		cells[y] = make([]uint8, width)
+// plotGrid holds the braille cells of each series of a plot, row-major in one slice per
+// series. Grids are pooled, so redrawing at the same size allocates no cells.
+type plotGrid struct {
+	cells  [][]uint8
+	width  int
+	height int
+}
+
+var gridPool = sync.Pool{New: func() any { return new(plotGrid) }}
+
+// reset sizes the grid for series plots of width x height cells and clears it.
+func (g *plotGrid) reset(series, width, height int) {
+	g.width, g.height = width, height
+	for len(g.cells) < series {
+		g.cells = append(g.cells, nil)
+	}
+	g.cells = g.cells[:series]
+	for i, cells := range g.cells {
+		if cap(cells) < width*height {
+			g.cells[i] = make([]uint8, width*height)
+			continue
+		}
+		g.cells[i] = cells[:width*height]
+		clear(g.cells[i])
+	}
+}
+
+// setDot sets the braille dot at pixel (x, y) of a series; each cell is 2x4 pixels.
+func (g *plotGrid) setDot(series, x, y int) {
+	if x < 0 || y < 0 {
+		return
+	}
+	cellX, cellY := x/2, y/4
+	if cellX >= g.width || cellY >= g.height {
+		return
 	}
-	return cells
+	g.cells[series][cellY*g.width+cellX] |= brailleDotMask(x%2, y%4)
 }
 
-func composeCell(seriesCells [][][]uint8, x, y int) (uint8, int) {
+// compose merges the dots of every series in cell (x, y) and returns the first series
+// drawn there, which picks the color, or -1.
+func (g *plotGrid) compose(x, y int) (uint8, int) {
 	var mask uint8
 	colorIdx := -1
-	for i, cells := range seriesCells {
-		if y < 0 || y >= len(cells) {
-			continue
-		}
-		if x < 0 || x >= len(cells[y]) {
-			continue
-		}
-		cellMask := cells[y][x]
+	for i, cells := range g.cells {
+		cellMask := cells[y*g.width+x]
 		if cellMask == 0 {
 			continue
 		}
@@ -449,22 +478,6 @@ func drawLine(x0, y0, x1, y1 int, plot func(x, y int)) {
 	}
 }
 
-func setBrailleDot(cells [][]uint8, x, y int) {
-	if y < 0 || x < 0 {
-		return
-	}
-	cellY := y / 4
-	cellX := x / 2
-	if cellY < 0 || cellY >= len(cells) {
-		return
-	}
-	if cellX < 0 || cellX >= len(cells[cellY]) {
-		return
-	}
-	dotMask := brailleDotMask(x%2, y%4)
-	cells[cellY][cellX] |= dotMask
-}
-
 func brailleDotMask(x, y int) uint8 {
 	switch {
 	case x == 0 && y == 0:
@@ -0,0 +1,41 @@
+package stats
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+func benchSeries(n, points int) []Series {
+	series := make([]Series, n)
+	for i := range series {
+		values := make([]float64, points)
+		for j := range values {
+			values[j] = 50 + 30*math.Sin(float64(j+i*7)/40)
+		}
+		series[i] = Series{Name: string(rune('A' + i)), Values: values}
+	}
+	return series
+}
+
+// BenchmarkRenderPlotWide draws many char curves across a very wide terminal, bypassing
+// the cache.
+func BenchmarkRenderPlotWide(b *testing.B) {
+	series := benchSeries(8, 5000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		renderPlot("Wide", series, 400, 20, true)
+	}
+}
+
+// BenchmarkPlotSeriesCached redraws an unchanged plot, as the stats UI does on every
+// refresh of a screen.
+func BenchmarkPlotSeriesCached(b *testing.B) {
+	series := benchSeries(8, 5000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := PlotSeriesWithColor(io.Discard, "Wide", series, 400, 20, true); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPlotSeries(t *testing.T) {
+	var buf bytes.Buffer
+	err := PlotSeries(&buf, "Test Plot", []Series{
+		{Name: "A", Values: []float64{1, 2, 3, 2, 1}},
+		{Name: "B", Values: []float64{1, 1, 2, 3, 4}},
+	}, 5, 4)
+	if err != nil {
+		t.Fatalf("PlotSeries failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Test Plot") {
+		t.Fatalf("expected title in output")
+	}
+	if !strings.Contains(out, "Scaled per series") {
+		t.Fatalf("expected scale note in output")
+	}
+	if !strings.Contains(out, "Legend:") {
+		t.Fatalf("expected legend in output")
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	expectedMin := 1 + 1 + 2 + 4 + 1
+	if len(lines) < expectedMin {
+		t.Fatalf("expected at least %d lines of output, got %d", expectedMin, len(lines))
+	}
+}
+
+func TestPlotSeriesCachesBySeriesAndSize(t *testing.T) {
+	series := []Series{{Name: "A", Values: []float64{1, 3, 2, 5}}}
+	render := func(s []Series, width int) string {
+		var buf bytes.Buffer
+		if err := PlotSeries(&buf, "Cached", s, width, 4); err != nil {
+			t.Fatalf("PlotSeries failed: %v", err)
+		}
+		return buf.String()
+	}
+	first := render(series, 12)
+	if again := render(series, 12); again != first {
+		t.Fatalf("expected the cached plot to match the first render")
+	}
+	if first != renderPlot("Cached", series, 12, 4, false) {
+		t.Fatalf("expected the cached plot to match a fresh render")
+	}
+	if render(series, 20) == first {
+		t.Fatalf("expected a different plot at another width")
+	}
+	changed := []Series{{Name: "A", Values: []float64{1, 3, 2, 6}}}
+	if render(changed, 12) == first {
+		t.Fatalf("expected a different plot for changed values")
+	}
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"math"
+	"sync"
+)
+
+// plotCacheSize bounds the rendered plots kept for reuse; the stats UI draws a handful of
+// plots per screen, so this covers several screens and terminal sizes.
+const plotCacheSize = 64
+
+// plotKey identifies a rendered plot: its title and series, size and whether it is colored.
+type plotKey struct {
+	hash   uint64
+	width  int
+	height int
+	color  bool
+}
+
+var (
+	plotCacheMu sync.Mutex
+	plotCache   = map[plotKey]string{}
+)
+
+func cachedPlot(key plotKey) (string, bool) {
+	plotCacheMu.Lock()
+	defer plotCacheMu.Unlock()
+	out, ok := plotCache[key]
+	return out, ok
+}
+
+// storePlot keeps a rendered plot, starting over once the cache is full.
+func storePlot(key plotKey, out string) {
+	plotCacheMu.Lock()
+	defer plotCacheMu.Unlock()
+	if len(plotCache) >= plotCacheSize {
+		clear(plotCache)
+	}
+	plotCache[key] = out
+}
+
+// hashSeries hashes the title, names and values of a plot, FNV-1a style but a 64-bit
+// word at a time: the cache is consulted on every redraw, so hashing must stay far cheaper
+// than drawing.
+func hashSeries(title string, series []Series) uint64 {
+	const prime = 1099511628211
+	h := uint64(14695981039346656037)
+	mix := func(v uint64) {
+		h ^= v
+		h *= prime
+	}
+	mixString := func(s string) {
+		mix(uint64(len(s)))
+		for i := 0; i < len(s); i++ {
+			mix(uint64(s[i]))
+		}
+	}
+	mixString(title)
+	for _, s := range series {
+		mixString(s.Name)
+		mix(uint64(len(s.Values)))
+		for _, v := range s.Values {
+			mix(math.Float64bits(v))
+		}
+	}
+	return h
+}
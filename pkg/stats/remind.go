@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseClock parses a local time of day written as HH:MM (24-hour).
+func ParseClock(s string) (time.Duration, error) {
+	hour, minute, ok := strings.Cut(strings.TrimSpace(s), ":")
+	h, herr := strconv.Atoi(hour)
+	m, merr := strconv.Atoi(minute)
+	if !ok || herr != nil || merr != nil || h < 0 || h > 23 || m < 0 || m > 59 || len(minute) != 2 {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// ReminderDue reports whether to nudge at now: there has been no practice today and the
+// day is past at (an offset from local midnight).
+func ReminderDue(s DaySummary, at time.Duration, now time.Time) bool {
+	if s.Sessions > 0 {
+		return false
+	}
+	local := now.Local()
+	y, m, d := local.Date()
+	return !local.Before(time.Date(y, m, d, 0, 0, 0, 0, time.Local).Add(at))
+}
+
+// ReminderMessage is the nudge printed by tuipe remind. A running streak is mentioned,
+// since it ends tonight without practice.
+func ReminderMessage(s DaySummary) string {
+	switch {
+	case s.Streak == 1:
+		return "tuipe: no practice yet today. Keep your 1-day streak going."
+	case s.Streak > 1:
+		return fmt.Sprintf("tuipe: no practice yet today. Keep your %d-day streak going.", s.Streak)
+	}
+	return "tuipe: no practice yet today."
+}
@@ -0,0 +1,131 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"context"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+// DailyReportThreshold is the session count above which unfiltered reports use per-day aggregates.
+const DailyReportThreshold = 5000
+
+// Report contains precomputed data for stats rendering.
+// When Daily is set, Sessions holds one synthetic entry per day and window data is not computed.
+type Report struct {
+	Daily            bool
+	SessionCount     int
+	Sessions         []model.SessionAggregate
+	AllSessions      []model.SessionAggregate
+	Outliers         map[int64]struct{}
+	WindowSessionIDs []int64
+	CharAggsAll      []model.CharAggregate
+	CharAggsWindow   []model.CharAggregate
+	ShiftAll         model.CharAggregate
+	HasShift         bool
+}
+
+// BuildReport loads and prepares data for stats rendering.
+func BuildReport(ctx context.Context, st *store.Store, cfg model.StatsConfig) (Report, error) {
+	if DailyEligible(cfg) {
+		count, err := st.CountSessions(ctx, cfg)
+		if err != nil {
+			return Report{}, err
+		}
+		if count > DailyReportThreshold {
+			return buildDailyReport(ctx, st, cfg, count)
+		}
+	}
+
+	sessions, err := st.ListSessions(ctx, cfg)
+	if err != nil {
+		return Report{}, err
+	}
+	allSessions := sessions
+	sessions, outliers := SplitOutliers(sessions, cfg)
+
+	allIDs := sessionIDs(sessions)
+	windowIDs := lastSessionIDs(sessions, cfg.CurveWindow)
+	charAggsAll, err := st.ListCharAggregatesForSessions(ctx, allIDs)
+	if err != nil {
+		return Report{}, err
+	}
+	charAggsWindow, err := st.ListCharAggregatesForSessions(ctx, windowIDs)
+	if err != nil {
+		return Report{}, err
+	}
+
+	shiftAll, hasShift := ShiftedAggregate(charAggsAll)
+	if cfg.FoldCase {
+		charAggsAll = FoldCase(charAggsAll)
+		charAggsWindow = FoldCase(charAggsWindow)
+	}
+
+	return Report{
+		SessionCount:     len(sessions),
+		Sessions:         sessions,
+		AllSessions:      allSessions,
+		Outliers:         outliers,
+		WindowSessionIDs: windowIDs,
+		CharAggsAll:      charAggsAll,
+		CharAggsWindow:   charAggsWindow,
+		ShiftAll:         shiftAll,
+		HasShift:         hasShift,
+	}, nil
+}
+
+func buildDailyReport(ctx context.Context, st *store.Store, cfg model.StatsConfig, count int) (Report, error) {
+	days, err := st.ListDailyAggregates(ctx, cfg)
+	if err != nil {
+		return Report{}, err
+	}
+	sessions := make([]model.SessionAggregate, 0, len(days))
+	for _, day := range days {
+		sessions = append(sessions, model.SessionAggregate{
+			EndedAt:    day.Day,
+			Correct:    day.Correct,
+			Incorrect:  day.Incorrect,
+			DurationMs: day.DurationMs,
+		})
+	}
+	charAggsAll, err := st.ListDailyCharAggregates(ctx, cfg)
+	if err != nil {
+		return Report{}, err
+	}
+	shiftAll, hasShift := ShiftedAggregate(charAggsAll)
+	if cfg.FoldCase {
+		charAggsAll = FoldCase(charAggsAll)
+	}
+	return Report{
+		Daily:        true,
+		SessionCount: count,
+		Sessions:     sessions,
+		AllSessions:  sessions,
+		Outliers:     map[int64]struct{}{},
+		CharAggsAll:  charAggsAll,
+		ShiftAll:     shiftAll,
+		HasShift:     hasShift,
+	}, nil
+}
+
+// DailyEligible reports whether cfg only uses filters the daily tables can answer.
+func DailyEligible(cfg model.StatsConfig) bool {
+	return cfg.Last == 0 && !cfg.ExcludeOutliers && cfg.Mode == "" && cfg.FocusWeak == nil && cfg.AppVersion == "" &&
+		cfg.Keyboard == "" && cfg.Layout == "" && cfg.WordList == "" && !cfg.IncludeIncomplete && !cfg.IncludeIgnored
+}
+
+func sessionIDs(sessions []model.SessionAggregate) []int64 {
+	ids := make([]int64, len(sessions))
+	for i, s := range sessions {
+		ids[i] = s.SessionID
+	}
+	return ids
+}
+
+func lastSessionIDs(sessions []model.SessionAggregate, window int) []int64 {
+	if window <= 0 || len(sessions) <= window {
+		return sessionIDs(sessions)
+	}
+	return sessionIDs(sessions[len(sessions)-window:])
+}
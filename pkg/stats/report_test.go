@@ -0,0 +1,246 @@
+package stats
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+func TestBuildReport(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "tuipe.db")
+	st, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		start := time.Unix(0, 0).Add(time.Duration(i) * time.Minute)
+		end := start.Add(30 * time.Second)
+		stats := model.SessionStats{
+			StartedAt:         start,
+			EndedAt:           end,
+			Lang:              "en",
+			Words:             10,
+			CapsPct:           0,
+			PunctPct:          0,
+			PunctSet:          ".,?!",
+			WordListPath:      "dummy",
+			CorrectNonSpace:   10,
+			IncorrectNonSpace: 1,
+			DurationMs:        end.Sub(start).Milliseconds(),
+		}
+		charStats := []model.CharStats{
+			{Char: "a", Correct: 5, Incorrect: 0},
+			{Char: "b", Correct: 4, Incorrect: 1},
+		}
+		id, err := st.InsertSession(ctx, stats, charStats)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	cfg := model.StatsConfig{
+		Lang:        "en",
+		Last:        2,
+		CurveWindow: 2,
+		Chars:       "a,b",
+	}
+	report, err := BuildReport(ctx, st, cfg)
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(report.Sessions))
+	}
+	if report.Sessions[0].SessionID != ids[1] || report.Sessions[1].SessionID != ids[2] {
+		t.Fatalf("unexpected session ids: %+v", report.Sessions)
+	}
+	if len(report.WindowSessionIDs) != 2 {
+		t.Fatalf("expected 2 window session ids, got %d", len(report.WindowSessionIDs))
+	}
+	if len(report.CharAggsAll) == 0 {
+		t.Fatalf("expected char aggregates for all sessions")
+	}
+	if len(report.CharAggsWindow) == 0 {
+		t.Fatalf("expected char aggregates for window sessions")
+	}
+}
+
+func TestDailyAggregates(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	ends := []time.Time{day, day.Add(time.Hour), day.Add(24 * time.Hour)}
+	for _, end := range ends {
+		stats := model.SessionStats{
+			StartedAt:         end.Add(-30 * time.Second),
+			EndedAt:           end,
+			Lang:              "en",
+			CorrectNonSpace:   10,
+			IncorrectNonSpace: 2,
+			DurationMs:        30000,
+		}
+		charStats := []model.CharStats{{Char: "a", Correct: 10, Incorrect: 2, LatencySumMs: 100, LatencyCount: 10}}
+		if _, err := st.InsertSession(ctx, stats, charStats); err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+	}
+
+	check := func() {
+		t.Helper()
+		days, err := st.ListDailyAggregates(ctx, model.StatsConfig{Lang: "en"})
+		if err != nil {
+			t.Fatalf("list daily: %v", err)
+		}
+		if len(days) != 2 {
+			t.Fatalf("expected 2 days, got %d", len(days))
+		}
+		if days[0].Sessions != 2 || days[0].Correct != 20 || days[0].DurationMs != 60000 {
+			t.Fatalf("unexpected first day: %+v", days[0])
+		}
+		chars, err := st.ListDailyCharAggregates(ctx, model.StatsConfig{Lang: "en"})
+		if err != nil {
+			t.Fatalf("list daily chars: %v", err)
+		}
+		if len(chars) != 1 || chars[0].Correct != 30 || chars[0].LatencyCount != 30 {
+			t.Fatalf("unexpected daily chars: %+v", chars)
+		}
+	}
+	check()
+	if err := st.RebuildDailyAggregates(ctx); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	check()
+}
+
+func TestBuildReportMetadataFilters(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	base := time.Unix(0, 0)
+	lists := []string{"common@0123456789ab", "common@ba9876543210", "rare@0123456789ab"}
+	for i, focus := range []bool{false, true, true} {
+		end := base.Add(time.Duration(i+1) * time.Minute)
+		stats := model.SessionStats{
+			StartedAt:       end.Add(-30 * time.Second),
+			EndedAt:         end,
+			Lang:            "en",
+			CorrectNonSpace: 10,
+			DurationMs:      30000,
+			Mode:            model.ModeWords,
+			FocusWeak:       focus,
+			WeakSet:         "qz",
+			Seed:            int64(i),
+			WordsTyped:      5,
+			AppVersion:      "v1.0.0",
+			WordListID:      lists[i],
+		}
+		if _, err := st.InsertSession(ctx, stats, nil); err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+	}
+
+	focusWeak := true
+	report, err := BuildReport(ctx, st, model.StatsConfig{Mode: model.ModeWords, FocusWeak: &focusWeak, AppVersion: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Sessions) != 2 {
+		t.Fatalf("expected 2 focus-weak sessions, got %d", len(report.Sessions))
+	}
+	for _, s := range report.Sessions {
+		if !s.FocusWeak || s.Mode != model.ModeWords {
+			t.Fatalf("unexpected session metadata: %+v", s)
+		}
+	}
+
+	report, err = BuildReport(ctx, st, model.StatsConfig{AppVersion: "v2.0.0"})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Sessions) != 0 {
+		t.Fatalf("expected no sessions for other version, got %d", len(report.Sessions))
+	}
+
+	for list, want := range map[string]int{"common": 2, "common@ba9876543210": 1, "rare": 1, "common@": 0} {
+		report, err = BuildReport(ctx, st, model.StatsConfig{WordList: list})
+		if err != nil {
+			t.Fatalf("build report: %v", err)
+		}
+		if len(report.Sessions) != want {
+			t.Fatalf("expected %d sessions for wordlist %q, got %d", want, list, len(report.Sessions))
+		}
+	}
+}
+
+func TestConcurrentStoresShareDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tuipe.db")
+	stores := make([]*store.Store, 2)
+	for i := range stores {
+		st, err := store.Open(path)
+		if err != nil {
+			t.Fatalf("open store: %v", err)
+		}
+		t.Cleanup(func() {
+			_ = st.Close()
+		})
+		stores[i] = st
+	}
+
+	ctx := context.Background()
+	errs := make(chan error, len(stores))
+	for i, st := range stores {
+		go func(i int, st *store.Store) {
+			for j := 0; j < 20; j++ {
+				end := time.Unix(int64(i*1000+j*10), 0)
+				stats := model.SessionStats{StartedAt: end.Add(-5 * time.Second), EndedAt: end, Lang: "en", DurationMs: 5000}
+				chars := []model.CharStats{{Char: "a", Correct: 1}}
+				if _, err := st.InsertSession(ctx, stats, chars); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := st.ListSessions(ctx, model.StatsConfig{}); err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- nil
+		}(i, st)
+	}
+	for range stores {
+		if err := <-errs; err != nil {
+			t.Fatalf("concurrent access: %v", err)
+		}
+	}
+	sessions, err := stores[0].ListSessions(ctx, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 40 {
+		t.Fatalf("expected 40 sessions, got %d", len(sessions))
+	}
+}
@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// ScoringRevision is bumped whenever tuipe changes how keystrokes are scored, so sessions
+// scored before and after the change are told apart.
+const ScoringRevision = 1
+
+// ScoringRules describes the rules a session is scored under: the scoring revision, the
+// WPM formula and the equivalence classes of characters accepted for each other.
+func ScoringRules(formula string, classes []string) string {
+	if formula == "" {
+		formula = model.WPMChars
+	}
+	equivalents := "exact"
+	if len(classes) > 0 {
+		sorted := slices.Clone(classes)
+		slices.Sort(sorted)
+		equivalents = shortHash(strings.Join(sorted, " "))
+	}
+	return fmt.Sprintf("r%d wpm=%s eq=%s", ScoringRevision, formula, equivalents)
+}
+
+// ParamsHash fingerprints the settings that shape generated texts, so sessions drilled on
+// the same kind of text can be recognized.
+func ParamsHash(cfg model.Config) string {
+	return shortHash(fmt.Sprintf("%s|%s|%s|%d|%g|%g|%q|%t|%d|%g|%s|%s|%d|%q|%q|%s|%d|%t|%g|%g|%g|%g|%g|%g",
+		cfg.Mode, cfg.Lang, cfg.List, cfg.Words, cfg.CapsPct, cfg.PunctPct, cfg.PunctSet,
+		cfg.FocusWeak, cfg.WeakTop, cfg.WeakFactor, cfg.Corpus, cfg.WordListMeta, cfg.Coverage,
+		cfg.ExcludeChars, cfg.OnlyChars, cfg.Rows, cfg.RepeatWindow, cfg.SentenceStyle,
+		cfg.CodeCamel, cfg.CodeSnake, cfg.CodeScreaming, cfg.CodeOps, cfg.ShiftMid, cfg.ShiftAll))
+}
+
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:6])
+}
+
+// MixedScoring lists the scoring rules and tuipe versions of sessions that were not all
+// scored under the same rules; both are nil when the recorded rules agree. Sessions saved
+// before the rules were recorded are not compared.
+func MixedScoring(sessions []model.SessionAggregate) (rules, versions []string) {
+	for _, s := range sessions {
+		if s.Scoring == "" {
+			continue
+		}
+		if !slices.Contains(rules, s.Scoring) {
+			rules = append(rules, s.Scoring)
+		}
+		if s.AppVersion != "" && !slices.Contains(versions, s.AppVersion) {
+			versions = append(versions, s.AppVersion)
+		}
+	}
+	if len(rules) < 2 {
+		return nil, nil
+	}
+	return rules, versions
+}
+
+// ScoringWarning explains that sessions were scored under different rules, naming the
+// rules and the tuipe versions involved. It is empty when MixedScoring finds no mix.
+func ScoringWarning(sessions []model.SessionAggregate) string {
+	rules, versions := MixedScoring(sessions)
+	if len(rules) == 0 {
+		return ""
+	}
+	warning := fmt.Sprintf("Warning: sessions were scored under %d different rules (%s)", len(rules), strings.Join(rules, "; "))
+	if len(versions) > 1 {
+		warning += " by tuipe " + strings.Join(versions, ", ")
+	}
+	return warning + "; speeds and accuracy may not be comparable."
+}
@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestScoringRules(t *testing.T) {
+	if got := ScoringRules("", nil); got != "r1 wpm=chars eq=exact" {
+		t.Fatalf("unexpected default rules %q", got)
+	}
+	a := ScoringRules(model.WPMWords, []string{"'’", "“”\""})
+	b := ScoringRules(model.WPMWords, []string{"“”\"", "'’"})
+	if a != b || !strings.HasPrefix(a, "r1 wpm=words eq=") {
+		t.Fatalf("expected class order not to matter, got %q and %q", a, b)
+	}
+}
+
+func TestParamsHashTracksGeneratorSettings(t *testing.T) {
+	cfg := model.Config{Lang: "en", Words: 25, PunctPct: 0.3}
+	base := ParamsHash(cfg)
+	cfg.Theme = model.Theme{Text: "#fff"}
+	cfg.WPMFormula = model.WPMCPM
+	if ParamsHash(cfg) != base {
+		t.Fatalf("expected display settings not to change the hash")
+	}
+	cfg.Words = 50
+	if ParamsHash(cfg) == base {
+		t.Fatalf("expected the word count to change the hash")
+	}
+}
+
+func TestScoringWarning(t *testing.T) {
+	now := time.Now()
+	sessions := []model.SessionAggregate{
+		{SessionID: 1, EndedAt: now, Correct: 100, DurationMs: 60000},
+		{SessionID: 2, EndedAt: now, Correct: 100, DurationMs: 60000, Scoring: "r1 wpm=chars eq=exact", AppVersion: "v1.0.0"},
+		{SessionID: 3, EndedAt: now, Correct: 100, DurationMs: 60000, Scoring: "r1 wpm=chars eq=exact", AppVersion: "v1.1.0"},
+	}
+	if warning := ScoringWarning(sessions); warning != "" {
+		t.Fatalf("expected no warning under the same rules, got %q", warning)
+	}
+	sessions[2].Scoring = "r1 wpm=words eq=exact"
+	warning := ScoringWarning(sessions)
+	if !strings.Contains(warning, "2 different rules") || !strings.Contains(warning, "v1.0.0, v1.1.0") {
+		t.Fatalf("unexpected warning %q", warning)
+	}
+	var buf bytes.Buffer
+	if err := RenderSummary(&buf, sessions); err != nil {
+		t.Fatalf("RenderSummary: %v", err)
+	}
+	if !strings.Contains(buf.String(), warning) {
+		t.Fatalf("expected the summary to include the warning:\n%s", buf.String())
+	}
+}
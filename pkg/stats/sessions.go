@@ -0,0 +1,185 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// RenderSessionList prints sessions newest first with speeds in five-character WPM,
+// marking excluded outliers and incomplete and ignored sessions.
+func RenderSessionList(w io.Writer, sessions []model.SessionAggregate, outliers map[int64]struct{}) error {
+	return RenderSessionListWithFormula(w, sessions, outliers, model.WPMChars)
+}
+
+// RenderSessionListWithFormula prints sessions newest first with speeds under the WPM
+// formula, marking excluded outliers and incomplete and ignored sessions.
+func RenderSessionListWithFormula(w io.Writer, sessions []model.SessionAggregate, outliers map[int64]struct{}, formula string) error {
+	if len(sessions) == 0 {
+		_, err := fmt.Fprintln(w, "No sessions found.")
+		return err
+	}
+	headers := []string{"ID", "Ended", "Mode", SpeedUnit(formula), "Accuracy", "Duration", "Note"}
+	rows := make([][]string, 0, len(sessions))
+	for i := len(sessions) - 1; i >= 0; i-- {
+		s := sessions[i]
+		_, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		var notes []string
+		if s.Incomplete {
+			notes = append(notes, "incomplete")
+		}
+		if s.Ignored {
+			notes = append(notes, "ignored")
+		}
+		if _, ok := outliers[s.SessionID]; ok {
+			notes = append(notes, "outlier (excluded)")
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", s.SessionID),
+			s.EndedAt.Local().Format("2006-01-02 15:04"),
+			sessionMode(s),
+			fmt.Sprintf("%.1f", SessionSpeed(formula, s)),
+			fmt.Sprintf("%.2f%%", acc*100),
+			formatDuration(s.DurationMs),
+			strings.Join(notes, ", "),
+		})
+	}
+	rightAlign := map[int]bool{0: true, 3: true, 4: true, 5: true}
+	for _, line := range formatTable(headers, rows, rightAlign) {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sessionMode(s model.SessionAggregate) string {
+	mode := s.Mode
+	if mode == "" {
+		mode = "-"
+	}
+	if s.FocusWeak {
+		mode += "+weak"
+	}
+	return mode
+}
+
+// describeWordList summarizes the recorded wordlist provenance, falling back to the path
+// for sessions saved before provenance was recorded.
+func describeWordList(s model.SessionStats) string {
+	var meta wordlist.Meta
+	if s.WordListMeta == "" || json.Unmarshal([]byte(s.WordListMeta), &meta) != nil {
+		return s.WordListPath
+	}
+	details := []string{meta.Source}
+	if meta.WordfreqVersion != "" {
+		details = append(details, meta.WordfreqVersion)
+	}
+	if meta.ListType != "" {
+		details = append(details, meta.ListType)
+	}
+	if meta.Rank != "" {
+		details = append(details, "rank "+meta.Rank)
+	}
+	details = append(details, fmt.Sprintf("%d words", meta.Words))
+	return fmt.Sprintf("%s/%s (%s)", meta.Lang, meta.List, strings.Join(details, ", "))
+}
+
+// describeEnv summarizes a recorded session environment on one line.
+func describeEnv(env model.SessionEnv) string {
+	parts := []string{orUnknown(env.Term), orUnknown(env.OS)}
+	if env.Width > 0 && env.Height > 0 {
+		parts = append(parts, fmt.Sprintf("%dx%d", env.Width, env.Height))
+	}
+	if env.SSH {
+		parts = append(parts, "over SSH")
+	}
+	if len(env.Options) > 0 {
+		parts = append(parts, "with "+strings.Join(env.Options, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func formatDuration(durationMs int64) string {
+	d := time.Duration(durationMs) * time.Millisecond
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// RenderSessionDetail prints metadata for one session and, when stored, its target and typed text.
+// Speed is given under every WPM formula, along with the one the session was shown in.
+func RenderSessionDetail(w io.Writer, id int64, s model.SessionStats) error {
+	wpm, cpm, acc := SessionMetrics(s.CorrectNonSpace, s.IncorrectNonSpace, s.DurationMs)
+	wordsPerMin := Speed(model.WPMWords, s.CorrectNonSpace, s.WordsTyped, s.DurationMs)
+	mode := sessionMode(model.SessionAggregate{Mode: s.Mode, FocusWeak: s.FocusWeak})
+	formula := s.WPMFormula
+	if formula == "" {
+		formula = model.WPMChars
+	}
+	lines := []string{
+		fmt.Sprintf("Session:    %d", id),
+		fmt.Sprintf("Ended:      %s", s.EndedAt.Local().Format("2006-01-02 15:04:05")),
+		fmt.Sprintf("Lang:       %s", s.Lang),
+		fmt.Sprintf("Mode:       %s", mode),
+		fmt.Sprintf("Duration:   %s", formatDuration(s.DurationMs)),
+		fmt.Sprintf("WPM:        %.2f (%.2f in words typed)", wpm, wordsPerMin),
+		fmt.Sprintf("CPM:        %.2f", cpm),
+		fmt.Sprintf("Shown as:   %s", formula),
+		fmt.Sprintf("Accuracy:   %.2f%%", acc*100),
+		fmt.Sprintf("Words:      %d typed of %d", s.WordsTyped, s.Words),
+	}
+	if score, ok := Consistency(s.Samples); ok {
+		lines = append(lines, fmt.Sprintf("Per second: %.0f%% consistency %s", score*100, Sparkline(SampleWPM(s.Samples))))
+	}
+	if s.Incomplete {
+		lines = append(lines, "Status:     incomplete (quit before the end of the text)")
+	}
+	if s.Ignored {
+		lines = append(lines, "Ignored:    yes (left out of every aggregate and curve)")
+	}
+	if s.Keyboard != "" || s.Layout != "" {
+		lines = append(lines, fmt.Sprintf("Keyboard:   %s / %s", orUnknown(s.Keyboard), orUnknown(s.Layout)))
+	}
+	if list := describeWordList(s); list != "" {
+		lines = append(lines, fmt.Sprintf("Wordlist:   %s", list))
+	}
+	if s.WordListID != "" {
+		lines = append(lines, fmt.Sprintf("List ID:    %s", s.WordListID))
+	}
+	if s.WeakSet != "" {
+		lines = append(lines, fmt.Sprintf("Weak set:   %s", s.WeakSet))
+	}
+	if s.AppVersion != "" {
+		lines = append(lines, fmt.Sprintf("Version:    %s", s.AppVersion))
+	}
+	if s.Env != nil {
+		lines = append(lines, fmt.Sprintf("Terminal:   %s", describeEnv(*s.Env)))
+	}
+	if s.Scoring != "" {
+		lines = append(lines, fmt.Sprintf("Scoring:    %s", s.Scoring))
+	}
+	if s.ParamsHash != "" {
+		lines = append(lines, fmt.Sprintf("Params:     %s", s.ParamsHash))
+	}
+	if s.Source != "" {
+		lines = append(lines, fmt.Sprintf("Source:     imported from %s", s.Source))
+	}
+	switch {
+	case s.TargetText == "" && s.TypedText == "" && s.Source != "":
+		// Imported histories carry no text, so the --store-text hint does not apply.
+	case s.TargetText == "" && s.TypedText == "":
+		lines = append(lines, "", "Text was not stored for this session (enable with --store-text).")
+	default:
+		lines = append(lines, "", "Target:", s.TargetText, "", "Typed:", s.TypedText)
+		if s.TextTruncated {
+			lines = append(lines, "", "(text truncated by store-text-max)")
+		}
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
@@ -0,0 +1,634 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/internal/wordlist"
+	"github.com/verte-zerg/tuipe/pkg/model"
+	"github.com/verte-zerg/tuipe/pkg/store"
+)
+
+func TestSessionDetailIncludesStoredText(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	end := time.Unix(600, 0)
+	id, err := st.InsertSession(ctx, model.SessionStats{
+		StartedAt:       end.Add(-10 * time.Second),
+		EndedAt:         end,
+		Lang:            "en",
+		Words:           2,
+		CorrectNonSpace: 8,
+		DurationMs:      10000,
+		Mode:            model.ModeWords,
+		WordsTyped:      2,
+		WPMFormula:      model.WPMWords,
+		Samples:         []int{1, 1, 0, 1, 1, 1, 1, 0, 1, 1},
+		TargetText:      "hello world",
+		TypedText:       "hello wprld",
+		WordListPath:    "/lists/en/common.txt",
+		WordListMeta:    wordlist.Meta{Source: "wordfreq", Lang: "en", List: "common", WordfreqVersion: "3.1.1", ListType: "large", Words: 10000}.String(),
+	}, nil)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+
+	session, err := st.GetSession(ctx, id)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if session.TargetText != "hello world" || session.TypedText != "hello wprld" {
+		t.Fatalf("unexpected stored text: %+v", session)
+	}
+	if len(session.Samples) != 10 || session.Samples[2] != 0 {
+		t.Fatalf("unexpected stored samples: %v", session.Samples)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderSessionDetail(&buf, id, session); err != nil {
+		t.Fatalf("render detail: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Mode:       words", "Shown as:   words", "Per second: 50% consistency", "Wordlist:   en/common (wordfreq, 3.1.1, large, 10000 words)", "Target:", "hello wprld"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in detail:\n%s", want, out)
+		}
+	}
+
+	if _, err := st.GetSession(ctx, id+1); err == nil {
+		t.Fatalf("expected error for missing session")
+	}
+}
+
+func TestExportImportRemapsIDs(t *testing.T) {
+	src, err := store.Open(filepath.Join(t.TempDir(), "src.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = src.Close()
+	})
+	dst, err := store.Open(filepath.Join(t.TempDir(), "dst.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = dst.Close()
+	})
+
+	ctx := context.Background()
+	end := time.Unix(1200, 0)
+	session := model.SessionStats{
+		StartedAt:       end.Add(-20 * time.Second),
+		EndedAt:         end,
+		Lang:            "en",
+		CorrectNonSpace: 12,
+		DurationMs:      20000,
+	}
+	chars := []model.CharStats{{Char: "a", Correct: 12, LatencySumMs: 600, LatencyCount: 12}}
+	if _, err := dst.InsertSession(ctx, session, nil); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	if _, err := src.InsertSession(ctx, session, chars); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+
+	export, err := src.ExportSessions(ctx)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if len(export.Sessions) != 1 || len(export.Sessions[0].Chars) != 1 {
+		t.Fatalf("unexpected export: %+v", export)
+	}
+	count, err := dst.ImportSessions(ctx, export.Sessions)
+	if err != nil || count != 1 {
+		t.Fatalf("import: count=%d err=%v", count, err)
+	}
+
+	sessions, err := dst.ListSessions(ctx, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 2 || sessions[1].SessionID != 2 {
+		t.Fatalf("expected imported session under a new id, got %+v", sessions)
+	}
+	aggs, err := dst.ListCharAggregatesForSessions(ctx, []int64{2})
+	if err != nil {
+		t.Fatalf("list chars: %v", err)
+	}
+	if len(aggs) != 1 || aggs[0].Correct != 12 {
+		t.Fatalf("unexpected imported char stats: %+v", aggs)
+	}
+}
+
+func TestMergeSkipsDuplicateSessions(t *testing.T) {
+	dir := t.TempDir()
+	otherPath := filepath.Join(dir, "laptop.db")
+	other, err := store.Open(otherPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	st, err := store.Open(filepath.Join(dir, "desktop.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	shared := model.SessionStats{
+		StartedAt:  time.Unix(100, 0),
+		EndedAt:    time.Unix(130, 0),
+		Lang:       "en",
+		DurationMs: 30000,
+	}
+	laptopOnly := shared
+	laptopOnly.StartedAt = time.Unix(500, 0)
+	laptopOnly.EndedAt = time.Unix(530, 0)
+	for _, s := range []model.SessionStats{shared, laptopOnly} {
+		if _, err := other.InsertSession(ctx, s, nil); err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+	}
+	if err := other.Close(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+	if _, err := st.InsertSession(ctx, shared, nil); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+
+	result, err := st.MergeFrom(ctx, otherPath)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if result.Added != 1 || result.Skipped != 1 {
+		t.Fatalf("expected 1 added and 1 skipped, got %+v", result)
+	}
+	result, err = st.MergeFrom(ctx, otherPath)
+	if err != nil {
+		t.Fatalf("merge again: %v", err)
+	}
+	if result.Added != 0 || result.Skipped != 2 {
+		t.Fatalf("expected repeated merge to skip everything, got %+v", result)
+	}
+}
+
+func TestBackupRotationAndAutoBackup(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "tuipe.db")
+	backupDir := filepath.Join(dir, "backups")
+
+	st, err := store.OpenWithOptions(dbPath, store.Options{BackupDir: backupDir, BackupKeep: 2})
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if backups, _ := store.ListBackups(backupDir); len(backups) != 0 {
+		t.Fatalf("expected no backup for a fresh database, got %v", backups)
+	}
+	ctx := context.Background()
+	if _, err := st.InsertSession(ctx, model.SessionStats{StartedAt: time.Unix(0, 0), EndedAt: time.Unix(10, 0), Lang: "en"}, nil); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	var last string
+	for i := 0; i < 3; i++ {
+		if last, err = st.Backup(ctx, backupDir, 2); err != nil {
+			t.Fatalf("backup: %v", err)
+		}
+	}
+	backups, err := store.ListBackups(backupDir)
+	if err != nil {
+		t.Fatalf("list backups: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected rotation to keep 2 backups, got %d", len(backups))
+	}
+	if backups[len(backups)-1] != last {
+		t.Fatalf("expected newest backup %s to be kept, got %v", last, backups)
+	}
+
+	restored, err := store.Open(backups[len(backups)-1])
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	sessions, err := restored.ListSessions(ctx, model.StatsConfig{})
+	_ = restored.Close()
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("expected backup to contain 1 session, got %d (err=%v)", len(sessions), err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+}
+
+func TestMigrationBacksUpOlderSchema(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "tuipe.db")
+	backupDir := filepath.Join(dir, "backups")
+	ctx := context.Background()
+
+	st, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	id, err := st.InsertSession(ctx, model.SessionStats{StartedAt: time.Unix(0, 0), EndedAt: time.Unix(60, 0), Lang: "en",
+		CorrectNonSpace: 50, DurationMs: 60000, WPMFormula: model.WPMCPM}, nil)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+
+	// Roll the file back to the schema before sessions recorded their WPM formula.
+	raw, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open raw db: %v", err)
+	}
+	for _, stmt := range []string{`ALTER TABLE sessions DROP COLUMN wpm_formula`, `PRAGMA user_version = 6`} {
+		if _, err := raw.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if err := raw.Close(); err != nil {
+		t.Fatalf("close raw db: %v", err)
+	}
+
+	st, err = store.OpenWithOptions(dbPath, store.Options{BackupDir: backupDir, BackupKeep: 2})
+	if err != nil {
+		t.Fatalf("migrate store: %v", err)
+	}
+	backups, err := store.ListBackups(backupDir)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected one backup before the migration, got %v (err=%v)", backups, err)
+	}
+	session, err := st.GetSession(ctx, id)
+	if err != nil {
+		t.Fatalf("get migrated session: %v", err)
+	}
+	if session.WPMFormula != "" || session.CorrectNonSpace != 50 {
+		t.Fatalf("expected the session to survive with an empty formula, got %+v", session)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+
+	backup, err := store.Open(backups[0])
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	sessions, err := backup.ListSessions(ctx, model.StatsConfig{})
+	_ = backup.Close()
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("expected the backup to hold the session, got %d (err=%v)", len(sessions), err)
+	}
+
+	st, err = store.OpenWithOptions(dbPath, store.Options{BackupDir: backupDir, BackupKeep: 2})
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	_ = st.Close()
+	if backups, _ := store.ListBackups(backupDir); len(backups) != 1 {
+		t.Fatalf("expected no backup once migrated, got %v", backups)
+	}
+}
+
+func TestPruneSessionsBefore(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	old := time.Date(2022, 6, 1, 12, 0, 0, 0, time.Local)
+	recent := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	for _, end := range []time.Time{old, recent} {
+		stats := model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: "en", CorrectNonSpace: 5, DurationMs: 60000}
+		if _, err := st.InsertSession(ctx, stats, []model.CharStats{{Char: "a", Correct: 5}}); err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+	}
+
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	count, err := st.CountSessionsBefore(ctx, cutoff)
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 session before cutoff, got %d (err=%v)", count, err)
+	}
+	deleted, err := st.PruneSessions(ctx, cutoff)
+	if err != nil || deleted != 1 {
+		t.Fatalf("expected 1 deleted session, got %d (err=%v)", deleted, err)
+	}
+	if err := st.Vacuum(ctx); err != nil {
+		t.Fatalf("vacuum: %v", err)
+	}
+
+	days, err := st.ListDailyAggregates(ctx, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("list daily: %v", err)
+	}
+	if len(days) != 1 || !days[0].Day.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("expected daily aggregates rebuilt without pruned day, got %+v", days)
+	}
+}
+
+func TestIgnoredSessionsLeaveStats(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	var ids []int64
+	for i, day := range []int{1, 2} {
+		end := time.Date(2024, 6, day, 12, 0, 0, 0, time.Local)
+		stats := model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: "en", CorrectNonSpace: 5 * (i + 1), DurationMs: 60000}
+		id, err := st.InsertSession(ctx, stats, []model.CharStats{{Char: "a", Correct: 5}})
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := st.SetSessionIgnored(ctx, ids[1], true); err != nil {
+		t.Fatalf("ignore session: %v", err)
+	}
+	if err := st.SetSessionIgnored(ctx, ids[1]+1, true); err == nil {
+		t.Fatalf("expected error for missing session")
+	}
+
+	report, err := BuildReport(ctx, st, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if report.SessionCount != 1 {
+		t.Fatalf("expected the ignored session left out, got %d sessions", report.SessionCount)
+	}
+	days, err := st.ListDailyAggregates(ctx, model.StatsConfig{})
+	if err != nil || len(days) != 1 {
+		t.Fatalf("expected 1 daily aggregate, got %+v (err=%v)", days, err)
+	}
+
+	all, err := st.ListSessions(ctx, model.StatsConfig{IncludeIgnored: true})
+	if err != nil || len(all) != 2 {
+		t.Fatalf("expected both sessions listed, got %d (err=%v)", len(all), err)
+	}
+	var buf bytes.Buffer
+	if err := RenderSessionList(&buf, all, nil); err != nil {
+		t.Fatalf("render list: %v", err)
+	}
+	if !strings.Contains(buf.String(), "ignored") {
+		t.Fatalf("expected the ignored session marked:\n%s", buf.String())
+	}
+
+	if err := st.SetSessionIgnored(ctx, ids[1], false); err != nil {
+		t.Fatalf("count session again: %v", err)
+	}
+	days, err = st.ListDailyAggregates(ctx, model.StatsConfig{})
+	if err != nil || len(days) != 2 {
+		t.Fatalf("expected 2 daily aggregates, got %+v (err=%v)", days, err)
+	}
+}
+
+func TestListSessionsLastAndPages(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	var ids []int64
+	for i := 0; i < 7; i++ {
+		end := time.Unix(int64(1000+i*60), 0)
+		lang := "en"
+		if i%2 == 1 {
+			lang = "de"
+		}
+		id, err := st.InsertSession(ctx, model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: lang, DurationMs: 60000}, nil)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	last, err := st.ListSessions(ctx, model.StatsConfig{Lang: "en", Last: 2})
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(last) != 2 || last[0].SessionID != ids[4] || last[1].SessionID != ids[6] {
+		t.Fatalf("expected last two en sessions oldest first, got %+v", last)
+	}
+
+	page, err := st.ListSessionsPage(ctx, model.StatsConfig{}, 2, 3)
+	if err != nil {
+		t.Fatalf("list page: %v", err)
+	}
+	if len(page) != 3 || page[0].SessionID != ids[4] || page[2].SessionID != ids[2] {
+		t.Fatalf("expected sessions 5..3 newest first, got %+v", page)
+	}
+	tail, err := st.ListSessionsPage(ctx, model.StatsConfig{}, 6, 3)
+	if err != nil {
+		t.Fatalf("list page: %v", err)
+	}
+	if len(tail) != 1 || tail[0].SessionID != ids[0] {
+		t.Fatalf("expected only the oldest session on the last page, got %+v", tail)
+	}
+}
+
+func TestIncompleteSessionsExcludedByDefault(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	end := time.Unix(5000, 0)
+	chars := []model.CharStats{{Char: "a", Correct: 5}}
+	if _, err := st.InsertSession(ctx, model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: "en", CorrectNonSpace: 5, DurationMs: 60000}, chars); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	id, err := st.InsertSession(ctx, model.SessionStats{StartedAt: end, EndedAt: end.Add(time.Minute), Lang: "en", CorrectNonSpace: 2, DurationMs: 60000, Incomplete: true}, chars)
+	if err != nil {
+		t.Fatalf("insert incomplete session: %v", err)
+	}
+
+	report, err := BuildReport(ctx, st, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Sessions) != 1 || report.CharAggsAll[0].Correct != 5 {
+		t.Fatalf("expected only the completed session, got %+v", report)
+	}
+	report, err = BuildReport(ctx, st, model.StatsConfig{IncludeIncomplete: true})
+	if err != nil {
+		t.Fatalf("build report: %v", err)
+	}
+	if len(report.Sessions) != 2 || !report.Sessions[1].Incomplete {
+		t.Fatalf("expected the incomplete session to be included, got %+v", report.Sessions)
+	}
+
+	days, err := st.ListDailyAggregates(ctx, model.StatsConfig{})
+	if err != nil {
+		t.Fatalf("list daily: %v", err)
+	}
+	if len(days) != 1 || days[0].Sessions != 1 {
+		t.Fatalf("expected daily aggregates to skip incomplete sessions, got %+v", days)
+	}
+	weak, err := st.GetWeakChars(ctx, 10, "")
+	if err != nil {
+		t.Fatalf("weak chars: %v", err)
+	}
+	if len(weak) != 1 || weak[0].Correct != 5 {
+		t.Fatalf("expected weak chars from completed sessions only, got %+v", weak)
+	}
+
+	session, err := st.GetSession(ctx, id)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if !session.Incomplete {
+		t.Fatalf("expected stored session to be incomplete")
+	}
+}
+
+func TestStoreRevisionTracksWrites(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+	changes := 0
+	st.OnChange(func() { changes++ })
+
+	ctx := context.Background()
+	before, err := st.Revision(ctx)
+	if err != nil {
+		t.Fatalf("revision: %v", err)
+	}
+	end := time.Unix(600, 0)
+	if _, err := st.InsertSession(ctx, model.SessionStats{StartedAt: end.Add(-time.Minute), EndedAt: end, Lang: "en", DurationMs: 60000}, nil); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	after, err := st.Revision(ctx)
+	if err != nil {
+		t.Fatalf("revision: %v", err)
+	}
+	if after == before || after.Sessions != 1 {
+		t.Fatalf("expected revision to change after insert: before %+v, after %+v", before, after)
+	}
+	if changes != 1 {
+		t.Fatalf("expected 1 change notification, got %d", changes)
+	}
+
+	if _, err := st.PruneSessions(ctx, end.Add(time.Second)); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	pruned, err := st.Revision(ctx)
+	if err != nil {
+		t.Fatalf("revision: %v", err)
+	}
+	if pruned == after || changes != 2 {
+		t.Fatalf("expected prune to change the revision and notify: %+v, %d changes", pruned, changes)
+	}
+
+	var ids []int64
+	for i := range 2 {
+		ended := end.Add(time.Duration(i+1) * time.Hour)
+		id, err := st.InsertSession(ctx, model.SessionStats{StartedAt: ended.Add(-time.Minute), EndedAt: ended, Lang: "en", DurationMs: 60000}, nil)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := st.SetSessionIgnored(ctx, ids[0], true); err != nil {
+		t.Fatalf("ignore session: %v", err)
+	}
+	ignored, err := st.Revision(ctx)
+	if err != nil {
+		t.Fatalf("revision: %v", err)
+	}
+	// Swapping which session is ignored keeps the count of ignored sessions the same.
+	if err := st.SetSessionIgnored(ctx, ids[0], false); err != nil {
+		t.Fatalf("count session again: %v", err)
+	}
+	if err := st.SetSessionIgnored(ctx, ids[1], true); err != nil {
+		t.Fatalf("ignore session: %v", err)
+	}
+	swapped, err := st.Revision(ctx)
+	if err != nil {
+		t.Fatalf("revision: %v", err)
+	}
+	if swapped == ignored {
+		t.Fatalf("expected swapping the ignored session to change the revision: %+v", swapped)
+	}
+}
+
+func TestStoreTotalsMatchSessions(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "tuipe.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = st.Close()
+	})
+
+	ctx := context.Background()
+	end := time.Unix(600, 0)
+	for i, s := range []model.SessionStats{
+		{Lang: "en", CorrectNonSpace: 52, IncorrectNonSpace: 3, DurationMs: 30000, WordsTyped: 9},
+		{Lang: "en", CorrectNonSpace: 41, IncorrectNonSpace: 1, DurationMs: 20000},
+		{Lang: "de", CorrectNonSpace: 30, IncorrectNonSpace: 2, DurationMs: 15000, WordsTyped: 6},
+		{Lang: "en", CorrectNonSpace: 10, DurationMs: 5000, Incomplete: true},
+	} {
+		s.EndedAt = end.Add(time.Duration(i) * time.Minute)
+		s.StartedAt = s.EndedAt.Add(-time.Duration(s.DurationMs) * time.Millisecond)
+		if _, err := st.InsertSession(ctx, s, nil); err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+	}
+
+	for _, lang := range []string{"", "en", "de", "ru"} {
+		sessions, err := st.ListSessions(ctx, model.StatsConfig{Lang: lang})
+		if err != nil {
+			t.Fatalf("list sessions: %v", err)
+		}
+		want := model.Totals{Sessions: len(sessions)}
+		for _, s := range sessions {
+			want.Correct += s.Correct
+			want.Incorrect += s.Incorrect
+			want.Words += CountedWords(s.Correct, s.WordsTyped)
+			want.DurationMs += s.DurationMs
+		}
+		got, err := st.GetTotals(ctx, lang)
+		if err != nil {
+			t.Fatalf("get totals: %v", err)
+		}
+		if got != want {
+			t.Fatalf("lang %q: expected totals %+v, got %+v", lang, want, got)
+		}
+	}
+}
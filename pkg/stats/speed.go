@@ -0,0 +1,51 @@
+package stats
+
+import "github.com/verte-zerg/tuipe/pkg/model"
+
+// Speed returns the typing speed of a session under a WPM formula: correct characters
+// per minute for model.WPMCPM, words typed per minute for model.WPMWords, and five
+// correct characters a word otherwise, including the empty formula of older sessions.
+func Speed(formula string, correct, wordsTyped int, durationMs int64) float64 {
+	if durationMs <= 0 {
+		return 0
+	}
+	minutes := float64(durationMs) / 60000.0
+	switch formula {
+	case model.WPMCPM:
+		return float64(correct) / minutes
+	case model.WPMWords:
+		return float64(CountedWords(correct, wordsTyped)) / minutes
+	}
+	return float64(correct) / 5.0 / minutes
+}
+
+// SessionSpeed returns the speed of an aggregated session under formula.
+func SessionSpeed(formula string, s model.SessionAggregate) float64 {
+	return Speed(formula, s.Correct, s.WordsTyped, s.DurationMs)
+}
+
+// CountedWords returns the words a session counts under model.WPMWords: the words typed,
+// or one per five correct characters for sessions that did not record them.
+func CountedWords(correct, wordsTyped int) int {
+	if wordsTyped > 0 {
+		return wordsTyped
+	}
+	return correct / 5
+}
+
+// SpeedUnit names the unit Speed reports under formula.
+func SpeedUnit(formula string) string {
+	if formula == model.WPMCPM {
+		return "CPM"
+	}
+	return "WPM"
+}
+
+// CompanionFormula is the formula shown beside formula where both fit: characters per
+// minute next to words per minute, and five-character WPM next to CPM.
+func CompanionFormula(formula string) string {
+	if formula == model.WPMCPM {
+		return model.WPMChars
+	}
+	return model.WPMCPM
+}
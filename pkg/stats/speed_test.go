@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSpeedFormulas(t *testing.T) {
+	cases := []struct {
+		formula string
+		words   int
+		want    float64
+	}{
+		{"", 30, 40},
+		{model.WPMChars, 30, 40},
+		{model.WPMWords, 30, 30},
+		{model.WPMWords, 0, 40},
+		{model.WPMCPM, 30, 200},
+	}
+	for _, tc := range cases {
+		if got := Speed(tc.formula, 200, tc.words, 60000); got != tc.want {
+			t.Fatalf("Speed(%q, words=%d) = %.1f, want %.1f", tc.formula, tc.words, got, tc.want)
+		}
+	}
+	if got := Speed(model.WPMCPM, 200, 30, 0); got != 0 {
+		t.Fatalf("expected zero speed without duration, got %.1f", got)
+	}
+}
+
+func TestCurveSeriesUsesFormulaUnit(t *testing.T) {
+	sessions := []model.SessionAggregate{{Correct: 200, WordsTyped: 30, DurationMs: 60000}}
+	series := CurveSeriesWithFormula(sessions, model.WPMCPM, 1)
+	if series[0].Name != "CPM" || series[0].Values[0] != 200 {
+		t.Fatalf("unexpected speed series: %+v", series[0])
+	}
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// SRSPassAccuracy is the per-session accuracy a character needs to pass its review.
+const SRSPassAccuracy = 0.9
+
+const (
+	srsInitialEase     = 2.5
+	srsMinEase         = 1.3
+	srsMaxEase         = 3.0
+	srsEaseStep        = 0.1
+	srsLapsePenalty    = 0.2
+	srsFirstInterval   = 24 * time.Hour
+	srsSecondInterval  = 3 * 24 * time.Hour
+	srsRelearnInterval = 10 * time.Minute
+)
+
+// SRSWord normalizes a text word for review tracking: surrounding punctuation is dropped
+// and letters are lower-cased.
+func SRSWord(word string) string {
+	word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	return strings.ToLower(word)
+}
+
+// SRSGrades grades a finished session. A character passes when its accuracy reaches
+// SRSPassAccuracy; a word passes when it was typed without a mistake.
+func SRSGrades(chars []model.CharStats, words []string, missed map[string]struct{}) []model.SRSGrade {
+	grades := make([]model.SRSGrade, 0, len(chars)+len(words))
+	for _, c := range chars {
+		total := c.Correct + c.Incorrect
+		if c.Char == " " || total == 0 {
+			continue
+		}
+		passed := float64(c.Correct)/float64(total) >= SRSPassAccuracy
+		grades = append(grades, model.SRSGrade{Kind: model.SRSChar, Item: c.Char, Passed: passed})
+	}
+	seen := map[string]struct{}{}
+	for _, w := range words {
+		word := SRSWord(w)
+		if word == "" {
+			continue
+		}
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		_, miss := missed[word]
+		grades = append(grades, model.SRSGrade{Kind: model.SRSWord, Item: word, Passed: !miss})
+	}
+	return grades
+}
+
+// ScheduleSRS applies grades to the review items of lang and returns the changed items.
+// Failures add an item or reset it to a short relearn interval with a lower ease. Passes
+// only count for items that are due, growing the interval SM-2 style, so an item keeps
+// resurfacing on schedule even while its accuracy is temporarily good.
+func ScheduleSRS(lang string, items []model.SRSItem, grades []model.SRSGrade, now time.Time) []model.SRSItem {
+	byKey := make(map[string]model.SRSItem, len(items))
+	for _, item := range items {
+		byKey[item.Kind+"\x00"+item.Item] = item
+	}
+	var changed []model.SRSItem
+	for _, g := range grades {
+		item, ok := byKey[g.Kind+"\x00"+g.Item]
+		switch {
+		case !g.Passed:
+			if !ok {
+				item = model.SRSItem{Lang: lang, Kind: g.Kind, Item: g.Item, Ease: srsInitialEase}
+			}
+			item.Reps = 0
+			item.Lapses++
+			item.Ease = max(srsMinEase, item.Ease-srsLapsePenalty)
+			item.Interval = srsRelearnInterval
+		case ok && !now.Before(item.DueAt):
+			item.Reps++
+			switch item.Reps {
+			case 1:
+				item.Interval = srsFirstInterval
+			case 2:
+				item.Interval = srsSecondInterval
+			default:
+				item.Interval = time.Duration(float64(item.Interval) * item.Ease)
+			}
+			item.Ease = min(srsMaxEase, item.Ease+srsEaseStep)
+		default:
+			continue
+		}
+		item.ReviewedAt = now
+		item.DueAt = now.Add(item.Interval)
+		changed = append(changed, item)
+	}
+	return changed
+}
+
+// DueSRS returns the items of kind that are due at now, most overdue first, up to limit
+// (0 = all).
+func DueSRS(items []model.SRSItem, kind string, now time.Time, limit int) []model.SRSItem {
+	var due []model.SRSItem
+	for _, item := range items {
+		if item.Kind == kind && !now.Before(item.DueAt) {
+			due = append(due, item)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].DueAt.Equal(due[j].DueAt) {
+			return due[i].DueAt.Before(due[j].DueAt)
+		}
+		return due[i].Item < due[j].Item
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSRSGrades(t *testing.T) {
+	grades := SRSGrades(
+		[]model.CharStats{{Char: "a", Correct: 9, Incorrect: 1}, {Char: "b", Correct: 4, Incorrect: 1}, {Char: " ", Incorrect: 3}},
+		[]string{"Hello,", "world", "hello"},
+		map[string]struct{}{"world": {}},
+	)
+	want := []model.SRSGrade{
+		{Kind: model.SRSChar, Item: "a", Passed: true},
+		{Kind: model.SRSChar, Item: "b", Passed: false},
+		{Kind: model.SRSWord, Item: "hello", Passed: true},
+		{Kind: model.SRSWord, Item: "world", Passed: false},
+	}
+	if len(grades) != len(want) {
+		t.Fatalf("expected %d grades, got %+v", len(want), grades)
+	}
+	for i := range want {
+		if grades[i] != want[i] {
+			t.Fatalf("grade %d = %+v, want %+v", i, grades[i], want[i])
+		}
+	}
+}
+
+func TestScheduleSRS(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	fail := []model.SRSGrade{{Kind: model.SRSChar, Item: "q", Passed: false}}
+	pass := []model.SRSGrade{{Kind: model.SRSChar, Item: "q", Passed: true}}
+
+	if got := ScheduleSRS("en", nil, pass, now); len(got) != 0 {
+		t.Fatalf("expected passes to not create items, got %+v", got)
+	}
+	items := ScheduleSRS("en", nil, fail, now)
+	if len(items) != 1 || items[0].Lapses != 1 || items[0].Lang != "en" || !items[0].DueAt.Equal(now.Add(srsRelearnInterval)) {
+		t.Fatalf("unexpected new item: %+v", items)
+	}
+	if got := ScheduleSRS("en", items, pass, now.Add(time.Minute)); len(got) != 0 {
+		t.Fatalf("expected a pass before the due time to be ignored, got %+v", got)
+	}
+
+	at := items[0].DueAt
+	for _, want := range []time.Duration{srsFirstInterval, srsSecondInterval} {
+		items = ScheduleSRS("en", items, pass, at)
+		if len(items) != 1 || items[0].Interval != want {
+			t.Fatalf("expected interval %s, got %+v", want, items)
+		}
+		at = items[0].DueAt
+	}
+	items = ScheduleSRS("en", items, pass, at)
+	if items[0].Interval <= srsSecondInterval {
+		t.Fatalf("expected the interval to keep growing, got %s", items[0].Interval)
+	}
+	items = ScheduleSRS("en", items, fail, items[0].DueAt)
+	if items[0].Reps != 0 || items[0].Lapses != 2 || items[0].Interval != srsRelearnInterval {
+		t.Fatalf("expected a lapse to reset the item, got %+v", items[0])
+	}
+}
+
+func TestDueSRS(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	items := []model.SRSItem{
+		{Kind: model.SRSChar, Item: "a", DueAt: now.Add(-time.Hour)},
+		{Kind: model.SRSChar, Item: "b", DueAt: now.Add(-2 * time.Hour)},
+		{Kind: model.SRSChar, Item: "c", DueAt: now.Add(time.Hour)},
+		{Kind: model.SRSWord, Item: "the", DueAt: now.Add(-3 * time.Hour)},
+	}
+	due := DueSRS(items, model.SRSChar, now, 0)
+	if len(due) != 2 || due[0].Item != "b" || due[1].Item != "a" {
+		t.Fatalf("unexpected due chars: %+v", due)
+	}
+	if due := DueSRS(items, model.SRSChar, now, 1); len(due) != 1 || due[0].Item != "b" {
+		t.Fatalf("expected limit to keep the most overdue, got %+v", due)
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/verte-zerg/tuipe/internal/model"
+	"github.com/verte-zerg/tuipe/pkg/model"
 )
 
 const sparkChars = " .:-=+*#%@"
@@ -31,6 +31,27 @@ func SessionMetrics(correct, incorrect int, durationMs int64) (wpm, cpm, accurac
 	return wpm, cpm, accuracy
 }
 
+// ReactionMetrics averages first-keystroke reaction time and space-bar latency in milliseconds.
+// Sessions without recorded timings are ignored.
+func ReactionMetrics(sessions []model.SessionAggregate) (firstKeyMs, spaceMs float64) {
+	var firstSum, spaceSum, firstCount, spaceCount int64
+	for _, s := range sessions {
+		if s.FirstKeyMs > 0 {
+			firstSum += s.FirstKeyMs
+			firstCount++
+		}
+		spaceSum += s.SpaceLatencySumMs
+		spaceCount += s.SpaceLatencyCount
+	}
+	if firstCount > 0 {
+		firstKeyMs = float64(firstSum) / float64(firstCount)
+	}
+	if spaceCount > 0 {
+		spaceMs = float64(spaceSum) / float64(spaceCount)
+	}
+	return firstKeyMs, spaceMs
+}
+
 // MovingAverage computes a rolling mean over the provided window size.
 func MovingAverage(values []float64, window int) []float64 {
 	if window <= 1 || len(values) == 0 {
@@ -87,42 +108,62 @@ func Sparkline(values []float64) string {
 	return b.String()
 }
 
-// RenderSummary prints a summary table for sessions.
+// RenderSummary prints a summary table for sessions with speeds in five-character WPM.
 func RenderSummary(w io.Writer, sessions []model.SessionAggregate) error {
+	return RenderSummaryWithFormula(w, sessions, model.WPMChars)
+}
+
+// RenderSummaryWithFormula prints a summary table for sessions, with speeds under the WPM
+// formula and its companion, and warns when they were scored under different rules.
+func RenderSummaryWithFormula(w io.Writer, sessions []model.SessionAggregate, formula string) error {
 	if len(sessions) == 0 {
 		_, err := fmt.Fprintln(w, "No sessions found.")
 		return err
 	}
-	var totalWPM, totalCPM, totalAcc float64
-	bestWPM := 0.0
+	companion := CompanionFormula(formula)
+	var totalSpeed, totalCompanion, totalAcc float64
+	bestSpeed := 0.0
 	for _, s := range sessions {
-		wpm, cpm, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
-		totalWPM += wpm
-		totalCPM += cpm
+		_, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
+		speed := SessionSpeed(formula, s)
+		totalSpeed += speed
+		totalCompanion += SessionSpeed(companion, s)
 		totalAcc += acc
-		if wpm > bestWPM {
-			bestWPM = wpm
+		if speed > bestSpeed {
+			bestSpeed = speed
 		}
 	}
 	count := float64(len(sessions))
+	firstKeyMs, spaceMs := ReactionMetrics(sessions)
 	if _, err := fmt.Fprintln(w, "Summary"); err != nil {
 		return err
 	}
 	if _, err := fmt.Fprintf(w, "Sessions: %d\n", len(sessions)); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintf(w, "Avg WPM: %.2f\n", totalWPM/count); err != nil {
+	if _, err := fmt.Fprintf(w, "Avg %s: %.2f\n", SpeedUnit(formula), totalSpeed/count); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintf(w, "Best WPM: %.2f\n", bestWPM); err != nil {
+	if _, err := fmt.Fprintf(w, "Best %s: %.2f\n", SpeedUnit(formula), bestSpeed); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintf(w, "Avg CPM: %.2f\n", totalCPM/count); err != nil {
+	if _, err := fmt.Fprintf(w, "Avg %s: %.2f\n", SpeedUnit(companion), totalCompanion/count); err != nil {
 		return err
 	}
 	if _, err := fmt.Fprintf(w, "Avg Accuracy: %.2f%%\n", (totalAcc/count)*100); err != nil {
 		return err
 	}
+	if _, err := fmt.Fprintf(w, "Avg First Key: %.0f ms\n", firstKeyMs); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Avg Space Latency: %.0f ms\n", spaceMs); err != nil {
+		return err
+	}
+	if warning := ScoringWarning(sessions); warning != "" {
+		if _, err := fmt.Fprintln(w, warning); err != nil {
+			return err
+		}
+	}
 	if _, err := fmt.Fprintln(w, ""); err != nil {
 		return err
 	}
@@ -131,32 +172,30 @@ func RenderSummary(w io.Writer, sessions []model.SessionAggregate) error {
 
 // RenderCurves prints learning curves for WPM and accuracy.
 func RenderCurves(w io.Writer, sessions []model.SessionAggregate, window int) error {
-	return RenderCurvesWithSize(w, sessions, window, 0, 10, false)
+	return RenderCurvesWithFormula(w, sessions, model.WPMChars, window)
+}
+
+// RenderCurvesWithFormula prints learning curves for speed under the WPM formula and accuracy.
+func RenderCurvesWithFormula(w io.Writer, sessions []model.SessionAggregate, formula string, window int) error {
+	return RenderCurvesWithSizeAndFormula(w, sessions, formula, window, 0, 10, false)
 }
 
 // RenderCurvesWithSize prints learning curves sized to a given total width.
 func RenderCurvesWithSize(w io.Writer, sessions []model.SessionAggregate, window, totalWidth, height int, useColor bool) error {
+	return RenderCurvesWithSizeAndFormula(w, sessions, model.WPMChars, window, totalWidth, height, useColor)
+}
+
+// RenderCurvesWithSizeAndFormula prints learning curves for speed under the WPM formula,
+// sized to a given total width.
+func RenderCurvesWithSizeAndFormula(w io.Writer, sessions []model.SessionAggregate, formula string, window, totalWidth, height int, useColor bool) error {
 	if len(sessions) == 0 {
 		return nil
 	}
-	wpms := make([]float64, len(sessions))
-	accs := make([]float64, len(sessions))
-	for i, s := range sessions {
-		wpm, _, acc := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs)
-		wpms[i] = wpm
-		accs[i] = acc * 100
-	}
-	wpms = MovingAverage(wpms, window)
-	accs = MovingAverage(accs, window)
-
 	width := 0
 	if totalWidth > 0 {
 		width = PlotWidthFor(totalWidth)
 	}
-	return PlotSeriesWithColor(w, "Learning Curves", []Series{
-		{Name: "WPM", Values: wpms},
-		{Name: "Accuracy", Values: accs},
-	}, width, height, useColor)
+	return PlotSeriesWithColor(w, "Learning Curves", CurveSeriesWithFormula(sessions, formula, window), width, height, useColor)
 }
 
 // RenderCharTable prints per-character aggregates.
@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Status holds the values available to status line templates.
+type Status struct {
+	LastWPM       float64
+	LastAccuracy  float64
+	TodayBestWPM  float64
+	TodaySessions int
+	Streak        int
+	// PracticedToday is set when there is at least one session since local midnight.
+	PracticedToday bool
+}
+
+// DefaultStatusFormat is the status line printed when no format is given.
+const DefaultStatusFormat = "{last_wpm} wpm {streak}d"
+
+var statusPlaceholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// StatusFields lists the placeholders accepted by FormatStatus.
+func StatusFields() []string {
+	return []string{"last_wpm", "last_acc", "today_best", "today_sessions", "streak", "practiced"}
+}
+
+// FormatStatus expands {field} placeholders in format. WPM values are rounded to whole
+// numbers and accuracy is a percentage without the sign, so templates stay short.
+func FormatStatus(format string, s Status) (string, error) {
+	values := map[string]string{
+		"last_wpm":       strconv.FormatFloat(s.LastWPM, 'f', 0, 64),
+		"last_acc":       strconv.FormatFloat(s.LastAccuracy*100, 'f', 0, 64),
+		"today_best":     strconv.FormatFloat(s.TodayBestWPM, 'f', 0, 64),
+		"today_sessions": strconv.Itoa(s.TodaySessions),
+		"streak":         strconv.Itoa(s.Streak),
+		"practiced":      yesNo(s.PracticedToday),
+	}
+	var unknown string
+	out := statusPlaceholder.ReplaceAllStringFunc(format, func(match string) string {
+		name := match[1 : len(match)-1]
+		value, ok := values[name]
+		if !ok && unknown == "" {
+			unknown = match
+		}
+		return value
+	})
+	if unknown != "" {
+		return "", fmt.Errorf("unknown placeholder %s (available: {%s})", unknown, strings.Join(StatusFields(), "}, {"))
+	}
+	return out, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
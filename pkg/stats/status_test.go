@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestFormatStatus(t *testing.T) {
+	status := Status{LastWPM: 71.6, LastAccuracy: 0.968, TodayBestWPM: 80.2, TodaySessions: 4, Streak: 12}
+	got, err := FormatStatus("{last_wpm}/{today_best} {last_acc}% x{today_sessions} {streak}d", status)
+	if err != nil {
+		t.Fatalf("FormatStatus failed: %v", err)
+	}
+	if want := "72/80 97% x4 12d"; got != want {
+		t.Fatalf("FormatStatus = %q, want %q", got, want)
+	}
+	if _, err := FormatStatus("{wpm}", status); err == nil {
+		t.Fatalf("expected an error for an unknown placeholder")
+	}
+}
+
+func TestDailyStreak(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.Local)
+	day := func(daysAgo, sessions int) model.DailyAggregate {
+		return model.DailyAggregate{Day: now.AddDate(0, 0, -daysAgo), Sessions: sessions}
+	}
+	if got := DailyStreak([]model.DailyAggregate{day(3, 1), day(2, 1), day(1, 2)}, now); got != 3 {
+		t.Fatalf("expected streak 3, got %d", got)
+	}
+	if got := DailyStreak([]model.DailyAggregate{day(2, 1), day(1, 0)}, now); got != 0 {
+		t.Fatalf("expected empty days to break the streak, got %d", got)
+	}
+}
+
+func TestReminderDue(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 30, 0, 0, time.Local)
+	at, err := ParseClock("09:00")
+	if err != nil {
+		t.Fatalf("ParseClock failed: %v", err)
+	}
+	if !ReminderDue(DaySummary{}, at, now) {
+		t.Fatalf("expected a reminder after 09:00 without practice")
+	}
+	if ReminderDue(DaySummary{Sessions: 1}, at, now) {
+		t.Fatalf("expected no reminder after practice")
+	}
+	if late, _ := ParseClock("18:00"); ReminderDue(DaySummary{}, late, now) {
+		t.Fatalf("expected no reminder before 18:00")
+	}
+	for _, bad := range []string{"9", "24:00", "09:5", "ab:cd"} {
+		if _, err := ParseClock(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+	if got := ReminderMessage(DaySummary{Streak: 12}); got != "tuipe: no practice yet today. Keep your 12-day streak going." {
+		t.Fatalf("unexpected reminder %q", got)
+	}
+}
@@ -0,0 +1,48 @@
+// Package stats contains statistics calculations and reporting.
+package stats
+
+import (
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// Streak counts consecutive local calendar days with at least one session.
+// The streak ends today, or yesterday when there has been no practice yet today.
+func Streak(sessions []model.SessionAggregate, now time.Time) int {
+	days := map[string]struct{}{}
+	for _, s := range sessions {
+		days[dayKey(s.EndedAt)] = struct{}{}
+	}
+	return streakFrom(days, now)
+}
+
+// DailyStreak is Streak computed from per-day aggregates, which avoids loading every session.
+func DailyStreak(daily []model.DailyAggregate, now time.Time) int {
+	days := map[string]struct{}{}
+	for _, d := range daily {
+		if d.Sessions > 0 {
+			days[dayKey(d.Day)] = struct{}{}
+		}
+	}
+	return streakFrom(days, now)
+}
+
+func streakFrom(days map[string]struct{}, now time.Time) int {
+	day := now.Local()
+	if _, ok := days[dayKey(day)]; !ok {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for {
+		if _, ok := days[dayKey(day)]; !ok {
+			return streak
+		}
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+}
+
+func dayKey(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// DaySummary totals one day of practice.
+type DaySummary struct {
+	Date       time.Time
+	Sessions   int
+	DurationMs int64
+	AvgWPM     float64
+	BestWPM    float64
+	Accuracy   float64
+	Streak     int
+	// GoalMinutes is the daily practice goal; 0 means no goal is set.
+	GoalMinutes int
+}
+
+// SummarizeDay totals the sessions of one day. AvgWPM and Accuracy are weighted by
+// typing time, like the daily curves; streak is left for the caller.
+func SummarizeDay(day time.Time, sessions []model.SessionAggregate) DaySummary {
+	summary := DaySummary{Date: day, Sessions: len(sessions)}
+	correct, incorrect := 0, 0
+	for _, s := range sessions {
+		correct += s.Correct
+		incorrect += s.Incorrect
+		summary.DurationMs += s.DurationMs
+		if wpm, _, _ := SessionMetrics(s.Correct, s.Incorrect, s.DurationMs); wpm > summary.BestWPM {
+			summary.BestWPM = wpm
+		}
+	}
+	summary.AvgWPM, _, summary.Accuracy = SessionMetrics(correct, incorrect, summary.DurationMs)
+	return summary
+}
+
+// RenderDaySummary prints a compact plain-text block for a day of practice.
+func RenderDaySummary(w io.Writer, s DaySummary) error {
+	lines := []string{"tuipe · " + s.Date.Local().Format("2006-01-02")}
+	if s.Sessions == 0 {
+		lines = append(lines, "No practice yet today.")
+	} else {
+		sessions := "sessions"
+		if s.Sessions == 1 {
+			sessions = "session"
+		}
+		minutes := time.Duration(s.DurationMs) * time.Millisecond
+		lines = append(lines,
+			fmt.Sprintf("Practice:  %.1f min in %d %s", minutes.Minutes(), s.Sessions, sessions),
+			fmt.Sprintf("WPM:       %.1f avg, %.1f best", s.AvgWPM, s.BestWPM),
+			fmt.Sprintf("Accuracy:  %.1f%%", s.Accuracy*100),
+		)
+	}
+	if s.GoalMinutes > 0 {
+		lines = append(lines, "Goal:      "+goalProgress(s.DurationMs, s.GoalMinutes))
+	}
+	days := "days"
+	if s.Streak == 1 {
+		days = "day"
+	}
+	lines = append(lines, fmt.Sprintf("Streak:    %d %s", s.Streak, days))
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// GoalMet reports whether durationMs of practice reaches a goal of goalMinutes.
+// It is always false without a goal.
+func GoalMet(durationMs int64, goalMinutes int) bool {
+	return goalMinutes > 0 && durationMs >= int64(goalMinutes)*time.Minute.Milliseconds()
+}
+
+func goalProgress(durationMs int64, goalMinutes int) string {
+	minutes := (time.Duration(durationMs) * time.Millisecond).Minutes()
+	if GoalMet(durationMs, goalMinutes) {
+		return fmt.Sprintf("%.1f / %d min, met", minutes, goalMinutes)
+	}
+	return fmt.Sprintf("%.1f / %d min (%.0f%%)", minutes, goalMinutes, minutes/float64(goalMinutes)*100)
+}
@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSummarizeDay(t *testing.T) {
+	day := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+	summary := SummarizeDay(day, []model.SessionAggregate{
+		{Correct: 250, DurationMs: 60000},
+		{Correct: 200, Incorrect: 50, DurationMs: 30000},
+	})
+	if summary.Sessions != 2 || summary.DurationMs != 90000 {
+		t.Fatalf("unexpected totals: %+v", summary)
+	}
+	if summary.BestWPM != 80 || summary.AvgWPM != 60 {
+		t.Fatalf("expected 60 avg / 80 best WPM, got %.1f / %.1f", summary.AvgWPM, summary.BestWPM)
+	}
+	summary.Streak = 1
+	var buf bytes.Buffer
+	if err := RenderDaySummary(&buf, summary); err != nil {
+		t.Fatalf("RenderDaySummary failed: %v", err)
+	}
+	if !containsAll(buf.String(), []string{"2024-05-10", "1.5 min in 2 sessions", "60.0 avg, 80.0 best", "90.0%", "1 day"}) {
+		t.Fatalf("unexpected summary:\n%s", buf.String())
+	}
+}
+
+func TestRenderDaySummaryWithoutPractice(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderDaySummary(&buf, DaySummary{Date: time.Now(), Streak: 3}); err != nil {
+		t.Fatalf("RenderDaySummary failed: %v", err)
+	}
+	if !containsAll(buf.String(), []string{"No practice yet today.", "3 days"}) {
+		t.Fatalf("unexpected summary:\n%s", buf.String())
+	}
+}
+
+func TestRenderDaySummaryGoal(t *testing.T) {
+	var buf bytes.Buffer
+	summary := DaySummary{Date: time.Now(), Sessions: 1, DurationMs: 6 * 60000, GoalMinutes: 15}
+	if err := RenderDaySummary(&buf, summary); err != nil {
+		t.Fatalf("RenderDaySummary failed: %v", err)
+	}
+	if !containsAll(buf.String(), []string{"Goal:", "6.0 / 15 min (40%)"}) {
+		t.Fatalf("unexpected summary:\n%s", buf.String())
+	}
+	buf.Reset()
+	summary.DurationMs = 15 * 60000
+	if err := RenderDaySummary(&buf, summary); err != nil {
+		t.Fatalf("RenderDaySummary failed: %v", err)
+	}
+	if !containsAll(buf.String(), []string{"15.0 / 15 min, met"}) {
+		t.Fatalf("unexpected summary:\n%s", buf.String())
+	}
+}
+
+func TestRenderCalendar(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.Local) }
+	daily := []model.DailyAggregate{
+		{Day: day(6), Sessions: 1, DurationMs: 2 * 60000},
+		{Day: day(7), Sessions: 2, DurationMs: 20 * 60000},
+		{Day: day(13), Sessions: 1, DurationMs: 8 * 60000},
+		{Day: day(15), Sessions: 1, DurationMs: 12 * 60000},
+	}
+	var buf bytes.Buffer
+	if err := RenderCalendar(&buf, daily, now, 2, 10); err != nil {
+		t.Fatalf("RenderCalendar failed: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	want := []string{
+		"2024-05-06 – 2024-05-15",
+		"Mon  - =",
+		"Tue  # .",
+		"Wed  . #",
+		"Thu  .",
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Fatalf("line %d: expected %q, got %q\n%s", i, line, lines[i], buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "# goal of 10 min met") {
+		t.Fatalf("expected a goal legend:\n%s", buf.String())
+	}
+}
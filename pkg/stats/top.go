@@ -4,7 +4,7 @@ package stats
 import (
 	"sort"
 
-	"github.com/verte-zerg/tuipe/internal/model"
+	"github.com/verte-zerg/tuipe/pkg/model"
 )
 
 // TopCharsByFrequency returns the top N characters by total frequency.
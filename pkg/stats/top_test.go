@@ -3,7 +3,7 @@ package stats
 import (
 	"testing"
 
-	"github.com/verte-zerg/tuipe/internal/model"
+	"github.com/verte-zerg/tuipe/pkg/model"
 )
 
 func TestTopCharsByFrequency(t *testing.T) {
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// RenderTrend describes the learning curves in words: each moving average at the first
+// and the latest session, the change between them and the best value. It is the text
+// alternative to RenderCurves for screen readers.
+func RenderTrend(w io.Writer, sessions []model.SessionAggregate, window int) error {
+	return RenderTrendWithFormula(w, sessions, model.WPMChars, window)
+}
+
+// RenderTrendWithFormula is RenderTrend with the speed under the WPM formula.
+func RenderTrendWithFormula(w io.Writer, sessions []model.SessionAggregate, formula string, window int) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "Trend (moving average of %d sessions)\n", max(window, 1)); err != nil {
+		return err
+	}
+	for _, s := range CurveSeriesWithFormula(sessions, formula, window) {
+		first, last := s.Values[0], s.Values[len(s.Values)-1]
+		best := first
+		for _, v := range s.Values {
+			best = max(best, v)
+		}
+		unit := ""
+		if s.Name == "Accuracy" {
+			unit = "%"
+		}
+		if _, err := fmt.Fprintf(w, "%s: %.1f%s at the start, %.1f%s now (%+.1f), best %.1f%s\n",
+			s.Name, first, unit, last, unit, last-first, best, unit); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "")
+	return err
+}
@@ -0,0 +1,27 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestRenderTrend(t *testing.T) {
+	sessions := []model.SessionAggregate{
+		{Correct: 200, DurationMs: 60000},
+		{Correct: 300, DurationMs: 60000},
+		{Correct: 250, Incorrect: 250, DurationMs: 60000},
+	}
+	var buf bytes.Buffer
+	if err := RenderTrend(&buf, sessions, 1); err != nil {
+		t.Fatalf("RenderTrend failed: %v", err)
+	}
+	want := []string{
+		"WPM: 40.0 at the start, 50.0 now (+10.0), best 60.0",
+		"Accuracy: 100.0% at the start, 50.0% now (-50.0), best 100.0%",
+	}
+	if !containsAll(buf.String(), want) {
+		t.Fatalf("unexpected trend:\n%s", buf.String())
+	}
+}
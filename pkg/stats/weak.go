@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// SelectWeakChars selects the lowest-accuracy characters from aggregates.
+func SelectWeakChars(aggs []model.CharAggregate, top int) map[rune]struct{} {
+	weakSet := map[rune]struct{}{}
+	if len(aggs) == 0 {
+		return weakSet
+	}
+	candidates := make([]model.CharAggregate, len(aggs))
+	copy(candidates, aggs)
+	sort.Slice(candidates, func(i, j int) bool {
+		ai := accuracy(candidates[i])
+		aj := accuracy(candidates[j])
+		if ai == aj {
+			return candidates[i].Char < candidates[j].Char
+		}
+		return ai < aj
+	})
+	if top <= 0 || top > len(candidates) {
+		top = len(candidates)
+	}
+	for i := 0; i < top; i++ {
+		runes := []rune(candidates[i].Char)
+		if len(runes) > 0 {
+			weakSet[runes[0]] = struct{}{}
+		}
+	}
+	return weakSet
+}
+
+func accuracy(agg model.CharAggregate) float64 {
+	total := agg.Correct + agg.Incorrect
+	if total == 0 {
+		return 1.0
+	}
+	return float64(agg.Correct) / float64(total)
+}
+
+// WorstChars returns up to n characters from aggs, lowest accuracy first; ties go to the
+// slower mean latency. Characters that were never typed are skipped.
+func WorstChars(aggs []model.CharAggregate, n int) []model.CharAggregate {
+	worst := make([]model.CharAggregate, 0, len(aggs))
+	for _, agg := range aggs {
+		if agg.Correct+agg.Incorrect > 0 {
+			worst = append(worst, agg)
+		}
+	}
+	sort.Slice(worst, func(i, j int) bool {
+		ai, aj := accuracy(worst[i]), accuracy(worst[j])
+		if ai != aj {
+			return ai < aj
+		}
+		li, lj := MeanLatencyMs(worst[i]), MeanLatencyMs(worst[j])
+		if li != lj {
+			return li > lj
+		}
+		return worst[i].Char < worst[j].Char
+	})
+	if n >= 0 && n < len(worst) {
+		worst = worst[:n]
+	}
+	return worst
+}
+
+// MeanLatencyMs is the average time to type the character correctly, or 0 without samples.
+func MeanLatencyMs(agg model.CharAggregate) float64 {
+	if agg.LatencyCount == 0 {
+		return 0
+	}
+	return float64(agg.LatencySumMs) / float64(agg.LatencyCount)
+}
+
+// WeakFocusSuggestion returns the least accurate character typed at least minSamples
+// times, when its accuracy is below threshold; ok is false when no character qualifies.
+func WeakFocusSuggestion(aggs []model.CharAggregate, minSamples int, threshold float64) (model.CharAggregate, bool) {
+	for _, agg := range WorstChars(aggs, -1) {
+		if agg.Correct+agg.Incorrect < minSamples {
+			continue
+		}
+		if accuracy(agg) < threshold {
+			return agg, true
+		}
+		break
+	}
+	return model.CharAggregate{}, false
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestWorstChars(t *testing.T) {
+	aggs := []model.CharAggregate{
+		{Char: "a", Correct: 9, Incorrect: 1, LatencySumMs: 900, LatencyCount: 9},
+		{Char: "b", Correct: 5, Incorrect: 5},
+		{Char: "c", Correct: 9, Incorrect: 1, LatencySumMs: 2700, LatencyCount: 9},
+		{Char: "d"},
+		{Char: "e", Correct: 4},
+	}
+	worst := WorstChars(aggs, 3)
+	got := make([]string, 0, len(worst))
+	for _, agg := range worst {
+		got = append(got, agg.Char)
+	}
+	if len(got) != 3 || got[0] != "b" || got[1] != "c" || got[2] != "a" {
+		t.Fatalf("unexpected worst chars: %v", got)
+	}
+	if all := WorstChars(aggs, 10); len(all) != 4 {
+		t.Fatalf("expected untyped chars to be skipped, got %d", len(all))
+	}
+	if ms := MeanLatencyMs(aggs[2]); ms != 300 {
+		t.Fatalf("unexpected mean latency %v", ms)
+	}
+}
+
+func TestWeakFocusSuggestion(t *testing.T) {
+	aggs := []model.CharAggregate{
+		{Char: "a", Correct: 95, Incorrect: 5},
+		{Char: "p", Correct: 42, Incorrect: 8},
+		{Char: "q", Correct: 1, Incorrect: 2},
+	}
+	agg, ok := WeakFocusSuggestion(aggs, 20, 0.9)
+	if !ok || agg.Char != "p" {
+		t.Fatalf("expected p to be suggested, got %+v (ok=%v)", agg, ok)
+	}
+	if _, ok := WeakFocusSuggestion(aggs, 20, 0.8); ok {
+		t.Fatalf("expected no suggestion above the threshold")
+	}
+	if _, ok := WeakFocusSuggestion(aggs[2:], 20, 0.9); ok {
+		t.Fatalf("expected rarely typed characters to be skipped")
+	}
+}
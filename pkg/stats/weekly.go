@@ -0,0 +1,198 @@
+package stats
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// weeklyBarWidth is the width of the longest bar in the weekly report charts.
+const weeklyBarWidth = 30
+
+// weekdayNames label the days of a week, Monday first.
+var weekdayNames = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// WeekSummary totals one week of practice, Monday to Sunday.
+type WeekSummary struct {
+	Start time.Time
+	Total DaySummary
+	Days  [7]DaySummary
+}
+
+// ActiveDays counts the days of the week with practice.
+func (w WeekSummary) ActiveDays() int {
+	n := 0
+	for _, d := range w.Days {
+		if d.Sessions > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// GoalDays counts the days of the week that reached a goal of goalMinutes.
+func (w WeekSummary) GoalDays(goalMinutes int) int {
+	n := 0
+	for _, d := range w.Days {
+		if GoalMet(d.DurationMs, goalMinutes) {
+			n++
+		}
+	}
+	return n
+}
+
+// WeeklyReport compares a week of practice with the week before it.
+type WeeklyReport struct {
+	Week     WeekSummary
+	Previous WeekSummary
+	Streak   int
+	// GoalMinutes is the daily practice goal; 0 means no goal is set.
+	GoalMinutes int
+}
+
+// WeekStart returns local midnight of the Monday of t's week.
+func WeekStart(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// SummarizeWeek totals the sessions that ended in the week starting at start; other
+// sessions are ignored.
+func SummarizeWeek(start time.Time, sessions []model.SessionAggregate) WeekSummary {
+	end := start.AddDate(0, 0, 7)
+	var week []model.SessionAggregate
+	var days [7][]model.SessionAggregate
+	for _, s := range sessions {
+		at := s.EndedAt.Local()
+		if at.Before(start) || !at.Before(end) {
+			continue
+		}
+		week = append(week, s)
+		for i := 6; i >= 0; i-- {
+			if !at.Before(start.AddDate(0, 0, i)) {
+				days[i] = append(days[i], s)
+				break
+			}
+		}
+	}
+	summary := WeekSummary{Start: start, Total: SummarizeDay(start, week)}
+	for i := range days {
+		summary.Days[i] = SummarizeDay(start.AddDate(0, 0, i), days[i])
+	}
+	return summary
+}
+
+// weeklyRow is one line of the weekly comparison table.
+type weeklyRow struct {
+	label, week, previous, change string
+}
+
+func weeklyRows(r WeeklyReport) []weeklyRow {
+	cur, prev := r.Week.Total, r.Previous.Total
+	minutes := func(ms int64) float64 { return (time.Duration(ms) * time.Millisecond).Minutes() }
+	rows := []weeklyRow{
+		{"Sessions", fmt.Sprint(cur.Sessions), fmt.Sprint(prev.Sessions), fmt.Sprintf("%+d", cur.Sessions-prev.Sessions)},
+		{"Practice", fmt.Sprintf("%.1f min", minutes(cur.DurationMs)), fmt.Sprintf("%.1f min", minutes(prev.DurationMs)), fmt.Sprintf("%+.1f min", minutes(cur.DurationMs)-minutes(prev.DurationMs))},
+		{"Active days", fmt.Sprint(r.Week.ActiveDays()), fmt.Sprint(r.Previous.ActiveDays()), fmt.Sprintf("%+d", r.Week.ActiveDays()-r.Previous.ActiveDays())},
+	}
+	if r.GoalMinutes > 0 {
+		goal, prevGoal := r.Week.GoalDays(r.GoalMinutes), r.Previous.GoalDays(r.GoalMinutes)
+		rows = append(rows, weeklyRow{fmt.Sprintf("Goal days (%d min)", r.GoalMinutes), fmt.Sprint(goal), fmt.Sprint(prevGoal), fmt.Sprintf("%+d", goal-prevGoal)})
+	}
+	// Speed and accuracy only compare when both weeks have sessions.
+	metric := func(label string, v, pv float64, format, unit string) weeklyRow {
+		row := weeklyRow{label, "–", "–", "–"}
+		if cur.Sessions > 0 {
+			row.week = fmt.Sprintf(format, v)
+		}
+		if prev.Sessions > 0 {
+			row.previous = fmt.Sprintf(format, pv)
+		}
+		if cur.Sessions > 0 && prev.Sessions > 0 {
+			row.change = fmt.Sprintf("%+.1f%s", v-pv, unit)
+		}
+		return row
+	}
+	return append(rows,
+		metric("Avg WPM", cur.AvgWPM, prev.AvgWPM, "%.1f", ""),
+		metric("Best WPM", cur.BestWPM, prev.BestWPM, "%.1f", ""),
+		metric("Accuracy", cur.Accuracy*100, prev.Accuracy*100, "%.1f%%", " pts"),
+	)
+}
+
+// RenderWeeklyReport prints a weekly report with the totals of the week, the change
+// from the week before and bar charts of practice time and speed per day. With
+// markdown, the report is a Markdown document; otherwise plain text.
+func RenderWeeklyReport(w io.Writer, r WeeklyReport, markdown bool) error {
+	span := fmt.Sprintf("%s – %s", r.Week.Start.Format("2006-01-02"), r.Week.Start.AddDate(0, 0, 6).Format("2006-01-02"))
+	rows := weeklyRows(r)
+	days := "days"
+	if r.Streak == 1 {
+		days = "day"
+	}
+	streak := fmt.Sprintf("Streak: %d %s", r.Streak, days)
+	minutesChart := weeklyChart(r.Week, func(d DaySummary) (float64, bool) {
+		return (time.Duration(d.DurationMs) * time.Millisecond).Minutes(), d.Sessions > 0
+	})
+	wpmChart := weeklyChart(r.Week, func(d DaySummary) (float64, bool) { return d.AvgWPM, d.Sessions > 0 })
+
+	var lines []string
+	if markdown {
+		lines = append(lines, "# tuipe weekly report: "+span, "",
+			"| | This week | Previous week | Change |",
+			"| --- | ---: | ---: | ---: |")
+		for _, row := range rows {
+			lines = append(lines, fmt.Sprintf("| %s | %s | %s | %s |", row.label, row.week, row.previous, row.change))
+		}
+		lines = append(lines, "", streak, "", "## Practice minutes per day", "", "```text")
+		lines = append(lines, minutesChart...)
+		lines = append(lines, "```", "", "## WPM per day", "", "```text")
+		lines = append(lines, wpmChart...)
+		lines = append(lines, "```")
+	} else {
+		labelWidth := 0
+		for _, row := range rows {
+			labelWidth = max(labelWidth, len(row.label))
+		}
+		lines = append(lines, "tuipe weekly report · "+span, "",
+			fmt.Sprintf("%-*s  %12s  %12s  %12s", labelWidth, "", "This week", "Previous", "Change"))
+		for _, row := range rows {
+			lines = append(lines, fmt.Sprintf("%-*s  %12s  %12s  %12s", labelWidth, row.label, row.week, row.previous, row.change))
+		}
+		lines = append(lines, "", streak, "", "Practice minutes per day")
+		lines = append(lines, minutesChart...)
+		lines = append(lines, "", "WPM per day")
+		lines = append(lines, wpmChart...)
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// weeklyChart draws one bar per weekday scaled to the largest value of the week;
+// value reports false for days without practice.
+func weeklyChart(week WeekSummary, value func(DaySummary) (float64, bool)) []string {
+	var top float64
+	for _, d := range week.Days {
+		if v, ok := value(d); ok && v > top {
+			top = v
+		}
+	}
+	lines := make([]string, 0, len(week.Days))
+	for i, d := range week.Days {
+		v, ok := value(d)
+		if !ok {
+			lines = append(lines, weekdayNames[i])
+			continue
+		}
+		n := 0
+		if top > 0 {
+			n = max(1, int(v/top*weeklyBarWidth+0.5))
+		}
+		lines = append(lines, fmt.Sprintf("%s  %-*s  %.1f", weekdayNames[i], weeklyBarWidth, strings.Repeat("#", n), v))
+	}
+	return lines
+}
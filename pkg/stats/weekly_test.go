@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+func TestSummarizeWeekSplitsDays(t *testing.T) {
+	start := WeekStart(time.Date(2024, 5, 10, 15, 0, 0, 0, time.Local))
+	if want := time.Date(2024, 5, 6, 0, 0, 0, 0, time.Local); !start.Equal(want) {
+		t.Fatalf("expected week to start on %v, got %v", want, start)
+	}
+	week := SummarizeWeek(start, []model.SessionAggregate{
+		{EndedAt: start.Add(-time.Minute), Correct: 100, DurationMs: 60000},
+		{EndedAt: start.Add(9 * time.Hour), Correct: 250, DurationMs: 60000},
+		{EndedAt: start.AddDate(0, 0, 4).Add(20 * time.Hour), Correct: 200, Incorrect: 50, DurationMs: 30000},
+		{EndedAt: start.AddDate(0, 0, 7), Correct: 100, DurationMs: 60000},
+	})
+	if week.Total.Sessions != 2 || week.ActiveDays() != 2 || week.Days[0].Sessions != 1 || week.Days[4].Sessions != 1 {
+		t.Fatalf("unexpected week: %+v", week)
+	}
+	if week.GoalDays(1) != 1 {
+		t.Fatalf("expected one day with a 1 min goal met, got %d", week.GoalDays(1))
+	}
+}
+
+func TestRenderWeeklyReportComparesWeeks(t *testing.T) {
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.Local)
+	report := WeeklyReport{
+		Week:     SummarizeWeek(start, []model.SessionAggregate{{EndedAt: start.Add(time.Hour), Correct: 300, DurationMs: 60000}}),
+		Previous: SummarizeWeek(start.AddDate(0, 0, -7), []model.SessionAggregate{{EndedAt: start.AddDate(0, 0, -2), Correct: 250, DurationMs: 60000}}),
+		Streak:   1,
+	}
+	var md bytes.Buffer
+	if err := RenderWeeklyReport(&md, report, true); err != nil {
+		t.Fatalf("RenderWeeklyReport failed: %v", err)
+	}
+	if !containsAll(md.String(), []string{"# tuipe weekly report: 2024-05-06 – 2024-05-12", "| Avg WPM | 60.0 | 50.0 | +10.0 |", "Streak: 1 day", "```text"}) {
+		t.Fatalf("unexpected markdown report:\n%s", md.String())
+	}
+	if !strings.Contains(md.String(), "Mon  "+strings.Repeat("#", weeklyBarWidth)+"  1.0") {
+		t.Fatalf("expected a full bar for Monday:\n%s", md.String())
+	}
+
+	report.Previous = SummarizeWeek(start.AddDate(0, 0, -7), nil)
+	var text bytes.Buffer
+	if err := RenderWeeklyReport(&text, report, false); err != nil {
+		t.Fatalf("RenderWeeklyReport failed: %v", err)
+	}
+	if strings.Contains(text.String(), "|") || !strings.Contains(text.String(), "+1.0 min") {
+		t.Fatalf("unexpected text report:\n%s", text.String())
+	}
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	backupPrefix = "tuipe-"
+	backupSuffix = ".db"
+	backupLayout = "20060102-150405"
+)
+
+// Backup snapshots the database into dir with VACUUM INTO and keeps at most keep
+// backups (keep <= 0 disables rotation). It returns the path of the new backup.
+func (s *Store) Backup(ctx context.Context, dir string, keep int) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	stamp := time.Now().Format(backupLayout)
+	path := filepath.Join(dir, backupPrefix+stamp+backupSuffix)
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		// "_" sorts after ".", keeping same-second backups in creation order.
+		path = filepath.Join(dir, fmt.Sprintf("%s%s_%02d%s", backupPrefix, stamp, i, backupSuffix))
+	}
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := rotateBackups(dir, keep); err != nil {
+		return path, fmt.Errorf("failed to rotate backups: %w", err)
+	}
+	return path, nil
+}
+
+// ListBackups returns backup file paths in dir, oldest first.
+func ListBackups(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func rotateBackups(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	paths, err := ListBackups(dir)
+	if err != nil {
+		return err
+	}
+	for len(paths) > keep {
+		if err := os.Remove(paths[0]); err != nil {
+			return err
+		}
+		paths = paths[1:]
+	}
+	return nil
+}
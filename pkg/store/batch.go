@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// batchRows bounds rows per multi-row INSERT, keeping bound parameters well under SQLite's limit.
+const batchRows = 500
+
+// insertRows runs prefix + "(?, ...), (?, ...)" + suffix in batches, with args(i) supplying row i.
+func insertRows(ctx context.Context, db execer, prefix, suffix string, cols, count int, args func(i int) []any) error {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", cols), ", ") + ")"
+	for start := 0; start < count; start += batchRows {
+		end := start + batchRows
+		if end > count {
+			end = count
+		}
+		values := make([]string, 0, end-start)
+		params := make([]any, 0, (end-start)*cols)
+		for i := start; i < end; i++ {
+			values = append(values, row)
+			params = append(params, args(i)...)
+		}
+		if _, err := db.ExecContext(ctx, prefix+strings.Join(values, ", ")+suffix, params...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertCharStats(ctx context.Context, db execer, sessionID int64, chars []model.CharStats) error {
+	return insertRows(ctx, db,
+		`INSERT INTO session_char_stats (session_id, char, correct, incorrect, latency_sum_ms, latency_count) VALUES `, ``,
+		6, len(chars), func(i int) []any {
+			cs := chars[i]
+			return []any{sessionID, cs.Char, cs.Correct, cs.Incorrect, cs.LatencySumMs, cs.LatencyCount}
+		})
+}
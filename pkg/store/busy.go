@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	// busyTimeoutMs is how long SQLite waits on a locked database before returning SQLITE_BUSY.
+	busyTimeoutMs = 5000
+	busyRetries   = 5
+	busyBackoff   = 100 * time.Millisecond
+)
+
+// dsn enables WAL journaling and a busy timeout so a practice session and a stats view
+// can share the database from separate processes.
+func dsn(path string) string {
+	return fmt.Sprintf("%s?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_txlock=immediate", path, busyTimeoutMs)
+}
+
+// withTx runs fn in a transaction, retrying the whole transaction when the database is busy.
+// OnChange hooks run once the transaction has committed.
+func (s *Store) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	err := retryBusy(ctx, func() (err error) {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				if rerr := tx.Rollback(); rerr != nil {
+					// Best-effort rollback.
+					_ = rerr
+				}
+			}
+		}()
+		if err = fn(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		return err
+	}
+	s.notifyChange()
+	return nil
+}
+
+func isBusy(err error) bool {
+	var serr *sqlite.Error
+	if !errors.As(err, &serr) {
+		return false
+	}
+	code := serr.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// retryBusy runs fn again with backoff while it fails with SQLITE_BUSY or SQLITE_LOCKED.
+func retryBusy(ctx context.Context, fn func() error) error {
+	delay := busyBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isBusy(err) || attempt >= busyRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
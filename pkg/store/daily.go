@@ -0,0 +1,293 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+const dayLayout = "2006-01-02"
+
+// dailyTables creates the pre-aggregated per-day tables.
+var dailyTables = []string{
+	`CREATE TABLE IF NOT EXISTS daily_aggregates (
+		day TEXT NOT NULL,
+		lang TEXT NOT NULL,
+		sessions INTEGER NOT NULL,
+		correct INTEGER NOT NULL,
+		incorrect INTEGER NOT NULL,
+		duration_ms INTEGER NOT NULL,
+		PRIMARY KEY (day, lang)
+	);`,
+	`CREATE TABLE IF NOT EXISTS daily_char_aggregates (
+		day TEXT NOT NULL,
+		lang TEXT NOT NULL,
+		char TEXT NOT NULL,
+		correct INTEGER NOT NULL,
+		incorrect INTEGER NOT NULL,
+		latency_sum_ms INTEGER NOT NULL,
+		latency_count INTEGER NOT NULL,
+		PRIMARY KEY (day, lang, char)
+	);`,
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func upsertDaily(ctx context.Context, db execer, stats model.SessionStats, chars []model.CharStats) error {
+	day := stats.EndedAt.Local().Format(dayLayout)
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO daily_aggregates (day, lang, sessions, correct, incorrect, duration_ms)
+		 VALUES (?, ?, 1, ?, ?, ?)
+		 ON CONFLICT(day, lang) DO UPDATE SET
+			sessions = sessions + 1,
+			correct = correct + excluded.correct,
+			incorrect = incorrect + excluded.incorrect,
+			duration_ms = duration_ms + excluded.duration_ms`,
+		day, stats.Lang, stats.CorrectNonSpace, stats.IncorrectNonSpace, stats.DurationMs,
+	); err != nil {
+		return err
+	}
+	return insertRows(ctx, db,
+		`INSERT INTO daily_char_aggregates (day, lang, char, correct, incorrect, latency_sum_ms, latency_count) VALUES `,
+		` ON CONFLICT(day, lang, char) DO UPDATE SET
+			correct = correct + excluded.correct,
+			incorrect = incorrect + excluded.incorrect,
+			latency_sum_ms = latency_sum_ms + excluded.latency_sum_ms,
+			latency_count = latency_count + excluded.latency_count`,
+		7, len(chars), func(i int) []any {
+			cs := chars[i]
+			return []any{day, stats.Lang, cs.Char, cs.Correct, cs.Incorrect, cs.LatencySumMs, cs.LatencyCount}
+		})
+}
+
+// RebuildDailyAggregates recomputes the per-day tables from raw session data.
+func (s *Store) RebuildDailyAggregates(ctx context.Context) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return rebuildDaily(ctx, tx)
+	})
+}
+
+func rebuildDaily(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM daily_aggregates`); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM daily_char_aggregates`); err != nil {
+		return err
+	}
+
+	sessions, err := loadRawSessions(ctx, tx)
+	if err != nil {
+		return err
+	}
+	chars, err := loadRawCharStats(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for _, raw := range sessions {
+		if err := upsertDaily(ctx, tx, raw.stats, chars[raw.id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type rawSession struct {
+	id    int64
+	stats model.SessionStats
+}
+
+func loadRawSessions(ctx context.Context, tx *sql.Tx) ([]rawSession, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, ended_at, lang, correct_nonspace, incorrect_nonspace, duration_ms FROM sessions WHERE completed = 1 AND ignored = 0`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+	var out []rawSession
+	for rows.Next() {
+		var raw rawSession
+		var endedAt string
+		if err := rows.Scan(&raw.id, &endedAt, &raw.stats.Lang, &raw.stats.CorrectNonSpace, &raw.stats.IncorrectNonSpace, &raw.stats.DurationMs); err != nil {
+			return nil, err
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, endedAt)
+		if err != nil {
+			return nil, err
+		}
+		raw.stats.EndedAt = parsed
+		out = append(out, raw)
+	}
+	return out, rows.Err()
+}
+
+func loadRawCharStats(ctx context.Context, db querier) (map[int64][]model.CharStats, error) {
+	rows, err := db.QueryContext(ctx, `SELECT session_id, char, correct, incorrect, latency_sum_ms, latency_count FROM session_char_stats`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+	out := map[int64][]model.CharStats{}
+	for rows.Next() {
+		var id int64
+		var cs model.CharStats
+		if err := rows.Scan(&id, &cs.Char, &cs.Correct, &cs.Incorrect, &cs.LatencySumMs, &cs.LatencyCount); err != nil {
+			return nil, err
+		}
+		out[id] = append(out[id], cs)
+	}
+	return out, rows.Err()
+}
+
+// CountSessions returns the number of sessions matching the filters of cfg.
+func (s *Store) CountSessions(ctx context.Context, cfg model.StatsConfig) (int, error) {
+	where, args := sessionFilter(cfg)
+	var count int
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM sessions WHERE %s`, where), args...).Scan(&count)
+	return count, err
+}
+
+// ListDailyAggregates returns per-day totals filtered by lang and since, oldest first.
+func (s *Store) ListDailyAggregates(ctx context.Context, cfg model.StatsConfig) ([]model.DailyAggregate, error) {
+	where, args := dailyFilter(cfg)
+	query := fmt.Sprintf(`SELECT day, SUM(sessions), SUM(correct), SUM(incorrect), SUM(duration_ms)
+		FROM daily_aggregates
+		WHERE %s
+		GROUP BY day
+		ORDER BY day ASC`, where)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.DailyAggregate
+	for rows.Next() {
+		var agg model.DailyAggregate
+		var day string
+		if err := rows.Scan(&day, &agg.Sessions, &agg.Correct, &agg.Incorrect, &agg.DurationMs); err != nil {
+			return nil, err
+		}
+		parsed, err := time.ParseInLocation(dayLayout, day, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		agg.Day = parsed
+		result = append(result, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListLangTotals returns all-time totals per language from the daily tables, ordered by lang.
+func (s *Store) ListLangTotals(ctx context.Context) ([]model.LangTotals, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT lang, SUM(sessions), SUM(correct), SUM(incorrect), SUM(duration_ms)
+		FROM daily_aggregates
+		GROUP BY lang
+		ORDER BY lang ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.LangTotals
+	for rows.Next() {
+		var t model.LangTotals
+		if err := rows.Scan(&t.Lang, &t.Sessions, &t.Correct, &t.Incorrect, &t.DurationMs); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+// ListDailyCharAggregates aggregates per-day character stats filtered by lang and since.
+func (s *Store) ListDailyCharAggregates(ctx context.Context, cfg model.StatsConfig) ([]model.CharAggregate, error) {
+	where, args := dailyFilter(cfg)
+	query := fmt.Sprintf(`SELECT char, SUM(correct), SUM(incorrect), SUM(latency_sum_ms), SUM(latency_count)
+		FROM daily_char_aggregates
+		WHERE %s
+		GROUP BY char`, where)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.CharAggregate
+	for rows.Next() {
+		var agg model.CharAggregate
+		if err := rows.Scan(&agg.Char, &agg.Correct, &agg.Incorrect, &agg.LatencySumMs, &agg.LatencyCount); err != nil {
+			return nil, err
+		}
+		result = append(result, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// dailyFilter applies the lang and since filters of cfg. The daily tables are not kept per
+// word list, mode or completion, so callers answer those filters from the sessions table.
+func dailyFilter(cfg model.StatsConfig) (string, []any) {
+	clauses := []string{"1=1"}
+	args := []any{}
+	if cfg.Lang != "" {
+		clauses = append(clauses, "lang = ?")
+		args = append(args, cfg.Lang)
+	}
+	if cfg.Since != nil {
+		clauses = append(clauses, "day >= ?")
+		args = append(args, cfg.Since.Local().Format(dayLayout))
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+func (s *Store) ensureDailyAggregates() error {
+	var daily, sessions int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM daily_aggregates`).Scan(&daily); err != nil {
+		return err
+	}
+	if daily > 0 {
+		return nil
+	}
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM sessions WHERE completed = 1 AND ignored = 0`).Scan(&sessions); err != nil {
+		return err
+	}
+	if sessions == 0 {
+		return nil
+	}
+	return s.RebuildDailyAggregates(context.Background())
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// ExportFormatVersion identifies the JSON layout of Export.
+const ExportFormatVersion = 1
+
+// Export is the portable JSON document written by `tuipe db export`.
+type Export struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Sessions   []SessionRecord `json:"sessions"`
+}
+
+// SessionRecord pairs a session with its per-character stats.
+type SessionRecord struct {
+	ID      int64              `json:"id"`
+	Session model.SessionStats `json:"session"`
+	Chars   []model.CharStats  `json:"chars"`
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ExportSessions returns every session with its char stats, oldest first.
+func (s *Store) ExportSessions(ctx context.Context) (Export, error) {
+	records, err := listSessionRecords(ctx, s.db)
+	if err != nil {
+		return Export{}, err
+	}
+	return Export{
+		Version:    ExportFormatVersion,
+		ExportedAt: time.Now().UTC(),
+		Sessions:   records,
+	}, nil
+}
+
+// ImportSessions inserts records under new IDs in a single transaction and returns the count.
+func (s *Store) ImportSessions(ctx context.Context, records []SessionRecord) (int, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, rec := range records {
+			if _, err := insertSession(ctx, tx, rec.Session, rec.Chars); err != nil {
+				return fmt.Errorf("session %d: %w", rec.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+func listSessionRecords(ctx context.Context, db querier) ([]SessionRecord, error) {
+	rows, err := db.QueryContext(ctx, `SELECT `+sessionColumns+` ORDER BY s.ended_at ASC, s.id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	records := []SessionRecord{}
+	for rows.Next() {
+		id, stats, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, SessionRecord{ID: id, Session: stats})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	chars, err := loadRawCharStats(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i].Chars = chars[records[i].ID]
+	}
+	return records, nil
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SetSessionIgnored marks a session ignored, keeping it out of every aggregate and curve,
+// or counts it again, and rebuilds the daily aggregates. It bumps the revision counter,
+// since the row count stays the same.
+func (s *Store) SetSessionIgnored(ctx context.Context, id int64, ignored bool) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE sessions SET ignored = ? WHERE id = ?`, ignored, id)
+		if err != nil {
+			return err
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return fmt.Errorf("session %d %w", id, ErrNotFound)
+		}
+		if err := bumpRevision(ctx, tx); err != nil {
+			return err
+		}
+		return rebuildDaily(ctx, tx)
+	})
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MergeResult reports how many sessions a merge added and skipped as duplicates.
+type MergeResult struct {
+	Added   int
+	Skipped int
+}
+
+type fingerprint struct {
+	startedAt  int64
+	lang       string
+	durationMs int64
+}
+
+// MergeFrom imports sessions from another tuipe database, skipping sessions whose
+// (started_at, lang, duration) fingerprint already exists. The other database is left untouched.
+func (s *Store) MergeFrom(ctx context.Context, path string) (MergeResult, error) {
+	records, err := readForeignRecords(ctx, path)
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return s.AddSessions(ctx, records)
+}
+
+// AddSessions inserts records under new IDs in a single transaction, skipping sessions
+// whose (started_at, lang, duration) fingerprint already exists.
+func (s *Store) AddSessions(ctx context.Context, records []SessionRecord) (MergeResult, error) {
+	var result MergeResult
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		result = MergeResult{}
+		seen, err := loadFingerprints(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			fp := fingerprint{rec.Session.StartedAt.UnixNano(), rec.Session.Lang, rec.Session.DurationMs}
+			if _, ok := seen[fp]; ok {
+				result.Skipped++
+				continue
+			}
+			if _, err := insertSession(ctx, tx, rec.Session, rec.Chars); err != nil {
+				return fmt.Errorf("session %d: %w", rec.ID, err)
+			}
+			seen[fp] = struct{}{}
+			result.Added++
+		}
+		return nil
+	})
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
+
+func loadFingerprints(ctx context.Context, db querier) (map[fingerprint]struct{}, error) {
+	rows, err := db.QueryContext(ctx, `SELECT started_at, lang, duration_ms FROM sessions`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	seen := map[fingerprint]struct{}{}
+	for rows.Next() {
+		var startedAt, lang string
+		var durationMs int64
+		if err := rows.Scan(&startedAt, &lang, &durationMs); err != nil {
+			return nil, err
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, startedAt)
+		if err != nil {
+			return nil, err
+		}
+		seen[fingerprint{parsed.UnixNano(), lang, durationMs}] = struct{}{}
+	}
+	return seen, rows.Err()
+}
+
+// readForeignRecords loads sessions from a copy of path so migrations never touch the original.
+func readForeignRecords(ctx context.Context, path string) ([]SessionRecord, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "tuipe-merge-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rerr := os.RemoveAll(dir); rerr != nil {
+			// Best-effort temp cleanup.
+			_ = rerr
+		}
+	}()
+
+	copyPath := filepath.Join(dir, "other.db")
+	if err := copyFile(path, copyPath); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path + "-wal"); err == nil {
+		if err := copyFile(path+"-wal", copyPath+"-wal"); err != nil {
+			return nil, err
+		}
+	}
+
+	other, err := Open(copyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := other.Close(); cerr != nil {
+			// Best-effort close of the temporary copy.
+			_ = cerr
+		}
+	}()
+	return listSessionRecords(ctx, other.db)
+}
+
+func copyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := in.Close(); cerr != nil {
+			// Best-effort close after read.
+			_ = cerr
+		}
+	}()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// mistakeTable holds the mistake bank: recently mistyped words with a decaying weight.
+const mistakeTable = `CREATE TABLE IF NOT EXISTS mistake_words (
+	lang TEXT NOT NULL,
+	word TEXT NOT NULL,
+	weight REAL NOT NULL,
+	updated_at TEXT NOT NULL,
+	PRIMARY KEY (lang, word)
+);`
+
+// ListMistakeWords returns the mistake bank of lang with weights as last saved.
+func (s *Store) ListMistakeWords(ctx context.Context, lang string) ([]model.MistakeWord, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT lang, word, weight, updated_at FROM mistake_words WHERE lang = ?`, lang)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.MistakeWord
+	for rows.Next() {
+		var w model.MistakeWord
+		var updatedAt string
+		if err := rows.Scan(&w.Lang, &w.Word, &w.Weight, &updatedAt); err != nil {
+			return nil, err
+		}
+		if w.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, rows.Err()
+}
+
+// SaveMistakeWords inserts or replaces words of the mistake bank in a single transaction.
+// Words with a weight of zero or less are removed from the bank.
+func (s *Store) SaveMistakeWords(ctx context.Context, words []model.MistakeWord) error {
+	if len(words) == 0 {
+		return nil
+	}
+	var keep, drop []model.MistakeWord
+	for _, w := range words {
+		if w.Weight > 0 {
+			keep = append(keep, w)
+		} else {
+			drop = append(drop, w)
+		}
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, w := range drop {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM mistake_words WHERE lang = ? AND word = ?`, w.Lang, w.Word); err != nil {
+				return err
+			}
+		}
+		return insertRows(ctx, tx,
+			`INSERT OR REPLACE INTO mistake_words (lang, word, weight, updated_at) VALUES `, ``,
+			4, len(keep), func(i int) []any {
+				w := keep[i]
+				return []any{w.Lang, w.Word, w.Weight, w.UpdatedAt.UTC().Format(time.RFC3339Nano)}
+			})
+	})
+}
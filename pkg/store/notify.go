@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// revisionTable counts the session updates that leave the row count unchanged, such as
+// ignoring a session, in a single row.
+const revisionTable = `CREATE TABLE IF NOT EXISTS session_revision (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	changes INTEGER NOT NULL
+);`
+
+// Revision identifies the contents of the sessions table. Any insert or delete, and
+// ignoring a session, changes it, so readers in other processes can poll it to notice
+// new data.
+type Revision struct {
+	Sessions int
+	LastID   int64
+	Changes  int64
+}
+
+// changeHooks holds the callbacks registered with OnChange.
+type changeHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// OnChange registers fn to run after every write committed through this Store. fn runs
+// on the writing goroutine and must not block or call back into the Store's writes.
+func (s *Store) OnChange(fn func()) {
+	s.hooks.mu.Lock()
+	defer s.hooks.mu.Unlock()
+	s.hooks.fns = append(s.hooks.fns, fn)
+}
+
+func (s *Store) notifyChange() {
+	s.hooks.mu.Lock()
+	fns := append([]func(){}, s.hooks.fns...)
+	s.hooks.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// Revision returns the current revision of the sessions table. It also sees writes made
+// by other processes, which OnChange hooks do not.
+func (s *Store) Revision(ctx context.Context) (Revision, error) {
+	var rev Revision
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*), COALESCE(MAX(id), 0),
+		(SELECT COALESCE(MAX(changes), 0) FROM session_revision) FROM sessions`).Scan(&rev.Sessions, &rev.LastID, &rev.Changes)
+	return rev, err
+}
+
+// bumpRevision increments the change counter read by Revision.
+func bumpRevision(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO session_revision (id, changes) VALUES (1, 1)
+		ON CONFLICT(id) DO UPDATE SET changes = changes + 1`)
+	return err
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// CountSessionsBefore returns the number of sessions that ended before the given time.
+func (s *Store) CountSessionsBefore(ctx context.Context, before time.Time) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sessions WHERE ended_at < ?`, before.Format(time.RFC3339Nano)).Scan(&count)
+	return count, err
+}
+
+// PruneSessions deletes sessions that ended before the given time, along with their
+// char stats and stored text, and rebuilds the daily aggregates. It returns the count deleted.
+func (s *Store) PruneSessions(ctx context.Context, before time.Time) (int, error) {
+	var count int
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		cutoff := before.Format(time.RFC3339Nano)
+		ids := `SELECT id FROM sessions WHERE ended_at < ?`
+		if _, err := tx.ExecContext(ctx, `DELETE FROM session_char_stats WHERE session_id IN (`+ids+`)`, cutoff); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM session_texts WHERE session_id IN (`+ids+`)`, cutoff); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE ended_at < ?`, cutoff)
+		if err != nil {
+			return err
+		}
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		count = int(deleted)
+		return rebuildDaily(ctx, tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Vacuum rebuilds the database file to reclaim space from deleted rows.
+func (s *Store) Vacuum(ctx context.Context) error {
+	return retryBusy(ctx, func() error {
+		_, err := s.db.ExecContext(ctx, `VACUUM`)
+		return err
+	})
+}
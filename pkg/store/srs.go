@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+)
+
+// srsTable holds the spaced-repetition state of mistyped characters and words.
+const srsTable = `CREATE TABLE IF NOT EXISTS srs_items (
+	lang TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	item TEXT NOT NULL,
+	ease REAL NOT NULL,
+	interval_ms INTEGER NOT NULL,
+	reps INTEGER NOT NULL,
+	lapses INTEGER NOT NULL,
+	due_at TEXT NOT NULL,
+	reviewed_at TEXT NOT NULL,
+	PRIMARY KEY (lang, kind, item)
+);`
+
+// ListSRSItems returns the review items of lang.
+func (s *Store) ListSRSItems(ctx context.Context, lang string) ([]model.SRSItem, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT lang, kind, item, ease, interval_ms, reps, lapses, due_at, reviewed_at
+		FROM srs_items WHERE lang = ?`, lang)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.SRSItem
+	for rows.Next() {
+		var item model.SRSItem
+		var intervalMs int64
+		var dueAt, reviewedAt string
+		if err := rows.Scan(&item.Lang, &item.Kind, &item.Item, &item.Ease, &intervalMs, &item.Reps, &item.Lapses, &dueAt, &reviewedAt); err != nil {
+			return nil, err
+		}
+		item.Interval = time.Duration(intervalMs) * time.Millisecond
+		if item.DueAt, err = time.Parse(time.RFC3339Nano, dueAt); err != nil {
+			return nil, err
+		}
+		if item.ReviewedAt, err = time.Parse(time.RFC3339Nano, reviewedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, rows.Err()
+}
+
+// SaveSRSItems inserts or replaces review items in a single transaction.
+func (s *Store) SaveSRSItems(ctx context.Context, items []model.SRSItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return insertRows(ctx, tx,
+			`INSERT OR REPLACE INTO srs_items (lang, kind, item, ease, interval_ms, reps, lapses, due_at, reviewed_at) VALUES `, ``,
+			9, len(items), func(i int) []any {
+				item := items[i]
+				return []any{item.Lang, item.Kind, item.Item, item.Ease, item.Interval.Milliseconds(), item.Reps, item.Lapses,
+					item.DueAt.UTC().Format(time.RFC3339Nano), item.ReviewedAt.UTC().Format(time.RFC3339Nano)}
+			})
+	})
+}
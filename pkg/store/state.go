@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// stateTable holds small pieces of app state, such as hints already shown, by key.
+const stateTable = `CREATE TABLE IF NOT EXISTS app_state (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);`
+
+// GetState returns the value stored under key; ok is false when nothing is stored.
+func (s *Store) GetState(ctx context.Context, key string) (value string, ok bool, err error) {
+	err = s.db.QueryRowContext(ctx, `SELECT value FROM app_state WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// SetState stores value under key, replacing any previous value.
+func (s *Store) SetState(ctx context.Context, key, value string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO app_state (key, value) VALUES (?, ?)`, key, value)
+		return err
+	})
+}
@@ -0,0 +1,702 @@
+// Package store handles SQLite persistence.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/verte-zerg/tuipe/pkg/model"
+
+	_ "modernc.org/sqlite" // SQLite driver.
+)
+
+// schemaVersion is stored in PRAGMA user_version once migrations have run.
+const schemaVersion = 15
+
+// ErrNotFound is returned when a requested row does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Store wraps SQLite access for session data.
+type Store struct {
+	db    *sql.DB
+	hooks changeHooks
+}
+
+// Options controls optional behavior of OpenWithOptions.
+type Options struct {
+	// BackupDir, when set, receives a backup before migrations change an existing database.
+	BackupDir  string
+	BackupKeep int
+}
+
+// Open opens or creates the SQLite database and applies migrations.
+func Open(path string) (*Store, error) {
+	return OpenWithOptions(path, Options{})
+}
+
+// OpenWithOptions opens or creates the SQLite database and applies migrations.
+func OpenWithOptions(path string, opts Options) (*Store, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", dsn(path))
+	if err != nil {
+		return nil, err
+	}
+	store := &Store{db: db}
+	if opts.BackupDir != "" {
+		if err := store.backupBeforeMigrate(opts); err != nil {
+			if cerr := db.Close(); cerr != nil {
+				// Best-effort close on backup failure.
+				_ = cerr
+			}
+			return nil, err
+		}
+	}
+	if err := store.migrate(); err != nil {
+		if cerr := db.Close(); cerr != nil {
+			// Best-effort close on migration failure.
+			_ = cerr
+		}
+		return nil, err
+	}
+	return store, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS sessions (
+			id INTEGER PRIMARY KEY,
+			started_at TEXT NOT NULL,
+			ended_at TEXT NOT NULL,
+			lang TEXT NOT NULL,
+			words INTEGER NOT NULL,
+			caps_pct REAL NOT NULL,
+			punct_pct REAL NOT NULL,
+			punct_set TEXT NOT NULL,
+			wordlist_path TEXT NOT NULL,
+			correct_nonspace INTEGER NOT NULL,
+			incorrect_nonspace INTEGER NOT NULL,
+			duration_ms INTEGER NOT NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS session_char_stats (
+			session_id INTEGER NOT NULL,
+			char TEXT NOT NULL,
+			correct INTEGER NOT NULL,
+			incorrect INTEGER NOT NULL,
+			latency_sum_ms INTEGER NOT NULL,
+			latency_count INTEGER NOT NULL,
+			PRIMARY KEY (session_id, char)
+		);`,
+		`CREATE TABLE IF NOT EXISTS session_texts (
+			session_id INTEGER PRIMARY KEY,
+			target TEXT NOT NULL,
+			typed TEXT NOT NULL,
+			truncated INTEGER NOT NULL DEFAULT 0
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_sessions_ended_at ON sessions(ended_at);`,
+		`CREATE INDEX IF NOT EXISTS idx_session_char_stats_char ON session_char_stats(char);`,
+		`CREATE INDEX IF NOT EXISTS idx_sessions_lang_ended_at ON sessions(lang, ended_at);`,
+	}
+	stmts = append(stmts, dailyTables...)
+	stmts = append(stmts, srsTable, mistakeTable, stateTable, revisionTable)
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	columns := []struct {
+		table      string
+		name       string
+		definition string
+	}{
+		{"sessions", "first_key_ms", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "space_latency_sum_ms", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "space_latency_count", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "mode", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "focus_weak", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "weak_set", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "seed", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "words_typed", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "app_version", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "keyboard", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "layout", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "completed", "INTEGER NOT NULL DEFAULT 1"},
+		{"sessions", "wordlist_meta", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "source", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "wpm_formula", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "samples", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "params_hash", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "scoring", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "env", "TEXT NOT NULL DEFAULT ''"},
+		{"session_texts", "keystrokes", "TEXT NOT NULL DEFAULT ''"},
+		{"sessions", "ignored", "INTEGER NOT NULL DEFAULT 0"},
+		{"sessions", "wordlist_id", "TEXT NOT NULL DEFAULT ''"},
+	}
+	for _, col := range columns {
+		if err := s.addColumnIfMissing(col.table, col.name, col.definition); err != nil {
+			return err
+		}
+	}
+	if err := s.ensureDailyAggregates(); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion))
+	return err
+}
+
+// backupBeforeMigrate snapshots an existing database whose schema is older than schemaVersion.
+func (s *Store) backupBeforeMigrate(opts Options) error {
+	var version, tables int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version >= schemaVersion {
+		return nil
+	}
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sessions'`).Scan(&tables); err != nil {
+		return err
+	}
+	if tables == 0 {
+		return nil
+	}
+	if _, err := s.Backup(context.Background(), opts.BackupDir, opts.BackupKeep); err != nil {
+		return fmt.Errorf("failed to back up before migration: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) addColumnIfMissing(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// InsertSession stores a session and its per-character stats.
+// Incomplete sessions are not counted in the daily aggregates.
+func (s *Store) InsertSession(ctx context.Context, stats model.SessionStats, chars []model.CharStats) (int64, error) {
+	var id int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		id, err = insertSession(ctx, tx, stats, chars)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func insertSession(ctx context.Context, tx *sql.Tx, stats model.SessionStats, chars []model.CharStats) (int64, error) {
+	env, err := formatEnv(stats.Env)
+	if err != nil {
+		return 0, err
+	}
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO sessions (started_at, ended_at, lang, words, caps_pct, punct_pct, punct_set, wordlist_path, correct_nonspace, incorrect_nonspace, duration_ms,
+			first_key_ms, space_latency_sum_ms, space_latency_count, mode, focus_weak, weak_set, seed, words_typed, app_version,
+			keyboard, layout, completed, wordlist_meta, source, wpm_formula, samples, params_hash, scoring, env, ignored, wordlist_id)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		stats.StartedAt.Format(time.RFC3339Nano),
+		stats.EndedAt.Format(time.RFC3339Nano),
+		stats.Lang,
+		stats.Words,
+		stats.CapsPct,
+		stats.PunctPct,
+		stats.PunctSet,
+		stats.WordListPath,
+		stats.CorrectNonSpace,
+		stats.IncorrectNonSpace,
+		stats.DurationMs,
+		stats.FirstKeyMs,
+		stats.SpaceLatencySumMs,
+		stats.SpaceLatencyCount,
+		stats.Mode,
+		stats.FocusWeak,
+		stats.WeakSet,
+		stats.Seed,
+		stats.WordsTyped,
+		stats.AppVersion,
+		stats.Keyboard,
+		stats.Layout,
+		!stats.Incomplete,
+		stats.WordListMeta,
+		stats.Source,
+		stats.WPMFormula,
+		formatSamples(stats.Samples),
+		stats.ParamsHash,
+		stats.Scoring,
+		env,
+		stats.Ignored,
+		stats.WordListID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertCharStats(ctx, tx, id, chars); err != nil {
+		return 0, err
+	}
+
+	if stats.TargetText != "" || stats.TypedText != "" {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO session_texts (session_id, target, typed, truncated, keystrokes) VALUES (?, ?, ?, ?, ?)`,
+			id, stats.TargetText, stats.TypedText, stats.TextTruncated, stats.Keystrokes,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	if !stats.Incomplete && !stats.Ignored {
+		if err := upsertDaily(ctx, tx, stats, chars); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+// sessionColumns lists the full session row plus stored text, for use with scanSession.
+const sessionColumns = `s.id, s.started_at, s.ended_at, s.lang, s.words, s.caps_pct, s.punct_pct, s.punct_set, s.wordlist_path,
+	s.correct_nonspace, s.incorrect_nonspace, s.duration_ms, s.first_key_ms, s.space_latency_sum_ms, s.space_latency_count,
+	s.mode, s.focus_weak, s.weak_set, s.seed, s.words_typed, s.app_version, s.keyboard, s.layout, s.completed, s.wordlist_meta, s.source, s.wpm_formula, s.samples,
+	s.params_hash, s.scoring, s.env, s.ignored, s.wordlist_id, t.target, t.typed, t.truncated, t.keystrokes
+	FROM sessions s
+	LEFT JOIN session_texts t ON t.session_id = s.id`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row rowScanner) (int64, model.SessionStats, error) {
+	var id int64
+	var stats model.SessionStats
+	var startedAt, endedAt string
+	var target, typed, keys sql.NullString
+	var truncated sql.NullBool
+	var completed bool
+	var samples, env string
+	if err := row.Scan(&id, &startedAt, &endedAt, &stats.Lang, &stats.Words, &stats.CapsPct, &stats.PunctPct, &stats.PunctSet, &stats.WordListPath,
+		&stats.CorrectNonSpace, &stats.IncorrectNonSpace, &stats.DurationMs, &stats.FirstKeyMs, &stats.SpaceLatencySumMs, &stats.SpaceLatencyCount,
+		&stats.Mode, &stats.FocusWeak, &stats.WeakSet, &stats.Seed, &stats.WordsTyped, &stats.AppVersion, &stats.Keyboard, &stats.Layout,
+		&completed, &stats.WordListMeta, &stats.Source, &stats.WPMFormula, &samples,
+		&stats.ParamsHash, &stats.Scoring, &env, &stats.Ignored, &stats.WordListID, &target, &typed, &truncated, &keys); err != nil {
+		return 0, model.SessionStats{}, err
+	}
+	var err error
+	if stats.StartedAt, err = time.Parse(time.RFC3339Nano, startedAt); err != nil {
+		return 0, model.SessionStats{}, err
+	}
+	if stats.EndedAt, err = time.Parse(time.RFC3339Nano, endedAt); err != nil {
+		return 0, model.SessionStats{}, err
+	}
+	stats.Incomplete = !completed
+	if stats.Samples, err = parseSamples(samples); err != nil {
+		return 0, model.SessionStats{}, err
+	}
+	if stats.Env, err = parseEnv(env); err != nil {
+		return 0, model.SessionStats{}, err
+	}
+	stats.TargetText = target.String
+	stats.TypedText = typed.String
+	stats.TextTruncated = truncated.Bool
+	stats.Keystrokes = keys.String
+	return id, stats, nil
+}
+
+// formatSamples stores per-second counts as a comma-separated list.
+func formatSamples(samples []int) string {
+	parts := make([]string, len(samples))
+	for i, n := range samples {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseSamples(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	samples := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid speed sample %q: %w", part, err)
+		}
+		samples[i] = n
+	}
+	return samples, nil
+}
+
+// formatEnv stores a session environment as JSON; a nil env is stored empty.
+func formatEnv(env *model.SessionEnv) (string, error) {
+	if env == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func parseEnv(s string) (*model.SessionEnv, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var env model.SessionEnv
+	if err := json.Unmarshal([]byte(s), &env); err != nil {
+		return nil, fmt.Errorf("invalid session environment: %w", err)
+	}
+	return &env, nil
+}
+
+// GetSession loads a single session with its stored text, if any.
+func (s *Store) GetSession(ctx context.Context, id int64) (model.SessionStats, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+sessionColumns+` WHERE s.id = ?`, id)
+	_, stats, err := scanSession(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.SessionStats{}, fmt.Errorf("session %d %w", id, ErrNotFound)
+		}
+		return model.SessionStats{}, err
+	}
+	return stats, nil
+}
+
+// GetWeakChars aggregates character stats over the most recent sessions.
+func (s *Store) GetWeakChars(ctx context.Context, window int, lang string) ([]model.CharAggregate, error) {
+	if window <= 0 {
+		return nil, nil
+	}
+	query := `WITH recent_sessions AS (
+		SELECT id FROM sessions
+		WHERE (? = '' OR lang = ?) AND completed = 1 AND ignored = 0
+		ORDER BY ended_at DESC
+		LIMIT ?
+	)
+	SELECT cs.char, SUM(cs.correct) AS correct, SUM(cs.incorrect) AS incorrect,
+		SUM(cs.latency_sum_ms) AS latency_sum_ms, SUM(cs.latency_count) AS latency_count
+	FROM session_char_stats cs
+	JOIN recent_sessions r ON r.id = cs.session_id
+	GROUP BY cs.char`
+
+	rows, err := s.db.QueryContext(ctx, query, lang, lang, window)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.CharAggregate
+	for rows.Next() {
+		var agg model.CharAggregate
+		if err := rows.Scan(&agg.Char, &agg.Correct, &agg.Incorrect, &agg.LatencySumMs, &agg.LatencyCount); err != nil {
+			return nil, err
+		}
+		result = append(result, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListSessions returns session aggregates filtered by stats config, oldest first.
+// When cfg.Last is set only the most recent Last sessions are loaded.
+func (s *Store) ListSessions(ctx context.Context, cfg model.StatsConfig) ([]model.SessionAggregate, error) {
+	where, args := sessionFilter(cfg)
+	query := fmt.Sprintf(`SELECT %s FROM sessions WHERE %s ORDER BY ended_at ASC`, sessionAggregateColumns, where)
+	if cfg.Last > 0 {
+		query = fmt.Sprintf(`SELECT * FROM (SELECT %s FROM sessions WHERE %s ORDER BY ended_at DESC LIMIT ?)
+			ORDER BY ended_at ASC`, sessionAggregateColumns, where)
+		args = append(args, cfg.Last)
+	}
+	return s.querySessionAggregates(ctx, query, args...)
+}
+
+// GetTotals sums the completed sessions of lang (all languages when empty) in SQL, so
+// the cost does not grow with the number of rows returned.
+func (s *Store) GetTotals(ctx context.Context, lang string) (model.Totals, error) {
+	where, args := sessionFilter(model.StatsConfig{Lang: lang})
+	query := fmt.Sprintf(`SELECT COUNT(*), COALESCE(SUM(correct_nonspace), 0), COALESCE(SUM(incorrect_nonspace), 0),
+		COALESCE(SUM(CASE WHEN words_typed > 0 THEN words_typed ELSE correct_nonspace / 5 END), 0),
+		COALESCE(SUM(duration_ms), 0)
+		FROM sessions WHERE %s`, where)
+	var t model.Totals
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&t.Sessions, &t.Correct, &t.Incorrect, &t.Words, &t.DurationMs)
+	return t, err
+}
+
+// ListSessionsPage returns up to limit sessions newest first, skipping the first offset matches.
+// cfg.Last is ignored; use CountSessions for the total.
+func (s *Store) ListSessionsPage(ctx context.Context, cfg model.StatsConfig, offset, limit int) ([]model.SessionAggregate, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	where, args := sessionFilter(cfg)
+	query := fmt.Sprintf(`SELECT %s FROM sessions WHERE %s ORDER BY ended_at DESC LIMIT ? OFFSET ?`, sessionAggregateColumns, where)
+	args = append(args, limit, offset)
+	return s.querySessionAggregates(ctx, query, args...)
+}
+
+// ListReplayIDs returns the IDs of the sessions with a stored keystroke log, newest first.
+// cfg.Last is ignored.
+func (s *Store) ListReplayIDs(ctx context.Context, cfg model.StatsConfig) ([]int64, error) {
+	where, args := sessionFilter(cfg)
+	query := fmt.Sprintf(`SELECT id FROM sessions
+		WHERE %s AND id IN (SELECT session_id FROM session_texts WHERE keystrokes != '')
+		ORDER BY ended_at DESC`, where)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+const sessionAggregateColumns = `id, ended_at, correct_nonspace, incorrect_nonspace, duration_ms,
+	first_key_ms, space_latency_sum_ms, space_latency_count, mode, focus_weak, keyboard, layout, completed, words_typed, wpm_formula,
+	app_version, scoring, env, ignored`
+
+func (s *Store) querySessionAggregates(ctx context.Context, query string, args ...any) ([]model.SessionAggregate, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var sessions []model.SessionAggregate
+	for rows.Next() {
+		var agg model.SessionAggregate
+		var endedAt, env string
+		var completed bool
+		if err := rows.Scan(&agg.SessionID, &endedAt, &agg.Correct, &agg.Incorrect, &agg.DurationMs,
+			&agg.FirstKeyMs, &agg.SpaceLatencySumMs, &agg.SpaceLatencyCount, &agg.Mode, &agg.FocusWeak,
+			&agg.Keyboard, &agg.Layout, &completed, &agg.WordsTyped, &agg.WPMFormula,
+			&agg.AppVersion, &agg.Scoring, &env, &agg.Ignored); err != nil {
+			return nil, err
+		}
+		if agg.Env, err = parseEnv(env); err != nil {
+			return nil, err
+		}
+		agg.Incomplete = !completed
+		parsed, err := time.Parse(time.RFC3339Nano, endedAt)
+		if err != nil {
+			return nil, err
+		}
+		agg.EndedAt = parsed
+		sessions = append(sessions, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func sessionFilter(cfg model.StatsConfig) (string, []any) {
+	clauses := []string{"1=1"}
+	args := []any{}
+	if !cfg.IncludeIncomplete {
+		clauses = append(clauses, "completed = 1")
+	}
+	if !cfg.IncludeIgnored {
+		clauses = append(clauses, "ignored = 0")
+	}
+	if cfg.Lang != "" {
+		clauses = append(clauses, "lang = ?")
+		args = append(args, cfg.Lang)
+	}
+	if cfg.Since != nil {
+		clauses = append(clauses, "ended_at >= ?")
+		args = append(args, cfg.Since.Format(time.RFC3339Nano))
+	}
+	if cfg.Mode != "" {
+		clauses = append(clauses, "mode = ?")
+		args = append(args, cfg.Mode)
+	}
+	if cfg.FocusWeak != nil {
+		clauses = append(clauses, "focus_weak = ?")
+		args = append(args, *cfg.FocusWeak)
+	}
+	if cfg.AppVersion != "" {
+		clauses = append(clauses, "app_version = ?")
+		args = append(args, cfg.AppVersion)
+	}
+	if cfg.Keyboard != "" {
+		clauses = append(clauses, "keyboard = ?")
+		args = append(args, cfg.Keyboard)
+	}
+	if cfg.Layout != "" {
+		clauses = append(clauses, "layout = ?")
+		args = append(args, cfg.Layout)
+	}
+	if cfg.WordList != "" {
+		// A bare name matches every version of the list.
+		clauses = append(clauses, "(wordlist_id = ? OR substr(wordlist_id, 1, instr(wordlist_id, '@') - 1) = ?)")
+		args = append(args, cfg.WordList, cfg.WordList)
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// ListCharAggregatesForSessions aggregates per-character stats across sessions.
+func (s *Store) ListCharAggregatesForSessions(ctx context.Context, sessionIDs []int64) ([]model.CharAggregate, error) {
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(sessionIDs))
+	args := make([]any, len(sessionIDs))
+	for i, id := range sessionIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	query := fmt.Sprintf(`SELECT char, SUM(correct) AS correct, SUM(incorrect) AS incorrect,
+		SUM(latency_sum_ms) AS latency_sum_ms, SUM(latency_count) AS latency_count
+		FROM session_char_stats
+		WHERE session_id IN (%s)
+		GROUP BY char`, strings.Join(placeholders, ","))
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	var result []model.CharAggregate
+	for rows.Next() {
+		var agg model.CharAggregate
+		if err := rows.Scan(&agg.Char, &agg.Correct, &agg.Incorrect, &agg.LatencySumMs, &agg.LatencyCount); err != nil {
+			return nil, err
+		}
+		result = append(result, agg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListCharStatsForSessions returns per-session stats for selected characters.
+func (s *Store) ListCharStatsForSessions(ctx context.Context, sessionIDs []int64, chars []string) (map[int64]map[string]model.CharAggregate, error) {
+	if len(sessionIDs) == 0 || len(chars) == 0 {
+		return map[int64]map[string]model.CharAggregate{}, nil
+	}
+	idPlaceholders := make([]string, len(sessionIDs))
+	args := make([]any, 0, len(sessionIDs)+len(chars))
+	for i, id := range sessionIDs {
+		idPlaceholders[i] = "?"
+		args = append(args, id)
+	}
+	charPlaceholders := make([]string, len(chars))
+	for i, ch := range chars {
+		charPlaceholders[i] = "?"
+		args = append(args, ch)
+	}
+
+	query := fmt.Sprintf(`SELECT session_id, char, correct, incorrect, latency_sum_ms, latency_count
+		FROM session_char_stats
+		WHERE session_id IN (%s) AND char IN (%s)`, strings.Join(idPlaceholders, ","), strings.Join(charPlaceholders, ","))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			// Best-effort rows close.
+			_ = cerr
+		}
+	}()
+
+	result := map[int64]map[string]model.CharAggregate{}
+	for rows.Next() {
+		var sessionID int64
+		var agg model.CharAggregate
+		if err := rows.Scan(&sessionID, &agg.Char, &agg.Correct, &agg.Incorrect, &agg.LatencySumMs, &agg.LatencyCount); err != nil {
+			return nil, err
+		}
+		if _, ok := result[sessionID]; !ok {
+			result[sessionID] = map[string]model.CharAggregate{}
+		}
+		result[sessionID][agg.Char] = agg
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}